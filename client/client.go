@@ -20,8 +20,10 @@ type Client interface {
 	GetStatus(ctx context.Context) (*types.DACStatus, error)
 	GetOffChainData(ctx context.Context, hash common.Hash) ([]byte, error)
 	ListOffChainData(ctx context.Context, hashes []common.Hash) (map[common.Hash][]byte, error)
+	ListOffChainDataByBatch(ctx context.Context, batchNum uint64, offset, limit uint) (*types.OffChainDataPage, error)
 	SignSequence(ctx context.Context, signedSequence types.SignedSequence) ([]byte, error)
 	SignSequenceBanana(ctx context.Context, signedSequence types.SignedSequenceBanana) ([]byte, error)
+	GetBatchRoot(ctx context.Context, batchNum uint64) (*types.BatchRoot, error)
 }
 
 // factory is the implementation of the data committee client factory
@@ -150,3 +152,47 @@ func (c *client) ListOffChainData(ctx context.Context, hashes []common.Hash) (ma
 
 	return preparedResult, nil
 }
+
+// ListOffChainDataByBatch returns a page of the data of the given batch ordered by key,
+// along with the total number of entries stored for the batch
+func (c *client) ListOffChainDataByBatch(
+	ctx context.Context,
+	batchNum uint64,
+	offset, limit uint,
+) (*types.OffChainDataPage, error) {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "sync_listOffChainDataByBatch",
+		types.ArgUint64(batchNum), types.ArgUint64(offset), types.ArgUint64(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	var result types.OffChainDataPage
+	if err = json.Unmarshal(response.Result, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetBatchRoot returns the root of the data stored for the given batch, served by the admin API of the node
+func (c *client) GetBatchRoot(ctx context.Context, batchNum uint64) (*types.BatchRoot, error) {
+	response, err := rpc.JSONRPCCallWithContext(ctx, c.url, "da_getBatchRoot", types.ArgUint64(batchNum))
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+	}
+
+	var result types.BatchRoot
+	if err = json.Unmarshal(response.Result, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
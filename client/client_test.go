@@ -319,6 +319,158 @@ func TestClient_ListOffChainData(t *testing.T) {
 	}
 }
 
+func TestClient_ListOffChainDataByBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		batchNum   uint64
+		offset     uint
+		limit      uint
+		result     string
+		page       *types.OffChainDataPage
+		statusCode int
+		err        error
+	}{
+		{
+			name:     "successfully got a page of offchain data",
+			batchNum: 1,
+			offset:   2,
+			limit:    3,
+			result: fmt.Sprintf(`{"result":{"items":[{"key":"%s","value":"0x%s"}],"total":3}}`,
+				common.BytesToHash([]byte("hash")).Hex(), hex.EncodeToString([]byte("offchaindata"))),
+			page: &types.OffChainDataPage{
+				Items: []types.OffChainDataItem{{
+					Key:   common.BytesToHash([]byte("hash")),
+					Value: []byte("offchaindata"),
+				}},
+				Total: 3,
+			},
+		},
+		{
+			name:     "error returned by server",
+			batchNum: 1,
+			limit:    3,
+			result:   `{"error":{"code":123,"message":"test error"}}`,
+			err:      errors.New("123 test error"),
+		},
+		{
+			name:       "unsuccessful status code returned by server",
+			batchNum:   1,
+			limit:      3,
+			statusCode: http.StatusUnauthorized,
+			err:        errors.New("invalid status code, expected: 200, found: 401"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res rpc.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+				require.Equal(t, "sync_listOffChainDataByBatch", res.Method)
+
+				var params []types.ArgUint64
+				require.NoError(t, json.Unmarshal(res.Params, &params))
+				require.Equal(t, []types.ArgUint64{
+					types.ArgUint64(tt.batchNum), types.ArgUint64(tt.offset), types.ArgUint64(tt.limit),
+				}, params)
+
+				if tt.statusCode > 0 {
+					w.WriteHeader(tt.statusCode)
+				}
+
+				_, err := fmt.Fprint(w, tt.result)
+				require.NoError(t, err)
+			}))
+			defer svr.Close()
+
+			c := &client{url: svr.URL}
+
+			got, err := c.ListOffChainDataByBatch(context.Background(), tt.batchNum, tt.offset, tt.limit)
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.page, got)
+			}
+		})
+	}
+}
+
+func TestClient_GetBatchRoot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		result     string
+		root       *types.BatchRoot
+		statusCode int
+		err        error
+	}{
+		{
+			name: "successfully got the batch root",
+			result: fmt.Sprintf(`{"result":{"batchNum":"0x5","root":"%s","values":1}}`,
+				common.BytesToHash([]byte("root")).Hex()),
+			root: &types.BatchRoot{
+				BatchNum: 5,
+				Root:     common.BytesToHash([]byte("root")),
+				Values:   1,
+			},
+		},
+		{
+			name:   "error returned by server",
+			result: `{"error":{"code":123,"message":"test error"}}`,
+			err:    errors.New("123 test error"),
+		},
+		{
+			name:       "unsuccessful status code returned by server",
+			statusCode: http.StatusUnauthorized,
+			err:        errors.New("invalid status code, expected: 200, found: 401"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res rpc.Request
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&res))
+				require.Equal(t, "da_getBatchRoot", res.Method)
+
+				var params []types.ArgUint64
+				require.NoError(t, json.Unmarshal(res.Params, &params))
+				require.Equal(t, []types.ArgUint64{5}, params)
+
+				if tt.statusCode > 0 {
+					w.WriteHeader(tt.statusCode)
+				}
+
+				_, err := fmt.Fprint(w, tt.result)
+				require.NoError(t, err)
+			}))
+			defer svr.Close()
+
+			c := &client{url: svr.URL}
+
+			got, err := c.GetBatchRoot(context.Background(), 5)
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.root, got)
+			}
+		})
+	}
+}
+
 func TestClient_SignSequenceBanana(t *testing.T) {
 	t.Parallel()
 
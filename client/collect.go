@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrThresholdNotMet is returned when not enough committee members signed within the collection timeout
+var ErrThresholdNotMet = errors.New("committee signature threshold not met")
+
+// Member is a committee member the signatures are collected from
+type Member struct {
+	Addr common.Address
+	URL  string
+}
+
+// SignFunc requests the signature of a sequence from the given committee member client
+type SignFunc func(ctx context.Context, c Client) ([]byte, error)
+
+// CollectResult is the outcome of a signature collection
+type CollectResult struct {
+	// Signatures are the signatures returned by the members, which should be validated by the caller
+	Signatures map[common.Address][]byte
+
+	// Collected is the number of signatures collected, and Required the number the committee requires
+	Collected uint64
+	Required  uint64
+
+	// Failed are the errors of the members that failed to sign
+	Failed map[common.Address]error
+
+	// TimedOut are the members that did not answer within the collection timeout
+	TimedOut []common.Address
+}
+
+// ThresholdMet returns whether enough signatures were collected
+func (r *CollectResult) ThresholdMet() bool {
+	return r.Collected >= r.Required
+}
+
+// memberSignature is the answer of a member to a signature request
+type memberSignature struct {
+	addr      common.Address
+	signature []byte
+	err       error
+}
+
+// Collector collects the signatures of a sequence from the committee members
+type Collector struct {
+	factory Factory
+	timeout time.Duration
+}
+
+// NewCollector returns a collector creating the member clients with the given factory, which gives up
+// on the members that did not sign after the given timeout. A zero timeout only stops on the context
+func NewCollector(factory Factory, timeout time.Duration) *Collector {
+	return &Collector{
+		factory: factory,
+		timeout: timeout,
+	}
+}
+
+// Collect requests the signatures of all the members at once, and returns as soon as the required amount is
+// collected, every member answered or the timeout expires. If the threshold is not met, the result tells how many
+// signatures were collected and which members failed or timed out, along with ErrThresholdNotMet
+func (c *Collector) Collect(
+	parentCtx context.Context, members []Member, required uint64, sign SignFunc,
+) (*CollectResult, error) {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(parentCtx, c.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parentCtx)
+	}
+	defer cancel()
+
+	answers := make(chan memberSignature, len(members))
+	for _, member := range members {
+		go func(member Member) {
+			signature, err := sign(ctx, c.factory.New(member.URL))
+			answers <- memberSignature{addr: member.Addr, signature: signature, err: err}
+		}(member)
+	}
+
+	result := &CollectResult{
+		Signatures: make(map[common.Address][]byte),
+		Required:   required,
+		Failed:     make(map[common.Address]error),
+	}
+
+	answered := make(map[common.Address]struct{}, len(members))
+
+collect:
+	for len(answered) < len(members) && !result.ThresholdMet() {
+		select {
+		case answer := <-answers:
+			if errors.Is(answer.err, context.DeadlineExceeded) {
+				// Reported as timed out along with the members that did not answer
+				continue
+			}
+
+			answered[answer.addr] = struct{}{}
+
+			if answer.err != nil {
+				result.Failed[answer.addr] = answer.err
+				continue
+			}
+
+			result.Signatures[answer.addr] = answer.signature
+			result.Collected++
+		case <-ctx.Done():
+			break collect
+		}
+	}
+
+	if result.ThresholdMet() {
+		return result, nil
+	}
+
+	for _, member := range members {
+		if _, ok := answered[member.Addr]; !ok {
+			result.TimedOut = append(result.TimedOut, member.Addr)
+		}
+	}
+
+	return result, fmt.Errorf("%w: collected %d of %d signatures, %d members failed and %d timed out",
+		ErrThresholdNotMet, result.Collected, result.Required, len(result.Failed), len(result.TimedOut))
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// urlClient is a Client that only knows the url it was created for
+type urlClient struct {
+	Client
+
+	url string
+}
+
+// urlFactory creates the clients of the collector tests
+type urlFactory struct{}
+
+func (urlFactory) New(url string) Client {
+	return &urlClient{url: url}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	t.Parallel()
+
+	members := []Member{
+		{Addr: common.HexToAddress("0x1"), URL: "member1"},
+		{Addr: common.HexToAddress("0x2"), URL: "member2"},
+		{Addr: common.HexToAddress("0x3"), URL: "member3"},
+	}
+
+	testErr := errors.New("test error")
+
+	sign := func(ctx context.Context, c Client) ([]byte, error) {
+		switch url := c.(*urlClient).url; url {
+		case "member2":
+			return nil, testErr
+		case "member3":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		default:
+			return []byte(url), nil
+		}
+	}
+
+	tests := []struct {
+		name      string
+		required  uint64
+		collected uint64
+		failed    map[common.Address]error
+		timedOut  []common.Address
+		err       error
+	}{
+		{
+			name:      "threshold met",
+			required:  1,
+			collected: 1,
+		},
+		{
+			name:      "threshold not met",
+			required:  2,
+			collected: 1,
+			failed:    map[common.Address]error{members[1].Addr: testErr},
+			timedOut:  []common.Address{members[2].Addr},
+			err:       ErrThresholdNotMet,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collector := NewCollector(urlFactory{}, 100*time.Millisecond)
+
+			result, err := collector.Collect(context.Background(), members, tt.required, sign)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				require.EqualError(t, err,
+					"committee signature threshold not met: collected 1 of 2 signatures, 1 members failed and 1 timed out")
+				require.Equal(t, tt.failed, result.Failed)
+				require.Equal(t, tt.timedOut, result.TimedOut)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.collected, result.Collected)
+			require.Equal(t, tt.required, result.Required)
+			require.Equal(t, []byte("member1"), result.Signatures[members[0].Addr])
+		})
+	}
+}
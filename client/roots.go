@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RootComparison is the outcome of comparing the roots of a batch across peer nodes
+type RootComparison struct {
+	BatchNum uint64
+
+	// Roots are the roots returned by the peers, by url
+	Roots map[string]common.Hash
+
+	// Majority is the root returned by most of the peers, the lowest one on a tie
+	Majority common.Hash
+
+	// Diverged are the peers whose root differs from the majority, which have corrupt or incomplete data
+	Diverged []string
+
+	// Failed are the errors of the peers that could not return their root
+	Failed map[string]error
+}
+
+// Consistent returns whether all the peers that answered returned the same root
+func (c *RootComparison) Consistent() bool {
+	return len(c.Diverged) == 0
+}
+
+// peerRoot is the answer of a peer to a root request
+type peerRoot struct {
+	url  string
+	root common.Hash
+	err  error
+}
+
+// CompareBatchRoots requests the root of the given batch to all the given peer nodes at once and reports
+// the ones that diverge from the majority. Only the roots are exchanged, not the data
+func CompareBatchRoots(ctx context.Context, factory Factory, urls []string, batchNum uint64) *RootComparison {
+	answers := make(chan peerRoot, len(urls))
+	for _, url := range urls {
+		go func(url string) {
+			root, err := factory.New(url).GetBatchRoot(ctx, batchNum)
+			if err != nil {
+				answers <- peerRoot{url: url, err: err}
+				return
+			}
+
+			answers <- peerRoot{url: url, root: root.Root}
+		}(url)
+	}
+
+	comparison := &RootComparison{
+		BatchNum: batchNum,
+		Roots:    make(map[string]common.Hash),
+		Failed:   make(map[string]error),
+	}
+
+	votes := make(map[common.Hash]int)
+	for range urls {
+		answer := <-answers
+		if answer.err != nil {
+			comparison.Failed[answer.url] = answer.err
+			continue
+		}
+
+		comparison.Roots[answer.url] = answer.root
+		votes[answer.root]++
+	}
+
+	for root, count := range votes {
+		majority := votes[comparison.Majority]
+		if count > majority || (count == majority && root.Cmp(comparison.Majority) < 0) {
+			comparison.Majority = root
+		}
+	}
+
+	for url, root := range comparison.Roots {
+		if root != comparison.Majority {
+			comparison.Diverged = append(comparison.Diverged, url)
+		}
+	}
+
+	sort.Strings(comparison.Diverged)
+
+	return comparison
+}
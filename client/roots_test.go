@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// rootClient is a Client returning the root configured for its url
+type rootClient struct {
+	Client
+
+	url   string
+	roots map[string]common.Hash
+}
+
+func (c *rootClient) GetBatchRoot(_ context.Context, batchNum uint64) (*types.BatchRoot, error) {
+	root, ok := c.roots[c.url]
+	if !ok {
+		return nil, errors.New("test error")
+	}
+
+	return &types.BatchRoot{BatchNum: types.ArgUint64(batchNum), Root: root}, nil
+}
+
+// rootFactory creates the clients of the root comparison tests
+type rootFactory struct {
+	roots map[string]common.Hash
+}
+
+func (f rootFactory) New(url string) Client {
+	return &rootClient{url: url, roots: f.roots}
+}
+
+func TestCompareBatchRoots(t *testing.T) {
+	t.Parallel()
+
+	urls := []string{"peer1", "peer2", "peer3", "peer4"}
+	root, other := common.HexToHash("0x01"), common.HexToHash("0x02")
+
+	tests := []struct {
+		name     string
+		roots    map[string]common.Hash
+		majority common.Hash
+		diverged []string
+		failed   int
+	}{
+		{
+			name:     "all peers agree",
+			roots:    map[string]common.Hash{"peer1": root, "peer2": root, "peer3": root, "peer4": root},
+			majority: root,
+		},
+		{
+			name:     "one peer diverged",
+			roots:    map[string]common.Hash{"peer1": root, "peer2": other, "peer3": root, "peer4": root},
+			majority: root,
+			diverged: []string{"peer2"},
+		},
+		{
+			name:     "tie resolved to the lowest root",
+			roots:    map[string]common.Hash{"peer1": other, "peer2": root},
+			majority: root,
+			diverged: []string{"peer1"},
+			failed:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			comparison := CompareBatchRoots(context.Background(), rootFactory{roots: tt.roots}, urls, 5)
+			require.Equal(t, uint64(5), comparison.BatchNum)
+			require.Equal(t, tt.majority, comparison.Majority)
+			require.Equal(t, tt.diverged, comparison.Diverged)
+			require.Equal(t, len(tt.diverged) == 0, comparison.Consistent())
+			require.Len(t, comparison.Failed, tt.failed)
+			require.Len(t, comparison.Roots, len(tt.roots))
+		})
+	}
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	dataavailability "github.com/0xPolygon/cdk-data-availability"
@@ -12,15 +14,21 @@ import (
 	"github.com/0xPolygon/cdk-data-availability/config"
 	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/grpc"
 	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/pkg/s3"
 	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/services/da"
 	"github.com/0xPolygon/cdk-data-availability/services/datacom"
 	"github.com/0xPolygon/cdk-data-availability/services/status"
 	"github.com/0xPolygon/cdk-data-availability/services/sync"
+	"github.com/0xPolygon/cdk-data-availability/shutdown"
 	"github.com/0xPolygon/cdk-data-availability/synchronizer"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/urfave/cli/v2"
 )
@@ -48,6 +56,13 @@ func main() {
 			Action:  start,
 			Flags:   []cli.Flag{&configFileFlag},
 		},
+		{
+			Name:    "address",
+			Aliases: []string{},
+			Usage:   "Print the address of the configured private key, to register the node in the committee",
+			Action:  printAddress,
+			Flags:   []cli.Flag{&configFileFlag},
+		},
 		{
 			Name:    "version",
 			Aliases: []string{},
@@ -67,6 +82,23 @@ func main() {
 	}
 }
 
+// printAddress prints the address of the configured private key without starting the node
+func printAddress(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	addr, err := config.AddressFromKeystore(c.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to load the private key: %w", err)
+	}
+
+	fmt.Println(addr.Hex())
+
+	return nil
+}
+
 func start(cliCtx *cli.Context) error {
 	// Load config
 	c, err := config.Load(cliCtx)
@@ -87,11 +119,58 @@ func start(cliCtx *cli.Context) error {
 		log.Fatal(err)
 	}
 
-	storage, err := db.New(cliCtx.Context, pg)
+	if err = db.CheckSchema(cliCtx.Context, pg); err != nil {
+		log.Fatal(err)
+	}
+
+	storage, err := db.New(cliCtx.Context, pg, c.DB.InsertChunkSize)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if c.DB.Warmup {
+		if err = db.Warmup(cliCtx.Context, pg, c.DB.MaxConns); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var replicaPg *sqlx.DB
+	if c.DB.ReplicaHost != "" {
+		replicaCfg := c.DB
+		replicaCfg.Host, replicaCfg.Port = c.DB.ReplicaHost, c.DB.ReplicaPort
+
+		if replicaPg, err = db.InitContext(cliCtx.Context, replicaCfg); err != nil {
+			log.Fatal(err)
+		}
+
+		replica, err := db.New(cliCtx.Context, replicaPg, c.DB.InsertChunkSize)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		storage = db.NewReplicaDB(storage, replica)
+	}
+
+	if c.S3.Enabled {
+		objectStore, err := s3.New(c.S3)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		storage = db.NewObjectStoreDB(storage, objectStore)
+	}
+
+	if c.DB.Audit {
+		storage = db.NewAuditDB(storage, db.NewLogAuditSink())
+	}
+
+	// Only the sequences signed for the sequencer are checked for empty values. The synchronizer stores the data
+	// committed on L1 as it is, since rejecting it would only keep its batch from ever being resolved
+	var dacStorage db.BlobStore = storage
+	if !c.DB.AllowEmptyValues {
+		dacStorage = db.NewNonEmptyDB(storage)
+	}
+
 	// Load private key
 	pk, err := config.NewKeyFromKeystore(c.PrivateKey)
 	if err != nil {
@@ -104,6 +183,26 @@ func start(cliCtx *cli.Context) error {
 		log.Fatal(err)
 	}
 
+	if c.L1.ChallengeWindow > 0 {
+		storage = db.NewChallengeWindowDB(storage, c.L1.ChallengeWindow, func(ctx context.Context) (uint64, error) {
+			header, err := etm.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return 0, err
+			}
+
+			return header.Number.Uint64(), nil
+		})
+	}
+
+	if c.L1.Reconciliation.Enabled {
+		verifier := synchronizer.NewAccInputHashVerifier(storage, etm, c.L1.GenesisBlock)
+		if _, err = synchronizer.Reconcile(
+			cliCtx.Context, storage, verifier, c.L1.Reconciliation, c.L1.BatchScope,
+		); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// ensure synchro/reorg start block is set
 	err = synchronizer.InitStartBlock(
 		cliCtx.Context,
@@ -116,11 +215,12 @@ func start(cliCtx *cli.Context) error {
 		log.Fatal(err)
 	}
 
-	var cancelFuncs []context.CancelFunc
+	if err = sequencer.ValidateDataPath(c.L1.SequencerDataPath); err != nil {
+		log.Fatal(err)
+	}
 
 	sequencerTracker := sequencer.NewTracker(c.L1, etm)
 	go sequencerTracker.Start(cliCtx.Context)
-	cancelFuncs = append(cancelFuncs, sequencerTracker.Stop)
 
 	detector, err := synchronizer.NewReorgDetector(c.L1.RpcURL, time.Second)
 	if err != nil {
@@ -131,8 +231,6 @@ func start(cliCtx *cli.Context) error {
 		log.Fatal(err)
 	}
 
-	cancelFuncs = append(cancelFuncs, detector.Stop)
-
 	batchSynchronizer, err := synchronizer.NewBatchSynchronizer(
 		c.L1,
 		crypto.PubkeyToAddress(pk.PublicKey),
@@ -146,33 +244,128 @@ func start(cliCtx *cli.Context) error {
 		log.Fatal(err)
 	}
 	go batchSynchronizer.Start(cliCtx.Context)
-	cancelFuncs = append(cancelFuncs, batchSynchronizer.Stop)
+
+	var fetcher sync.Fetcher
+	if c.L1.FetchOnMiss {
+		fetcher = batchSynchronizer
+	}
+
+	blsKey, err := config.NewBLSKey(c.Signature)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dacEndpoints := datacom.NewEndpoints(
+		dacStorage, pk, blsKey, sequencerTracker, c.L1.SignatureChainID, c.L1.MaxBatchesPerSequence, c.L1.MaxBlobSize,
+	)
+
+	if len(c.Signature.RotationKeys) > 0 {
+		rotationKeys, err := config.NewRotationKeys(c.Signature)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		dacEndpoints.SetKeySelector(datacom.NewKeySelector(
+			append([]*ecdsa.PrivateKey{pk}, rotationKeys...), etm, c.Signature.RotationGracePeriod.Duration,
+		))
+	}
 
 	// Register services
-	server := rpc.NewServer(
-		c.RPC,
-		[]rpc.Service{
-			{
-				Name:    status.APISTATUS,
-				Service: status.NewEndpoints(storage),
-			},
-			{
-				Name:    sync.APISYNC,
-				Service: sync.NewEndpoints(storage),
-			},
-			{
-				Name:    datacom.APIDATACOM,
-				Service: datacom.NewEndpoints(storage, pk, sequencerTracker),
-			},
+	services := []rpc.Service{
+		{
+			Name:    status.APISTATUS,
+			Service: status.NewEndpoints(storage, batchSynchronizer),
 		},
-	)
+		{
+			Name:    sync.APISYNC,
+			Service: sync.NewEndpoints(storage, fetcher),
+		},
+		{
+			Name:    datacom.APIDATACOM,
+			Service: dacEndpoints,
+		},
+	}
 
-	// Run!
-	if err = server.Start(); err != nil {
-		log.Fatal(err)
+	if c.RPC.EnableAdminAPI {
+		verifier := synchronizer.NewAccInputHashVerifier(storage, etm, c.L1.GenesisBlock)
+		services = append(services, rpc.Service{
+			Name:    da.APIDA,
+			Service: da.NewEndpoints(verifier, sequencerTracker, storage, storage, storage),
+		})
+	}
+
+	server := rpc.NewServer(c.RPC, services)
+	server.SetBatchDataSource(storage)
+
+	// The components are stopped in reverse dependency order, each one waited for before the next: first the
+	// RPC server, so no new requests are accepted and the in-flight ones are drained, then the ones feeding the
+	// storage, the synchronizer before the reorg detector and the sequencer tracker it reads from, and the
+	// storage itself last, once nothing can write to it anymore
+	orchestrator := shutdown.New(c.ShutdownTimeout.Duration)
+	orchestrator.Register("rpc server", server.Shutdown)
+
+	if c.GRPC.Enabled {
+		grpcServer := grpc.NewServer(c.GRPC, storage)
+		go func() {
+			if err := grpcServer.Start(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+
+		orchestrator.Register("grpc server", grpcServer.Stop)
+	}
+
+	if c.DB.IntegritySampleInterval.Duration > 0 {
+		integrityMonitor := db.NewIntegrityMonitor(
+			storage, c.DB.IntegritySampleSize, c.DB.IntegritySampleInterval.Duration,
+		)
+		go integrityMonitor.Start(cliCtx.Context)
+
+		orchestrator.Register("integrity monitor", shutdown.Func(integrityMonitor.Stop))
+	}
+
+	if c.DB.StoredBytesInterval.Duration > 0 {
+		capacityMonitor := db.NewCapacityMonitor(storage, c.DB.StoredBytesInterval.Duration)
+		go capacityMonitor.Start(cliCtx.Context)
+
+		orchestrator.Register("capacity monitor", shutdown.Func(capacityMonitor.Stop))
+	}
+
+	orchestrator.Register("batch synchronizer", shutdown.Func(batchSynchronizer.Stop))
+	orchestrator.Register("reorg detector", shutdown.Func(detector.Stop))
+	orchestrator.Register("sequencer tracker", shutdown.Func(sequencerTracker.Stop))
+
+	if c.Metrics.Enabled {
+		metricsServer := metrics.NewServer(c.Metrics)
+		go func() {
+			if err := metricsServer.Start(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+
+		orchestrator.Register("metrics server", func(context.Context) error {
+			return metricsServer.Stop()
+		})
+	}
+
+	if replicaPg != nil {
+		orchestrator.Register("database replica", func(context.Context) error {
+			return replicaPg.Close()
+		})
 	}
 
-	waitSignal(cancelFuncs)
+	orchestrator.Register("database", func(context.Context) error {
+		return pg.Close()
+	})
+
+	// Run!
+	go func() {
+		if err := server.Start(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	waitSignal(orchestrator)
 	return nil
 }
 
@@ -180,20 +373,17 @@ func setupLog(c log.Config) {
 	log.Init(c)
 }
 
-func waitSignal(cancelFuncs []context.CancelFunc) {
+func waitSignal(orchestrator *shutdown.Orchestrator) {
 	signals := make(chan os.Signal, 1)
-	signal.Notify(signals, os.Interrupt)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
 
-	for sig := range signals {
-		switch sig {
-		case os.Interrupt, os.Kill:
-			log.Info("terminating application gracefully...")
+	<-signals
+	log.Info("terminating application gracefully...")
 
-			exitStatus := 0
-			for _, cancel := range cancelFuncs {
-				cancel()
-			}
-			os.Exit(exitStatus)
-		}
+	if err := orchestrator.Shutdown(context.Background()); err != nil {
+		log.Errorf("failed to terminate application gracefully: %v", err)
+		os.Exit(1)
 	}
+
+	os.Exit(0)
 }
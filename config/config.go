@@ -2,15 +2,23 @@ package config
 
 import (
 	"crypto/ecdsa"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/0xPolygon/cdk-data-availability/config/types"
 	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/grpc"
 	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/0xPolygon/cdk-data-availability/pkg/s3"
 	"github.com/0xPolygon/cdk-data-availability/rpc"
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 	"github.com/urfave/cli/v2"
@@ -19,15 +27,47 @@ import (
 const (
 	// FlagCfg flag used for config aka cfg
 	FlagCfg = "cfg"
+
+	// SignatureSchemeECDSA signs the sequences with the ECDSA private key, which is what the L1 contracts verify
+	SignatureSchemeECDSA = "ecdsa"
+
+	// SignatureSchemeBLS signs the sequences with a BLS private key, whose signatures can be aggregated
+	SignatureSchemeBLS = "bls"
 )
 
 // Config represents the full configuration of the data node
 type Config struct {
 	PrivateKey types.KeystoreFileConfig
+	Signature  SignatureConfig
 	DB         db.Config
 	Log        log.Config
 	RPC        rpc.Config
+	GRPC       grpc.Config
 	L1         L1Config
+	Metrics    metrics.Config
+	S3         s3.Config
+
+	// ShutdownTimeout is the time given to the node to drain the in-flight requests and stop all its components
+	// on shutdown, after which it exits anyway. 0 means no limit
+	ShutdownTimeout types.Duration `mapstructure:"ShutdownTimeout"`
+}
+
+// SignatureConfig selects the algorithm the sequences are signed with
+type SignatureConfig struct {
+	// Scheme is either "ecdsa" or "bls". BLS signatures of the committee members can be aggregated into one,
+	// but they are only useful to committees whose verification supports them
+	Scheme string `mapstructure:"Scheme"`
+
+	// BLSKeyPath is the file holding the hex encoded BLS private key, required by the "bls" scheme
+	BLSKeyPath string `mapstructure:"BLSKeyPath"`
+
+	// RotationKeys are keystores of other ECDSA keys the sequences can be signed with. The key registered in the
+	// committee among them and the private key is used, so the registered key can be replaced without downtime
+	RotationKeys []types.KeystoreFileConfig `mapstructure:"RotationKeys"`
+
+	// RotationGracePeriod is how long a rotated key that is no longer registered in the committee is still used,
+	// when none of the other keys is registered yet
+	RotationGracePeriod types.Duration `mapstructure:"RotationGracePeriod"`
 }
 
 // L1Config is a struct that defines L1 contract and service settings
@@ -41,10 +81,184 @@ type L1Config struct {
 	TrackSequencer             bool           `mapstructure:"TrackSequencer"`
 	TrackSequencerPollInterval types.Duration `mapstructure:"TrackSequencerPollInterval"`
 
+	// HealthCheckInterval is how often the connection to L1 is checked and re-dialed if it was lost.
+	// 0 disables the health check
+	HealthCheckInterval types.Duration `mapstructure:"HealthCheckInterval"`
+
+	// FallbackRpcURLs are alternate L1 endpoints used in order when RpcURL fails. The health check
+	// switches back to RpcURL as soon as it recovers
+	FallbackRpcURLs []string `mapstructure:"FallbackRpcURLs"`
+
+	// SequencerURLAllowlist is an optional list of host patterns (e.g. "*.example.com") that a trusted
+	// sequencer URL must match before the tracker uses it. If empty, any URL is accepted
+	SequencerURLAllowlist []string `mapstructure:"SequencerURLAllowlist"`
+
+	// MaxInFlightBatches is the maximum number of discovered batches waiting to be resolved. The discovery of
+	// new batches pauses while it is reached, until the resolver catches up. 0 means no limit
+	MaxInFlightBatches uint64 `mapstructure:"MaxInFlightBatches"`
+
+	// MaxResolveAttempts is the number of failed attempts to resolve a missing batch key after which it is moved
+	// to the failed batches and no longer retried, so a batch whose data cannot be found does not take room in
+	// the queue forever. 0 retries the keys until they are resolved
+	MaxResolveAttempts uint `mapstructure:"MaxResolveAttempts"`
+
+	// ResolveBackoffCap is the maximum delay before retrying a missing batch key that failed to be resolved.
+	// The delay starts at RetryPeriod and doubles with every failure. 0 retries the keys every RetryPeriod
+	ResolveBackoffCap types.Duration `mapstructure:"ResolveBackoffCap"`
+
+	// WriteFailureThreshold is the number of consecutive failures to store the offchain data after which the
+	// synchronizer pauses its writes for WriteFailureBackoff instead of retrying them in a loop, and reports
+	// the writes as paused in the status. 0 never pauses the writes
+	WriteFailureThreshold uint `mapstructure:"WriteFailureThreshold"`
+
+	// WriteFailureBackoff is how long the writes are paused once WriteFailureThreshold is reached, after which
+	// a single write probes whether the database recovered
+	WriteFailureBackoff types.Duration `mapstructure:"WriteFailureBackoff"`
+
+	// ResolvedHistoryRetention is how long the resolved missing batch keys are kept in the resolved batches history,
+	// along with when they were queued and resolved and how many attempts it took, to look into slow resolutions.
+	// 0 deletes them once resolved
+	ResolvedHistoryRetention types.Duration `mapstructure:"ResolvedHistoryRetention"`
+
+	// BatchResolvedWebhook is the URL the resolved batches are posted to once their data is stored.
+	// Empty disables it
+	BatchResolvedWebhook string `mapstructure:"BatchResolvedWebhook"`
+
+	// BatchResolvedHookConcurrency is the number of resolved batch notifications sent at once
+	BatchResolvedHookConcurrency uint `mapstructure:"BatchResolvedHookConcurrency"`
+
+	// FinalizationDepth is the number of L1 blocks after which sequenced batches are considered final and
+	// their offchain data is marked as finalized. 0 disables the finalization of offchain data
+	FinalizationDepth uint64 `mapstructure:"FinalizationDepth"`
+
+	// FinalizeOnVerification marks the offchain data as finalized only once its batches are verified on L1,
+	// as seen in the VerifyBatches events, instead of once they are sequenced. FinalizationDepth then applies
+	// to the block of the verification, 0 finalizing as soon as it is seen
+	FinalizeOnVerification bool `mapstructure:"FinalizeOnVerification"`
+
+	// PruneFinalized deletes the offchain data as soon as it is marked as finalized, except the one of the
+	// batches still within the ChallengeWindow. It requires FinalizationDepth or FinalizeOnVerification to be set
+	PruneFinalized bool `mapstructure:"PruneFinalized"`
+
+	// SignatureChainID binds the signatures of banana sequences to the given chain ID, so they cannot be
+	// replayed on another chain served by the same committee. It changes the signed hash, so it must only be
+	// set when the sequencer binds the chain ID too. 0 does not check the chain ID of the sequences, so the ones
+	// the sequencer does not bind are signed over the accInputHash as the L1 contracts expect
+	SignatureChainID uint64 `mapstructure:"SignatureChainID"`
+
+	// MaxBatchesPerSequence is the maximum number of batches of a sequence the node accepts to sign,
+	// which bounds the work and memory spent per request. 0 means no limit
+	MaxBatchesPerSequence uint64 `mapstructure:"MaxBatchesPerSequence"`
+
+	// MaxBlobSize is the maximum size in bytes of the data of a batch of a banana sequence the node accepts to
+	// sign, so a single batch cannot exhaust the storage. 0 means no limit
+	MaxBlobSize uint64 `mapstructure:"MaxBlobSize"`
+
+	// ChallengeWindow is the number of L1 blocks after being sequenced during which the offchain data of a batch
+	// is never pruned by PruneFinalized, even if finalized, so it can still be served to challengers.
+	// 0 disables the check
+	ChallengeWindow uint64 `mapstructure:"ChallengeWindow"`
+
+	// FetchOnMiss enables fetching the data of a known but not yet resolved key from the trusted sequencer
+	// when it is requested, instead of failing the request
+	FetchOnMiss bool `mapstructure:"FetchOnMiss"`
+
+	// FetchOnMissTimeout is the maximum time spent fetching the data of a requested key on a miss
+	FetchOnMissTimeout types.Duration `mapstructure:"FetchOnMissTimeout"`
+
+	// Reconciliation configures the check of the stored data against the sync state on startup
+	Reconciliation ReconciliationConfig `mapstructure:"Reconciliation"`
+
+	// BatchScope restricts the batches whose offchain data the synchronizer resolves and stores
+	BatchScope BatchScopeConfig `mapstructure:"BatchScope"`
+
+	// StrictSequencerResponse rejects the batches returned by the trusted sequencer that miss a required field
+	// or have an unknown one, instead of silently ignoring the unknown fields and zeroing the missing ones
+	StrictSequencerResponse bool `mapstructure:"StrictSequencerResponse"`
+
+	// SequencerChangeConfirmations is the number of blocks the L1 block of a trusted sequencer address or URL
+	// change must be behind the head before the tracker applies it, so a change that gets reorged out is never
+	// used. 0 applies the changes as soon as they are seen
+	SequencerChangeConfirmations uint64 `mapstructure:"SequencerChangeConfirmations"`
+
+	// SequencerResubscribeThreshold is the number of resubscribes of a tracker watch within
+	// SequencerResubscribeWindow above which the watch is reported unhealthy, as frequent resubscribes point
+	// to an unhealthy L1 endpoint. 0 disables the check
+	SequencerResubscribeThreshold uint `mapstructure:"SequencerResubscribeThreshold"`
+
+	// SequencerResubscribeWindow is the window the resubscribes are counted over
+	SequencerResubscribeWindow types.Duration `mapstructure:"SequencerResubscribeWindow"`
+
+	// PrefetchWindow is the number of most recent batches whose offchain data is read every time new batches
+	// are discovered, so it is already cached by the database when clients request it. 0 disables the prefetch
+	PrefetchWindow uint64 `mapstructure:"PrefetchWindow"`
+
+	// SequencerDataPath is an optional path and/or query template appended to the trusted sequencer URL to
+	// request a batch, e.g. "/rpc?batch={batchNum}", where {batchNum} is replaced by the batch number.
+	// Empty requests the batches at the sequencer URL itself
+	SequencerDataPath string `mapstructure:"SequencerDataPath"`
+
+	// SequencerHTTP configures the HTTP client shared by all calls to the trusted sequencer
+	SequencerHTTP HTTPClientConfig `mapstructure:"SequencerHTTP"`
+
 	// GenesisBlock represents the block number where PolygonValidium contract is deployed on L1
 	GenesisBlock uint64 `mapstructure:"GenesisBlock"`
 }
 
+// ReconciliationConfig defines how the range of stored batches is sampled for gaps on startup
+type ReconciliationConfig struct {
+	// Enabled defines if the reconciliation runs on startup
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Samples is the number of windows checked across the range of stored batches
+	Samples uint `mapstructure:"Samples"`
+
+	// SampleSize is the number of consecutive batches checked in every window
+	SampleSize uint `mapstructure:"SampleSize"`
+
+	// Resync resets the L1 sync task when gaps are found, so the missing batches are queued again
+	Resync bool `mapstructure:"Resync"`
+}
+
+// BatchScopeConfig restricts the batches the synchronizer resolves and stores, for nodes serving only some of
+// them. The batches out of scope are never queued to be resolved, so such a node cannot serve the data of
+// arbitrary batches. A batch is in scope if it is one of Batches, or within the range when MinBatch or MaxBatch
+// is set. Nothing set means every batch is in scope
+type BatchScopeConfig struct {
+	// MinBatch is the first batch in scope, 0 means no lower bound
+	MinBatch uint64 `mapstructure:"MinBatch"`
+
+	// MaxBatch is the last batch in scope, 0 means no upper bound
+	MaxBatch uint64 `mapstructure:"MaxBatch"`
+
+	// Batches are batches in scope, besides the range if it is set
+	Batches []uint64 `mapstructure:"Batches"`
+}
+
+// HTTPClientConfig defines the connection pool and TLS settings of an HTTP client
+type HTTPClientConfig struct {
+	// MaxIdleConns is the maximum number of idle connections kept across all hosts
+	MaxIdleConns int `mapstructure:"MaxIdleConns"`
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per host
+	MaxIdleConnsPerHost int `mapstructure:"MaxIdleConnsPerHost"`
+
+	// MaxConnsPerHost limits the total number of connections per host, 0 means no limit
+	MaxConnsPerHost int `mapstructure:"MaxConnsPerHost"`
+
+	// IdleConnTimeout is the time an idle connection is kept open before it is closed
+	IdleConnTimeout types.Duration `mapstructure:"IdleConnTimeout"`
+
+	// DisableKeepAlives disables connection reuse, opening a new connection for every request
+	DisableKeepAlives bool `mapstructure:"DisableKeepAlives"`
+
+	// TLSHandshakeTimeout is the maximum time to wait for a TLS handshake
+	TLSHandshakeTimeout types.Duration `mapstructure:"TLSHandshakeTimeout"`
+
+	// TLSInsecureSkipVerify disables the verification of the server certificate. Only use it for testing
+	TLSInsecureSkipVerify bool `mapstructure:"TLSInsecureSkipVerify"`
+}
+
 // Load loads the configuration baseed on the cli context
 func Load(ctx *cli.Context) (*Config, error) {
 	cfg, err := Default()
@@ -101,3 +315,60 @@ func NewKeyFromKeystore(cfg types.KeystoreFileConfig) (*ecdsa.PrivateKey, error)
 	}
 	return key.PrivateKey, nil
 }
+
+// AddressFromKeystore returns the address of the private key of the given keystore file, the one the node
+// must be registered with in the committee
+func AddressFromKeystore(cfg types.KeystoreFileConfig) (common.Address, error) {
+	pk, err := NewKeyFromKeystore(cfg)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if pk == nil {
+		return common.Address{}, errors.New("no private key configured")
+	}
+
+	return crypto.PubkeyToAddress(pk.PublicKey), nil
+}
+
+// NewRotationKeys loads the private keys of the rotation keystores
+func NewRotationKeys(cfg SignatureConfig) ([]*ecdsa.PrivateKey, error) {
+	keys := make([]*ecdsa.PrivateKey, 0, len(cfg.RotationKeys))
+	for i, keystoreCfg := range cfg.RotationKeys {
+		pk, err := NewKeyFromKeystore(keystoreCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rotation key %d: %w", i, err)
+		}
+
+		if pk == nil {
+			return nil, fmt.Errorf("rotation key %d has no keystore configured", i)
+		}
+
+		keys = append(keys, pk)
+	}
+
+	return keys, nil
+}
+
+// NewBLSKey loads the BLS private key the sequences are signed with.
+// It returns nil when the sequences are signed with the ECDSA private key instead
+func NewBLSKey(cfg SignatureConfig) (*daTypes.BLSPrivateKey, error) {
+	switch strings.ToLower(cfg.Scheme) {
+	case "", SignatureSchemeECDSA:
+		return nil, nil
+	case SignatureSchemeBLS:
+	default:
+		return nil, fmt.Errorf("unknown signature scheme %q", cfg.Scheme)
+	}
+
+	if cfg.BLSKeyPath == "" {
+		return nil, fmt.Errorf("the %s signature scheme requires a BLS key path", SignatureSchemeBLS)
+	}
+
+	encoded, err := os.ReadFile(filepath.Clean(cfg.BLSKeyPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return daTypes.BLSPrivateKeyFromBytes(common.FromHex(strings.TrimSpace(string(encoded))))
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/config/types"
+	daTypes "github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
 )
@@ -39,6 +41,22 @@ func Test_Defaults(t *testing.T) {
 			path:          "L1.BlockBatchSize",
 			expectedValue: uint(64),
 		},
+		{
+			path:          "L1.SequencerHTTP.MaxIdleConnsPerHost",
+			expectedValue: 32,
+		},
+		{
+			path:          "L1.SequencerHTTP.IdleConnTimeout",
+			expectedValue: types.NewDuration(90 * time.Second),
+		},
+		{
+			path:          "ShutdownTimeout",
+			expectedValue: types.NewDuration(30 * time.Second),
+		},
+		{
+			path:          "Signature.Scheme",
+			expectedValue: SignatureSchemeECDSA,
+		},
 		// TODO: more default checks
 	}
 
@@ -134,6 +152,20 @@ func Test_NewKeyFromKeystore(t *testing.T) {
 	})
 }
 
+func Test_AddressFromKeystore(t *testing.T) {
+	t.Parallel()
+
+	addr, err := AddressFromKeystore(types.KeystoreFileConfig{
+		Path:     "../test/config/test-member.keystore",
+		Password: "testonly",
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), addr)
+
+	_, err = AddressFromKeystore(types.KeystoreFileConfig{})
+	require.EqualError(t, err, "no private key configured")
+}
+
 func getValueFromStruct(path string, object interface{}) interface{} {
 	keySlice := strings.Split(path, ".")
 	v := reflect.ValueOf(object)
@@ -147,3 +179,55 @@ func getValueFromStruct(path string, object interface{}) interface{} {
 	}
 	return v.Interface()
 }
+
+func Test_NewBLSKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := daTypes.GenerateBLSKey()
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "bls.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte(common.Bytes2Hex(key.Bytes())+"\n"), 0600))
+
+	tcs := []struct {
+		name    string
+		cfg     SignatureConfig
+		key     *daTypes.BLSPrivateKey
+		wantErr string
+	}{
+		{
+			name: "ecdsa scheme",
+			cfg:  SignatureConfig{Scheme: SignatureSchemeECDSA, BLSKeyPath: keyPath},
+		},
+		{
+			name: "bls scheme",
+			cfg:  SignatureConfig{Scheme: SignatureSchemeBLS, BLSKeyPath: keyPath},
+			key:  key,
+		},
+		{
+			name:    "bls scheme without key",
+			cfg:     SignatureConfig{Scheme: SignatureSchemeBLS},
+			wantErr: "the bls signature scheme requires a BLS key path",
+		},
+		{
+			name:    "unknown scheme",
+			cfg:     SignatureConfig{Scheme: "schnorr"},
+			wantErr: "unknown signature scheme \"schnorr\"",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := NewBLSKey(tc.cfg)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.key, actual)
+			}
+		})
+	}
+}
@@ -10,6 +10,13 @@ import (
 // DefaultValues is the default configuration
 const DefaultValues = `
 PrivateKey = {Path = "/pk/test-member.keystore", Password = "testonly"}
+ShutdownTimeout = "30s"
+
+[Signature]
+Scheme = "ecdsa"
+BLSKeyPath = ""
+RotationKeys = []
+RotationGracePeriod = "1h"
 
 [L1]
 RpcURL = "ws://127.0.0.1:8546"
@@ -21,6 +28,52 @@ BlockBatchSize = "64"
 GenesisBlock = "0"
 TrackSequencer = true
 TrackSequencerPollInterval = "1m"
+HealthCheckInterval = "30s"
+FallbackRpcURLs = []
+SequencerURLAllowlist = []
+MaxInFlightBatches = 10000
+MaxResolveAttempts = 0
+ResolveBackoffCap = "0s"
+WriteFailureThreshold = 5
+WriteFailureBackoff = "1m"
+ResolvedHistoryRetention = "0s"
+BatchResolvedWebhook = ""
+BatchResolvedHookConcurrency = 4
+FinalizationDepth = 64
+FinalizeOnVerification = false
+PruneFinalized = false
+SignatureChainID = 0
+MaxBatchesPerSequence = 1000
+MaxBlobSize = 0
+ChallengeWindow = 50400
+FetchOnMiss = false
+FetchOnMissTimeout = "5s"
+StrictSequencerResponse = false
+SequencerChangeConfirmations = 0
+SequencerResubscribeThreshold = 0
+SequencerResubscribeWindow = "10m"
+PrefetchWindow = 0
+SequencerDataPath = ""
+
+[L1.Reconciliation]
+Enabled = true
+Samples = 16
+SampleSize = 100
+Resync = false
+
+[L1.BatchScope]
+MinBatch = 0
+MaxBatch = 0
+Batches = []
+
+[L1.SequencerHTTP]
+MaxIdleConns = 100
+MaxIdleConnsPerHost = 32
+MaxConnsPerHost = 0
+IdleConnTimeout = "90s"
+DisableKeepAlives = false
+TLSHandshakeTimeout = "10s"
+TLSInsecureSkipVerify = false
 
 [Log]
 Environment = "development" # "production" or "development"
@@ -35,6 +88,15 @@ Host = "cdk-data-availability-db"
 Port = "5432"
 EnableLog = false
 MaxConns = 200
+InsertChunkSize = 500
+Warmup = false
+Audit = false
+AllowEmptyValues = false
+ReplicaHost = ""
+ReplicaPort = "5432"
+IntegritySampleInterval = "0s"
+IntegritySampleSize = 100
+StoredBytesInterval = "10m"
 
 [RPC]
 Host = "0.0.0.0"
@@ -42,6 +104,36 @@ Port = 8444
 ReadTimeout = "60s"
 WriteTimeout = "60s"
 MaxRequestsPerIPAndSecond = 500
+MaxRequestBodySize = 10485760
+EnableAdminAPI = false
+AccessLogLevel = ""
+DefaultMethodTimeout = "30s"
+MethodTimeouts = {}
+CompressionEncodings = []
+CompressionMinSize = 1024
+StrongConsistencyMethods = []
+
+[GRPC]
+Enabled = false
+Host = "0.0.0.0"
+Port = 8445
+IntegrityScanPageSize = 1000
+MaxIntegrityScanBatches = 100000
+
+[Metrics]
+Enabled = false
+Host = "0.0.0.0"
+Port = 9091
+
+[S3]
+Enabled = false
+Endpoint = ""
+Region = ""
+Bucket = ""
+Prefix = ""
+AccessKeyID = ""
+SecretAccessKey = ""
+UseSSL = true
 `
 
 // Default parses the default configuration values.
@@ -0,0 +1,373 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// unknownAuditSource is the source of the writes whose context does not carry one
+const unknownAuditSource = "unknown"
+
+// auditSourceKey is the context key of the source of the writes
+type auditSourceKey struct{}
+
+// WithAuditSource returns a context whose writes are audited as performed by the given source
+func WithAuditSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, auditSourceKey{}, source)
+}
+
+// auditSource returns the source of the writes performed with the given context
+func auditSource(ctx context.Context) string {
+	if source, ok := ctx.Value(auditSourceKey{}).(string); ok {
+		return source
+	}
+
+	return unknownAuditSource
+}
+
+// AuditEntry describes a write to the store and its outcome
+type AuditEntry struct {
+	Operation string
+	Source    string
+	Keys      []common.Hash
+	BatchNums []uint64
+	Block     uint64
+	Task      string
+
+	// Before is the cutoff of a prune of the history, and Pruned the number of rows it deleted
+	Before time.Time
+	Pruned uint64
+
+	Err error
+}
+
+// AuditSink receives an entry for every write to the store
+type AuditSink interface {
+	Audit(entry AuditEntry)
+}
+
+// logAuditSink is an AuditSink that writes the entries to the logger
+type logAuditSink struct {
+	logger *log.Logger
+}
+
+// NewLogAuditSink creates an AuditSink that writes the entries as structured log lines
+func NewLogAuditSink() AuditSink {
+	return &logAuditSink{logger: log.WithFields("component", "audit")}
+}
+
+// Audit writes the given entry to the logger
+func (s *logAuditSink) Audit(entry AuditEntry) {
+	kv := []interface{}{"operation", entry.Operation, "source", entry.Source}
+
+	if len(entry.Keys) > 0 {
+		keys := make([]string, len(entry.Keys))
+		for i, key := range entry.Keys {
+			keys[i] = key.Hex()
+		}
+
+		kv = append(kv, "keys", keys)
+	}
+
+	if len(entry.BatchNums) > 0 {
+		kv = append(kv, "batchNums", entry.BatchNums)
+	}
+
+	if entry.Task != "" {
+		kv = append(kv, "task", entry.Task, "block", entry.Block)
+	}
+
+	if !entry.Before.IsZero() {
+		kv = append(kv, "before", entry.Before, "pruned", entry.Pruned)
+	}
+
+	if entry.Err != nil {
+		s.logger.Warnw("write failed", append(kv, "outcome", "failed", "error", entry.Err.Error())...)
+		return
+	}
+
+	s.logger.Infow("write succeeded", append(kv, "outcome", "succeeded")...)
+}
+
+// auditDB is a DB that audits every write to the wrapped DB
+type auditDB struct {
+	DB
+
+	sink AuditSink
+}
+
+// NewAuditDB wraps the given DB so that every write to it is reported to the given sink
+func NewAuditDB(db DB, sink AuditSink) DB {
+	return &auditDB{
+		DB:   db,
+		sink: sink,
+	}
+}
+
+// StoreLastProcessedBlock stores a record of a block processed by the synchronizer for named task
+func (db *auditDB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
+	err := db.DB.StoreLastProcessedBlock(ctx, block, task)
+	db.sink.Audit(AuditEntry{
+		Operation: "StoreLastProcessedBlock",
+		Source:    auditSource(ctx),
+		Block:     block,
+		Task:      task,
+		Err:       err,
+	})
+
+	return err
+}
+
+// StoreL1TxHash stores the hash of the L1 transaction that sequenced the batches from the given one on,
+// along with their committed keys
+func (db *auditDB) StoreL1TxHash(
+	ctx context.Context, from uint64, keys []common.Hash, txHash common.Hash, l1Block uint64,
+) error {
+	err := db.DB.StoreL1TxHash(ctx, from, keys, txHash, l1Block)
+	db.sink.Audit(AuditEntry{
+		Operation: "StoreL1TxHash",
+		Source:    auditSource(ctx),
+		Keys:      append([]common.Hash{txHash}, keys...),
+		BatchNums: []uint64{from, from + uint64(len(keys)) - 1},
+		Err:       err,
+	})
+
+	return err
+}
+
+// StoreMissingBatchKeys stores missing batch keys in the database
+func (db *auditDB) StoreMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	err := db.DB.StoreMissingBatchKeys(ctx, bks)
+	db.sink.Audit(batchKeysEntry(ctx, "StoreMissingBatchKeys", bks, err))
+
+	return err
+}
+
+// InsertMissingBatchKeys stores missing batch keys in the database and returns how many were inserted
+func (db *auditDB) InsertMissingBatchKeys(ctx context.Context, bks []types.BatchKey) (uint64, error) {
+	inserted, err := db.DB.InsertMissingBatchKeys(ctx, bks)
+	db.sink.Audit(batchKeysEntry(ctx, "InsertMissingBatchKeys", bks, err))
+
+	return inserted, err
+}
+
+// DeleteMissingBatchKeys deletes the given missing batch keys from the database
+func (db *auditDB) DeleteMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	err := db.DB.DeleteMissingBatchKeys(ctx, bks)
+	db.sink.Audit(batchKeysEntry(ctx, "DeleteMissingBatchKeys", bks, err))
+
+	return err
+}
+
+// IncrementBatchAttempts counts one more failed attempt to resolve the given missing batch key
+func (db *auditDB) IncrementBatchAttempts(ctx context.Context, key types.BatchKey) (uint, error) {
+	attempts, err := db.DB.IncrementBatchAttempts(ctx, key)
+	db.sink.Audit(batchKeysEntry(ctx, "IncrementBatchAttempts", []types.BatchKey{key}, err))
+
+	return attempts, err
+}
+
+// StoreFailedBatch moves the given missing batch key to the failed batches
+func (db *auditDB) StoreFailedBatch(ctx context.Context, key types.BatchKey, reason string) error {
+	err := db.DB.StoreFailedBatch(ctx, key, reason)
+	db.sink.Audit(batchKeysEntry(ctx, "StoreFailedBatch", []types.BatchKey{key}, err))
+
+	return err
+}
+
+// RequeueFailedBatch moves the given failed batch key back to the missing batches
+func (db *auditDB) RequeueFailedBatch(ctx context.Context, key types.BatchKey) error {
+	err := db.DB.RequeueFailedBatch(ctx, key)
+	db.sink.Audit(batchKeysEntry(ctx, "RequeueFailedBatch", []types.BatchKey{key}, err))
+
+	return err
+}
+
+// CorrectOffChainDataBatchNums moves the given keys to their corrected batch numbers
+func (db *auditDB) CorrectOffChainDataBatchNums(ctx context.Context, corrections []types.KeyBatchCorrection) error {
+	err := db.DB.CorrectOffChainDataBatchNums(ctx, corrections)
+
+	entry := AuditEntry{
+		Operation: "CorrectOffChainDataBatchNums",
+		Source:    auditSource(ctx),
+		Keys:      make([]common.Hash, len(corrections)),
+		BatchNums: make([]uint64, len(corrections)),
+		Err:       err,
+	}
+
+	for i, c := range corrections {
+		entry.Keys[i] = c.Key
+		entry.BatchNums[i] = uint64(c.To)
+	}
+
+	db.sink.Audit(entry)
+
+	return err
+}
+
+// DeleteMissingBatchKeysTx deletes the given missing batch keys within the given transaction
+func (db *auditDB) DeleteMissingBatchKeysTx(ctx context.Context, bks []types.BatchKey, tx Tx) error {
+	err := db.DB.DeleteMissingBatchKeysTx(ctx, bks, tx)
+	db.sink.Audit(batchKeysEntry(ctx, "DeleteMissingBatchKeysTx", bks, err))
+
+	return err
+}
+
+// StoreOffChainData stores and array of key values in the Db
+func (db *auditDB) StoreOffChainData(ctx context.Context, ods []types.OffChainData) error {
+	err := db.DB.StoreOffChainData(ctx, ods)
+	db.sink.Audit(offChainDataEntry(ctx, "StoreOffChainData", ods, err))
+
+	return err
+}
+
+// StoreOffChainDataTx stores and array of key values within the given transaction
+func (db *auditDB) StoreOffChainDataTx(ctx context.Context, ods []types.OffChainData, tx Tx) error {
+	err := db.DB.StoreOffChainDataTx(ctx, ods, tx)
+	db.sink.Audit(offChainDataEntry(ctx, "StoreOffChainDataTx", ods, err))
+
+	return err
+}
+
+// StoreOffChainDataPartial stores the given key values, skipping the ones that cannot be stored.
+// Only the stored keys are audited
+func (db *auditDB) StoreOffChainDataPartial(
+	ctx context.Context, ods []types.OffChainData,
+) ([]types.FailedRecord, error) {
+	failed, err := db.DB.StoreOffChainDataPartial(ctx, ods)
+
+	skipped := make(map[common.Hash]bool, len(failed))
+	for _, record := range failed {
+		skipped[record.Key] = true
+	}
+
+	stored := make([]types.OffChainData, 0, len(ods))
+	for _, od := range ods {
+		if err != nil || !skipped[od.Key] {
+			stored = append(stored, od)
+		}
+	}
+
+	db.sink.Audit(offChainDataEntry(ctx, "StoreOffChainDataPartial", stored, err))
+
+	return failed, err
+}
+
+// ReplaceOffChainData replaces the stored value of the given key
+func (db *auditDB) ReplaceOffChainData(ctx context.Context, key common.Hash, newValue []byte) error {
+	err := db.DB.ReplaceOffChainData(ctx, key, newValue)
+	db.sink.Audit(AuditEntry{
+		Operation: "ReplaceOffChainData",
+		Source:    auditSource(ctx),
+		Keys:      []common.Hash{key},
+		Err:       err,
+	})
+
+	return err
+}
+
+// MarkFinalized marks the offchain data of all the batches up to the given batch number as finalized
+func (db *auditDB) MarkFinalized(ctx context.Context, upToBatch uint64) error {
+	err := db.DB.MarkFinalized(ctx, upToBatch)
+	db.sink.Audit(AuditEntry{
+		Operation: "MarkFinalized",
+		Source:    auditSource(ctx),
+		BatchNums: []uint64{upToBatch},
+		Err:       err,
+	})
+
+	return err
+}
+
+// PruneFinalized deletes the finalized offchain data of the batches up to the given batch number
+func (db *auditDB) PruneFinalized(ctx context.Context, upToBatch uint64) (uint64, error) {
+	pruned, err := db.DB.PruneFinalized(ctx, upToBatch)
+	db.sink.Audit(AuditEntry{
+		Operation: "PruneFinalized",
+		Source:    auditSource(ctx),
+		BatchNums: []uint64{upToBatch},
+		Err:       err,
+	})
+
+	return pruned, err
+}
+
+// PruneResolvedBatches deletes the resolved batches history up to the given time and returns how many
+// keys were deleted
+func (db *auditDB) PruneResolvedBatches(ctx context.Context, before time.Time) (uint64, error) {
+	pruned, err := db.DB.PruneResolvedBatches(ctx, before)
+	db.sink.Audit(AuditEntry{
+		Operation: "PruneResolvedBatches",
+		Source:    auditSource(ctx),
+		Before:    before,
+		Pruned:    pruned,
+		Err:       err,
+	})
+
+	return pruned, err
+}
+
+// ArchiveMissingBatchKeys moves the given resolved missing batch keys to the resolved batches history
+func (db *auditDB) ArchiveMissingBatchKeys(ctx context.Context, resolved []types.ResolvedBatch) error {
+	err := db.DB.ArchiveMissingBatchKeys(ctx, resolved)
+	db.sink.Audit(resolvedBatchesEntry(ctx, "ArchiveMissingBatchKeys", resolved, err))
+
+	return err
+}
+
+// ArchiveMissingBatchKeysTx moves the given resolved missing batch keys to the resolved batches history
+// within the given transaction
+func (db *auditDB) ArchiveMissingBatchKeysTx(ctx context.Context, resolved []types.ResolvedBatch, tx Tx) error {
+	err := db.DB.ArchiveMissingBatchKeysTx(ctx, resolved, tx)
+	db.sink.Audit(resolvedBatchesEntry(ctx, "ArchiveMissingBatchKeysTx", resolved, err))
+
+	return err
+}
+
+// resolvedBatchesEntry builds the audit entry of a write of the given resolved batch keys
+func resolvedBatchesEntry(ctx context.Context, operation string, resolved []types.ResolvedBatch, err error) AuditEntry {
+	bks := make([]types.BatchKey, len(resolved))
+	for i, r := range resolved {
+		bks[i] = types.BatchKey{Number: uint64(r.Number), Hash: r.Hash}
+	}
+
+	return batchKeysEntry(ctx, operation, bks, err)
+}
+
+// batchKeysEntry builds the audit entry of a write of the given batch keys
+func batchKeysEntry(ctx context.Context, operation string, bks []types.BatchKey, err error) AuditEntry {
+	entry := AuditEntry{
+		Operation: operation,
+		Source:    auditSource(ctx),
+		Keys:      make([]common.Hash, len(bks)),
+		BatchNums: make([]uint64, len(bks)),
+		Err:       err,
+	}
+
+	for i, bk := range bks {
+		entry.Keys[i] = bk.Hash
+		entry.BatchNums[i] = bk.Number
+	}
+
+	return entry
+}
+
+// offChainDataEntry builds the audit entry of a write of the given offchain data
+func offChainDataEntry(ctx context.Context, operation string, ods []types.OffChainData, err error) AuditEntry {
+	entry := AuditEntry{
+		Operation: operation,
+		Source:    auditSource(ctx),
+		Keys:      make([]common.Hash, len(ods)),
+		Err:       err,
+	}
+
+	for i, od := range ods {
+		entry.Keys[i] = od.Key
+	}
+
+	return entry
+}
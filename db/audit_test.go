@@ -0,0 +1,203 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAuditSink struct {
+	entries []db.AuditEntry
+}
+
+func (s *recordingAuditSink) Audit(entry db.AuditEntry) {
+	s.entries = append(s.entries, entry)
+}
+
+func TestAuditDB(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("test error")
+	key1 := common.HexToHash("0x01")
+	key2 := common.HexToHash("0x02")
+	cutoff := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		source string
+		mock   func(*mocks.DB)
+		write  func(context.Context, db.DB) error
+		entry  db.AuditEntry
+	}{
+		{
+			name:   "StoreOffChainData succeeded",
+			source: "datacom",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("StoreOffChainData", mock.Anything, mock.Anything).Return(nil)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				return d.StoreOffChainData(ctx, []types.OffChainData{{Key: key1}, {Key: key2}})
+			},
+			entry: db.AuditEntry{
+				Operation: "StoreOffChainData",
+				Source:    "datacom",
+				Keys:      []common.Hash{key1, key2},
+			},
+		},
+		{
+			name: "StoreMissingBatchKeys failed without source",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("StoreMissingBatchKeys", mock.Anything, mock.Anything).Return(testErr)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				return d.StoreMissingBatchKeys(ctx, []types.BatchKey{{Number: 3, Hash: key1}})
+			},
+			entry: db.AuditEntry{
+				Operation: "StoreMissingBatchKeys",
+				Source:    "unknown",
+				Keys:      []common.Hash{key1},
+				BatchNums: []uint64{3},
+				Err:       testErr,
+			},
+		},
+		{
+			name:   "DeleteMissingBatchKeys succeeded",
+			source: "synchronizer",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("DeleteMissingBatchKeys", mock.Anything, mock.Anything).Return(nil)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				return d.DeleteMissingBatchKeys(ctx, []types.BatchKey{{Number: 4, Hash: key2}})
+			},
+			entry: db.AuditEntry{
+				Operation: "DeleteMissingBatchKeys",
+				Source:    "synchronizer",
+				Keys:      []common.Hash{key2},
+				BatchNums: []uint64{4},
+			},
+		},
+		{
+			name:   "ReplaceOffChainData succeeded",
+			source: "healer",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("ReplaceOffChainData", mock.Anything, key1, []byte("value")).Return(nil)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				return d.ReplaceOffChainData(ctx, key1, []byte("value"))
+			},
+			entry: db.AuditEntry{
+				Operation: "ReplaceOffChainData",
+				Source:    "healer",
+				Keys:      []common.Hash{key1},
+			},
+		},
+		{
+			name:   "StoreLastProcessedBlock succeeded",
+			source: "synchronizer",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("StoreLastProcessedBlock", mock.Anything, uint64(10), "L1").Return(nil)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				return d.StoreLastProcessedBlock(ctx, 10, "L1")
+			},
+			entry: db.AuditEntry{
+				Operation: "StoreLastProcessedBlock",
+				Source:    "synchronizer",
+				Block:     10,
+				Task:      "L1",
+			},
+		},
+		{
+			name:   "MarkFinalized succeeded",
+			source: "synchronizer",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("MarkFinalized", mock.Anything, uint64(7)).Return(nil)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				return d.MarkFinalized(ctx, 7)
+			},
+			entry: db.AuditEntry{
+				Operation: "MarkFinalized",
+				Source:    "synchronizer",
+				BatchNums: []uint64{7},
+			},
+		},
+		{
+			name:   "PruneFinalized failed",
+			source: "pruner",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("PruneFinalized", mock.Anything, uint64(7)).Return(uint64(0), testErr)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				_, err := d.PruneFinalized(ctx, 7)
+				return err
+			},
+			entry: db.AuditEntry{
+				Operation: "PruneFinalized",
+				Source:    "pruner",
+				BatchNums: []uint64{7},
+				Err:       testErr,
+			},
+		},
+		{
+			name:   "PruneResolvedBatches succeeded",
+			source: "synchronizer",
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("PruneResolvedBatches", mock.Anything, cutoff).Return(uint64(5), nil)
+			},
+			write: func(ctx context.Context, d db.DB) error {
+				_, err := d.PruneResolvedBatches(ctx, cutoff)
+				return err
+			},
+			entry: db.AuditEntry{
+				Operation: "PruneResolvedBatches",
+				Source:    "synchronizer",
+				Before:    cutoff,
+				Pruned:    5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			tt.mock(dbMock)
+
+			ctx := context.Background()
+			if tt.source != "" {
+				ctx = db.WithAuditSource(ctx, tt.source)
+			}
+
+			sink := &recordingAuditSink{}
+
+			err := tt.write(ctx, db.NewAuditDB(dbMock, sink))
+			require.ErrorIs(t, err, tt.entry.Err)
+			require.Equal(t, []db.AuditEntry{tt.entry}, sink.entries)
+		})
+	}
+
+	t.Run("reads are not audited", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetLastProcessedBlock", mock.Anything, "L1").Return(uint64(10), nil)
+
+		sink := &recordingAuditSink{}
+
+		_, err := db.NewAuditDB(dbMock, sink).GetLastProcessedBlock(context.Background(), "L1")
+		require.NoError(t, err)
+		require.Empty(t, sink.entries)
+	})
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// benchDSNEnv is the environment variable with the connection string of the Postgres database to benchmark against
+const benchDSNEnv = "DATA_NODE_BENCH_DSN"
+
+// BenchmarkStoreOffChainData measures the throughput of storing a sequence of offchain data
+// across insert chunk sizes and blob sizes. It needs a real Postgres database, see docs/running.md
+func BenchmarkStoreOffChainData(b *testing.B) {
+	dsn := os.Getenv(benchDSNEnv)
+	if dsn == "" {
+		b.Skipf("%s is not set", benchDSNEnv)
+	}
+
+	pg, err := sqlx.Connect("postgres", dsn)
+	require.NoError(b, err)
+
+	defer pg.Close()
+
+	require.NoError(b, RunMigrationsUp(pg))
+
+	const sequenceLen = 2000
+
+	for _, blobSize := range []int{1 << 10, 16 << 10, 128 << 10} {
+		for _, chunkSize := range []uint{10, 50, 100, 500, 1000, 5000} {
+			b.Run(fmt.Sprintf("blob=%dKB/chunk=%d", blobSize>>10, chunkSize), func(b *testing.B) {
+				dbPG, err := New(context.Background(), pg, chunkSize)
+				require.NoError(b, err)
+
+				b.SetBytes(int64(sequenceLen * blobSize))
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					ods := randomOffChainData(b, sequenceLen, blobSize)
+					b.StartTimer()
+
+					require.NoError(b, dbPG.StoreOffChainData(context.Background(), ods))
+				}
+
+				b.StopTimer()
+
+				_, err = pg.Exec("TRUNCATE data_node.offchain_data;")
+				require.NoError(b, err)
+			})
+		}
+	}
+}
+
+func randomOffChainData(b *testing.B, n, size int) []types.OffChainData {
+	b.Helper()
+
+	ods := make([]types.OffChainData, n)
+	for i := range ods {
+		value := make([]byte, size)
+
+		_, err := rand.Read(value)
+		require.NoError(b, err)
+
+		ods[i] = types.OffChainData{
+			Key:      crypto.Keccak256Hash(value),
+			Value:    value,
+			BatchNum: uint64(i + 1),
+		}
+	}
+
+	return ods
+}
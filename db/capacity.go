@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// CapacityMonitor periodically exports the size of the stored values, so the operators can alert
+// before the disk fills. Summing the sizes scans the whole table, so it is not done on every scrape
+type CapacityMonitor struct {
+	db       DB
+	interval time.Duration
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewCapacityMonitor returns the monitor exporting the size of the values of the given DB at every interval
+func NewCapacityMonitor(db DB, interval time.Duration) *CapacityMonitor {
+	return &CapacityMonitor{
+		db:       db,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start exports the size right away and then at every interval until the context is done or the monitor
+// is stopped
+func (m *CapacityMonitor) Start(ctx context.Context) {
+	m.wg.Add(1)
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Refresh(ctx); err != nil {
+			log.Errorf("failed to get the size of the stored values: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the monitor and waits for the running refresh to finish
+func (m *CapacityMonitor) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+	m.wg.Wait()
+}
+
+// Refresh exports and returns the size in bytes of the stored values
+func (m *CapacityMonitor) Refresh(ctx context.Context) (uint64, error) {
+	size, err := m.db.TotalStoredBytes(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	storedBytes.Set(float64(size))
+
+	return size, nil
+}
@@ -0,0 +1,61 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapacityMonitor_Refresh(t *testing.T) {
+	t.Parallel()
+
+	t.Run("size exported", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("TotalStoredBytes", mock.Anything).Return(uint64(10240), nil).Once()
+
+		size, err := db.NewCapacityMonitor(dbMock, time.Minute).Refresh(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(10240), size)
+	})
+
+	t.Run("size fails", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("TotalStoredBytes", mock.Anything).Return(uint64(0), errors.New("test error")).Once()
+
+		_, err := db.NewCapacityMonitor(dbMock, time.Minute).Refresh(context.Background())
+		require.EqualError(t, err, "test error")
+	})
+}
+
+func TestCapacityMonitor_Stop(t *testing.T) {
+	t.Parallel()
+
+	dbMock := mocks.NewDB(t)
+	dbMock.On("TotalStoredBytes", mock.Anything).Return(uint64(0), nil).Maybe()
+
+	monitor := db.NewCapacityMonitor(dbMock, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		monitor.Start(context.Background())
+		close(done)
+	}()
+
+	monitor.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("monitor did not stop")
+	}
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// challengeWindowDB is a DB that never prunes the offchain data of batches sequenced within
+// the challenge window, since a late client may still legitimately request it
+type challengeWindowDB struct {
+	DB
+
+	window uint64
+	head   func(ctx context.Context) (uint64, error)
+}
+
+// NewChallengeWindowDB wraps the given DB so the finalized offchain data of the batches sequenced within the last
+// window L1 blocks is not pruned, regardless of being finalized. The head function returns the current L1 block.
+// Batches synchronized before their L1 block was tracked are considered out of the window
+func NewChallengeWindowDB(db DB, window uint64, head func(ctx context.Context) (uint64, error)) DB {
+	return &challengeWindowDB{
+		DB:     db,
+		window: window,
+		head:   head,
+	}
+}
+
+// PruneFinalized deletes the finalized offchain data of the batches up to the given batch number,
+// except the ones still within the challenge window
+func (db *challengeWindowDB) PruneFinalized(ctx context.Context, upToBatch uint64) (uint64, error) {
+	head, err := db.head(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the L1 head to check the challenge window: %w", err)
+	}
+
+	var windowStart uint64
+	if head > db.window {
+		windowStart = head - db.window
+	}
+
+	firstInWindow, err := db.DB.GetFirstBatchSequencedAfter(ctx, windowStart)
+	if err != nil {
+		return 0, err
+	}
+
+	if firstInWindow > 0 && upToBatch >= firstInWindow {
+		log.Warnf("REFUSING TO PRUNE batches %d to %d: they were sequenced within the challenge window "+
+			"of %d blocks (L1 head %d)", firstInWindow, upToBatch, db.window, head)
+
+		if firstInWindow == 1 {
+			return 0, nil
+		}
+
+		upToBatch = firstInWindow - 1
+	}
+
+	return db.DB.PruneFinalized(ctx, upToBatch)
+}
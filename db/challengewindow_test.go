@@ -0,0 +1,110 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeWindowDB_PruneFinalized(t *testing.T) {
+	t.Parallel()
+
+	const window = 100
+
+	testErr := errors.New("test error")
+
+	tests := []struct {
+		name          string
+		head          uint64
+		headErr       error
+		windowStart   uint64
+		firstInWindow uint64
+		firstErr      error
+		upToBatch     uint64
+		prunedUpTo    uint64
+		err           string
+	}{
+		{
+			name:          "all batches out of the window",
+			head:          1000,
+			windowStart:   900,
+			firstInWindow: 51,
+			upToBatch:     50,
+			prunedUpTo:    50,
+		},
+		{
+			name:        "no batch sequenced within the window",
+			head:        1000,
+			windowStart: 900,
+			upToBatch:   50,
+			prunedUpTo:  50,
+		},
+		{
+			name:          "batches within the window are kept",
+			head:          1000,
+			windowStart:   900,
+			firstInWindow: 41,
+			upToBatch:     50,
+			prunedUpTo:    40,
+		},
+		{
+			name:          "every batch within the window",
+			head:          50,
+			firstInWindow: 1,
+			upToBatch:     50,
+		},
+		{
+			name:      "head not found",
+			headErr:   testErr,
+			upToBatch: 50,
+			err:       "failed to get the L1 head to check the challenge window: test error",
+		},
+		{
+			name:        "first batch in the window not found",
+			head:        1000,
+			windowStart: 900,
+			firstErr:    testErr,
+			upToBatch:   50,
+			err:         "test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			if tt.headErr == nil {
+				dbMock.On("GetFirstBatchSequencedAfter", mock.Anything, tt.windowStart).
+					Return(tt.firstInWindow, tt.firstErr).Once()
+			}
+			if tt.prunedUpTo > 0 {
+				dbMock.On("PruneFinalized", mock.Anything, tt.prunedUpTo).Return(uint64(3), nil).Once()
+			}
+
+			head := func(context.Context) (uint64, error) {
+				return tt.head, tt.headErr
+			}
+
+			pruned, err := db.NewChallengeWindowDB(dbMock, window, head).PruneFinalized(context.Background(), tt.upToBatch)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.prunedUpTo > 0 {
+				require.Equal(t, uint64(3), pruned)
+			} else {
+				require.Zero(t, pruned)
+			}
+		})
+	}
+}
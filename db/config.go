@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/0xPolygon/cdk-data-availability/config/types"
 	"github.com/0xPolygon/cdk-data-availability/log"
 	"github.com/jmoiron/sqlx"
 )
@@ -31,6 +32,41 @@ type Config struct {
 
 	// MaxConns is the maximum number of connections in the pool.
 	MaxConns int `mapstructure:"MaxConns"`
+
+	// InsertChunkSize is the maximum number of rows inserted by a single statement when storing offchain data.
+	// Larger chunks mean fewer round trips but bigger statements, so it should be lowered for big blobs.
+	// Zero means DefaultInsertChunkSize
+	InsertChunkSize uint `mapstructure:"InsertChunkSize"`
+
+	// Warmup opens MaxConns connections on startup and runs the hot read queries on each of them,
+	// so the first requests after a restart do not hit a cold connection pool
+	Warmup bool `mapstructure:"Warmup"`
+
+	// Audit logs a structured entry with the keys, the source and the outcome of every write to the storage
+	Audit bool `mapstructure:"Audit"`
+
+	// AllowEmptyValues allows the sequencer to store zero-length offchain data values. By default the sequences
+	// holding one are rejected with ErrEmptyOffChainData, since a batch without data almost always indicates a bug
+	// upstream. The data the synchronizer resolves is stored either way, as it is already committed on L1
+	AllowEmptyValues bool `mapstructure:"AllowEmptyValues"`
+
+	// ReplicaHost is the address of a read replica of the database, with the same name and credentials.
+	// The offchain data reads are served by it unless they ask for strong consistency. Empty disables it
+	ReplicaHost string `mapstructure:"ReplicaHost"`
+
+	// ReplicaPort is the port of the read replica
+	ReplicaPort string `mapstructure:"ReplicaPort"`
+
+	// IntegritySampleInterval is how often a random sample of the stored values is checked against their keys.
+	// Zero disables the sampling
+	IntegritySampleInterval types.Duration `mapstructure:"IntegritySampleInterval"`
+
+	// IntegritySampleSize is the number of values checked by every sample. Zero means DefaultIntegritySampleSize
+	IntegritySampleSize uint `mapstructure:"IntegritySampleSize"`
+
+	// StoredBytesInterval is how often the size of the stored values is exported as a metric. Computing it
+	// scans the whole table, so it should not be too frequent on large databases. Zero disables it
+	StoredBytesInterval types.Duration `mapstructure:"StoredBytesInterval"`
 }
 
 // InitContext initializes DB connection by the given config
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Consistency is the consistency of the offchain data reads when a read replica is configured
+type Consistency string
+
+const (
+	// ConsistencyEventual reads from the replica, which may not see the latest writes yet
+	ConsistencyEventual Consistency = "eventual"
+
+	// ConsistencyStrong reads from the primary, which sees all the committed writes
+	ConsistencyStrong Consistency = "strong"
+)
+
+// ParseConsistency returns the consistency of the given name, matched case insensitively
+func ParseConsistency(name string) (Consistency, error) {
+	switch c := Consistency(strings.ToLower(strings.TrimSpace(name))); c {
+	case ConsistencyEventual, ConsistencyStrong:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown read consistency %q", name)
+	}
+}
+
+// consistencyKey is the context key of the consistency of the reads
+type consistencyKey struct{}
+
+// WithConsistency returns a context whose offchain data reads have the given consistency
+func WithConsistency(ctx context.Context, consistency Consistency) context.Context {
+	return context.WithValue(ctx, consistencyKey{}, consistency)
+}
+
+// consistencyFrom returns the consistency of the reads performed with the given context, eventual by default
+func consistencyFrom(ctx context.Context) Consistency {
+	if consistency, ok := ctx.Value(consistencyKey{}).(Consistency); ok {
+		return consistency
+	}
+
+	return ConsistencyEventual
+}
+
+// replicaDB is a DB that serves the offchain data reads with eventual consistency from a read replica.
+// Every other call, and the reads with strong consistency, go to the wrapped primary DB
+type replicaDB struct {
+	DB
+
+	replica DB
+}
+
+// NewReplicaDB wraps the given primary DB so the offchain data reads are served by the given replica,
+// unless their context asks for strong consistency
+func NewReplicaDB(primary, replica DB) DB {
+	return &replicaDB{
+		DB:      primary,
+		replica: replica,
+	}
+}
+
+// reader returns the DB the offchain data reads performed with the given context are served by
+func (db *replicaDB) reader(ctx context.Context) DB {
+	if consistencyFrom(ctx) == ConsistencyStrong {
+		return db.DB
+	}
+
+	return db.replica
+}
+
+// GetOffChainData returns the value identified by the key
+func (db *replicaDB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	return db.reader(ctx).GetOffChainData(ctx, key)
+}
+
+// GetOffChainDataAsOf returns the value identified by the key if it is stored under a batch up to the given one
+func (db *replicaDB) GetOffChainDataAsOf(
+	ctx context.Context, key common.Hash, maxBatchNum uint64,
+) (*types.OffChainData, error) {
+	return db.reader(ctx).GetOffChainDataAsOf(ctx, key, maxBatchNum)
+}
+
+// ListOffChainData returns values identified by the given keys
+func (db *replicaDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	return db.reader(ctx).ListOffChainData(ctx, keys)
+}
+
+// ListOffChainDataByBatch returns a page of the values stored for the given batch
+func (db *replicaDB) ListOffChainDataByBatch(
+	ctx context.Context,
+	batchNum uint64,
+	offset, limit uint,
+) ([]types.OffChainData, uint64, error) {
+	return db.reader(ctx).ListOffChainDataByBatch(ctx, batchNum, offset, limit)
+}
+
+// GetBatchConcatenated returns the value stored for the key committed for the given batch
+func (db *replicaDB) GetBatchConcatenated(ctx context.Context, batchNum uint64) ([]byte, error) {
+	return db.reader(ctx).GetBatchConcatenated(ctx, batchNum)
+}
+
+// ListOffChainDataAfterKey returns a page of the values stored for the given batch range, ordered by key
+func (db *replicaDB) ListOffChainDataAfterKey(
+	ctx context.Context,
+	from, to uint64,
+	after common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	return db.reader(ctx).ListOffChainDataAfterKey(ctx, from, to, after, limit)
+}
+
+// ListOffChainDataInBatchRange returns a page of the values stored for the given batch range,
+// ordered by batch number and key
+func (db *replicaDB) ListOffChainDataInBatchRange(
+	ctx context.Context,
+	from, to uint64,
+	limit, offset uint,
+) ([]types.OffChainData, error) {
+	return db.reader(ctx).ListOffChainDataInBatchRange(ctx, from, to, limit, offset)
+}
+
+// ListForcedOffChainData returns a page of the values stored for the forced batches of the given batch range,
+// ordered by batch number, position in the sequence calldata and key
+func (db *replicaDB) ListForcedOffChainData(
+	ctx context.Context,
+	from, to uint64,
+	limit, offset uint,
+) ([]types.OffChainData, error) {
+	return db.reader(ctx).ListForcedOffChainData(ctx, from, to, limit, offset)
+}
+
+// OffChainDataExists returns whether the value identified by the key is stored
+func (db *replicaDB) OffChainDataExists(ctx context.Context, key common.Hash) (bool, error) {
+	return db.reader(ctx).OffChainDataExists(ctx, key)
+}
+
+// AllExist returns whether the offchain data of all the given keys is stored, along with the missing keys
+func (db *replicaDB) AllExist(ctx context.Context, keys []common.Hash) (bool, []common.Hash, error) {
+	return db.reader(ctx).AllExist(ctx, keys)
+}
@@ -0,0 +1,81 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConsistency(t *testing.T) {
+	t.Parallel()
+
+	consistency, err := db.ParseConsistency(" Strong")
+	require.NoError(t, err)
+	require.Equal(t, db.ConsistencyStrong, consistency)
+
+	consistency, err = db.ParseConsistency("eventual")
+	require.NoError(t, err)
+	require.Equal(t, db.ConsistencyEventual, consistency)
+
+	_, err = db.ParseConsistency("linearizable")
+	require.EqualError(t, err, `unknown read consistency "linearizable"`)
+}
+
+func TestReplicaDB(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+	primaryData := &types.OffChainData{Key: key, Value: []byte("primary")}
+	replicaData := &types.OffChainData{Key: key, Value: []byte("replica")}
+
+	t.Run("eventual reads served by the replica", func(t *testing.T) {
+		t.Parallel()
+
+		primary := mocks.NewDB(t)
+		replica := mocks.NewDB(t)
+
+		replica.On("GetOffChainData", context.Background(), key).Return(replicaData, nil)
+
+		got, err := db.NewReplicaDB(primary, replica).GetOffChainData(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, replicaData, got)
+	})
+
+	t.Run("strong reads served by the primary", func(t *testing.T) {
+		t.Parallel()
+
+		primary := mocks.NewDB(t)
+		replica := mocks.NewDB(t)
+
+		ctx := db.WithConsistency(context.Background(), db.ConsistencyStrong)
+		primary.On("GetOffChainData", ctx, key).Return(primaryData, nil)
+		primary.On("AllExist", ctx, []common.Hash{key}).Return(true, nil, nil)
+
+		replicaDB := db.NewReplicaDB(primary, replica)
+
+		got, err := replicaDB.GetOffChainData(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, primaryData, got)
+
+		exist, _, err := replicaDB.AllExist(ctx, []common.Hash{key})
+		require.NoError(t, err)
+		require.True(t, exist)
+	})
+
+	t.Run("writes go to the primary", func(t *testing.T) {
+		t.Parallel()
+
+		primary := mocks.NewDB(t)
+		replica := mocks.NewDB(t)
+
+		ods := []types.OffChainData{*primaryData}
+		primary.On("StoreOffChainData", context.Background(), ods).Return(nil)
+
+		require.NoError(t, db.NewReplicaDB(primary, replica).StoreOffChainData(context.Background(), ods))
+	})
+}
@@ -3,12 +3,16 @@ package db
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -23,59 +27,371 @@ const (
 	getLastProcessedBlockSQL = `SELECT block FROM data_node.sync_tasks WHERE task = $1;`
 
 	// getMissingBatchKeysSQL is a query that returns the missing batch keys from the database
-	getMissingBatchKeysSQL = `SELECT num, hash FROM data_node.missing_batches LIMIT $1;`
-
-	// getOffchainDataSQL is a query that returns the offchain data for a given key
+	getMissingBatchKeysSQL = `
+		SELECT num, hash, enqueued_at, sequence_index, forced, attempts FROM data_node.missing_batches LIMIT $1;`
+
+	// getMissingBatchKeysInRangeSQL is a query that returns the missing batch keys of the batches in a given range
+	getMissingBatchKeysInRangeSQL = `SELECT num, hash FROM data_node.missing_batches WHERE num BETWEEN $1 AND $2 ORDER BY num;`
+
+	// getMissingBatchKeySQL is a query that returns the missing batch key of a given hash
+	getMissingBatchKeySQL = `
+		SELECT num, hash, enqueued_at, sequence_index, forced, attempts
+		FROM data_node.missing_batches WHERE hash = $1 ORDER BY num LIMIT 1;`
+
+	// incrementBatchAttemptsSQL is a query that counts one more failed attempt to resolve a missing batch key
+	// and returns the new count
+	incrementBatchAttemptsSQL = `
+		UPDATE data_node.missing_batches SET attempts = attempts + 1
+		WHERE num = $1 AND hash = $2
+		RETURNING attempts;`
+
+	// storeFailedBatchSQL is a query that stores a batch key that failed to be resolved, along with the reason
+	storeFailedBatchSQL = `
+		INSERT INTO data_node.failed_batches (num, hash, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (num, hash) DO UPDATE SET reason = EXCLUDED.reason, failed_at = NOW();`
+
+	// listFailedBatchesSQL is a query that returns the batch keys that failed to be resolved
+	listFailedBatchesSQL = `SELECT num, hash, reason, failed_at FROM data_node.failed_batches ORDER BY num, hash;`
+
+	// deleteFailedBatchSQL is a query that deletes a batch key from the failed batches
+	deleteFailedBatchSQL = `DELETE FROM data_node.failed_batches WHERE num = $1 AND hash = $2;`
+
+	// pruneResolvedBatchesSQL is a query that deletes the resolved batches history up to a given time
+	pruneResolvedBatchesSQL = `DELETE FROM data_node.resolved_batches_history WHERE resolved_at < $1;`
+
+	// findDuplicateValuesSQL is a query that returns the keys of the values stored more than once, grouped by value.
+	// The values are grouped by their hash so the groups do not hold the values themselves. The empty values are
+	// metadata of the values kept in an object store, so they are not compared
+	findDuplicateValuesSQL = `
+		SELECT string_agg(key, ',' ORDER BY key) AS keys
+		FROM data_node.offchain_data
+		WHERE value <> ''
+		GROUP BY md5(value)
+		HAVING COUNT(*) > 1
+		ORDER BY 1;`
+
+	// storeSavepointSQL, rollbackToStoreSavepointSQL and releaseStoreSavepointSQL manage the savepoint
+	// the offchain data is stored within when failing records must not roll back the others
+	storeSavepointSQL           = `SAVEPOINT store_offchain_data;`
+	rollbackToStoreSavepointSQL = `ROLLBACK TO SAVEPOINT store_offchain_data;`
+	releaseStoreSavepointSQL    = `RELEASE SAVEPOINT store_offchain_data;`
+
+	// getOffchainDataSQL is a query that returns the offchain data for a given key,
+	// along with the hash of the L1 transaction that sequenced its batch if it is known
 	getOffchainDataSQL = `
-		SELECT key, value
-		FROM data_node.offchain_data 
-		WHERE key = $1 LIMIT 1;
+		SELECT o.key, o.value, o.batch_num, COALESCE(c.l1_tx_hash, '') AS l1_tx_hash
+		FROM data_node.offchain_data o
+		LEFT JOIN data_node.batch_commitments c ON o.batch_num > 0 AND c.batch_num = o.batch_num
+		WHERE o.key = $1 LIMIT 1;
+	`
+
+	// getOffchainDataAsOfSQL is a query that returns the offchain data for a given key
+	// only if it is stored under a known batch up to a given one
+	getOffchainDataAsOfSQL = `
+		SELECT o.key, o.value, o.batch_num, COALESCE(c.l1_tx_hash, '') AS l1_tx_hash
+		FROM data_node.offchain_data o
+		LEFT JOIN data_node.batch_commitments c ON c.batch_num = o.batch_num
+		WHERE o.key = $1 AND o.batch_num > 0 AND o.batch_num <= $2 LIMIT 1;
 	`
 
 	// listOffchainDataSQL is a query that returns the offchain data for a given list of keys
 	listOffchainDataSQL = `
-		SELECT key, value
+		SELECT key, value, batch_num, sequence_index, forced
 		FROM data_node.offchain_data 
 		WHERE key IN (?);
 	`
 
+	// listOffchainDataByBatchSQL is a query that returns a page of the offchain data of a given batch,
+	// in the order of the sequence calldata and then by key
+	listOffchainDataByBatchSQL = `
+		SELECT key, value, batch_num, sequence_index
+		FROM data_node.offchain_data
+		WHERE batch_num = $1
+		ORDER BY sequence_index NULLS LAST, key
+		LIMIT $2 OFFSET $3;
+	`
+
+	// listOffchainDataAfterKeySQL is a query that returns a page of the offchain data of a given batch range,
+	// ordered by key and starting after a given key
+	listOffchainDataAfterKeySQL = `
+		SELECT key, value, batch_num
+		FROM data_node.offchain_data
+		WHERE batch_num BETWEEN $1 AND $2 AND key > $3
+		ORDER BY key
+		LIMIT $4;
+	`
+
+	// listOffchainDataInBatchRangeSQL is a query that returns a page of the offchain data of a given batch range,
+	// ordered by batch number and key
+	listOffchainDataInBatchRangeSQL = `
+		SELECT key, value, batch_num
+		FROM data_node.offchain_data
+		WHERE batch_num BETWEEN $1 AND $2
+		ORDER BY batch_num, key
+		LIMIT $3 OFFSET $4;
+	`
+
+	// listForcedOffchainDataSQL is a query that returns a page of the offchain data of the forced batches
+	// of a given batch range, ordered by batch number, position in the sequence calldata and key
+	listForcedOffchainDataSQL = `
+		SELECT key, value, batch_num, sequence_index, forced
+		FROM data_node.offchain_data
+		WHERE forced AND batch_num BETWEEN $1 AND $2
+		ORDER BY batch_num, sequence_index NULLS LAST, key
+		LIMIT $3 OFFSET $4;
+	`
+
+	// countOffchainDataByBatchSQL is a query that returns the count of rows of a given batch
+	countOffchainDataByBatchSQL = `SELECT COUNT(*) FROM data_node.offchain_data WHERE batch_num = $1;`
+
+	// offchainDataExistsSQL is a query that returns whether the offchain data for a given key is stored
+	offchainDataExistsSQL = `SELECT EXISTS(SELECT 1 FROM data_node.offchain_data WHERE key = $1);`
+
+	// listExistingKeysSQL is a query that returns which keys of a given list have their offchain data stored
+	listExistingKeysSQL = `SELECT key FROM data_node.offchain_data WHERE key IN (?);`
+
+	// getBatchNumRangeSQL is a query that returns the lowest and highest known batch numbers of the offchain data
+	getBatchNumRangeSQL = `
+		SELECT COALESCE(MIN(batch_num), 0) AS first, COALESCE(MAX(batch_num), 0) AS last
+		FROM data_node.offchain_data
+		WHERE batch_num > 0;`
+
+	// getDistinctBatchNumsSQL is a query that returns the distinct batch numbers in a given range
+	// that are either stored or known to be missing
+	getDistinctBatchNumsSQL = `
+		SELECT batch_num FROM data_node.offchain_data WHERE batch_num BETWEEN $1 AND $2
+		UNION
+		SELECT num FROM data_node.missing_batches WHERE num BETWEEN $1 AND $2
+		ORDER BY 1;`
+
+	// markFinalizedSQL is a query that marks the offchain data up to a given batch number as finalized
+	markFinalizedSQL = `
+		UPDATE data_node.offchain_data
+		SET finalized = TRUE
+		WHERE batch_num > 0 AND batch_num <= $1 AND NOT finalized;`
+
+	// pruneFinalizedSQL is a query that deletes the finalized offchain data up to a given batch number
+	pruneFinalizedSQL = `DELETE FROM data_node.offchain_data WHERE finalized AND batch_num <= $1;`
+
+	// countOffchainDataPerBatchSQL is a query that returns the count of rows of every batch in a given range
+	countOffchainDataPerBatchSQL = `
+		SELECT batch_num, COUNT(*)
+		FROM data_node.offchain_data
+		WHERE batch_num BETWEEN $1 AND $2
+		GROUP BY batch_num
+		ORDER BY batch_num;`
+
+	// getBatchDataSizeSQL is a query that returns the size in bytes of the offchain data values of a given batch.
+	// Values are stored hex encoded, so their size is half the length of the stored text
+	getBatchDataSizeSQL = `
+		SELECT COALESCE(SUM(octet_length(value)), 0) / 2
+		FROM data_node.offchain_data
+		WHERE batch_num = $1;`
+
+	// getBatchRangeDataSizeSQL is a query that returns the size in bytes of the offchain data values
+	// of the batches in a given range
+	getBatchRangeDataSizeSQL = `
+		SELECT COALESCE(SUM(octet_length(value)), 0) / 2
+		FROM data_node.offchain_data
+		WHERE batch_num BETWEEN $1 AND $2;`
+
+	// replaceOffchainDataSQL is a query that replaces the value of the offchain data of a given key
+	replaceOffchainDataSQL = `UPDATE data_node.offchain_data SET value = $2 WHERE key = $1;`
+
+	// correctBatchNumSQL is a query that moves the offchain data of a given key from a batch number to another
+	correctBatchNumSQL = `UPDATE data_node.offchain_data SET batch_num = $3 WHERE key = $1 AND batch_num = $2;`
+
+	// getCommittedKeySQL is a query that returns the key committed on L1 for a given batch, if it is known
+	getCommittedKeySQL = `SELECT key FROM data_node.batch_commitments WHERE batch_num = $1 AND key IS NOT NULL;`
+
+	// getFirstBatchSequencedAfterSQL is a query that returns the lowest batch number sequenced after a given L1 block
+	getFirstBatchSequencedAfterSQL = `
+		SELECT COALESCE(MIN(batch_num), 0)
+		FROM data_node.batch_commitments
+		WHERE l1_block > $1;`
+
+	// getFirstStoredBatchNumSQL is a query that returns the lowest known batch number of the offchain data,
+	// NULL if there is none
+	getFirstStoredBatchNumSQL = `SELECT MIN(batch_num) FROM data_node.offchain_data WHERE batch_num > 0;`
+
 	// countOffchainDataSQL is a query that returns the count of rows in the offchain_data table
 	countOffchainDataSQL = "SELECT COUNT(*) FROM data_node.offchain_data;"
+
+	// totalStoredBytesSQL is a query that returns the size in bytes of all the offchain data values.
+	// Values are stored hex encoded, so their size is half the length of the stored text
+	totalStoredBytesSQL = "SELECT COALESCE(SUM(octet_length(value)), 0) / 2 FROM data_node.offchain_data;"
+)
+
+const (
+	// DefaultInsertChunkSize is the default number of rows inserted by a single statement when storing offchain data
+	DefaultInsertChunkSize = 500
+
+	// scanContextCheckInterval is the number of scanned rows after which the context is checked for cancellation
+	scanContextCheckInterval = 100
+
+	// maxCountByBatchRange is the maximum number of batches whose offchain data can be counted at once
+	maxCountByBatchRange = 10000
+
+	// offchainDataInsertColumns is the number of columns set for every row by the offchain data insert query
+	offchainDataInsertColumns = 5
+
+	// batchKeyColumns is the number of columns of a batch key
+	batchKeyColumns = 2
+
+	// batchKeyInsertColumns is the number of columns set for every row by the missing batch keys insert query
+	batchKeyInsertColumns = 4
+
+	// resolvedBatchColumns is the number of columns set for every row by the resolved batches archive query
+	resolvedBatchColumns = 3
+
+	// maxBatchKeysPerQuery is the maximum number of batch keys of a single query,
+	// bounded by the 65535 bind parameters Postgres allows per statement
+	maxBatchKeysPerQuery = 65535 / batchKeyColumns
+
+	// maxKeysPerQuery is the maximum number of keys of a single query,
+	// bounded by the 65535 bind parameters Postgres allows per statement
+	maxKeysPerQuery = 65535
+
+	// batchCommitmentColumns is the number of columns set for every row by the batch commitments insert query
+	batchCommitmentColumns = 2
+
+	// maxInsertChunkSize is the maximum number of rows of a single insert statement,
+	// bounded by the 65535 bind parameters Postgres allows per statement
+	maxInsertChunkSize = 65535 / offchainDataInsertColumns
 )
 
 var (
 	// ErrStateNotSynchronized indicates the state database may be empty
 	ErrStateNotSynchronized = errors.New("state not synchronized")
+
+	// ErrOffChainDataMismatch indicates a different value is already stored for the key of the offchain data
+	ErrOffChainDataMismatch = errors.New("offchain data does not match the stored value")
+
+	// ErrInvalidOffChainData indicates a value does not hash to the key it is stored under
+	ErrInvalidOffChainData = errors.New("offchain data does not hash to its key")
 )
 
-// DB defines functions that a DB instance should implement
-type DB interface {
+// Tx is the interface that defines functions a db tx has to implement
+type Tx interface {
+	sqlx.ExecerContext
+	sqlx.QueryerContext
+	driver.Tx
+}
+
+// SyncStore defines the functions to keep track of the synchronization state
+type SyncStore interface {
 	StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error
 	GetLastProcessedBlock(ctx context.Context, task string) (uint64, error)
 
 	StoreMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error
+	InsertMissingBatchKeys(ctx context.Context, bks []types.BatchKey) (uint64, error)
 	GetMissingBatchKeys(ctx context.Context, limit uint) ([]types.BatchKey, error)
+	GetMissingBatchKeysInRange(ctx context.Context, from, to uint64) ([]types.BatchKey, error)
+	GetMissingBatchKey(ctx context.Context, hash common.Hash) (*types.BatchKey, error)
 	DeleteMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error
+	DeleteMissingBatchKeysTx(ctx context.Context, bks []types.BatchKey, tx Tx) error
+	IncrementBatchAttempts(ctx context.Context, key types.BatchKey) (uint, error)
+	FilterMissingBatchKeys(ctx context.Context, bks []types.BatchKey) ([]types.BatchKey, error)
+
+	ArchiveMissingBatchKeys(ctx context.Context, resolved []types.ResolvedBatch) error
+	ArchiveMissingBatchKeysTx(ctx context.Context, resolved []types.ResolvedBatch, tx Tx) error
+	PruneResolvedBatches(ctx context.Context, before time.Time) (uint64, error)
+
+	StoreFailedBatch(ctx context.Context, key types.BatchKey, reason string) error
+	ListFailedBatches(ctx context.Context) ([]types.FailedBatch, error)
+	RequeueFailedBatch(ctx context.Context, key types.BatchKey) error
+
+	GetDistinctBatchNums(ctx context.Context, from, to uint64) ([]uint64, error)
 
+	StoreL1TxHash(ctx context.Context, from uint64, keys []common.Hash, txHash common.Hash, l1Block uint64) error
+	GetCommittedKey(ctx context.Context, batchNum uint64) (common.Hash, error)
+	GetFirstBatchSequencedAfter(ctx context.Context, l1Block uint64) (uint64, error)
+}
+
+// BlobStore defines the functions to store and retrieve offchain data
+type BlobStore interface {
 	GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error)
+	GetOffChainDataAsOf(ctx context.Context, key common.Hash, maxBatchNum uint64) (*types.OffChainData, error)
 	ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error)
+	ListOffChainDataByBatch(ctx context.Context, batchNum uint64, offset, limit uint) ([]types.OffChainData, uint64, error)
+	GetBatchConcatenated(ctx context.Context, batchNum uint64) ([]byte, error)
+	FindDuplicateValues(ctx context.Context) ([]types.DuplicateGroup, error)
+	ListOffChainDataAfterKey(
+		ctx context.Context, from, to uint64, after common.Hash, limit uint,
+	) ([]types.OffChainData, error)
+	ListOffChainDataInBatchRange(ctx context.Context, from, to uint64, limit, offset uint) ([]types.OffChainData, error)
+	ListForcedOffChainData(ctx context.Context, from, to uint64, limit, offset uint) ([]types.OffChainData, error)
 	StoreOffChainData(ctx context.Context, od []types.OffChainData) error
+	StoreOffChainDataTx(ctx context.Context, od []types.OffChainData, tx Tx) error
+	StoreOffChainDataPartial(ctx context.Context, od []types.OffChainData) ([]types.FailedRecord, error)
+	ReplaceOffChainData(ctx context.Context, key common.Hash, newValue []byte) error
+	CorrectOffChainDataBatchNums(ctx context.Context, corrections []types.KeyBatchCorrection) error
+	OffChainDataExists(ctx context.Context, key common.Hash) (bool, error)
+	AllExist(ctx context.Context, keys []common.Hash) (bool, []common.Hash, error)
 	CountOffchainData(ctx context.Context) (uint64, error)
+	TotalStoredBytes(ctx context.Context) (uint64, error)
+	CountOffchainDataByBatch(ctx context.Context, from, to uint64) (map[uint64]uint64, error)
+	GetBatchDataSize(ctx context.Context, batchNum uint64) (uint64, error)
+	GetBatchRangeDataSize(ctx context.Context, from, to uint64) (uint64, error)
+	GetBatchNumRange(ctx context.Context) (uint64, uint64, error)
+	GetFirstStoredBatchNum(ctx context.Context) (uint64, bool, error)
+
+	MarkFinalized(ctx context.Context, upToBatch uint64) error
+	PruneFinalized(ctx context.Context, upToBatch uint64) (uint64, error)
+}
+
+// DB defines functions that a DB instance should implement
+type DB interface {
+	SyncStore
+	BlobStore
+
+	BeginStateTransaction(ctx context.Context) (Tx, error)
+	RunMaintenance(ctx context.Context) error
 }
 
 // DB is the database layer of the data node
 type pgDB struct {
 	pg *sqlx.DB
 
-	storeLastProcessedBlockStmt *sqlx.Stmt
-	getLastProcessedBlockStmt   *sqlx.Stmt
-	getMissingBatchKeysStmt     *sqlx.Stmt
-	getOffChainDataStmt         *sqlx.Stmt
-	countOffChainDataStmt       *sqlx.Stmt
+	storeLastProcessedBlockStmt     *sqlx.Stmt
+	getLastProcessedBlockStmt       *sqlx.Stmt
+	getMissingBatchKeysStmt         *sqlx.Stmt
+	getMissingBatchKeyStmt          *sqlx.Stmt
+	getOffChainDataStmt             *sqlx.Stmt
+	countOffChainDataStmt           *sqlx.Stmt
+	listOffChainDataByBatchStmt     *sqlx.Stmt
+	countOffChainDataByBatchStmt    *sqlx.Stmt
+	offChainDataExistsStmt          *sqlx.Stmt
+	getBatchNumRangeStmt            *sqlx.Stmt
+	getDistinctBatchNumsStmt        *sqlx.Stmt
+	markFinalizedStmt               *sqlx.Stmt
+	pruneFinalizedStmt              *sqlx.Stmt
+	countOffChainDataPerBatchStmt   *sqlx.Stmt
+	getMissingBatchKeysInRangeStmt  *sqlx.Stmt
+	getBatchDataSizeStmt            *sqlx.Stmt
+	getBatchRangeDataSizeStmt       *sqlx.Stmt
+	getCommittedKeyStmt             *sqlx.Stmt
+	getFirstBatchSequencedAfterStmt *sqlx.Stmt
+	getFirstStoredBatchNumStmt      *sqlx.Stmt
+	totalStoredBytesStmt            *sqlx.Stmt
+
+	insertChunkSize int
+
+	maintenanceRunning atomic.Bool
 }
 
-// New instantiates a DB
-func New(ctx context.Context, pg *sqlx.DB) (DB, error) {
+// New instantiates a DB that stores offchain data in statements of up to insertChunkSize rows.
+// A zero insertChunkSize means DefaultInsertChunkSize
+func New(ctx context.Context, pg *sqlx.DB, insertChunkSize uint) (DB, error) {
+	if insertChunkSize == 0 {
+		insertChunkSize = DefaultInsertChunkSize
+	}
+
+	if insertChunkSize > maxInsertChunkSize {
+		return nil, fmt.Errorf("insert chunk size %d exceeds the maximum of %d", insertChunkSize, maxInsertChunkSize)
+	}
+
 	storeLastProcessedBlockStmt, err := pg.PreparexContext(ctx, storeLastProcessedBlockSQL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare the store last processed block statement: %w", err)
@@ -91,6 +407,11 @@ func New(ctx context.Context, pg *sqlx.DB) (DB, error) {
 		return nil, fmt.Errorf("failed to prepare the get missing batch keys statement: %w", err)
 	}
 
+	getMissingBatchKeyStmt, err := pg.PreparexContext(ctx, getMissingBatchKeySQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get missing batch key statement: %w", err)
+	}
+
 	getOffChainDataStmt, err := pg.PreparexContext(ctx, getOffchainDataSQL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare the get offchain data statement: %w", err)
@@ -101,16 +422,114 @@ func New(ctx context.Context, pg *sqlx.DB) (DB, error) {
 		return nil, fmt.Errorf("failed to prepare the count offchain data statement: %w", err)
 	}
 
+	listOffChainDataByBatchStmt, err := pg.PreparexContext(ctx, listOffchainDataByBatchSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the list offchain data by batch statement: %w", err)
+	}
+
+	countOffChainDataByBatchStmt, err := pg.PreparexContext(ctx, countOffchainDataByBatchSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the count offchain data by batch statement: %w", err)
+	}
+
+	offChainDataExistsStmt, err := pg.PreparexContext(ctx, offchainDataExistsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the offchain data exists statement: %w", err)
+	}
+
+	getBatchNumRangeStmt, err := pg.PreparexContext(ctx, getBatchNumRangeSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get batch num range statement: %w", err)
+	}
+
+	getDistinctBatchNumsStmt, err := pg.PreparexContext(ctx, getDistinctBatchNumsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get distinct batch nums statement: %w", err)
+	}
+
+	markFinalizedStmt, err := pg.PreparexContext(ctx, markFinalizedSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the mark finalized statement: %w", err)
+	}
+
+	pruneFinalizedStmt, err := pg.PreparexContext(ctx, pruneFinalizedSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the prune finalized statement: %w", err)
+	}
+
+	countOffChainDataPerBatchStmt, err := pg.PreparexContext(ctx, countOffchainDataPerBatchSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the count offchain data per batch statement: %w", err)
+	}
+
+	getMissingBatchKeysInRangeStmt, err := pg.PreparexContext(ctx, getMissingBatchKeysInRangeSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get missing batch keys in range statement: %w", err)
+	}
+
+	getBatchDataSizeStmt, err := pg.PreparexContext(ctx, getBatchDataSizeSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get batch data size statement: %w", err)
+	}
+
+	getBatchRangeDataSizeStmt, err := pg.PreparexContext(ctx, getBatchRangeDataSizeSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get batch range data size statement: %w", err)
+	}
+
+	getCommittedKeyStmt, err := pg.PreparexContext(ctx, getCommittedKeySQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get committed key statement: %w", err)
+	}
+
+	getFirstBatchSequencedAfterStmt, err := pg.PreparexContext(ctx, getFirstBatchSequencedAfterSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get first batch sequenced after statement: %w", err)
+	}
+
+	getFirstStoredBatchNumStmt, err := pg.PreparexContext(ctx, getFirstStoredBatchNumSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the get first stored batch number statement: %w", err)
+	}
+
+	totalStoredBytesStmt, err := pg.PreparexContext(ctx, totalStoredBytesSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the total stored bytes statement: %w", err)
+	}
+
 	return &pgDB{
-		pg:                          pg,
-		storeLastProcessedBlockStmt: storeLastProcessedBlockStmt,
-		getLastProcessedBlockStmt:   getLastProcessedBlockStmt,
-		getMissingBatchKeysStmt:     getMissingBatchKeysStmt,
-		getOffChainDataStmt:         getOffChainDataStmt,
-		countOffChainDataStmt:       countOffChainDataStmt,
+		pg:                              pg,
+		storeLastProcessedBlockStmt:     storeLastProcessedBlockStmt,
+		getLastProcessedBlockStmt:       getLastProcessedBlockStmt,
+		getMissingBatchKeysStmt:         getMissingBatchKeysStmt,
+		getMissingBatchKeyStmt:          getMissingBatchKeyStmt,
+		getOffChainDataStmt:             getOffChainDataStmt,
+		countOffChainDataStmt:           countOffChainDataStmt,
+		listOffChainDataByBatchStmt:     listOffChainDataByBatchStmt,
+		countOffChainDataByBatchStmt:    countOffChainDataByBatchStmt,
+		offChainDataExistsStmt:          offChainDataExistsStmt,
+		getBatchNumRangeStmt:            getBatchNumRangeStmt,
+		getDistinctBatchNumsStmt:        getDistinctBatchNumsStmt,
+		markFinalizedStmt:               markFinalizedStmt,
+		pruneFinalizedStmt:              pruneFinalizedStmt,
+		countOffChainDataPerBatchStmt:   countOffChainDataPerBatchStmt,
+		getMissingBatchKeysInRangeStmt:  getMissingBatchKeysInRangeStmt,
+		getBatchDataSizeStmt:            getBatchDataSizeStmt,
+		getBatchRangeDataSizeStmt:       getBatchRangeDataSizeStmt,
+		getCommittedKeyStmt:             getCommittedKeyStmt,
+		getFirstBatchSequencedAfterStmt: getFirstBatchSequencedAfterStmt,
+		getFirstStoredBatchNumStmt:      getFirstStoredBatchNumStmt,
+		totalStoredBytesStmt:            totalStoredBytesStmt,
+		insertChunkSize:                 int(insertChunkSize),
 	}, nil
 }
 
+// BeginStateTransaction begins a transaction that the Tx variants of the store functions can share,
+// so several writes are committed or rolled back together
+func (db *pgDB) BeginStateTransaction(ctx context.Context) (Tx, error) {
+	return db.pg.BeginTxx(ctx, nil)
+}
+
 // StoreLastProcessedBlock stores a record of a block processed by the synchronizer for named task
 func (db *pgDB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
 	_, err := db.storeLastProcessedBlockStmt.ExecContext(ctx, task, block)
@@ -130,21 +549,37 @@ func (db *pgDB) GetLastProcessedBlock(ctx context.Context, task string) (uint64,
 
 // StoreMissingBatchKeys stores missing batch keys in the database
 func (db *pgDB) StoreMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	_, err := db.InsertMissingBatchKeys(ctx, bks)
+
+	return err
+}
+
+// InsertMissingBatchKeys stores missing batch keys in the database and returns how many of them were
+// actually inserted, so that keys which were already queued can be told apart from new work.
+// The inserted keys are enqueued at the current database time, while the already queued ones keep theirs
+func (db *pgDB) InsertMissingBatchKeys(ctx context.Context, bks []types.BatchKey) (uint64, error) {
 	if len(bks) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	query, args := buildBatchKeysInsertQuery(bks)
 
-	if _, err := db.pg.ExecContext(ctx, query, args...); err != nil {
+	res, err := db.pg.ExecContext(ctx, query, args...)
+	if err != nil {
 		batchNumbers := make([]string, len(bks))
 		for i, bk := range bks {
 			batchNumbers[i] = fmt.Sprintf("%d", bk.Number)
 		}
-		return fmt.Errorf("failed to store missing batches (batch numbers: %s): %w", strings.Join(batchNumbers, ", "), err)
+		return 0, fmt.Errorf("failed to store missing batches (batch numbers: %s): %w",
+			strings.Join(batchNumbers, ", "), err)
 	}
 
-	return nil
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the inserted missing batches count: %w", err)
+	}
+
+	return uint64(inserted), nil
 }
 
 // GetMissingBatchKeys returns the missing batch keys that is not yet present in offchain table
@@ -156,184 +591,1313 @@ func (db *pgDB) GetMissingBatchKeys(ctx context.Context, limit uint) ([]types.Ba
 
 	defer rows.Close()
 
-	type row struct {
-		Number uint64 `db:"num"`
-		Hash   string `db:"hash"`
+	return scanBatchKeys(ctx, rows)
+}
+
+// GetMissingBatchKeysInRange returns the missing batch keys of the batches in the given inclusive range,
+// ordered by batch number
+func (db *pgDB) GetMissingBatchKeysInRange(ctx context.Context, from, to uint64) ([]types.BatchKey, error) {
+	rows, err := db.getMissingBatchKeysInRangeStmt.QueryxContext(ctx, from, to)
+	if err != nil {
+		return nil, err
 	}
 
-	var bks []types.BatchKey
-	for rows.Next() {
-		bk := row{}
-		if err = rows.StructScan(&bk); err != nil {
-			return nil, err
+	defer rows.Close()
+
+	return scanBatchKeys(ctx, rows)
+}
+
+// GetMissingBatchKey returns the missing batch key of the given hash
+func (db *pgDB) GetMissingBatchKey(ctx context.Context, hash common.Hash) (*types.BatchKey, error) {
+	bk := batchKeyRow{}
+	if err := db.getMissingBatchKeyStmt.QueryRowxContext(ctx, hash.Hex()).StructScan(&bk); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
 		}
 
-		bks = append(bks, types.BatchKey{
-			Number: bk.Number,
-			Hash:   common.HexToHash(bk.Hash),
-		})
+		return nil, err
 	}
 
-	return bks, nil
+	batchKey := bk.batchKey()
+
+	return &batchKey, nil
 }
 
 // DeleteMissingBatchKeys deletes the missing batch keys from the missing_batch table in the db
 func (db *pgDB) DeleteMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
-	if len(bks) == 0 {
-		return nil
+	return deleteMissingBatchKeys(ctx, db.pg, bks)
+}
+
+// DeleteMissingBatchKeysTx deletes the missing batch keys from the missing_batch table within the given transaction
+func (db *pgDB) DeleteMissingBatchKeysTx(ctx context.Context, bks []types.BatchKey, tx Tx) error {
+	return deleteMissingBatchKeys(ctx, tx, bks)
+}
+
+// IncrementBatchAttempts counts one more failed attempt to resolve the given missing batch key and returns
+// the new count. The count is incremented by the database, so concurrent resolvers never lose an attempt.
+// It returns ErrStateNotSynchronized if the key is not missing
+func (db *pgDB) IncrementBatchAttempts(ctx context.Context, key types.BatchKey) (uint, error) {
+	var attempts uint
+	err := db.pg.QueryRowxContext(ctx, incrementBatchAttemptsSQL, key.Number, key.Hash.Hex()).Scan(&attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: batch %d, key %s is not missing", ErrStateNotSynchronized, key.Number, key.Hash.Hex())
+		}
+
+		return 0, fmt.Errorf("failed to increment the attempts of batch %d: %w", key.Number, err)
 	}
 
-	const columnsAffected = 2
+	return attempts, nil
+}
 
-	args := make([]interface{}, len(bks)*columnsAffected)
-	values := make([]string, len(bks))
-	for i, bk := range bks {
-		values[i] = fmt.Sprintf("($%d, $%d)", i*columnsAffected+1, i*columnsAffected+2) //nolint:mnd
-		args[i*columnsAffected] = bk.Number
-		args[i*columnsAffected+1] = bk.Hash.Hex()
+// StoreFailedBatch moves the given missing batch key to the failed batches along with the reason it failed,
+// so it is no longer resolved. Both happen in a single transaction
+func (db *pgDB) StoreFailedBatch(ctx context.Context, key types.BatchKey, reason string) error {
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin the store failed batch transaction: %w", err)
 	}
 
-	query := fmt.Sprintf(`
-		DELETE FROM data_node.missing_batches WHERE (num, hash) IN (%s);
-	`, strings.Join(values, ","))
+	if err = storeFailedBatch(ctx, tx, key, reason); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("failed to rollback the store failed batch transaction: %w", rollbackErr)
+		}
 
-	if _, err := db.pg.ExecContext(ctx, query, args...); err != nil {
-		return fmt.Errorf("failed to delete missing batches: %w", err)
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the store failed batch transaction: %w", err)
 	}
 
 	return nil
 }
 
-// StoreOffChainData stores and array of key values in the Db
-func (db *pgDB) StoreOffChainData(ctx context.Context, ods []types.OffChainData) error {
-	if len(ods) == 0 {
-		return nil
+// ListFailedBatches returns the batch keys that failed to be resolved, ordered by batch number
+func (db *pgDB) ListFailedBatches(ctx context.Context) ([]types.FailedBatch, error) {
+	rows, err := db.pg.QueryxContext(ctx, listFailedBatchesSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed batches: %w", err)
 	}
 
-	query, args := buildOffchainDataInsertQuery(ods)
-	if _, err := db.pg.ExecContext(ctx, query, args...); err != nil {
-		return fmt.Errorf("failed to store offchain data: %w", err)
+	defer rows.Close()
+
+	failed := []types.FailedBatch{}
+	for rows.Next() {
+		if err = checkScanContext(ctx, len(failed)); err != nil {
+			return nil, err
+		}
+
+		row := failedBatchRow{}
+		if err = rows.StructScan(&row); err != nil {
+			return nil, err
+		}
+
+		failed = append(failed, types.FailedBatch{
+			Number:   types.ArgUint64(row.Number),
+			Hash:     common.HexToHash(row.Hash),
+			Reason:   row.Reason,
+			FailedAt: row.FailedAt,
+		})
 	}
 
-	return nil
+	return failed, rows.Err()
 }
 
-// GetOffChainData returns the value identified by the key
-func (db *pgDB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
-	data := struct {
-		Key   string `db:"key"`
-		Value string `db:"value"`
-	}{}
+// RequeueFailedBatch moves the given failed batch key back to the missing batches, so it is resolved again.
+// It returns ErrStateNotSynchronized if the key is not a failed batch
+func (db *pgDB) RequeueFailedBatch(ctx context.Context, key types.BatchKey) error {
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin the requeue failed batch transaction: %w", err)
+	}
 
-	if err := db.getOffChainDataStmt.QueryRowxContext(ctx, key.Hex()).StructScan(&data); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrStateNotSynchronized
+	if err = requeueFailedBatch(ctx, tx, key); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("failed to rollback the requeue failed batch transaction: %w", rollbackErr)
 		}
 
-		return nil, err
+		return err
 	}
 
-	return &types.OffChainData{
-		Key:   common.HexToHash(data.Key),
-		Value: common.FromHex(data.Value),
-	}, nil
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the requeue failed batch transaction: %w", err)
+	}
+
+	return nil
 }
 
-// ListOffChainData returns values identified by the given keys
-func (db *pgDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
-	if len(keys) == 0 {
-		return nil, nil
+// requeueFailedBatch deletes the given batch key from the failed batches and queues it as missing again
+func requeueFailedBatch(ctx context.Context, execer sqlx.ExecerContext, key types.BatchKey) error {
+	res, err := execer.ExecContext(ctx, deleteFailedBatchSQL, key.Number, key.Hash.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to delete failed batch %d: %w", key.Number, err)
 	}
 
-	preparedKeys := make([]string, len(keys))
-	for i, key := range keys {
-		preparedKeys[i] = key.Hex()
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get the deleted failed batches count: %w", err)
 	}
 
-	query, args, err := sqlx.In(listOffchainDataSQL, preparedKeys)
-	if err != nil {
-		return nil, err
+	if deleted == 0 {
+		return fmt.Errorf("%w: batch %d, key %s is not a failed batch", ErrStateNotSynchronized, key.Number, key.Hash.Hex())
 	}
 
-	// sqlx.In returns queries with the `?` bindvar, we can rebind it for our backend
-	query = db.pg.Rebind(query)
+	query, args := buildBatchKeysInsertQuery([]types.BatchKey{key})
+	if _, err = execer.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to queue batch %d as missing: %w", key.Number, err)
+	}
 
-	rows, err := db.pg.QueryxContext(ctx, query, args...)
-	if err != nil {
-		return nil, err
+	return nil
+}
+
+// storeFailedBatch stores the given batch key as failed and deletes it from the missing batches
+func storeFailedBatch(ctx context.Context, execer sqlx.ExecerContext, key types.BatchKey, reason string) error {
+	if _, err := execer.ExecContext(ctx, storeFailedBatchSQL, key.Number, key.Hash.Hex(), reason); err != nil {
+		return fmt.Errorf("failed to store failed batch %d: %w", key.Number, err)
 	}
 
-	defer rows.Close()
+	return deleteMissingBatchKeys(ctx, execer, []types.BatchKey{key})
+}
 
-	type row struct {
-		Key   string `db:"key"`
-		Value string `db:"value"`
+// deleteMissingBatchKeys deletes the given missing batch keys with a single statement
+func deleteMissingBatchKeys(ctx context.Context, execer sqlx.ExecerContext, bks []types.BatchKey) error {
+	if len(bks) == 0 {
+		return nil
 	}
 
-	list := make([]types.OffChainData, 0, len(keys))
-	for rows.Next() {
-		data := row{}
-		if err = rows.StructScan(&data); err != nil {
-			return nil, err
-		}
+	tuples, args := buildBatchKeysTuples(bks)
+	query := fmt.Sprintf(`
+		DELETE FROM data_node.missing_batches WHERE (num, hash) IN (%s);
+	`, tuples)
 
-		list = append(list, types.OffChainData{
-			Key:   common.HexToHash(data.Key),
-			Value: common.FromHex(data.Value),
-		})
+	if _, err := execer.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete missing batches: %w", err)
 	}
 
-	return list, nil
+	return nil
 }
 
-// CountOffchainData returns the count of rows in the offchain_data table
-func (db *pgDB) CountOffchainData(ctx context.Context) (uint64, error) {
-	var count uint64
-	if err := db.countOffChainDataStmt.QueryRowContext(ctx).Scan(&count); err != nil {
-		return 0, err
+// ArchiveMissingBatchKeys moves the given resolved missing batch keys to the resolved batches history, along with
+// the attempts it took to resolve them, instead of deleting them. They keep their enqueue time and are resolved at
+// the current database time. Both happen in a single statement
+func (db *pgDB) ArchiveMissingBatchKeys(ctx context.Context, resolved []types.ResolvedBatch) error {
+	return archiveMissingBatchKeys(ctx, db.pg, resolved)
+}
+
+// ArchiveMissingBatchKeysTx moves the given resolved missing batch keys to the resolved batches history
+// within the given transaction
+func (db *pgDB) ArchiveMissingBatchKeysTx(ctx context.Context, resolved []types.ResolvedBatch, tx Tx) error {
+	return archiveMissingBatchKeys(ctx, tx, resolved)
+}
+
+// archiveMissingBatchKeys moves the given resolved missing batch keys to the resolved batches history
+// with a single statement
+func archiveMissingBatchKeys(ctx context.Context, execer sqlx.ExecerContext, resolved []types.ResolvedBatch) error {
+	if len(resolved) == 0 {
+		return nil
 	}
 
-	return count, nil
+	query, args := buildArchiveMissingBatchKeysQuery(resolved)
+	if _, err := execer.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to archive missing batches: %w", err)
+	}
+
+	return nil
 }
 
-// buildBatchKeysInsertQuery builds the query to insert missing batch keys
-func buildBatchKeysInsertQuery(bks []types.BatchKey) (string, []interface{}) {
-	const columnsAffected = 2
+// PruneResolvedBatches deletes the resolved batches history up to the given time and returns how many
+// keys were deleted
+func (db *pgDB) PruneResolvedBatches(ctx context.Context, before time.Time) (uint64, error) {
+	res, err := db.pg.ExecContext(ctx, pruneResolvedBatchesSQL, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune resolved batches: %w", err)
+	}
 
-	args := make([]interface{}, len(bks)*columnsAffected)
-	values := make([]string, len(bks))
-	for i, bk := range bks {
-		values[i] = fmt.Sprintf("($%d, $%d)", i*columnsAffected+1, i*columnsAffected+2) //nolint:mnd
-		args[i*columnsAffected] = bk.Number
-		args[i*columnsAffected+1] = bk.Hash.Hex()
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the pruned resolved batches count: %w", err)
 	}
 
-	return fmt.Sprintf(`
-		INSERT INTO data_node.missing_batches (num, hash)
-		VALUES %s
-		ON CONFLICT (num, hash) DO NOTHING;
-	`, strings.Join(values, ",")), args
+	return uint64(pruned), nil //nolint:gosec
 }
 
-// buildOffchainDataInsertQuery builds the query to insert offchain data
-func buildOffchainDataInsertQuery(ods []types.OffChainData) (string, []interface{}) {
-	const columnsAffected = 2
+// FilterMissingBatchKeys returns the given batch keys that are still missing, ordered by batch number
+func (db *pgDB) FilterMissingBatchKeys(ctx context.Context, bks []types.BatchKey) ([]types.BatchKey, error) {
+	if len(bks) == 0 {
+		return nil, nil
+	}
 
-	// Remove duplicates from the given offchain data
-	ods = types.RemoveDuplicateOffChainData(ods)
+	if len(bks) > maxBatchKeysPerQuery {
+		return nil, fmt.Errorf("too many batch keys, at most %d can be filtered at once", maxBatchKeysPerQuery)
+	}
 
-	args := make([]interface{}, len(ods)*columnsAffected)
-	values := make([]string, len(ods))
-	for i, od := range ods {
-		values[i] = fmt.Sprintf("($%d, $%d)", i*columnsAffected+1, i*columnsAffected+2) //nolint:mnd
+	tuples, args := buildBatchKeysTuples(bks)
+	query := fmt.Sprintf(`
+		SELECT num, hash FROM data_node.missing_batches WHERE (num, hash) IN (%s) ORDER BY num;
+	`, tuples)
+
+	rows, err := db.pg.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter missing batches: %w", err)
+	}
+
+	defer rows.Close()
+
+	return scanBatchKeys(ctx, rows)
+}
+
+// StoreOffChainData stores and array of key values in the Db
+func (db *pgDB) StoreOffChainData(ctx context.Context, ods []types.OffChainData) error {
+	if len(ods) == 0 {
+		return nil
+	}
+
+	// Remove duplicates from the given offchain data, so that no statement upserts the same key twice
+	ods = types.RemoveDuplicateOffChainData(ods)
+
+	if len(ods) <= db.insertChunkSize {
+		return storeOffChainDataChunk(ctx, db.pg, ods)
+	}
+
+	// Store all the chunks atomically
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin the store offchain data transaction: %w", err)
+	}
+
+	for start := 0; start < len(ods); start += db.insertChunkSize {
+		end := min(start+db.insertChunkSize, len(ods))
+
+		if err = storeOffChainDataChunk(ctx, tx, ods[start:end]); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("failed to rollback the store offchain data transaction: %w", rollbackErr)
+			}
+
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the store offchain data transaction: %w", err)
+	}
+
+	return nil
+}
+
+// StoreOffChainDataTx stores and array of key values within the given transaction.
+// The caller commits or rolls back the transaction, also on error
+func (db *pgDB) StoreOffChainDataTx(ctx context.Context, ods []types.OffChainData, tx Tx) error {
+	if len(ods) == 0 {
+		return nil
+	}
+
+	ods = types.RemoveDuplicateOffChainData(ods)
+
+	for start := 0; start < len(ods); start += db.insertChunkSize {
+		end := min(start+db.insertChunkSize, len(ods))
+
+		if err := storeOffChainDataChunk(ctx, tx, ods[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StoreOffChainDataPartial stores the given offchain data, skipping the records that cannot be stored instead of
+// rolling back the others, and returns the skipped ones along with the reason. The error is only set when
+// nothing could be stored. Use StoreOffChainData when the records must be stored atomically
+func (db *pgDB) StoreOffChainDataPartial(ctx context.Context, ods []types.OffChainData) ([]types.FailedRecord, error) {
+	if len(ods) == 0 {
+		return nil, nil
+	}
+
+	ods = types.RemoveDuplicateOffChainData(ods)
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin the store offchain data transaction: %w", err)
+	}
+
+	failed := []types.FailedRecord{}
+	for start := 0; start < len(ods); start += db.insertChunkSize {
+		end := min(start+db.insertChunkSize, len(ods))
+
+		chunkFailed, err := storeOffChainDataPartialChunk(ctx, tx, ods[start:end])
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return nil, fmt.Errorf("failed to rollback the store offchain data transaction: %w", rollbackErr)
+			}
+
+			return nil, err
+		}
+
+		failed = append(failed, chunkFailed...)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit the store offchain data transaction: %w", err)
+	}
+
+	return failed, nil
+}
+
+// storeOffChainDataPartialChunk stores the given chunk within a savepoint. If the chunk fails, its records
+// are stored one by one within their own savepoint to find the failing ones, which are returned
+func storeOffChainDataPartialChunk(
+	ctx context.Context, execer sqlx.ExecerContext, ods []types.OffChainData,
+) ([]types.FailedRecord, error) {
+	storeErr, err := trySavepoint(ctx, execer, func() error {
+		return storeOffChainDataChunk(ctx, execer, ods)
+	})
+	if err != nil || storeErr == nil {
+		return nil, err
+	}
+
+	if len(ods) == 1 {
+		return []types.FailedRecord{newFailedRecord(ods[0], storeErr)}, nil
+	}
+
+	var failed []types.FailedRecord
+	for _, od := range ods {
+		storeErr, err = trySavepoint(ctx, execer, func() error {
+			return storeOffChainDataChunk(ctx, execer, []types.OffChainData{od})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if storeErr != nil {
+			failed = append(failed, newFailedRecord(od, storeErr))
+		}
+	}
+
+	return failed, nil
+}
+
+// trySavepoint runs the given store within a savepoint, which is rolled back to if the store fails.
+// It returns the error of the store, and the error of the savepoint statements, which leaves the transaction
+// unusable
+func trySavepoint(ctx context.Context, execer sqlx.ExecerContext, store func() error) (error, error) {
+	if _, err := execer.ExecContext(ctx, storeSavepointSQL); err != nil {
+		return nil, fmt.Errorf("failed to create the store offchain data savepoint: %w", err)
+	}
+
+	if storeErr := store(); storeErr != nil {
+		if _, err := execer.ExecContext(ctx, rollbackToStoreSavepointSQL); err != nil {
+			return storeErr, fmt.Errorf("failed to rollback to the store offchain data savepoint: %w", err)
+		}
+
+		return storeErr, nil
+	}
+
+	if _, err := execer.ExecContext(ctx, releaseStoreSavepointSQL); err != nil {
+		return nil, fmt.Errorf("failed to release the store offchain data savepoint: %w", err)
+	}
+
+	return nil, nil
+}
+
+// newFailedRecord returns the failed record of the given offchain data, that failed to be stored with the given error
+func newFailedRecord(od types.OffChainData, err error) types.FailedRecord {
+	return types.FailedRecord{Key: od.Key, BatchNum: od.BatchNum, Reason: err.Error()}
+}
+
+// storeOffChainDataChunk stores the given offchain data with a single statement.
+// Every row is either inserted or has its batch number updated, unless a different value is already stored
+// for its key, which would break the invariant that the key is the hash of the value
+func storeOffChainDataChunk(ctx context.Context, execer sqlx.ExecerContext, ods []types.OffChainData) error {
+	query, args := buildOffchainDataInsertQuery(ods)
+
+	res, err := execer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to store offchain data: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get the stored offchain data count: %w", err)
+	}
+
+	if affected < int64(len(ods)) {
+		return fmt.Errorf("%w: %d of %d values conflict with the stored ones",
+			ErrOffChainDataMismatch, int64(len(ods))-affected, len(ods))
+	}
+
+	return nil
+}
+
+// ReplaceOffChainData replaces the stored value of the given key, which is meant to heal a corrupt value.
+// The new value is only stored if it hashes to the key, and ErrStateNotSynchronized is returned
+// if nothing is stored for the key
+func (db *pgDB) ReplaceOffChainData(ctx context.Context, key common.Hash, newValue []byte) error {
+	if err := verifyOffChainData(key, newValue); err != nil {
+		return err
+	}
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin the replace offchain data transaction: %w", err)
+	}
+
+	if err = replaceOffChainData(ctx, tx, key, newValue); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("failed to rollback the replace offchain data transaction: %w", rollbackErr)
+		}
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the replace offchain data transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CorrectOffChainDataBatchNums moves each of the given keys from the batch number it is stored under to the
+// corrected one, all of them or none. ErrStateNotSynchronized is returned if a key is not stored under
+// the batch number it is corrected from, e.g. because it was corrected concurrently
+func (db *pgDB) CorrectOffChainDataBatchNums(ctx context.Context, corrections []types.KeyBatchCorrection) error {
+	if len(corrections) == 0 {
+		return nil
+	}
+
+	tx, err := db.pg.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin the correct batch numbers transaction: %w", err)
+	}
+
+	for _, c := range corrections {
+		if err = correctBatchNum(ctx, tx, c); err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				return fmt.Errorf("failed to rollback the correct batch numbers transaction: %w", rollbackErr)
+			}
+
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the correct batch numbers transaction: %w", err)
+	}
+
+	return nil
+}
+
+// correctBatchNum updates the batch number of the given key, failing if it is not stored under the expected one
+func correctBatchNum(ctx context.Context, execer sqlx.ExecerContext, c types.KeyBatchCorrection) error {
+	res, err := execer.ExecContext(ctx, correctBatchNumSQL, c.Key.Hex(), uint64(c.From), uint64(c.To))
+	if err != nil {
+		return fmt.Errorf("failed to correct the batch number of %s: %w", c.Key.Hex(), err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get the corrected offchain data count: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%w: %s is not stored under batch %d", ErrStateNotSynchronized, c.Key.Hex(), c.From)
+	}
+
+	return nil
+}
+
+// replaceOffChainData updates the value of the given key, failing if nothing is stored for it
+func replaceOffChainData(ctx context.Context, execer sqlx.ExecerContext, key common.Hash, value []byte) error {
+	res, err := execer.ExecContext(ctx, replaceOffchainDataSQL, key.Hex(), common.Bytes2Hex(value))
+	if err != nil {
+		return fmt.Errorf("failed to replace offchain data %s: %w", key.Hex(), err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get the replaced offchain data count: %w", err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%w: no offchain data stored for %s", ErrStateNotSynchronized, key.Hex())
+	}
+
+	return nil
+}
+
+// verifyOffChainData returns ErrInvalidOffChainData if the given value does not hash to the given key
+func verifyOffChainData(key common.Hash, value []byte) error {
+	if actual := crypto.Keccak256Hash(value); actual != key {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidOffChainData, key.Hex(), actual.Hex())
+	}
+
+	return nil
+}
+
+// GetOffChainData returns the value identified by the key
+func (db *pgDB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	data := offChainDataRow{}
+
+	if err := db.getOffChainDataStmt.QueryRowxContext(ctx, key.Hex()).StructScan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
+		}
+
+		return nil, err
+	}
+
+	od := data.toOffChainData()
+	return &od, nil
+}
+
+// GetOffChainDataAsOf returns the value identified by the key as the node knew it once the given batch was
+// processed, so audits can be replayed against a fixed point. Values stored under a later batch, or whose batch
+// is not known, are ignored and ErrStateNotSynchronized is returned
+func (db *pgDB) GetOffChainDataAsOf(
+	ctx context.Context, key common.Hash, maxBatchNum uint64,
+) (*types.OffChainData, error) {
+	data := offChainDataRow{}
+
+	if err := db.pg.QueryRowxContext(ctx, getOffchainDataAsOfSQL, key.Hex(), maxBatchNum).StructScan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotSynchronized
+		}
+
+		return nil, err
+	}
+
+	od := data.toOffChainData()
+	return &od, nil
+}
+
+// ListOffChainData returns values identified by the given keys
+func (db *pgDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	keys = uniqueKeys(keys)
+
+	list := make([]types.OffChainData, 0, len(keys))
+	err := forEachKeysChunk(keys, func(chunk []string) error {
+		query, args, err := sqlx.In(listOffchainDataSQL, chunk)
+		if err != nil {
+			return err
+		}
+
+		// sqlx.In returns queries with the `?` bindvar, we can rebind it for our backend
+		query = db.pg.Rebind(query)
+
+		rows, err := db.pg.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		data, err := scanOffChainData(ctx, rows, len(chunk))
+		if err != nil {
+			return err
+		}
+
+		list = append(list, data...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// ListOffChainDataByBatch returns a page of the values stored for the given batch ordered by their position
+// in the sequence calldata and then by key, along with the total number of values stored for the batch
+func (db *pgDB) ListOffChainDataByBatch(
+	ctx context.Context,
+	batchNum uint64,
+	offset, limit uint,
+) ([]types.OffChainData, uint64, error) {
+	var total uint64
+	if err := db.countOffChainDataByBatchStmt.QueryRowContext(ctx, batchNum).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if total == 0 || limit == 0 || uint64(offset) >= total {
+		return []types.OffChainData{}, total, nil
+	}
+
+	rows, err := db.listOffChainDataByBatchStmt.QueryxContext(ctx, batchNum, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	list, err := scanOffChainData(ctx, rows, int(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetBatchConcatenated returns the data of the given batch, which is the value stored for the key committed
+// on L1 for it. The contract commits to a single hash per batch, so the rows attributed to the batch number are
+// not used: they hold stale values if the batch was sequenced again, and none if the value is stored under
+// another batch committing the same data.
+// ErrStateNotSynchronized is returned if the committed key is unknown or its value is not stored
+func (db *pgDB) GetBatchConcatenated(ctx context.Context, batchNum uint64) ([]byte, error) {
+	return committedValue(ctx, db.GetCommittedKey, db.GetOffChainData, batchNum)
+}
+
+// committedValue returns the value stored for the key committed for the given batch, read with the given functions
+func committedValue(
+	ctx context.Context,
+	committedKey func(ctx context.Context, batchNum uint64) (common.Hash, error),
+	get func(ctx context.Context, key common.Hash) (*types.OffChainData, error),
+	batchNum uint64,
+) ([]byte, error) {
+	key, err := committedKey(ctx, batchNum)
+	if err != nil {
+		return nil, err
+	}
+
+	od, err := get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return od.Value, nil
+}
+
+// FindDuplicateValues returns the groups of keys storing the same value, ordered by their lowest key.
+// Keys are the hash of their value, so any group is an anomaly, usually a bug deriving the keys on ingest.
+// It scans the whole table, so it is meant as an occasional diagnostic
+func (db *pgDB) FindDuplicateValues(ctx context.Context) ([]types.DuplicateGroup, error) {
+	rows, err := db.pg.QueryContext(ctx, findDuplicateValuesSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate values: %w", err)
+	}
+
+	defer rows.Close()
+
+	groups := []types.DuplicateGroup{}
+	for rows.Next() {
+		if err = checkScanContext(ctx, len(groups)); err != nil {
+			return nil, err
+		}
+
+		var keys string
+		if err = rows.Scan(&keys); err != nil {
+			return nil, err
+		}
+
+		group := types.DuplicateGroup{}
+		for _, key := range strings.Split(keys, ",") {
+			group.Keys = append(group.Keys, common.HexToHash(key))
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+// ListOffChainDataAfterKey returns up to limit values stored for the given inclusive batch range, ordered by key
+// and starting after the given key. Passing the last key of a page returns the next one, which keeps every page
+// as cheap as the first on large ranges
+func (db *pgDB) ListOffChainDataAfterKey(
+	ctx context.Context,
+	from, to uint64,
+	after common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	if limit == 0 {
+		return []types.OffChainData{}, nil
+	}
+
+	rows, err := db.pg.QueryxContext(ctx, listOffchainDataAfterKeySQL, from, to, after.Hex(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanOffChainData(ctx, rows, int(limit))
+}
+
+// ListOffChainDataInBatchRange returns a page of the values stored for the given inclusive batch range,
+// ordered by batch number and key, so the values of a range can be exported with a single query per page
+func (db *pgDB) ListOffChainDataInBatchRange(
+	ctx context.Context,
+	from, to uint64,
+	limit, offset uint,
+) ([]types.OffChainData, error) {
+	if to < from {
+		return nil, fmt.Errorf("invalid batch range %d-%d", from, to)
+	}
+
+	if limit == 0 {
+		return []types.OffChainData{}, nil
+	}
+
+	rows, err := db.pg.QueryxContext(ctx, listOffchainDataInBatchRangeSQL, from, to, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the offchain data of batches %d-%d: %w", from, to, err)
+	}
+
+	defer rows.Close()
+
+	return scanOffChainData(ctx, rows, int(limit))
+}
+
+// ListForcedOffChainData returns a page of the values stored for the forced batches of the given inclusive batch
+// range, ordered by batch number, position in the sequence calldata and key, to audit the forced transactions
+func (db *pgDB) ListForcedOffChainData(
+	ctx context.Context,
+	from, to uint64,
+	limit, offset uint,
+) ([]types.OffChainData, error) {
+	if to < from {
+		return nil, fmt.Errorf("invalid batch range %d-%d", from, to)
+	}
+
+	if limit == 0 {
+		return []types.OffChainData{}, nil
+	}
+
+	rows, err := db.pg.QueryxContext(ctx, listForcedOffchainDataSQL, from, to, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the forced offchain data of batches %d-%d: %w", from, to, err)
+	}
+
+	defer rows.Close()
+
+	return scanOffChainData(ctx, rows, int(limit))
+}
+
+// OffChainDataExists returns whether the value identified by the key is stored
+func (db *pgDB) OffChainDataExists(ctx context.Context, key common.Hash) (bool, error) {
+	var exists bool
+	if err := db.offChainDataExistsStmt.QueryRowContext(ctx, key.Hex()).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// AllExist returns whether the offchain data of all the given keys is stored, along with the keys
+// whose offchain data is missing, in the given order. It runs a single query per maxKeysPerQuery keys
+func (db *pgDB) AllExist(ctx context.Context, keys []common.Hash) (bool, []common.Hash, error) {
+	if len(keys) == 0 {
+		return true, nil, nil
+	}
+
+	keys = uniqueKeys(keys)
+
+	existing := make(map[common.Hash]struct{}, len(keys))
+	err := forEachKeysChunk(keys, func(chunk []string) error {
+		query, args, err := sqlx.In(listExistingKeysSQL, chunk)
+		if err != nil {
+			return err
+		}
+
+		rows, err := db.pg.QueryxContext(ctx, db.pg.Rebind(query), args...)
+		if err != nil {
+			return fmt.Errorf("failed to list the existing keys: %w", err)
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var key string
+			if err = rows.Scan(&key); err != nil {
+				return err
+			}
+
+			existing[common.HexToHash(key)] = struct{}{}
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	var missing []common.Hash
+	for _, key := range keys {
+		if _, ok := existing[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	return len(missing) == 0, missing, nil
+}
+
+// CountOffchainData returns the count of rows in the offchain_data table
+func (db *pgDB) CountOffchainData(ctx context.Context) (uint64, error) {
+	var count uint64
+	if err := db.countOffChainDataStmt.QueryRowContext(ctx).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetBatchNumRange returns the lowest and highest batch numbers of the stored offchain data.
+// Both are 0 if no stored offchain data has a known batch number
+func (db *pgDB) GetBatchNumRange(ctx context.Context) (uint64, uint64, error) {
+	var first, last uint64
+	if err := db.getBatchNumRangeStmt.QueryRowContext(ctx).Scan(&first, &last); err != nil {
+		return 0, 0, err
+	}
+
+	return first, last, nil
+}
+
+// GetFirstStoredBatchNum returns the lowest batch number of the stored offchain data.
+// The returned flag is false if no stored offchain data has a known batch number
+func (db *pgDB) GetFirstStoredBatchNum(ctx context.Context) (uint64, bool, error) {
+	var batchNum sql.NullInt64
+	if err := db.getFirstStoredBatchNumStmt.QueryRowContext(ctx).Scan(&batchNum); err != nil {
+		return 0, false, err
+	}
+
+	if !batchNum.Valid {
+		return 0, false, nil
+	}
+
+	return uint64(batchNum.Int64), true, nil
+}
+
+// GetDistinctBatchNums returns the sorted batch numbers in the given inclusive range
+// that are either stored or known to be missing
+func (db *pgDB) GetDistinctBatchNums(ctx context.Context, from, to uint64) ([]uint64, error) {
+	rows, err := db.getDistinctBatchNumsStmt.QueryContext(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var nums []uint64
+	for rows.Next() {
+		var num uint64
+		if err = rows.Scan(&num); err != nil {
+			return nil, err
+		}
+
+		nums = append(nums, num)
+	}
+
+	return nums, rows.Err()
+}
+
+// StoreL1TxHash stores the hash and the block of the L1 transaction that sequenced the batches from the given
+// one on, along with the keys committed for them, in order. A batch sequenced again after a reorg gets the hash
+// and the key of the new transaction
+func (db *pgDB) StoreL1TxHash(
+	ctx context.Context, from uint64, keys []common.Hash, txHash common.Hash, l1Block uint64,
+) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("no committed keys for the batches from %d", from)
+	}
+
+	to := from + uint64(len(keys)) - 1
+	query, args := buildBatchCommitmentsInsertQuery(from, keys, txHash, l1Block)
+	if _, err := db.pg.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to store the L1 tx hash of batches %d-%d: %w", from, to, err)
+	}
+
+	return nil
+}
+
+// GetCommittedKey returns the key committed on L1 for the given batch.
+// ErrStateNotSynchronized is returned if the batch was not sequenced, or was synchronized before the keys
+// were kept
+func (db *pgDB) GetCommittedKey(ctx context.Context, batchNum uint64) (common.Hash, error) {
+	var key string
+	if err := db.getCommittedKeyStmt.QueryRowContext(ctx, batchNum).Scan(&key); errors.Is(err, sql.ErrNoRows) {
+		return common.Hash{}, ErrStateNotSynchronized
+	} else if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get the committed key of batch %d: %w", batchNum, err)
+	}
+
+	return common.HexToHash(key), nil
+}
+
+// GetFirstBatchSequencedAfter returns the lowest batch number sequenced after the given L1 block,
+// or 0 if no batch was sequenced after it
+func (db *pgDB) GetFirstBatchSequencedAfter(ctx context.Context, l1Block uint64) (uint64, error) {
+	var batchNum uint64
+	if err := db.getFirstBatchSequencedAfterStmt.QueryRowContext(ctx, l1Block).Scan(&batchNum); err != nil {
+		return 0, fmt.Errorf("failed to get the first batch sequenced after block %d: %w", l1Block, err)
+	}
+
+	return batchNum, nil
+}
+
+// CountOffchainDataByBatch returns the count of rows of every batch in the given inclusive range.
+// Batches without offchain data are not included
+func (db *pgDB) CountOffchainDataByBatch(ctx context.Context, from, to uint64) (map[uint64]uint64, error) {
+	if to < from || to-from >= maxCountByBatchRange {
+		return nil, fmt.Errorf("invalid batch range, at most %d batches can be counted", maxCountByBatchRange)
+	}
+
+	rows, err := db.countOffChainDataPerBatchStmt.QueryContext(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := make(map[uint64]uint64)
+	for rows.Next() {
+		var num, count uint64
+		if err = rows.Scan(&num, &count); err != nil {
+			return nil, err
+		}
+
+		counts[num] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetBatchDataSize returns the size in bytes of the offchain data values stored for the given batch.
+// Values kept in an object store are not accounted for
+func (db *pgDB) GetBatchDataSize(ctx context.Context, batchNum uint64) (uint64, error) {
+	var size uint64
+	if err := db.getBatchDataSizeStmt.QueryRowContext(ctx, batchNum).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to get the data size of batch %d: %w", batchNum, err)
+	}
+
+	return size, nil
+}
+
+// GetBatchRangeDataSize returns the size in bytes of the offchain data values stored for the batches
+// in the given inclusive range. Values kept in an object store are not accounted for
+func (db *pgDB) GetBatchRangeDataSize(ctx context.Context, from, to uint64) (uint64, error) {
+	var size uint64
+	if err := db.getBatchRangeDataSizeStmt.QueryRowContext(ctx, from, to).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to get the data size of batches %d to %d: %w", from, to, err)
+	}
+
+	return size, nil
+}
+
+// TotalStoredBytes returns the size in bytes of all the offchain data values stored, which reflects the disk
+// usage better than the count of rows when the sizes vary. Values kept in an object store are not accounted for
+func (db *pgDB) TotalStoredBytes(ctx context.Context) (uint64, error) {
+	var size uint64
+	if err := db.totalStoredBytesStmt.QueryRowContext(ctx).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to get the total stored bytes: %w", err)
+	}
+
+	return size, nil
+}
+
+// MarkFinalized marks the offchain data of all the batches up to the given batch number as finalized
+func (db *pgDB) MarkFinalized(ctx context.Context, upToBatch uint64) error {
+	if _, err := db.markFinalizedStmt.ExecContext(ctx, upToBatch); err != nil {
+		return fmt.Errorf("failed to mark offchain data up to batch %d as finalized: %w", upToBatch, err)
+	}
+
+	return nil
+}
+
+// PruneFinalized deletes the finalized offchain data of the batches up to the given batch number.
+// Data that is not finalized is never deleted. It returns the number of deleted rows
+func (db *pgDB) PruneFinalized(ctx context.Context, upToBatch uint64) (uint64, error) {
+	res, err := db.pruneFinalizedStmt.ExecContext(ctx, upToBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune finalized offchain data up to batch %d: %w", upToBatch, err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(deleted), nil //nolint:gosec
+}
+
+// offChainDataRow is the database representation of the offchain data
+type offChainDataRow struct {
+	Key      string        `db:"key"`
+	Value    string        `db:"value"`
+	BatchNum uint64        `db:"batch_num"`
+	Index    sql.NullInt64 `db:"sequence_index"`
+	Forced   bool          `db:"forced"`
+	L1TxHash string        `db:"l1_tx_hash"`
+}
+
+func (r offChainDataRow) toOffChainData() types.OffChainData {
+	od := types.OffChainData{
+		Key:      common.HexToHash(r.Key),
+		Value:    common.FromHex(r.Value),
+		BatchNum: r.BatchNum,
+		Index:    sequenceIndex(r.Index),
+		Forced:   r.Forced,
+	}
+
+	if r.L1TxHash != "" {
+		od.L1TxHash = common.HexToHash(r.L1TxHash)
+	}
+
+	return od
+}
+
+// scanOffChainData scans all the offchain data rows of the given result set,
+// aborting early if the given context is done
+func scanOffChainData(ctx context.Context, rows *sqlx.Rows, sizeHint int) ([]types.OffChainData, error) {
+	list := make([]types.OffChainData, 0, sizeHint)
+	for rows.Next() {
+		if err := checkScanContext(ctx, len(list)); err != nil {
+			return nil, err
+		}
+
+		data := offChainDataRow{}
+		if err := rows.StructScan(&data); err != nil {
+			return nil, err
+		}
+
+		list = append(list, data.toOffChainData())
+	}
+
+	return list, rows.Err()
+}
+
+// uniqueKeys returns the given keys without duplicates, keeping the order of their first occurrence
+func uniqueKeys(keys []common.Hash) []common.Hash {
+	seen := make(map[common.Hash]struct{}, len(keys))
+	unique := make([]common.Hash, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			unique = append(unique, key)
+		}
+	}
+
+	return unique
+}
+
+// forEachKeysChunk calls fn with the hex encoded keys, in chunks that fit in the bind parameters of a single query
+func forEachKeysChunk(keys []common.Hash, fn func(chunk []string) error) error {
+	for start := 0; start < len(keys); start += maxKeysPerQuery {
+		end := min(start+maxKeysPerQuery, len(keys))
+
+		chunk := make([]string, end-start)
+		for i, key := range keys[start:end] {
+			chunk[i] = key.Hex()
+		}
+
+		if err := fn(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanBatchKeys scans all the batch key rows of the given result set,
+// aborting early if the given context is done
+func scanBatchKeys(ctx context.Context, rows *sqlx.Rows) ([]types.BatchKey, error) {
+	var bks []types.BatchKey
+	for rows.Next() {
+		if err := checkScanContext(ctx, len(bks)); err != nil {
+			return nil, err
+		}
+
+		bk := batchKeyRow{}
+		if err := rows.StructScan(&bk); err != nil {
+			return nil, err
+		}
+
+		bks = append(bks, bk.batchKey())
+	}
+
+	return bks, rows.Err()
+}
+
+// batchKeyRow is a row of the missing batches. The enqueue time, index and forced flag are only selected
+// by some queries
+type batchKeyRow struct {
+	Number     uint64        `db:"num"`
+	Hash       string        `db:"hash"`
+	EnqueuedAt sql.NullTime  `db:"enqueued_at"`
+	Index      sql.NullInt64 `db:"sequence_index"`
+	Forced     bool          `db:"forced"`
+	Attempts   uint          `db:"attempts"`
+}
+
+// failedBatchRow is a row of the failed batches
+type failedBatchRow struct {
+	Number   uint64    `db:"num"`
+	Hash     string    `db:"hash"`
+	Reason   string    `db:"reason"`
+	FailedAt time.Time `db:"failed_at"`
+}
+
+// batchKey returns the batch key of the row
+func (r batchKeyRow) batchKey() types.BatchKey {
+	return types.BatchKey{
+		Number:     r.Number,
+		Hash:       common.HexToHash(r.Hash),
+		EnqueuedAt: r.EnqueuedAt.Time,
+		Index:      sequenceIndex(r.Index),
+		Forced:     r.Forced,
+		Attempts:   r.Attempts,
+	}
+}
+
+// sequenceIndex returns the sequence index of the given column, nil if it is not known
+func sequenceIndex(index sql.NullInt64) *uint {
+	if !index.Valid {
+		return nil
+	}
+
+	i := uint(index.Int64) //nolint:gosec
+	return &i
+}
+
+// sequenceIndexArg returns the sequence index column value of the given index, NULL if it is not known
+func sequenceIndexArg(index *uint) interface{} {
+	if index == nil {
+		return nil
+	}
+
+	return int64(*index) //nolint:gosec
+}
+
+// checkScanContext returns the error of the given context every scanContextCheckInterval scanned rows,
+// so that a long scan stops as soon as its request is cancelled
+func checkScanContext(ctx context.Context, scanned int) error {
+	if scanned%scanContextCheckInterval != 0 {
+		return nil
+	}
+
+	return ctx.Err()
+}
+
+// buildBatchKeysTuples builds the list of (num, hash) tuples of the given batch keys and their arguments
+func buildBatchKeysTuples(bks []types.BatchKey) (string, []interface{}) {
+	const columnsAffected = batchKeyColumns
+
+	args := make([]interface{}, len(bks)*columnsAffected)
+	values := make([]string, len(bks))
+	for i, bk := range bks {
+		values[i] = fmt.Sprintf("($%d, $%d)", i*columnsAffected+1, i*columnsAffected+2) //nolint:mnd
+		args[i*columnsAffected] = bk.Number
+		args[i*columnsAffected+1] = bk.Hash.Hex()
+	}
+
+	return strings.Join(values, ","), args
+}
+
+// buildBatchKeysInsertQuery builds the query to insert missing batch keys
+func buildBatchKeysInsertQuery(bks []types.BatchKey) (string, []interface{}) {
+	const columnsAffected = batchKeyInsertColumns
+
+	args := make([]interface{}, len(bks)*columnsAffected)
+	values := make([]string, len(bks))
+	for i, bk := range bks {
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d)", //nolint:mnd
+			i*columnsAffected+1, i*columnsAffected+2, i*columnsAffected+3, i*columnsAffected+4) //nolint:mnd
+		args[i*columnsAffected] = bk.Number
+		args[i*columnsAffected+1] = bk.Hash.Hex()
+		args[i*columnsAffected+2] = sequenceIndexArg(bk.Index)
+		args[i*columnsAffected+3] = bk.Forced
+	}
+
+	return fmt.Sprintf(`
+		INSERT INTO data_node.missing_batches (num, hash, sequence_index, forced)
+		VALUES %s
+		ON CONFLICT (num, hash) DO NOTHING;
+	`, strings.Join(values, ",")), args
+}
+
+// buildBatchCommitmentsInsertQuery builds the query to store the L1 transaction that sequenced the batches
+// from the given one on, along with their committed keys
+func buildBatchCommitmentsInsertQuery(
+	from uint64, keys []common.Hash, txHash common.Hash, l1Block uint64,
+) (string, []interface{}) {
+	const columnsAffected = batchCommitmentColumns
+
+	args := make([]interface{}, 0, len(keys)*columnsAffected+2) //nolint:mnd
+	args = append(args, txHash.Hex(), l1Block)
+
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = fmt.Sprintf("($%d::BIGINT, $%d::VARCHAR)", //nolint:mnd
+			len(args)+1, len(args)+2) //nolint:mnd
+		args = append(args, from+uint64(i), key.Hex())
+	}
+
+	return fmt.Sprintf(`
+		INSERT INTO data_node.batch_commitments (batch_num, key, l1_tx_hash, l1_block)
+		SELECT c.batch_num, c.key, $1, $2 FROM (VALUES %s) AS c (batch_num, key)
+		ON CONFLICT (batch_num) DO UPDATE
+		SET key = EXCLUDED.key, l1_tx_hash = EXCLUDED.l1_tx_hash, l1_block = EXCLUDED.l1_block;
+	`, strings.Join(values, ",")), args
+}
+
+// buildArchiveMissingBatchKeysQuery builds the query to move resolved missing batch keys to the history
+func buildArchiveMissingBatchKeysQuery(resolved []types.ResolvedBatch) (string, []interface{}) {
+	const columnsAffected = resolvedBatchColumns
+
+	args := make([]interface{}, len(resolved)*columnsAffected)
+	values := make([]string, len(resolved))
+	for i, r := range resolved {
+		values[i] = fmt.Sprintf("($%d::BIGINT, $%d::VARCHAR, $%d::INTEGER)", //nolint:mnd
+			i*columnsAffected+1, i*columnsAffected+2, i*columnsAffected+3) //nolint:mnd
+		args[i*columnsAffected] = uint64(r.Number)
+		args[i*columnsAffected+1] = r.Hash.Hex()
+		args[i*columnsAffected+2] = r.Attempts
+	}
+
+	return fmt.Sprintf(`
+		WITH resolved (num, hash, attempts) AS (VALUES %s),
+		deleted AS (
+			DELETE FROM data_node.missing_batches m USING resolved r
+			WHERE m.num = r.num AND m.hash = r.hash
+			RETURNING m.num, m.hash, m.enqueued_at, r.attempts
+		)
+		INSERT INTO data_node.resolved_batches_history (num, hash, enqueued_at, attempts)
+		SELECT num, hash, enqueued_at, attempts FROM deleted;
+	`, strings.Join(values, ",")), args
+}
+
+// buildOffchainDataInsertQuery builds the query to insert offchain data
+// A batch number of 0 means it is not known yet, so it never overwrites an already known batch number,
+// and neither does an unknown sequence index nor an untagged forced batch.
+// The value of a key is immutable, so it is never updated.
+// Conflicting rows with a different value are not updated either, while an empty value is only metadata
+// of a value kept in an object store and matches any value
+func buildOffchainDataInsertQuery(ods []types.OffChainData) (string, []interface{}) {
+	const columnsAffected = offchainDataInsertColumns
+
+	args := make([]interface{}, len(ods)*columnsAffected)
+	values := make([]string, len(ods))
+	for i, od := range ods {
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", //nolint:mnd
+			i*columnsAffected+1, i*columnsAffected+2, i*columnsAffected+3, i*columnsAffected+4, //nolint:mnd
+			i*columnsAffected+5) //nolint:mnd
 		args[i*columnsAffected] = od.Key.Hex()
 		args[i*columnsAffected+1] = common.Bytes2Hex(od.Value)
+		args[i*columnsAffected+2] = od.BatchNum
+		args[i*columnsAffected+3] = sequenceIndexArg(od.Index)
+		args[i*columnsAffected+4] = od.Forced
 	}
 
 	return fmt.Sprintf(`
-		INSERT INTO data_node.offchain_data (key, value)
+		INSERT INTO data_node.offchain_data (key, value, batch_num, sequence_index, forced)
 		VALUES %s
-		ON CONFLICT (key) DO UPDATE 
-		SET value = EXCLUDED.value;
+		ON CONFLICT (key) DO UPDATE
+		SET batch_num = COALESCE(NULLIF(EXCLUDED.batch_num, 0), data_node.offchain_data.batch_num),
+			sequence_index = COALESCE(EXCLUDED.sequence_index, data_node.offchain_data.sequence_index),
+			forced = EXCLUDED.forced OR data_node.offchain_data.forced
+		WHERE data_node.offchain_data.value = EXCLUDED.value
+			OR data_node.offchain_data.value = '' OR EXCLUDED.value = '';
 	`, strings.Join(values, ",")), args
 }
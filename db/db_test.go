@@ -2,14 +2,18 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"math/big"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
 )
@@ -26,8 +30,11 @@ func Test_New(t *testing.T) {
 
 	wdb := sqlx.NewDb(db, "postgres")
 
-	_, err = New(context.Background(), wdb)
+	_, err = New(context.Background(), wdb, DefaultInsertChunkSize)
 	require.NoError(t, err)
+
+	_, err = New(context.Background(), wdb, maxInsertChunkSize+1)
+	require.ErrorContains(t, err, "exceeds the maximum")
 }
 
 func Test_DB_StoreLastProcessedBlock(t *testing.T) {
@@ -75,7 +82,7 @@ func Test_DB_StoreLastProcessedBlock(t *testing.T) {
 
 			wdb := sqlx.NewDb(db, "postgres")
 
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
 			err = dbPG.StoreLastProcessedBlock(context.Background(), tt.block, tt.task)
@@ -140,7 +147,7 @@ func Test_DB_GetLastProcessedBlock(t *testing.T) {
 
 			wdb := sqlx.NewDb(db, "postgres")
 
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
 			err = dbPG.StoreLastProcessedBlock(context.Background(), tt.block, tt.task)
@@ -177,7 +184,7 @@ func Test_DB_StoreMissingBatchKeys(t *testing.T) {
 				Number: 1,
 				Hash:   common.BytesToHash([]byte("key1")),
 			}},
-			expectedQuery: `INSERT INTO data_node.missing_batches (num, hash) VALUES ($1, $2) ON CONFLICT (num, hash) DO NOTHING`,
+			expectedQuery: `INSERT INTO data_node.missing_batches (num, hash, sequence_index, forced) VALUES ($1, $2, $3, $4) ON CONFLICT (num, hash) DO NOTHING`,
 		},
 		{
 			name: "several values inserted",
@@ -188,7 +195,7 @@ func Test_DB_StoreMissingBatchKeys(t *testing.T) {
 				Number: 2,
 				Hash:   common.BytesToHash([]byte("key2")),
 			}},
-			expectedQuery: `INSERT INTO data_node.missing_batches (num, hash) VALUES ($1, $2),($3, $4) ON CONFLICT (num, hash) DO NOTHING`,
+			expectedQuery: `INSERT INTO data_node.missing_batches (num, hash, sequence_index, forced) VALUES ($1, $2, $3, $4),($5, $6, $7, $8) ON CONFLICT (num, hash) DO NOTHING`,
 		},
 		{
 			name: "error returned",
@@ -196,7 +203,7 @@ func Test_DB_StoreMissingBatchKeys(t *testing.T) {
 				Number: 1,
 				Hash:   common.BytesToHash([]byte("key1")),
 			}},
-			expectedQuery: `INSERT INTO data_node.missing_batches (num, hash) VALUES ($1, $2) ON CONFLICT (num, hash) DO NOTHING`,
+			expectedQuery: `INSERT INTO data_node.missing_batches (num, hash, sequence_index, forced) VALUES ($1, $2, $3, $4) ON CONFLICT (num, hash) DO NOTHING`,
 			returnErr:     errors.New("test error"),
 		},
 	}
@@ -215,18 +222,34 @@ func Test_DB_StoreMissingBatchKeys(t *testing.T) {
 			mock.ExpectPrepare(regexp.QuoteMeta(storeLastProcessedBlockSQL))
 			mock.ExpectPrepare(regexp.QuoteMeta(getLastProcessedBlockSQL))
 			mock.ExpectPrepare(regexp.QuoteMeta(getMissingBatchKeysSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getMissingBatchKeySQL))
 			mock.ExpectPrepare(regexp.QuoteMeta(getOffchainDataSQL))
 			mock.ExpectPrepare(regexp.QuoteMeta(countOffchainDataSQL))
-
-			dbPG, err := New(context.Background(), wdb)
+			mock.ExpectPrepare(regexp.QuoteMeta(listOffchainDataByBatchSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(countOffchainDataByBatchSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(offchainDataExistsSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getBatchNumRangeSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getDistinctBatchNumsSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(markFinalizedSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(pruneFinalizedSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(countOffchainDataPerBatchSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getMissingBatchKeysInRangeSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getBatchDataSizeSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getBatchRangeDataSizeSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getCommittedKeySQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getFirstBatchSequencedAfterSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(getFirstStoredBatchNumSQL))
+			mock.ExpectPrepare(regexp.QuoteMeta(totalStoredBytesSQL))
+
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
 			defer db.Close()
 
 			if tt.expectedQuery != "" {
-				args := make([]driver.Value, 0, len(tt.bk)*2)
+				args := make([]driver.Value, 0, len(tt.bk)*4)
 				for _, o := range tt.bk {
-					args = append(args, o.Number, o.Hash.Hex())
+					args = append(args, o.Number, o.Hash.Hex(), sequenceIndexArg(o.Index), o.Forced)
 				}
 
 				expected := mock.ExpectExec(regexp.QuoteMeta(tt.expectedQuery)).WithArgs(args...)
@@ -249,6 +272,84 @@ func Test_DB_StoreMissingBatchKeys(t *testing.T) {
 	}
 }
 
+func Test_DB_InsertMissingBatchKeys(t *testing.T) {
+	t.Parallel()
+
+	bks := []types.BatchKey{
+		{Number: 1, Hash: common.BytesToHash([]byte("key1"))},
+		{Number: 2, Hash: common.BytesToHash([]byte("key2"))},
+		{Number: 3, Hash: common.BytesToHash([]byte("key3"))},
+	}
+
+	testTable := []struct {
+		name      string
+		bks       []types.BatchKey
+		affected  int64
+		inserted  uint64
+		returnErr error
+	}{
+		{
+			name: "no values given",
+		},
+		{
+			name:     "already queued values are not counted",
+			bks:      bks,
+			affected: 1,
+			inserted: 1,
+		},
+		{
+			name:      "error returned",
+			bks:       bks,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			if len(tt.bks) > 0 {
+				query, args := buildBatchKeysInsertQuery(tt.bks)
+
+				driverArgs := make([]driver.Value, len(args))
+				for i, arg := range args {
+					driverArgs[i] = arg
+				}
+
+				expected := mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(driverArgs...)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					expected.WillReturnResult(sqlmock.NewResult(0, tt.affected))
+				}
+			}
+
+			inserted, err := dbPG.InsertMissingBatchKeys(context.Background(), tt.bks)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.inserted, inserted)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func Test_DB_GetMissingBatchKeys(t *testing.T) {
 	t.Parallel()
 
@@ -260,8 +361,9 @@ func Test_DB_GetMissingBatchKeys(t *testing.T) {
 		{
 			name: "successfully selected data",
 			bks: []types.BatchKey{{
-				Number: 1,
-				Hash:   common.BytesToHash([]byte("key1")),
+				Number:     1,
+				Hash:       common.BytesToHash([]byte("key1")),
+				EnqueuedAt: time.Unix(1000, 0),
 			}},
 		},
 		{
@@ -288,21 +390,24 @@ func Test_DB_GetMissingBatchKeys(t *testing.T) {
 			constructorExpect(mock)
 
 			wdb := sqlx.NewDb(db, "postgres")
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
 			// Seed data
 			seedMissingBatchKeys(t, dbPG, mock, tt.bks)
 
 			var limit = uint(10)
-			expected := mock.ExpectQuery(`SELECT num, hash FROM data_node\.missing_batches LIMIT \$1\;`).WithArgs(limit)
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getMissingBatchKeysSQL)).WithArgs(limit)
 
 			if tt.returnErr != nil {
 				expected.WillReturnError(tt.returnErr)
 			} else {
+				rows := sqlmock.NewRows([]string{"num", "hash", "enqueued_at"})
 				for _, bk := range tt.bks {
-					expected.WillReturnRows(sqlmock.NewRows([]string{"num", "hash"}).AddRow(bk.Number, bk.Hash.Hex()))
+					rows.AddRow(bk.Number, bk.Hash.Hex(), bk.EnqueuedAt)
 				}
+
+				expected.WillReturnRows(rows)
 			}
 
 			data, err := dbPG.GetMissingBatchKeys(context.Background(), limit)
@@ -318,42 +423,38 @@ func Test_DB_GetMissingBatchKeys(t *testing.T) {
 	}
 }
 
-func Test_DB_DeleteMissingBatchKeys(t *testing.T) {
+func Test_DB_GetMissingBatchKeysInRange(t *testing.T) {
 	t.Parallel()
 
 	testTable := []struct {
-		name          string
-		bks           []types.BatchKey
-		expectedQuery string
-		returnErr     error
+		name      string
+		from      uint64
+		to        uint64
+		bks       []types.BatchKey
+		returnErr error
 	}{
 		{
-			name: "value deleted",
-			bks: []types.BatchKey{{
-				Number: 1,
-				Hash:   common.BytesToHash([]byte("key1")),
-			}},
-			expectedQuery: `DELETE FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2))`,
-		},
-		{
-			name: "multiple values deleted",
+			name: "keys in range returned",
+			from: 2,
+			to:   5,
 			bks: []types.BatchKey{{
-				Number: 1,
-				Hash:   common.BytesToHash([]byte("key1")),
-			}, {
 				Number: 2,
 				Hash:   common.BytesToHash([]byte("key2")),
+			}, {
+				Number: 4,
+				Hash:   common.BytesToHash([]byte("key4")),
 			}},
-			expectedQuery: `DELETE FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2),($3, $4))`,
 		},
 		{
-			name: "error returned",
-			bks: []types.BatchKey{{
-				Number: 1,
-				Hash:   common.BytesToHash([]byte("key1")),
-			}},
-			expectedQuery: `DELETE FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2))`,
-			returnErr:     errors.New("test error"),
+			name: "no keys in range",
+			from: 2,
+			to:   5,
+		},
+		{
+			name:      "error returned",
+			from:      2,
+			to:        5,
+			returnErr: errors.New("test error"),
 		},
 	}
 
@@ -366,33 +467,33 @@ func Test_DB_DeleteMissingBatchKeys(t *testing.T) {
 			db, mock, err := sqlmock.New()
 			require.NoError(t, err)
 
+			defer db.Close()
+
 			constructorExpect(mock)
 
 			wdb := sqlx.NewDb(db, "postgres")
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
-			defer db.Close()
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getMissingBatchKeysInRangeSQL)).WithArgs(tt.from, tt.to)
 
-			if tt.expectedQuery != "" {
-				args := make([]driver.Value, 0, len(tt.bks)*2)
-				for _, o := range tt.bks {
-					args = append(args, o.Number, o.Hash.Hex())
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"num", "hash"})
+				for _, bk := range tt.bks {
+					rows.AddRow(bk.Number, bk.Hash.Hex())
 				}
 
-				expected := mock.ExpectExec(regexp.QuoteMeta(tt.expectedQuery)).WithArgs(args...)
-				if tt.returnErr != nil {
-					expected.WillReturnError(tt.returnErr)
-				} else {
-					expected.WillReturnResult(sqlmock.NewResult(int64(len(tt.bks)), int64(len(tt.bks))))
-				}
+				expected.WillReturnRows(rows)
 			}
 
-			err = dbPG.DeleteMissingBatchKeys(context.Background(), tt.bks)
+			data, err := dbPG.GetMissingBatchKeysInRange(context.Background(), tt.from, tt.to)
 			if tt.returnErr != nil {
 				require.ErrorIs(t, err, tt.returnErr)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tt.bks, data)
 			}
 
 			require.NoError(t, mock.ExpectationsWereMet())
@@ -400,44 +501,113 @@ func Test_DB_DeleteMissingBatchKeys(t *testing.T) {
 	}
 }
 
-func Test_DB_StoreOffChainData(t *testing.T) {
+func Test_DB_GetMissingBatchKey(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		hash      common.Hash
+		bk        *types.BatchKey
+		returnErr error
+		err       error
+	}{
+		{
+			name: "successfully selected data",
+			hash: common.BytesToHash([]byte("key1")),
+			bk: &types.BatchKey{
+				Number:     1,
+				Hash:       common.BytesToHash([]byte("key1")),
+				EnqueuedAt: time.Unix(1000, 0),
+			},
+		},
+		{
+			name:      "no rows",
+			hash:      common.BytesToHash([]byte("key1")),
+			returnErr: sql.ErrNoRows,
+			err:       ErrStateNotSynchronized,
+		},
+		{
+			name:      "error returned",
+			hash:      common.BytesToHash([]byte("key1")),
+			returnErr: errors.New("test error"),
+			err:       errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getMissingBatchKeySQL)).WithArgs(tt.hash.Hex())
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"num", "hash", "enqueued_at"}).
+					AddRow(tt.bk.Number, tt.bk.Hash.Hex(), tt.bk.EnqueuedAt))
+			}
+
+			data, err := dbPG.GetMissingBatchKey(context.Background(), tt.hash)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.bk, data)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_DeleteMissingBatchKeys(t *testing.T) {
 	t.Parallel()
 
 	testTable := []struct {
 		name          string
-		ods           []types.OffChainData
+		bks           []types.BatchKey
 		expectedQuery string
 		returnErr     error
 	}{
 		{
-			name: "no values inserted",
-		},
-		{
-			name: "one value inserted",
-			ods: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
+			name: "value deleted",
+			bks: []types.BatchKey{{
+				Number: 1,
+				Hash:   common.BytesToHash([]byte("key1")),
 			}},
-			expectedQuery: `INSERT INTO data_node.offchain_data (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`,
+			expectedQuery: `DELETE FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2))`,
 		},
 		{
-			name: "several values inserted",
-			ods: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
+			name: "multiple values deleted",
+			bks: []types.BatchKey{{
+				Number: 1,
+				Hash:   common.BytesToHash([]byte("key1")),
 			}, {
-				Key:   common.BytesToHash([]byte("key2")),
-				Value: []byte("value2"),
+				Number: 2,
+				Hash:   common.BytesToHash([]byte("key2")),
 			}},
-			expectedQuery: `INSERT INTO data_node.offchain_data (key, value) VALUES ($1, $2),($3, $4) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`,
+			expectedQuery: `DELETE FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2),($3, $4))`,
 		},
 		{
 			name: "error returned",
-			ods: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
+			bks: []types.BatchKey{{
+				Number: 1,
+				Hash:   common.BytesToHash([]byte("key1")),
 			}},
-			expectedQuery: `INSERT INTO data_node.offchain_data (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`,
+			expectedQuery: `DELETE FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2))`,
 			returnErr:     errors.New("test error"),
 		},
 	}
@@ -454,26 +624,26 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 			constructorExpect(mock)
 
 			wdb := sqlx.NewDb(db, "postgres")
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
 			defer db.Close()
 
 			if tt.expectedQuery != "" {
-				args := make([]driver.Value, 0, len(tt.ods)*3)
-				for _, od := range tt.ods {
-					args = append(args, od.Key.Hex(), common.Bytes2Hex(od.Value))
+				args := make([]driver.Value, 0, len(tt.bks)*2)
+				for _, o := range tt.bks {
+					args = append(args, o.Number, o.Hash.Hex())
 				}
 
 				expected := mock.ExpectExec(regexp.QuoteMeta(tt.expectedQuery)).WithArgs(args...)
 				if tt.returnErr != nil {
 					expected.WillReturnError(tt.returnErr)
 				} else {
-					expected.WillReturnResult(sqlmock.NewResult(int64(len(tt.ods)), int64(len(tt.ods))))
+					expected.WillReturnResult(sqlmock.NewResult(int64(len(tt.bks)), int64(len(tt.bks))))
 				}
 			}
 
-			err = dbPG.StoreOffChainData(context.Background(), tt.ods)
+			err = dbPG.DeleteMissingBatchKeys(context.Background(), tt.bks)
 			if tt.returnErr != nil {
 				require.ErrorIs(t, err, tt.returnErr)
 			} else {
@@ -485,45 +655,33 @@ func Test_DB_StoreOffChainData(t *testing.T) {
 	}
 }
 
-func Test_DB_GetOffChainData(t *testing.T) {
+func Test_DB_IncrementBatchAttempts(t *testing.T) {
 	t.Parallel()
 
+	key := types.BatchKey{
+		Number: 1,
+		Hash:   common.BytesToHash([]byte("key1")),
+	}
+
 	testTable := []struct {
 		name      string
-		od        []types.OffChainData
-		key       common.Hash
-		expected  *types.OffChainData
+		attempts  uint
 		returnErr error
+		err       string
 	}{
 		{
-			name: "successfully selected value",
-			od: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
-			}},
-			key: common.BytesToHash([]byte("key1")),
-			expected: &types.OffChainData{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
-			},
+			name:     "attempts incremented",
+			attempts: 3,
 		},
 		{
-			name: "error returned",
-			od: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
-			}},
-			key:       common.BytesToHash([]byte("key1")),
-			returnErr: errors.New("test error"),
+			name:      "key not missing",
+			returnErr: sql.ErrNoRows,
+			err:       "state not synchronized: batch 1, key " + key.Hash.Hex() + " is not missing",
 		},
 		{
-			name: "no rows",
-			od: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
-			}},
-			key:       common.BytesToHash([]byte("undefined")),
-			returnErr: ErrStateNotSynchronized,
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+			err:       "failed to increment the attempts of batch 1: test error",
 		},
 	}
 
@@ -536,33 +694,28 @@ func Test_DB_GetOffChainData(t *testing.T) {
 			db, mock, err := sqlmock.New()
 			require.NoError(t, err)
 
+			defer db.Close()
+
 			constructorExpect(mock)
 
 			wdb := sqlx.NewDb(db, "postgres")
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
-			defer db.Close()
-
-			// Seed data
-			seedOffchainData(t, dbPG, mock, tt.od)
-
-			expected := mock.ExpectQuery(regexp.QuoteMeta(getOffchainDataSQL)).
-				WithArgs(tt.key.Hex())
-
+			expected := mock.ExpectQuery(regexp.QuoteMeta(incrementBatchAttemptsSQL)).
+				WithArgs(key.Number, key.Hash.Hex())
 			if tt.returnErr != nil {
 				expected.WillReturnError(tt.returnErr)
 			} else {
-				expected.WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).
-					AddRow(tt.expected.Key.Hex(), common.Bytes2Hex(tt.expected.Value)))
+				expected.WillReturnRows(sqlmock.NewRows([]string{"attempts"}).AddRow(tt.attempts))
 			}
 
-			data, err := dbPG.GetOffChainData(context.Background(), tt.key)
-			if tt.returnErr != nil {
-				require.ErrorIs(t, err, tt.returnErr)
+			attempts, err := dbPG.IncrementBatchAttempts(context.Background(), key)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tt.expected, data)
+				require.Equal(t, tt.attempts, attempts)
 			}
 
 			require.NoError(t, mock.ExpectationsWereMet())
@@ -570,82 +723,147 @@ func Test_DB_GetOffChainData(t *testing.T) {
 	}
 }
 
-func Test_DB_ListOffChainData(t *testing.T) {
+func Test_DB_FilterMissingBatchKeys(t *testing.T) {
 	t.Parallel()
 
+	bks := []types.BatchKey{{
+		Number: 1,
+		Hash:   common.BytesToHash([]byte("key1")),
+	}, {
+		Number: 2,
+		Hash:   common.BytesToHash([]byte("key2")),
+	}}
+
 	testTable := []struct {
 		name      string
-		od        []types.OffChainData
-		keys      []common.Hash
-		expected  []types.OffChainData
-		sql       string
+		bks       []types.BatchKey
+		missing   []types.BatchKey
 		returnErr error
 	}{
 		{
-			name: "successfully selected one value",
-			od: []types.OffChainData{{
+			name: "no keys given",
+		},
+		{
+			name:    "some keys still missing",
+			bks:     bks,
+			missing: bks[1:],
+		},
+		{
+			name: "no keys missing",
+			bks:  bks,
+		},
+		{
+			name:      "error returned",
+			bks:       bks,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			if len(tt.bks) > 0 {
+				args := make([]driver.Value, 0, len(tt.bks)*2)
+				for _, bk := range tt.bks {
+					args = append(args, bk.Number, bk.Hash.Hex())
+				}
+
+				expected := mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT num, hash FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2),($3, $4)) ORDER BY num`,
+				)).WithArgs(args...)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					rows := sqlmock.NewRows([]string{"num", "hash"})
+					for _, bk := range tt.missing {
+						rows.AddRow(bk.Number, bk.Hash.Hex())
+					}
+
+					expected.WillReturnRows(rows)
+				}
+			}
+
+			missing, err := dbPG.FilterMissingBatchKeys(context.Background(), tt.bks)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.missing, missing)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_StoreOffChainData(t *testing.T) {
+	t.Parallel()
+
+	index := uint(1)
+
+	testTable := []struct {
+		name          string
+		ods           []types.OffChainData
+		expectedQuery string
+		conflicts     int
+		returnErr     error
+	}{
+		{
+			name: "no values inserted",
+		},
+		{
+			name: "one value inserted",
+			ods: []types.OffChainData{{
 				Key:   common.BytesToHash([]byte("key1")),
 				Value: []byte("value1"),
 			}},
-			keys: []common.Hash{
-				common.BytesToHash([]byte("key1")),
-			},
-			expected: []types.OffChainData{
-				{
-					Key:   common.BytesToHash([]byte("key1")),
-					Value: []byte("value1"),
-				},
-			},
-			sql: `SELECT key, value FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
+			expectedQuery: `INSERT INTO data_node.offchain_data (key, value, batch_num, sequence_index, forced) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (key) DO UPDATE SET batch_num = COALESCE(NULLIF(EXCLUDED.batch_num, 0), data_node.offchain_data.batch_num), sequence_index = COALESCE(EXCLUDED.sequence_index, data_node.offchain_data.sequence_index), forced = EXCLUDED.forced OR data_node.offchain_data.forced WHERE data_node.offchain_data.value = EXCLUDED.value OR data_node.offchain_data.value = '' OR EXCLUDED.value = ''`,
 		},
 		{
-			name: "successfully selected two values",
-			od: []types.OffChainData{{
+			name: "several values inserted",
+			ods: []types.OffChainData{{
 				Key:   common.BytesToHash([]byte("key1")),
 				Value: []byte("value1"),
 			}, {
-				Key:   common.BytesToHash([]byte("key2")),
-				Value: []byte("value2"),
+				Key:      common.BytesToHash([]byte("key2")),
+				Value:    []byte("value2"),
+				BatchNum: 2,
+				Index:    &index,
 			}},
-			keys: []common.Hash{
-				common.BytesToHash([]byte("key1")),
-				common.BytesToHash([]byte("key2")),
-			},
-			expected: []types.OffChainData{
-				{
-					Key:   common.BytesToHash([]byte("key1")),
-					Value: []byte("value1"),
-				},
-				{
-					Key:   common.BytesToHash([]byte("key2")),
-					Value: []byte("value2"),
-				},
-			},
-			sql: `SELECT key, value FROM data_node\.offchain_data WHERE key IN \(\$1\, \$2\)`,
+			expectedQuery: `INSERT INTO data_node.offchain_data (key, value, batch_num, sequence_index, forced) VALUES ($1, $2, $3, $4, $5),($6, $7, $8, $9, $10) ON CONFLICT (key) DO UPDATE SET batch_num = COALESCE(NULLIF(EXCLUDED.batch_num, 0), data_node.offchain_data.batch_num), sequence_index = COALESCE(EXCLUDED.sequence_index, data_node.offchain_data.sequence_index), forced = EXCLUDED.forced OR data_node.offchain_data.forced WHERE data_node.offchain_data.value = EXCLUDED.value OR data_node.offchain_data.value = '' OR EXCLUDED.value = ''`,
 		},
 		{
 			name: "error returned",
-			od: []types.OffChainData{{
+			ods: []types.OffChainData{{
 				Key:   common.BytesToHash([]byte("key1")),
 				Value: []byte("value1"),
 			}},
-			keys: []common.Hash{
-				common.BytesToHash([]byte("key1")),
-			},
-			sql:       `SELECT key, value FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
-			returnErr: errors.New("test error"),
+			expectedQuery: `INSERT INTO data_node.offchain_data (key, value, batch_num, sequence_index, forced) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (key) DO UPDATE SET batch_num = COALESCE(NULLIF(EXCLUDED.batch_num, 0), data_node.offchain_data.batch_num), sequence_index = COALESCE(EXCLUDED.sequence_index, data_node.offchain_data.sequence_index), forced = EXCLUDED.forced OR data_node.offchain_data.forced WHERE data_node.offchain_data.value = EXCLUDED.value OR data_node.offchain_data.value = '' OR EXCLUDED.value = ''`,
+			returnErr:     errors.New("test error"),
 		},
 		{
-			name: "no rows",
-			od: []types.OffChainData{{
+			name: "value conflicts with the stored one",
+			ods: []types.OffChainData{{
 				Key:   common.BytesToHash([]byte("key1")),
 				Value: []byte("value1"),
 			}},
-			keys: []common.Hash{
-				common.BytesToHash([]byte("undefined")),
-			},
-			sql:       `SELECT key, value FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
-			returnErr: ErrStateNotSynchronized,
+			expectedQuery: `INSERT INTO data_node.offchain_data (key, value, batch_num, sequence_index, forced) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (key) DO UPDATE SET batch_num = COALESCE(NULLIF(EXCLUDED.batch_num, 0), data_node.offchain_data.batch_num), sequence_index = COALESCE(EXCLUDED.sequence_index, data_node.offchain_data.sequence_index), forced = EXCLUDED.forced OR data_node.offchain_data.forced WHERE data_node.offchain_data.value = EXCLUDED.value OR data_node.offchain_data.value = '' OR EXCLUDED.value = ''`,
+			conflicts:     1,
+			returnErr:     ErrOffChainDataMismatch,
 		},
 	}
 
@@ -658,43 +876,35 @@ func Test_DB_ListOffChainData(t *testing.T) {
 			db, mock, err := sqlmock.New()
 			require.NoError(t, err)
 
-			defer db.Close()
-
 			constructorExpect(mock)
 
 			wdb := sqlx.NewDb(db, "postgres")
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
 			require.NoError(t, err)
 
-			// Seed data
-			seedOffchainData(t, dbPG, mock, tt.od)
-
-			preparedKeys := make([]driver.Value, len(tt.keys))
-			for i, key := range tt.keys {
-				preparedKeys[i] = key.Hex()
-			}
-
-			expected := mock.ExpectQuery(tt.sql).
-				WithArgs(preparedKeys...)
-
-			if tt.returnErr != nil {
-				expected.WillReturnError(tt.returnErr)
-			} else {
-				returnData := sqlmock.NewRows([]string{"key", "value"})
+			defer db.Close()
 
-				for _, data := range tt.expected {
-					returnData = returnData.AddRow(data.Key.Hex(), common.Bytes2Hex(data.Value))
+			if tt.expectedQuery != "" {
+				args := make([]driver.Value, 0, len(tt.ods)*4)
+				for _, od := range tt.ods {
+					args = append(args, od.Key.Hex(), common.Bytes2Hex(od.Value), od.BatchNum, sequenceIndexArg(od.Index),
+						od.Forced)
 				}
 
-				expected.WillReturnRows(returnData)
+				expected := mock.ExpectExec(regexp.QuoteMeta(tt.expectedQuery)).WithArgs(args...)
+				if tt.returnErr != nil && tt.conflicts == 0 {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					affected := int64(len(tt.ods) - tt.conflicts)
+					expected.WillReturnResult(sqlmock.NewResult(affected, affected))
+				}
 			}
 
-			data, err := dbPG.ListOffChainData(context.Background(), tt.keys)
+			err = dbPG.StoreOffChainData(context.Background(), tt.ods)
 			if tt.returnErr != nil {
 				require.ErrorIs(t, err, tt.returnErr)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tt.expected, data)
 			}
 
 			require.NoError(t, mock.ExpectationsWereMet())
@@ -702,36 +912,29 @@ func Test_DB_ListOffChainData(t *testing.T) {
 	}
 }
 
-func Test_DB_CountOffchainData(t *testing.T) {
+func Test_DB_StoreOffChainData_Chunked(t *testing.T) {
 	t.Parallel()
 
+	ods := []types.OffChainData{{
+		Key:   common.BytesToHash([]byte("key1")),
+		Value: []byte("value1"),
+	}, {
+		Key:   common.BytesToHash([]byte("key2")),
+		Value: []byte("value2"),
+	}, {
+		Key:   common.BytesToHash([]byte("key3")),
+		Value: []byte("value3"),
+	}}
+
 	testTable := []struct {
 		name      string
-		od        []types.OffChainData
-		count     uint64
 		returnErr error
 	}{
 		{
-			name: "two values found",
-			od: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
-			}, {
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value2"),
-			}},
-			count: 2,
-		},
-		{
-			name:  "no values found",
-			count: 0,
+			name: "all chunks stored",
 		},
 		{
-			name: "error returned",
-			od: []types.OffChainData{{
-				Key:   common.BytesToHash([]byte("key1")),
-				Value: []byte("value1"),
-			}},
+			name:      "error returned",
 			returnErr: errors.New("test error"),
 		},
 	}
@@ -745,31 +948,35 @@ func Test_DB_CountOffchainData(t *testing.T) {
 			db, mock, err := sqlmock.New()
 			require.NoError(t, err)
 
-			defer db.Close()
-
 			constructorExpect(mock)
 
 			wdb := sqlx.NewDb(db, "postgres")
-			dbPG, err := New(context.Background(), wdb)
+			dbPG, err := New(context.Background(), wdb, 2)
 			require.NoError(t, err)
 
-			// Seed data
-			seedOffchainData(t, dbPG, mock, tt.od)
+			defer db.Close()
 
-			expected := mock.ExpectQuery(regexp.QuoteMeta(countOffchainDataSQL))
+			mock.ExpectBegin()
+
+			firstQuery, firstArgs := buildOffchainDataInsertQuery(ods[:2])
+			mock.ExpectExec(regexp.QuoteMeta(firstQuery)).WithArgs(toDriverValues(firstArgs)...).
+				WillReturnResult(sqlmock.NewResult(2, 2))
 
+			secondQuery, secondArgs := buildOffchainDataInsertQuery(ods[2:])
+			expected := mock.ExpectExec(regexp.QuoteMeta(secondQuery)).WithArgs(toDriverValues(secondArgs)...)
 			if tt.returnErr != nil {
 				expected.WillReturnError(tt.returnErr)
+				mock.ExpectRollback()
 			} else {
-				expected.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.count))
+				expected.WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			}
 
-			actual, err := dbPG.CountOffchainData(context.Background())
+			err = dbPG.StoreOffChainData(context.Background(), ods)
 			if tt.returnErr != nil {
 				require.ErrorIs(t, err, tt.returnErr)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, tt.count, actual)
 			}
 
 			require.NoError(t, mock.ExpectationsWereMet())
@@ -777,52 +984,2847 @@ func Test_DB_CountOffchainData(t *testing.T) {
 	}
 }
 
-func constructorExpect(mock sqlmock.Sqlmock) {
-	mock.ExpectPrepare(regexp.QuoteMeta(storeLastProcessedBlockSQL))
-	mock.ExpectPrepare(regexp.QuoteMeta(getLastProcessedBlockSQL))
-	mock.ExpectPrepare(regexp.QuoteMeta(getMissingBatchKeysSQL))
-	mock.ExpectPrepare(regexp.QuoteMeta(getOffchainDataSQL))
-	mock.ExpectPrepare(regexp.QuoteMeta(countOffchainDataSQL))
-}
+func Test_DB_StateTransaction(t *testing.T) {
+	t.Parallel()
 
-func seedOffchainData(t *testing.T, db DB, mock sqlmock.Sqlmock, ods []types.OffChainData) {
-	t.Helper()
+	ods := []types.OffChainData{{
+		Key:      common.BytesToHash([]byte("key1")),
+		Value:    []byte("value1"),
+		BatchNum: 1,
+	}, {
+		Key:      common.BytesToHash([]byte("key2")),
+		Value:    []byte("value2"),
+		BatchNum: 1,
+	}}
+	bks := []types.BatchKey{{Number: 1, Hash: ods[0].Key}, {Number: 1, Hash: ods[1].Key}}
 
-	if len(ods) == 0 {
-		return
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "writes committed together",
+		},
+		{
+			name:      "writes rolled back together",
+			returnErr: errors.New("test error"),
+		},
 	}
 
-	query, args := buildOffchainDataInsertQuery(ods)
+	for _, tt := range testTable {
+		tt := tt
 
-	argValues := make([]driver.Value, len(args))
-	for i, arg := range args {
-		argValues[i] = arg
-	}
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
 
-	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(argValues...).
-		WillReturnResult(sqlmock.NewResult(int64(len(ods)), int64(len(ods))))
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
 
-	err := db.StoreOffChainData(context.Background(), ods)
-	require.NoError(t, err)
-}
+			constructorExpect(mock)
 
-func seedMissingBatchKeys(t *testing.T, db DB, mock sqlmock.Sqlmock, bks []types.BatchKey) {
-	t.Helper()
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, 1)
+			require.NoError(t, err)
 
-	if len(bks) == 0 {
-		return
-	}
+			defer db.Close()
 
-	query, args := buildBatchKeysInsertQuery(bks)
+			mock.ExpectBegin()
 
-	argValues := make([]driver.Value, len(args))
-	for i, arg := range args {
-		argValues[i] = arg
+			for _, od := range ods {
+				query, args := buildOffchainDataInsertQuery([]types.OffChainData{od})
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(toDriverValues(args)...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			expected := mock.ExpectExec(`DELETE FROM data_node.missing_batches WHERE`).
+				WithArgs(uint64(1), ods[0].Key.Hex(), uint64(1), ods[1].Key.Hex())
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+				mock.ExpectRollback()
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			tx, err := dbPG.BeginStateTransaction(context.Background())
+			require.NoError(t, err)
+
+			require.NoError(t, dbPG.StoreOffChainDataTx(context.Background(), ods, tx))
+
+			err = dbPG.DeleteMissingBatchKeysTx(context.Background(), bks, tx)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+				require.NoError(t, tx.Rollback())
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, tx.Commit())
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
 	}
+}
 
-	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(argValues...).
-		WillReturnResult(sqlmock.NewResult(int64(len(bks)), int64(len(bks))))
+func Test_DB_ReplaceOffChainData(t *testing.T) {
+	t.Parallel()
 
-	err := db.StoreMissingBatchKeys(context.Background(), bks)
-	require.NoError(t, err)
+	value := []byte("value1")
+	key := crypto.Keccak256Hash(value)
+
+	testTable := []struct {
+		name      string
+		key       common.Hash
+		value     []byte
+		affected  int64
+		execErr   error
+		returnErr error
+	}{
+		{
+			name:     "value replaced",
+			key:      key,
+			value:    value,
+			affected: 1,
+		},
+		{
+			name:      "value does not hash to the key",
+			key:       common.BytesToHash([]byte("key1")),
+			value:     value,
+			returnErr: ErrInvalidOffChainData,
+		},
+		{
+			name:      "nothing stored for the key",
+			key:       key,
+			value:     value,
+			returnErr: ErrStateNotSynchronized,
+		},
+		{
+			name:      "error returned",
+			key:       key,
+			value:     value,
+			execErr:   errors.New("test error"),
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			if !errors.Is(tt.returnErr, ErrInvalidOffChainData) {
+				mock.ExpectBegin()
+
+				expected := mock.ExpectExec(regexp.QuoteMeta(replaceOffchainDataSQL)).
+					WithArgs(tt.key.Hex(), common.Bytes2Hex(tt.value))
+				if tt.execErr != nil {
+					expected.WillReturnError(tt.execErr)
+				} else {
+					expected.WillReturnResult(sqlmock.NewResult(0, tt.affected))
+				}
+
+				if tt.returnErr != nil {
+					mock.ExpectRollback()
+				} else {
+					mock.ExpectCommit()
+				}
+			}
+
+			err = dbPG.ReplaceOffChainData(context.Background(), tt.key, tt.value)
+			if tt.returnErr != nil {
+				require.ErrorContains(t, err, tt.returnErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_StoreFailedBatch(t *testing.T) {
+	t.Parallel()
+
+	key := types.BatchKey{Number: 10, Hash: common.HexToHash("0x1")}
+
+	testTable := []struct {
+		name      string
+		storeErr  error
+		deleteErr error
+		returnErr error
+	}{
+		{
+			name: "batch key moved",
+		},
+		{
+			name:      "store fails",
+			storeErr:  errors.New("test error"),
+			returnErr: errors.New("failed to store failed batch 10: test error"),
+		},
+		{
+			name:      "delete fails",
+			deleteErr: errors.New("test error"),
+			returnErr: errors.New("failed to delete missing batches: test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			mock.ExpectBegin()
+
+			store := mock.ExpectExec(regexp.QuoteMeta(storeFailedBatchSQL)).
+				WithArgs(key.Number, key.Hash.Hex(), "no data found")
+			if tt.storeErr != nil {
+				store.WillReturnError(tt.storeErr)
+			} else {
+				store.WillReturnResult(sqlmock.NewResult(0, 1))
+
+				remove := mock.ExpectExec(regexp.QuoteMeta(
+					`DELETE FROM data_node.missing_batches WHERE (num, hash) IN (($1, $2))`,
+				)).WithArgs(key.Number, key.Hash.Hex())
+				if tt.deleteErr != nil {
+					remove.WillReturnError(tt.deleteErr)
+				} else {
+					remove.WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+			}
+
+			if tt.returnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err = dbPG.StoreFailedBatch(context.Background(), key, "no data found")
+			if tt.returnErr != nil {
+				require.EqualError(t, err, tt.returnErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_CorrectOffChainDataBatchNums(t *testing.T) {
+	t.Parallel()
+
+	corrections := []types.KeyBatchCorrection{
+		{Key: common.HexToHash("0x1"), From: 5, To: 4},
+		{Key: common.HexToHash("0x2"), From: 3, To: 5},
+	}
+
+	testTable := []struct {
+		name      string
+		affected  int64
+		execErr   error
+		returnErr error
+	}{
+		{
+			name:     "batch numbers corrected",
+			affected: 1,
+		},
+		{
+			name:      "key not stored under the batch",
+			returnErr: ErrStateNotSynchronized,
+		},
+		{
+			name:      "error returned",
+			execErr:   errors.New("test error"),
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			mock.ExpectBegin()
+
+			mock.ExpectExec(regexp.QuoteMeta(correctBatchNumSQL)).
+				WithArgs(corrections[0].Key.Hex(), uint64(5), uint64(4)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			expected := mock.ExpectExec(regexp.QuoteMeta(correctBatchNumSQL)).
+				WithArgs(corrections[1].Key.Hex(), uint64(3), uint64(5))
+			if tt.execErr != nil {
+				expected.WillReturnError(tt.execErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			}
+
+			if tt.returnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err = dbPG.CorrectOffChainDataBatchNums(context.Background(), corrections)
+			if tt.returnErr != nil {
+				require.ErrorContains(t, err, tt.returnErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetOffChainData(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		od        []types.OffChainData
+		key       common.Hash
+		expected  *types.OffChainData
+		returnErr error
+	}{
+		{
+			name: "successfully selected value",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			key: common.BytesToHash([]byte("key1")),
+			expected: &types.OffChainData{
+				Key:      common.BytesToHash([]byte("key1")),
+				Value:    []byte("value1"),
+				BatchNum: 1,
+			},
+		},
+		{
+			name: "successfully selected value with L1 tx hash",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			key: common.BytesToHash([]byte("key1")),
+			expected: &types.OffChainData{
+				Key:      common.BytesToHash([]byte("key1")),
+				Value:    []byte("value1"),
+				BatchNum: 1,
+				L1TxHash: common.BytesToHash([]byte("tx1")),
+			},
+		},
+		{
+			name: "error returned",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			key:       common.BytesToHash([]byte("key1")),
+			returnErr: errors.New("test error"),
+		},
+		{
+			name: "no rows",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			key:       common.BytesToHash([]byte("undefined")),
+			returnErr: ErrStateNotSynchronized,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			// Seed data
+			seedOffchainData(t, dbPG, mock, tt.od)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getOffchainDataSQL)).
+				WithArgs(tt.key.Hex())
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				l1TxHash := ""
+				if tt.expected.L1TxHash != (common.Hash{}) {
+					l1TxHash = tt.expected.L1TxHash.Hex()
+				}
+
+				expected.WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num", "l1_tx_hash"}).
+					AddRow(tt.expected.Key.Hex(), common.Bytes2Hex(tt.expected.Value), tt.expected.BatchNum, l1TxHash))
+			}
+
+			data, err := dbPG.GetOffChainData(context.Background(), tt.key)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, data)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetOffChainDataAsOf(t *testing.T) {
+	t.Parallel()
+
+	key := common.BytesToHash([]byte("key1"))
+
+	testTable := []struct {
+		name      string
+		expected  *types.OffChainData
+		returnErr error
+	}{
+		{
+			name: "value stored up to the cutoff",
+			expected: &types.OffChainData{
+				Key:      key,
+				Value:    []byte("value1"),
+				BatchNum: 5,
+				L1TxHash: common.BytesToHash([]byte("tx1")),
+			},
+		},
+		{
+			name:      "value stored after the cutoff",
+			returnErr: ErrStateNotSynchronized,
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getOffchainDataAsOfSQL)).
+				WithArgs(key.Hex(), uint64(10))
+
+			switch {
+			case errors.Is(tt.returnErr, ErrStateNotSynchronized):
+				expected.WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num", "l1_tx_hash"}))
+			case tt.returnErr != nil:
+				expected.WillReturnError(tt.returnErr)
+			default:
+				expected.WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num", "l1_tx_hash"}).
+					AddRow(key.Hex(), common.Bytes2Hex(tt.expected.Value), tt.expected.BatchNum, tt.expected.L1TxHash.Hex()))
+			}
+
+			data, err := dbPG.GetOffChainDataAsOf(context.Background(), key, 10)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, data)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_StoreL1TxHash(t *testing.T) {
+	t.Parallel()
+
+	txHash := common.BytesToHash([]byte("tx1"))
+	keys := []common.Hash{common.BytesToHash([]byte("key1")), common.BytesToHash([]byte("key2"))}
+
+	testTable := []struct {
+		name          string
+		keys          []common.Hash
+		expectedQuery string
+		returnErr     error
+	}{
+		{
+			name: "successfully stored",
+			keys: keys,
+			expectedQuery: `
+		INSERT INTO data_node.batch_commitments (batch_num, key, l1_tx_hash, l1_block)
+		SELECT c.batch_num, c.key, $1, $2 FROM (VALUES ($3::BIGINT, $4::VARCHAR),($5::BIGINT, $6::VARCHAR)) AS c (batch_num, key)
+		ON CONFLICT (batch_num) DO UPDATE
+		SET key = EXCLUDED.key, l1_tx_hash = EXCLUDED.l1_tx_hash, l1_block = EXCLUDED.l1_block;
+	`,
+		},
+		{
+			name:      "no keys",
+			returnErr: errors.New("no committed keys for the batches from 1"),
+		},
+		{
+			name: "error returned",
+			keys: keys,
+			expectedQuery: `
+		INSERT INTO data_node.batch_commitments (batch_num, key, l1_tx_hash, l1_block)
+		SELECT c.batch_num, c.key, $1, $2 FROM (VALUES ($3::BIGINT, $4::VARCHAR),($5::BIGINT, $6::VARCHAR)) AS c (batch_num, key)
+		ON CONFLICT (batch_num) DO UPDATE
+		SET key = EXCLUDED.key, l1_tx_hash = EXCLUDED.l1_tx_hash, l1_block = EXCLUDED.l1_block;
+	`,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			if tt.expectedQuery != "" {
+				expected := mock.ExpectExec(regexp.QuoteMeta(tt.expectedQuery)).
+					WithArgs(txHash.Hex(), uint64(100), uint64(1), tt.keys[0].Hex(), uint64(2), tt.keys[1].Hex())
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					expected.WillReturnResult(sqlmock.NewResult(0, int64(len(tt.keys))))
+				}
+			}
+
+			err = dbPG.StoreL1TxHash(context.Background(), 1, tt.keys, txHash, 100)
+			if tt.returnErr != nil {
+				require.ErrorContains(t, err, tt.returnErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetCommittedKey(t *testing.T) {
+	t.Parallel()
+
+	key := common.BytesToHash([]byte("key1"))
+
+	testTable := []struct {
+		name      string
+		rows      *sqlmock.Rows
+		returnErr error
+		err       error
+	}{
+		{
+			name: "key known",
+			rows: sqlmock.NewRows([]string{"key"}).AddRow(key.Hex()),
+		},
+		{
+			name: "key unknown",
+			rows: sqlmock.NewRows([]string{"key"}),
+			err:  ErrStateNotSynchronized,
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+			err:       errors.New("failed to get the committed key of batch 1: test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getCommittedKeySQL)).WithArgs(1)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(tt.rows)
+			}
+
+			got, err := dbPG.GetCommittedKey(context.Background(), 1)
+			switch {
+			case errors.Is(tt.err, ErrStateNotSynchronized):
+				require.ErrorIs(t, err, ErrStateNotSynchronized)
+			case tt.err != nil:
+				require.EqualError(t, err, tt.err.Error())
+			default:
+				require.NoError(t, err)
+				require.Equal(t, key, got)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListOffChainData(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		od        []types.OffChainData
+		keys      []common.Hash
+		expected  []types.OffChainData
+		sql       string
+		returnErr error
+	}{
+		{
+			name: "successfully selected one value",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			keys: []common.Hash{
+				common.BytesToHash([]byte("key1")),
+			},
+			expected: []types.OffChainData{
+				{
+					Key:   common.BytesToHash([]byte("key1")),
+					Value: []byte("value1"),
+				},
+			},
+			sql: `SELECT key, value, batch_num, sequence_index, forced FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
+		},
+		{
+			name: "successfully selected two values",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}, {
+				Key:   common.BytesToHash([]byte("key2")),
+				Value: []byte("value2"),
+			}},
+			keys: []common.Hash{
+				common.BytesToHash([]byte("key1")),
+				common.BytesToHash([]byte("key2")),
+			},
+			expected: []types.OffChainData{
+				{
+					Key:   common.BytesToHash([]byte("key1")),
+					Value: []byte("value1"),
+				},
+				{
+					Key:   common.BytesToHash([]byte("key2")),
+					Value: []byte("value2"),
+				},
+			},
+			sql: `SELECT key, value, batch_num, sequence_index, forced FROM data_node\.offchain_data WHERE key IN \(\$1\, \$2\)`,
+		},
+		{
+			name: "error returned",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			keys: []common.Hash{
+				common.BytesToHash([]byte("key1")),
+			},
+			sql:       `SELECT key, value, batch_num, sequence_index, forced FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
+			returnErr: errors.New("test error"),
+		},
+		{
+			name: "no rows",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			keys: []common.Hash{
+				common.BytesToHash([]byte("undefined")),
+			},
+			sql:       `SELECT key, value, batch_num, sequence_index, forced FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
+			returnErr: ErrStateNotSynchronized,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			// Seed data
+			seedOffchainData(t, dbPG, mock, tt.od)
+
+			preparedKeys := make([]driver.Value, len(tt.keys))
+			for i, key := range tt.keys {
+				preparedKeys[i] = key.Hex()
+			}
+
+			expected := mock.ExpectQuery(tt.sql).
+				WithArgs(preparedKeys...)
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				returnData := sqlmock.NewRows([]string{"key", "value", "batch_num"})
+
+				for _, data := range tt.expected {
+					returnData = returnData.AddRow(data.Key.Hex(), common.Bytes2Hex(data.Value), data.BatchNum)
+				}
+
+				expected.WillReturnRows(returnData)
+			}
+
+			data, err := dbPG.ListOffChainData(context.Background(), tt.keys)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, data)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListOffChainData_ManyKeys(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	constructorExpect(mock)
+
+	wdb := sqlx.NewDb(db, "postgres")
+	dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+	require.NoError(t, err)
+
+	// One more key than fits in a query, plus a duplicate which must only be requested once
+	keys := make([]common.Hash, maxKeysPerQuery+1)
+	for i := range keys {
+		keys[i] = common.BigToHash(big.NewInt(int64(i)))
+	}
+	keys = append(keys, keys[0])
+
+	expected := make([]types.OffChainData, 0, 2)
+	for _, chunk := range [][]common.Hash{keys[:maxKeysPerQuery], keys[maxKeysPerQuery : maxKeysPerQuery+1]} {
+		args := make([]driver.Value, len(chunk))
+		for i, key := range chunk {
+			args[i] = key.Hex()
+		}
+
+		// Only the first key of every chunk is stored
+		od := types.OffChainData{Key: chunk[0], Value: []byte("value")}
+		expected = append(expected, od)
+
+		mock.ExpectQuery(`SELECT key, value, batch_num, sequence_index, forced FROM data_node\.offchain_data WHERE key IN`).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num"}).
+				AddRow(od.Key.Hex(), common.Bytes2Hex(od.Value), 0))
+	}
+
+	data, err := dbPG.ListOffChainData(context.Background(), keys)
+	require.NoError(t, err)
+	require.Equal(t, expected, data)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_scanOffChainData(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		rows      int
+		cancel    bool
+		returnErr error
+	}{
+		{
+			name: "all rows scanned",
+			rows: scanContextCheckInterval + 1,
+		},
+		{
+			name:      "context cancelled",
+			rows:      scanContextCheckInterval + 1,
+			cancel:    true,
+			returnErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"key", "value", "batch_num"})
+			for i := 0; i < tt.rows; i++ {
+				rows.AddRow(common.BytesToHash([]byte{byte(i)}).Hex(), "", 0)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(listOffchainDataSQL)).WillReturnRows(rows)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			result, err := wdb.Queryx(listOffchainDataSQL)
+			require.NoError(t, err)
+
+			defer result.Close()
+
+			// the query is already running when the request is cancelled
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancel {
+				cancel()
+			} else {
+				defer cancel()
+			}
+
+			list, err := scanOffChainData(ctx, result, tt.rows)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, list, tt.rows)
+			}
+		})
+	}
+}
+
+func Test_DB_ListOffChainDataByBatch(t *testing.T) {
+	t.Parallel()
+
+	index := uint(3)
+
+	testTable := []struct {
+		name        string
+		batchNum    uint64
+		offset      uint
+		limit       uint
+		total       uint64
+		expected    []types.OffChainData
+		countErr    error
+		returnErr   error
+		expectQuery bool
+	}{
+		{
+			name:     "successfully selected a page",
+			batchNum: 1,
+			offset:   1,
+			limit:    2,
+			total:    4,
+			expected: []types.OffChainData{
+				{
+					Key:      common.BytesToHash([]byte("key2")),
+					Value:    []byte("value2"),
+					BatchNum: 1,
+					Index:    &index,
+				},
+				{
+					Key:      common.BytesToHash([]byte("key3")),
+					Value:    []byte("value3"),
+					BatchNum: 1,
+				},
+			},
+			expectQuery: true,
+		},
+		{
+			name:     "offset beyond the stored data",
+			batchNum: 1,
+			offset:   4,
+			limit:    2,
+			total:    4,
+			expected: []types.OffChainData{},
+		},
+		{
+			name:     "no data stored for the batch",
+			batchNum: 1,
+			limit:    2,
+			expected: []types.OffChainData{},
+		},
+		{
+			name:     "error returned by count",
+			batchNum: 1,
+			limit:    2,
+			countErr: errors.New("test error"),
+		},
+		{
+			name:        "error returned by list",
+			batchNum:    1,
+			limit:       2,
+			total:       4,
+			returnErr:   errors.New("test error"),
+			expectQuery: true,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expectedCount := mock.ExpectQuery(regexp.QuoteMeta(countOffchainDataByBatchSQL)).WithArgs(tt.batchNum)
+			if tt.countErr != nil {
+				expectedCount.WillReturnError(tt.countErr)
+			} else {
+				expectedCount.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.total))
+			}
+
+			if tt.expectQuery {
+				expected := mock.ExpectQuery(regexp.QuoteMeta(listOffchainDataByBatchSQL)).
+					WithArgs(tt.batchNum, tt.limit, tt.offset)
+
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					returnData := sqlmock.NewRows([]string{"key", "value", "batch_num", "sequence_index"})
+
+					for _, data := range tt.expected {
+						returnData = returnData.AddRow(data.Key.Hex(), common.Bytes2Hex(data.Value), data.BatchNum,
+							sequenceIndexArg(data.Index))
+					}
+
+					expected.WillReturnRows(returnData)
+				}
+			}
+
+			data, total, err := dbPG.ListOffChainDataByBatch(context.Background(), tt.batchNum, tt.offset, tt.limit)
+			switch {
+			case tt.countErr != nil:
+				require.ErrorIs(t, err, tt.countErr)
+			case tt.returnErr != nil:
+				require.ErrorIs(t, err, tt.returnErr)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, data)
+				require.Equal(t, tt.total, total)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetBatchConcatenated(t *testing.T) {
+	t.Parallel()
+
+	key := common.BytesToHash([]byte("key1"))
+
+	testTable := []struct {
+		name     string
+		keyKnown bool
+		stored   *types.OffChainData
+		keyErr   error
+		expected []byte
+		err      error
+	}{
+		{
+			name:     "value of the committed key stored under the batch",
+			keyKnown: true,
+			stored:   &types.OffChainData{Key: key, Value: []byte("value1"), BatchNum: 1},
+			expected: []byte("value1"),
+		},
+		{
+			name:     "value of the committed key stored under another batch",
+			keyKnown: true,
+			stored:   &types.OffChainData{Key: key, Value: []byte("value1"), BatchNum: 7},
+			expected: []byte("value1"),
+		},
+		{
+			name: "committed key unknown",
+			err:  ErrStateNotSynchronized,
+		},
+		{
+			name:     "value of the committed key not stored",
+			keyKnown: true,
+			err:      ErrStateNotSynchronized,
+		},
+		{
+			name:   "error getting the committed key",
+			keyErr: errors.New("test error"),
+			err:    errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expectedKey := mock.ExpectQuery(regexp.QuoteMeta(getCommittedKeySQL)).WithArgs(1)
+			switch {
+			case tt.keyErr != nil:
+				expectedKey.WillReturnError(tt.keyErr)
+			case tt.keyKnown:
+				expectedKey.WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow(key.Hex()))
+			default:
+				expectedKey.WillReturnRows(sqlmock.NewRows([]string{"key"}))
+			}
+
+			if tt.keyKnown {
+				rows := sqlmock.NewRows([]string{"key", "value", "batch_num", "l1_tx_hash"})
+				if tt.stored != nil {
+					rows.AddRow(tt.stored.Key.Hex(), common.Bytes2Hex(tt.stored.Value), tt.stored.BatchNum, "")
+				}
+
+				mock.ExpectQuery(regexp.QuoteMeta(getOffchainDataSQL)).WithArgs(key.Hex()).WillReturnRows(rows)
+			}
+
+			blob, err := dbPG.GetBatchConcatenated(context.Background(), 1)
+			switch {
+			case errors.Is(tt.err, ErrStateNotSynchronized):
+				require.ErrorIs(t, err, ErrStateNotSynchronized)
+			case tt.err != nil:
+				require.ErrorContains(t, err, tt.err.Error())
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, blob)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_FindDuplicateValues(t *testing.T) {
+	t.Parallel()
+
+	key1 := common.BytesToHash([]byte("key1"))
+	key2 := common.BytesToHash([]byte("key2"))
+	key3 := common.BytesToHash([]byte("key3"))
+
+	testTable := []struct {
+		name      string
+		rows      []string
+		expected  []types.DuplicateGroup
+		returnErr error
+	}{
+		{
+			name:     "no duplicate values",
+			expected: []types.DuplicateGroup{},
+		},
+		{
+			name: "duplicate values found",
+			rows: []string{key1.Hex() + "," + key2.Hex(), key1.Hex() + "," + key2.Hex() + "," + key3.Hex()},
+			expected: []types.DuplicateGroup{
+				{Keys: []common.Hash{key1, key2}},
+				{Keys: []common.Hash{key1, key2, key3}},
+			},
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(findDuplicateValuesSQL))
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"keys"})
+				for _, row := range tt.rows {
+					rows = rows.AddRow(row)
+				}
+
+				expected.WillReturnRows(rows)
+			}
+
+			groups, err := dbPG.FindDuplicateValues(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, groups)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_OffChainDataExists(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		key       common.Hash
+		exists    bool
+		returnErr error
+	}{
+		{
+			name:   "value exists",
+			key:    common.BytesToHash([]byte("key1")),
+			exists: true,
+		},
+		{
+			name: "value does not exist",
+			key:  common.BytesToHash([]byte("key2")),
+		},
+		{
+			name:      "error returned",
+			key:       common.BytesToHash([]byte("key1")),
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(offchainDataExistsSQL)).WithArgs(tt.key.Hex())
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.exists))
+			}
+
+			actual, err := dbPG.OffChainDataExists(context.Background(), tt.key)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.exists, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_AllExist(t *testing.T) {
+	t.Parallel()
+
+	key1 := common.BytesToHash([]byte("key1"))
+	key2 := common.BytesToHash([]byte("key2"))
+	key3 := common.BytesToHash([]byte("key3"))
+
+	testTable := []struct {
+		name      string
+		keys      []common.Hash
+		stored    []common.Hash
+		sql       string
+		allExist  bool
+		missing   []common.Hash
+		returnErr error
+	}{
+		{
+			name:     "empty input",
+			allExist: true,
+		},
+		{
+			name:     "all keys present",
+			keys:     []common.Hash{key1, key2},
+			stored:   []common.Hash{key2, key1},
+			sql:      `SELECT key FROM data_node\.offchain_data WHERE key IN \(\$1, \$2\)`,
+			allExist: true,
+		},
+		{
+			name:    "some keys missing",
+			keys:    []common.Hash{key1, key2, key3, key2},
+			stored:  []common.Hash{key1},
+			sql:     `SELECT key FROM data_node\.offchain_data WHERE key IN \(\$1, \$2, \$3\)`,
+			missing: []common.Hash{key2, key3},
+		},
+		{
+			name:      "error returned",
+			keys:      []common.Hash{key1},
+			sql:       `SELECT key FROM data_node\.offchain_data WHERE key IN \(\$1\)`,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			if tt.sql != "" {
+				keys := uniqueKeys(tt.keys)
+				args := make([]driver.Value, len(keys))
+				for i, key := range keys {
+					args[i] = key.Hex()
+				}
+
+				expected := mock.ExpectQuery(tt.sql).WithArgs(args...)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					rows := sqlmock.NewRows([]string{"key"})
+					for _, key := range tt.stored {
+						rows.AddRow(key.Hex())
+					}
+
+					expected.WillReturnRows(rows)
+				}
+			}
+
+			allExist, missing, err := dbPG.AllExist(context.Background(), tt.keys)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.allExist, allExist)
+				require.Equal(t, tt.missing, missing)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_CountOffchainData(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		od        []types.OffChainData
+		count     uint64
+		returnErr error
+	}{
+		{
+			name: "two values found",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}, {
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value2"),
+			}},
+			count: 2,
+		},
+		{
+			name:  "no values found",
+			count: 0,
+		},
+		{
+			name: "error returned",
+			od: []types.OffChainData{{
+				Key:   common.BytesToHash([]byte("key1")),
+				Value: []byte("value1"),
+			}},
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			// Seed data
+			seedOffchainData(t, dbPG, mock, tt.od)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(countOffchainDataSQL))
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.count))
+			}
+
+			actual, err := dbPG.CountOffchainData(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.count, actual)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetBatchNumRange(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		first     uint64
+		last      uint64
+		returnErr error
+	}{
+		{
+			name:  "range returned",
+			first: 3,
+			last:  10,
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getBatchNumRangeSQL))
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"first", "last"}).AddRow(tt.first, tt.last))
+			}
+
+			first, last, err := dbPG.GetBatchNumRange(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.first, first)
+				require.Equal(t, tt.last, last)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetFirstStoredBatchNum(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		batchNum  any
+		expected  uint64
+		found     bool
+		returnErr error
+	}{
+		{
+			name:     "first batch number returned",
+			batchNum: int64(3),
+			expected: 3,
+			found:    true,
+		},
+		{
+			name: "no stored batch",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getFirstStoredBatchNumSQL))
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(tt.batchNum))
+			}
+
+			batchNum, found, err := dbPG.GetFirstStoredBatchNum(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, batchNum)
+				require.Equal(t, tt.found, found)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetDistinctBatchNums(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		from      uint64
+		to        uint64
+		nums      []uint64
+		returnErr error
+	}{
+		{
+			name: "batch numbers returned",
+			from: 1,
+			to:   5,
+			nums: []uint64{1, 2, 4},
+		},
+		{
+			name: "no batch numbers",
+			from: 1,
+			to:   5,
+		},
+		{
+			name:      "error returned",
+			from:      1,
+			to:        5,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getDistinctBatchNumsSQL)).WithArgs(tt.from, tt.to)
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"batch_num"})
+				for _, num := range tt.nums {
+					rows.AddRow(num)
+				}
+
+				expected.WillReturnRows(rows)
+			}
+
+			nums, err := dbPG.GetDistinctBatchNums(context.Background(), tt.from, tt.to)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.nums, nums)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_CountOffchainDataByBatch(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name        string
+		from        uint64
+		to          uint64
+		counts      map[uint64]uint64
+		returnErr   error
+		rangeErr    bool
+		expectQuery bool
+	}{
+		{
+			name:        "counts returned",
+			from:        1,
+			to:          5,
+			counts:      map[uint64]uint64{1: 2, 2: 1, 4: 3},
+			expectQuery: true,
+		},
+		{
+			name:        "no offchain data",
+			from:        1,
+			to:          5,
+			counts:      map[uint64]uint64{},
+			expectQuery: true,
+		},
+		{
+			name:        "error returned",
+			from:        1,
+			to:          5,
+			returnErr:   errors.New("test error"),
+			expectQuery: true,
+		},
+		{
+			name:     "inverted range",
+			from:     5,
+			to:       1,
+			rangeErr: true,
+		},
+		{
+			name:     "range too big",
+			from:     1,
+			to:       maxCountByBatchRange + 1,
+			rangeErr: true,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			if tt.expectQuery {
+				expected := mock.ExpectQuery(regexp.QuoteMeta(countOffchainDataPerBatchSQL)).WithArgs(tt.from, tt.to)
+
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					rows := sqlmock.NewRows([]string{"batch_num", "count"})
+					for num := tt.from; num <= tt.to; num++ {
+						if count, ok := tt.counts[num]; ok {
+							rows.AddRow(num, count)
+						}
+					}
+
+					expected.WillReturnRows(rows)
+				}
+			}
+
+			counts, err := dbPG.CountOffchainDataByBatch(context.Background(), tt.from, tt.to)
+			switch {
+			case tt.rangeErr:
+				require.ErrorContains(t, err, "invalid batch range")
+			case tt.returnErr != nil:
+				require.ErrorIs(t, err, tt.returnErr)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.counts, counts)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetBatchDataSize(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		batchNum  uint64
+		size      uint64
+		returnErr error
+	}{
+		{
+			name:     "size returned",
+			batchNum: 1,
+			size:     1024,
+		},
+		{
+			name:      "error returned",
+			batchNum:  1,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getBatchDataSizeSQL)).WithArgs(tt.batchNum)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(tt.size))
+			}
+
+			size, err := dbPG.GetBatchDataSize(context.Background(), tt.batchNum)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.size, size)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetBatchRangeDataSize(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		from      uint64
+		to        uint64
+		size      uint64
+		returnErr error
+	}{
+		{
+			name: "size returned",
+			from: 1,
+			to:   10,
+			size: 10240,
+		},
+		{
+			name:      "error returned",
+			from:      1,
+			to:        10,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getBatchRangeDataSizeSQL)).WithArgs(tt.from, tt.to)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(tt.size))
+			}
+
+			size, err := dbPG.GetBatchRangeDataSize(context.Background(), tt.from, tt.to)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.size, size)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_TotalStoredBytes(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		size      uint64
+		returnErr error
+	}{
+		{
+			name: "size returned",
+			size: 10240,
+		},
+		{
+			name: "nothing stored",
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(totalStoredBytesSQL))
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(tt.size))
+			}
+
+			size, err := dbPG.TotalStoredBytes(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.size, size)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_MarkFinalized(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		upToBatch uint64
+		returnErr error
+	}{
+		{
+			name:      "marked as finalized",
+			upToBatch: 10,
+		},
+		{
+			name:      "error returned",
+			upToBatch: 10,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectExec(regexp.QuoteMeta(markFinalizedSQL)).WithArgs(tt.upToBatch)
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(0, 3))
+			}
+
+			err = dbPG.MarkFinalized(context.Background(), tt.upToBatch)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_PruneFinalized(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		upToBatch uint64
+		deleted   uint64
+		returnErr error
+	}{
+		{
+			name:      "finalized data pruned",
+			upToBatch: 10,
+			deleted:   3,
+		},
+		{
+			name:      "error returned",
+			upToBatch: 10,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectExec(regexp.QuoteMeta(pruneFinalizedSQL)).WithArgs(tt.upToBatch)
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(0, int64(tt.deleted)))
+			}
+
+			deleted, err := dbPG.PruneFinalized(context.Background(), tt.upToBatch)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.deleted, deleted)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ArchiveMissingBatchKeys(t *testing.T) {
+	t.Parallel()
+
+	resolved := []types.ResolvedBatch{
+		{Number: 1, Hash: common.BytesToHash([]byte("key1")), Attempts: 1},
+		{Number: 2, Hash: common.BytesToHash([]byte("key2")), Attempts: 3},
+	}
+
+	testTable := []struct {
+		name      string
+		resolved  []types.ResolvedBatch
+		returnErr error
+	}{
+		{
+			name: "no keys given",
+		},
+		{
+			name:     "keys archived",
+			resolved: resolved,
+		},
+		{
+			name:      "error returned",
+			resolved:  resolved,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			if len(tt.resolved) > 0 {
+				query, args := buildArchiveMissingBatchKeysQuery(tt.resolved)
+				require.Contains(t, query, "VALUES ($1::BIGINT, $2::VARCHAR, $3::INTEGER),($4::BIGINT, $5::VARCHAR, $6::INTEGER)")
+
+				driverArgs := make([]driver.Value, len(args))
+				for i, arg := range args {
+					driverArgs[i] = arg
+				}
+
+				expected := mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(driverArgs...)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					expected.WillReturnResult(sqlmock.NewResult(0, int64(len(tt.resolved))))
+				}
+			}
+
+			err = dbPG.ArchiveMissingBatchKeys(context.Background(), tt.resolved)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ArchiveMissingBatchKeysTx(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	constructorExpect(mock)
+
+	dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+	require.NoError(t, err)
+
+	ods := []types.OffChainData{{Key: common.BytesToHash([]byte("key1")), Value: []byte("value1"), BatchNum: 1}}
+	resolved := []types.ResolvedBatch{{Number: 1, Hash: ods[0].Key, Attempts: 1}}
+
+	mock.ExpectBegin()
+
+	insertQuery, insertArgs := buildOffchainDataInsertQuery(ods)
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).WithArgs(toDriverValues(insertArgs)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	archiveQuery, archiveArgs := buildArchiveMissingBatchKeysQuery(resolved)
+	mock.ExpectExec(regexp.QuoteMeta(archiveQuery)).WithArgs(toDriverValues(archiveArgs)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectCommit()
+
+	tx, err := dbPG.BeginStateTransaction(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, dbPG.StoreOffChainDataTx(context.Background(), ods, tx))
+	require.NoError(t, dbPG.ArchiveMissingBatchKeysTx(context.Background(), resolved, tx))
+	require.NoError(t, tx.Commit())
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DB_PruneResolvedBatches(t *testing.T) {
+	t.Parallel()
+
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name      string
+		deleted   uint64
+		returnErr error
+	}{
+		{
+			name:    "history pruned",
+			deleted: 3,
+		},
+		{
+			name:      "error returned",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectExec(regexp.QuoteMeta(pruneResolvedBatchesSQL)).WithArgs(before)
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnResult(sqlmock.NewResult(0, int64(tt.deleted)))
+			}
+
+			deleted, err := dbPG.PruneResolvedBatches(context.Background(), before)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.deleted, deleted)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_GetFirstBatchSequencedAfter(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		l1Block   uint64
+		batchNum  uint64
+		returnErr error
+	}{
+		{
+			name:     "first batch found",
+			l1Block:  100,
+			batchNum: 7,
+		},
+		{
+			name:    "no batch sequenced after the block",
+			l1Block: 100,
+		},
+		{
+			name:      "error returned",
+			l1Block:   100,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			wdb := sqlx.NewDb(db, "postgres")
+			dbPG, err := New(context.Background(), wdb, DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(getFirstBatchSequencedAfterSQL)).WithArgs(tt.l1Block)
+
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"batch_num"}).AddRow(tt.batchNum))
+			}
+
+			batchNum, err := dbPG.GetFirstBatchSequencedAfter(context.Background(), tt.l1Block)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.batchNum, batchNum)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func constructorExpect(mock sqlmock.Sqlmock) {
+	mock.ExpectPrepare(regexp.QuoteMeta(storeLastProcessedBlockSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getLastProcessedBlockSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getMissingBatchKeysSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getMissingBatchKeySQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getOffchainDataSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(countOffchainDataSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(listOffchainDataByBatchSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(countOffchainDataByBatchSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(offchainDataExistsSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getBatchNumRangeSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getDistinctBatchNumsSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(markFinalizedSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(pruneFinalizedSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(countOffchainDataPerBatchSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getMissingBatchKeysInRangeSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getBatchDataSizeSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getBatchRangeDataSizeSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getCommittedKeySQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getFirstBatchSequencedAfterSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(getFirstStoredBatchNumSQL))
+	mock.ExpectPrepare(regexp.QuoteMeta(totalStoredBytesSQL))
+}
+
+func toDriverValues(args []interface{}) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+
+	return values
+}
+
+func seedOffchainData(t *testing.T, db DB, mock sqlmock.Sqlmock, ods []types.OffChainData) {
+	t.Helper()
+
+	if len(ods) == 0 {
+		return
+	}
+
+	query, args := buildOffchainDataInsertQuery(types.RemoveDuplicateOffChainData(ods))
+
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(toDriverValues(args)...).
+		WillReturnResult(sqlmock.NewResult(int64(len(ods)), int64(len(ods))))
+
+	err := db.StoreOffChainData(context.Background(), ods)
+	require.NoError(t, err)
+}
+
+func seedMissingBatchKeys(t *testing.T, db DB, mock sqlmock.Sqlmock, bks []types.BatchKey) {
+	t.Helper()
+
+	if len(bks) == 0 {
+		return
+	}
+
+	query, args := buildBatchKeysInsertQuery(bks)
+
+	argValues := make([]driver.Value, len(args))
+	for i, arg := range args {
+		argValues[i] = arg
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(argValues...).
+		WillReturnResult(sqlmock.NewResult(int64(len(bks)), int64(len(bks))))
+
+	err := db.StoreMissingBatchKeys(context.Background(), bks)
+	require.NoError(t, err)
+}
+
+func Test_DB_ListFailedBatches(t *testing.T) {
+	t.Parallel()
+
+	failedAt := time.Unix(1000, 0).UTC()
+
+	testTable := []struct {
+		name      string
+		rows      [][]driver.Value
+		expected  []types.FailedBatch
+		returnErr error
+	}{
+		{
+			name:     "no failed batches",
+			expected: []types.FailedBatch{},
+		},
+		{
+			name: "failed batches listed",
+			rows: [][]driver.Value{
+				{10, common.HexToHash("0x1").Hex(), "no data found", failedAt},
+				{11, common.HexToHash("0x2").Hex(), "timeout", failedAt},
+			},
+			expected: []types.FailedBatch{
+				{Number: 10, Hash: common.HexToHash("0x1"), Reason: "no data found", FailedAt: failedAt},
+				{Number: 11, Hash: common.HexToHash("0x2"), Reason: "timeout", FailedAt: failedAt},
+			},
+		},
+		{
+			name:      "query fails",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(listFailedBatchesSQL))
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"num", "hash", "reason", "failed_at"})
+				for _, row := range tt.rows {
+					rows.AddRow(row...)
+				}
+
+				expected.WillReturnRows(rows)
+			}
+
+			got, err := dbPG.ListFailedBatches(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, got)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_RequeueFailedBatch(t *testing.T) {
+	t.Parallel()
+
+	key := types.BatchKey{Number: 10, Hash: common.HexToHash("0x1")}
+
+	testTable := []struct {
+		name      string
+		deleted   int64
+		deleteErr error
+		insertErr error
+		returnErr error
+	}{
+		{
+			name:    "batch key requeued",
+			deleted: 1,
+		},
+		{
+			name:      "not a failed batch",
+			returnErr: ErrStateNotSynchronized,
+		},
+		{
+			name:      "delete fails",
+			deleteErr: errors.New("test error"),
+			returnErr: errors.New("failed to delete failed batch 10: test error"),
+		},
+		{
+			name:      "insert fails",
+			deleted:   1,
+			insertErr: errors.New("test error"),
+			returnErr: errors.New("failed to queue batch 10 as missing: test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			mock.ExpectBegin()
+
+			remove := mock.ExpectExec(regexp.QuoteMeta(deleteFailedBatchSQL)).
+				WithArgs(key.Number, key.Hash.Hex())
+			if tt.deleteErr != nil {
+				remove.WillReturnError(tt.deleteErr)
+			} else {
+				remove.WillReturnResult(sqlmock.NewResult(0, tt.deleted))
+			}
+
+			if tt.deleted > 0 {
+				insert := mock.ExpectExec(regexp.QuoteMeta(
+					`INSERT INTO data_node.missing_batches (num, hash, sequence_index, forced) VALUES ($1, $2, $3, $4) ON CONFLICT (num, hash) DO NOTHING`,
+				)).WithArgs(key.Number, key.Hash.Hex(), nil, false)
+				if tt.insertErr != nil {
+					insert.WillReturnError(tt.insertErr)
+				} else {
+					insert.WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+			}
+
+			if tt.returnErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			err = dbPG.RequeueFailedBatch(context.Background(), key)
+			switch {
+			case errors.Is(tt.returnErr, ErrStateNotSynchronized):
+				require.ErrorIs(t, err, ErrStateNotSynchronized)
+			case tt.returnErr != nil:
+				require.EqualError(t, err, tt.returnErr.Error())
+			default:
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListOffChainDataAfterKey(t *testing.T) {
+	t.Parallel()
+
+	after := common.HexToHash("0x1")
+
+	testTable := []struct {
+		name      string
+		limit     uint
+		expected  []types.OffChainData
+		returnErr error
+	}{
+		{
+			name:  "page listed",
+			limit: 2,
+			expected: []types.OffChainData{
+				{Key: common.HexToHash("0x2"), Value: []byte("value2"), BatchNum: 1},
+				{Key: common.HexToHash("0x3"), Value: []byte("value3"), BatchNum: 2},
+			},
+		},
+		{
+			name:     "zero limit",
+			expected: []types.OffChainData{},
+		},
+		{
+			name:      "error returned",
+			limit:     2,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			if tt.limit > 0 {
+				expected := mock.ExpectQuery(regexp.QuoteMeta(listOffchainDataAfterKeySQL)).
+					WithArgs(uint64(1), uint64(5), after.Hex(), tt.limit)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					rows := sqlmock.NewRows([]string{"key", "value", "batch_num"})
+					for _, od := range tt.expected {
+						rows.AddRow(od.Key.Hex(), common.Bytes2Hex(od.Value), od.BatchNum)
+					}
+
+					expected.WillReturnRows(rows)
+				}
+			}
+
+			got, err := dbPG.ListOffChainDataAfterKey(context.Background(), 1, 5, after, tt.limit)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, got)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListOffChainDataInBatchRange(t *testing.T) {
+	t.Parallel()
+
+	stored := []types.OffChainData{
+		{Key: common.HexToHash("0x2"), Value: []byte("value2"), BatchNum: 1},
+		{Key: common.HexToHash("0x3"), Value: []byte("value3"), BatchNum: 1},
+		{Key: common.HexToHash("0x1"), Value: []byte("value1"), BatchNum: 2},
+	}
+
+	testTable := []struct {
+		name      string
+		from, to  uint64
+		limit     uint
+		offset    uint
+		expected  []types.OffChainData
+		returnErr error
+		err       string
+	}{
+		{
+			name:     "first page ordered by batch number and key",
+			from:     1,
+			to:       2,
+			limit:    2,
+			expected: stored[:2],
+		},
+		{
+			name:     "last page shorter than the limit",
+			from:     1,
+			to:       2,
+			limit:    2,
+			offset:   2,
+			expected: stored[2:],
+		},
+		{
+			name:     "single batch range",
+			from:     2,
+			to:       2,
+			limit:    2,
+			expected: stored[2:],
+		},
+		{
+			name:     "zero limit",
+			from:     1,
+			to:       2,
+			expected: []types.OffChainData{},
+		},
+		{
+			name: "invalid range",
+			from: 2,
+			to:   1,
+			err:  "invalid batch range 2-1",
+		},
+		{
+			name:      "error returned",
+			from:      1,
+			to:        2,
+			limit:     2,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			if tt.limit > 0 && tt.from <= tt.to {
+				expected := mock.ExpectQuery(regexp.QuoteMeta(listOffchainDataInBatchRangeSQL)).
+					WithArgs(tt.from, tt.to, tt.limit, tt.offset)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					rows := sqlmock.NewRows([]string{"key", "value", "batch_num"})
+					for _, od := range tt.expected {
+						rows.AddRow(od.Key.Hex(), common.Bytes2Hex(od.Value), od.BatchNum)
+					}
+
+					expected.WillReturnRows(rows)
+				}
+			}
+
+			got, err := dbPG.ListOffChainDataInBatchRange(context.Background(), tt.from, tt.to, tt.limit, tt.offset)
+			switch {
+			case tt.err != "":
+				require.EqualError(t, err, tt.err)
+			case tt.returnErr != nil:
+				require.ErrorIs(t, err, tt.returnErr)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, got)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_ListForcedOffChainData(t *testing.T) {
+	t.Parallel()
+
+	index := uint(0)
+	forced := []types.OffChainData{
+		{Key: common.HexToHash("0x2"), Value: []byte("value2"), BatchNum: 1, Index: &index, Forced: true},
+		{Key: common.HexToHash("0x1"), Value: []byte("value1"), BatchNum: 3, Forced: true},
+	}
+
+	testTable := []struct {
+		name      string
+		from, to  uint64
+		limit     uint
+		offset    uint
+		expected  []types.OffChainData
+		returnErr error
+		err       string
+	}{
+		{
+			name:     "forced data of the range",
+			from:     1,
+			to:       3,
+			limit:    10,
+			expected: forced,
+		},
+		{
+			name:     "no forced data",
+			from:     4,
+			to:       5,
+			limit:    10,
+			expected: []types.OffChainData{},
+		},
+		{
+			name:     "zero limit",
+			from:     1,
+			to:       3,
+			expected: []types.OffChainData{},
+		},
+		{
+			name: "invalid range",
+			from: 3,
+			to:   1,
+			err:  "invalid batch range 3-1",
+		},
+		{
+			name:      "error returned",
+			from:      1,
+			to:        3,
+			limit:     10,
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			if tt.limit > 0 && tt.from <= tt.to {
+				expected := mock.ExpectQuery(regexp.QuoteMeta(listForcedOffchainDataSQL)).
+					WithArgs(tt.from, tt.to, tt.limit, tt.offset)
+				if tt.returnErr != nil {
+					expected.WillReturnError(tt.returnErr)
+				} else {
+					rows := sqlmock.NewRows([]string{"key", "value", "batch_num", "sequence_index", "forced"})
+					for _, od := range tt.expected {
+						rows.AddRow(od.Key.Hex(), common.Bytes2Hex(od.Value), od.BatchNum, sequenceIndexArg(od.Index), od.Forced)
+					}
+
+					expected.WillReturnRows(rows)
+				}
+			}
+
+			got, err := dbPG.ListForcedOffChainData(context.Background(), tt.from, tt.to, tt.limit, tt.offset)
+			switch {
+			case tt.err != "":
+				require.EqualError(t, err, tt.err)
+			case tt.returnErr != nil:
+				require.ErrorIs(t, err, tt.returnErr)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, got)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_DB_StoreOffChainDataPartial(t *testing.T) {
+	t.Parallel()
+
+	ods := []types.OffChainData{
+		{Key: common.HexToHash("0x1"), Value: []byte("value1"), BatchNum: 1},
+		{Key: common.HexToHash("0x2"), Value: []byte("value2"), BatchNum: 1},
+	}
+
+	storeErr := errors.New("test error")
+
+	expectStore := func(mock sqlmock.Sqlmock, ods []types.OffChainData, err error) {
+		query, args := buildOffchainDataInsertQuery(ods)
+		driverArgs := make([]driver.Value, len(args))
+		for i, arg := range args {
+			driverArgs[i] = arg
+		}
+
+		mock.ExpectExec(regexp.QuoteMeta(storeSavepointSQL)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		store := mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(driverArgs...)
+		if err != nil {
+			store.WillReturnError(err)
+			mock.ExpectExec(regexp.QuoteMeta(rollbackToStoreSavepointSQL)).WillReturnResult(sqlmock.NewResult(0, 0))
+		} else {
+			store.WillReturnResult(sqlmock.NewResult(0, int64(len(ods))))
+			mock.ExpectExec(regexp.QuoteMeta(releaseStoreSavepointSQL)).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+	}
+
+	t.Run("all records stored", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		defer db.Close()
+
+		constructorExpect(mock)
+
+		dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+		expectStore(mock, ods, nil)
+		mock.ExpectCommit()
+
+		failed, err := dbPG.StoreOffChainDataPartial(context.Background(), ods)
+		require.NoError(t, err)
+		require.Empty(t, failed)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failing record skipped", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		defer db.Close()
+
+		constructorExpect(mock)
+
+		dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+		expectStore(mock, ods, storeErr)
+		expectStore(mock, ods[:1], storeErr)
+		expectStore(mock, ods[1:], nil)
+		mock.ExpectCommit()
+
+		failed, err := dbPG.StoreOffChainDataPartial(context.Background(), ods)
+		require.NoError(t, err)
+		require.Equal(t, []types.FailedRecord{
+			{Key: ods[0].Key, BatchNum: 1, Reason: "failed to store offchain data: test error"},
+		}, failed)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("savepoint fails", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+
+		defer db.Close()
+
+		constructorExpect(mock)
+
+		dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+		require.NoError(t, err)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(storeSavepointSQL)).WillReturnError(storeErr)
+		mock.ExpectRollback()
+
+		_, err = dbPG.StoreOffChainDataPartial(context.Background(), ods)
+		require.EqualError(t, err, "failed to create the store offchain data savepoint: test error")
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
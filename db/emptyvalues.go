@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrEmptyOffChainData indicates a zero-length value was given to be stored
+var ErrEmptyOffChainData = errors.New("empty offchain data")
+
+// nonEmptyDB is a DB that rejects zero-length offchain data values.
+// keccak256("") is a valid key, but a batch without L2 data almost always means the data was lost
+// upstream, and storing it would hide the bug behind a key that looks resolved
+type nonEmptyDB struct {
+	DB
+}
+
+// NewNonEmptyDB wraps the given DB so writes of zero-length offchain data values fail with ErrEmptyOffChainData.
+// It must wrap the object store DB, which stores the offchain data metadata without values. It is meant for the
+// data received from the sequencer, the data committed on L1 is stored even if empty
+func NewNonEmptyDB(db DB) DB {
+	return &nonEmptyDB{
+		DB: db,
+	}
+}
+
+// StoreOffChainData stores the given offchain data, unless any of the values is empty
+func (db *nonEmptyDB) StoreOffChainData(ctx context.Context, ods []types.OffChainData) error {
+	if err := checkNonEmpty(ods); err != nil {
+		return err
+	}
+
+	return db.DB.StoreOffChainData(ctx, ods)
+}
+
+// StoreOffChainDataTx stores the given offchain data within the given transaction, unless any value is empty
+func (db *nonEmptyDB) StoreOffChainDataTx(ctx context.Context, ods []types.OffChainData, tx Tx) error {
+	if err := checkNonEmpty(ods); err != nil {
+		return err
+	}
+
+	return db.DB.StoreOffChainDataTx(ctx, ods, tx)
+}
+
+// StoreOffChainDataPartial stores the given offchain data, skipping the empty values along with the records
+// that cannot be stored
+func (db *nonEmptyDB) StoreOffChainDataPartial(
+	ctx context.Context, ods []types.OffChainData,
+) ([]types.FailedRecord, error) {
+	var (
+		nonEmpty = make([]types.OffChainData, 0, len(ods))
+		empty    []types.FailedRecord
+	)
+
+	for _, od := range ods {
+		if len(od.Value) == 0 {
+			empty = append(empty, newFailedRecord(od, ErrEmptyOffChainData))
+			continue
+		}
+
+		nonEmpty = append(nonEmpty, od)
+	}
+
+	failed, err := db.DB.StoreOffChainDataPartial(ctx, nonEmpty)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(empty, failed...), nil
+}
+
+// checkNonEmpty returns ErrEmptyOffChainData if any of the given values is empty
+func checkNonEmpty(ods []types.OffChainData) error {
+	for _, od := range ods {
+		if len(od.Value) == 0 {
+			return fmt.Errorf("%w: key %s, batch %d", ErrEmptyOffChainData, od.Key.Hex(), od.BatchNum)
+		}
+	}
+
+	return nil
+}
+
+// ReplaceOffChainData replaces the stored value of the given key, unless the new value is empty
+func (db *nonEmptyDB) ReplaceOffChainData(ctx context.Context, key common.Hash, newValue []byte) error {
+	if len(newValue) == 0 {
+		return fmt.Errorf("%w: key %s", ErrEmptyOffChainData, key.Hex())
+	}
+
+	return db.DB.ReplaceOffChainData(ctx, key, newValue)
+}
@@ -0,0 +1,91 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonEmptyDB(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("value")
+	key := crypto.Keccak256Hash(value)
+	emptyKey := crypto.Keccak256Hash(nil)
+
+	t.Run("stores non empty values", func(t *testing.T) {
+		t.Parallel()
+
+		ods := []types.OffChainData{{Key: key, Value: value, BatchNum: 1}}
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainData", context.Background(), ods).Return(nil)
+
+		require.NoError(t, db.NewNonEmptyDB(dbMock).StoreOffChainData(context.Background(), ods))
+	})
+
+	t.Run("rejects empty values", func(t *testing.T) {
+		t.Parallel()
+
+		ods := []types.OffChainData{
+			{Key: key, Value: value, BatchNum: 1},
+			{Key: emptyKey, Value: nil, BatchNum: 2},
+		}
+
+		err := db.NewNonEmptyDB(mocks.NewDB(t)).StoreOffChainData(context.Background(), ods)
+		require.ErrorIs(t, err, db.ErrEmptyOffChainData)
+		require.EqualError(t, err, "empty offchain data: key "+emptyKey.Hex()+", batch 2")
+	})
+
+	t.Run("partial store skips empty values", func(t *testing.T) {
+		t.Parallel()
+
+		ods := []types.OffChainData{
+			{Key: key, Value: value, BatchNum: 1},
+			{Key: emptyKey, Value: nil, BatchNum: 2},
+		}
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("StoreOffChainDataPartial", context.Background(), ods[:1]).Return(nil, nil)
+
+		failed, err := db.NewNonEmptyDB(dbMock).StoreOffChainDataPartial(context.Background(), ods)
+		require.NoError(t, err)
+		require.Equal(t, []types.FailedRecord{
+			{Key: emptyKey, BatchNum: 2, Reason: db.ErrEmptyOffChainData.Error()},
+		}, failed)
+	})
+
+	t.Run("replaces with non empty value", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ReplaceOffChainData", context.Background(), key, value).Return(nil)
+
+		require.NoError(t, db.NewNonEmptyDB(dbMock).ReplaceOffChainData(context.Background(), key, value))
+	})
+
+	t.Run("rejects replacing with empty value", func(t *testing.T) {
+		t.Parallel()
+
+		err := db.NewNonEmptyDB(mocks.NewDB(t)).ReplaceOffChainData(context.Background(), emptyKey, []byte{})
+		require.ErrorIs(t, err, db.ErrEmptyOffChainData)
+		require.NotErrorIs(t, err, db.ErrInvalidOffChainData)
+	})
+
+	t.Run("other methods are delegated", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("OffChainDataExists", context.Background(), common.Hash{}).Return(true, nil)
+
+		exists, err := db.NewNonEmptyDB(dbMock).OffChainDataExists(context.Background(), common.Hash{})
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// maintenanceTables are the tables vacuumed and analyzed by RunMaintenance, the largest one first
+var maintenanceTables = []string{
+	"data_node.offchain_data",
+	"data_node.missing_batches",
+	"data_node.batch_commitments",
+	"data_node.resolved_batches_history",
+	"data_node.sync_tasks",
+}
+
+// ErrMaintenanceRunning is returned when the maintenance is requested while it is already running
+var ErrMaintenanceRunning = errors.New("database maintenance already running")
+
+// RunMaintenance vacuums the tables of the data node and refreshes their statistics, which go stale after
+// large prunes or bulk inserts. VACUUM cannot run inside a transaction, so every table is vacuumed on its own.
+// Only one maintenance runs at a time, ErrMaintenanceRunning is returned to the concurrent callers
+func (db *pgDB) RunMaintenance(ctx context.Context) error {
+	if !db.maintenanceRunning.CompareAndSwap(false, true) {
+		return ErrMaintenanceRunning
+	}
+	defer db.maintenanceRunning.Store(false)
+
+	for _, table := range maintenanceTables {
+		start := time.Now()
+
+		if _, err := db.pg.ExecContext(ctx, "VACUUM (ANALYZE) "+table); err != nil {
+			return fmt.Errorf("failed to vacuum %s: %w", table, err)
+		}
+
+		log.Infof("vacuumed and analyzed %s in %s", table, time.Since(start))
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DB_RunMaintenance(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		running   bool
+		returnErr error
+	}{
+		{
+			name: "tables vacuumed",
+		},
+		{
+			name:      "vacuum fails",
+			returnErr: errors.New("test error"),
+		},
+		{
+			name:      "maintenance already running",
+			running:   true,
+			returnErr: ErrMaintenanceRunning,
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			constructorExpect(mock)
+
+			dbPG, err := New(context.Background(), sqlx.NewDb(db, "postgres"), DefaultInsertChunkSize)
+			require.NoError(t, err)
+
+			pg, ok := dbPG.(*pgDB)
+			require.True(t, ok)
+
+			pg.maintenanceRunning.Store(tt.running)
+
+			if !tt.running {
+				for i, table := range maintenanceTables {
+					expected := mock.ExpectExec(regexp.QuoteMeta("VACUUM (ANALYZE) " + table))
+					if tt.returnErr != nil && i == 1 {
+						expected.WillReturnError(tt.returnErr)
+						break
+					}
+
+					expected.WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			}
+
+			err = dbPG.RunMaintenance(context.Background())
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// The guard is released once the maintenance ends, unless it was held by another caller
+			require.Equal(t, tt.running, pg.maintenanceRunning.Load())
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
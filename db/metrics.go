@@ -0,0 +1,50 @@
+package db
+
+import (
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsSubsystem = "storage"
+
+	backendDatabase    = "database"
+	backendObjectStore = "object_store"
+)
+
+var (
+	backendAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "backend_available",
+		Help:      "Whether the last read from the storage backend succeeded (1) or failed (0)",
+	}, []string{"backend"})
+
+	sampledCorruptionRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sampled_corruption_rate",
+		Help:      "Ratio of the values of the last integrity sample that do not hash to their key",
+	})
+
+	sampledCorruptValues = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sampled_corrupt_values_total",
+		Help:      "Total number of sampled values that do not hash to their key",
+	})
+
+	storedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "stored_bytes",
+		Help:      "Size in bytes of the offchain data values stored in the database",
+	})
+)
+
+func init() {
+	metrics.Register(backendAvailable)
+	metrics.Register(sampledCorruptionRate)
+	metrics.Register(sampledCorruptValues)
+	metrics.Register(storedBytes)
+}
@@ -0,0 +1,14 @@
+-- +migrate Down
+DROP INDEX IF EXISTS data_node.idx_offchain_data_batch_num;
+ALTER TABLE data_node.offchain_data DROP COLUMN IF EXISTS batch_num;
+
+-- +migrate Up
+-- Add the 'batch_num' column to 'offchain_data' table, 0 means the batch number is not known yet
+ALTER TABLE data_node.offchain_data
+    ADD COLUMN IF NOT EXISTS batch_num BIGINT NOT NULL DEFAULT 0;
+
+-- Create an index for the 'batch_num' column to query the data of a batch
+CREATE INDEX IF NOT EXISTS idx_offchain_data_batch_num ON data_node.offchain_data(batch_num);
+
+-- The sync task for L1 is not reset: the batch numbers of the data stored before are populated as the
+-- synchronizer sees their keys again, or all at once by an opt-in resync, see docs/running.md
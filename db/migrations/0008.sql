@@ -0,0 +1,11 @@
+-- +migrate Down
+DROP INDEX IF EXISTS data_node.idx_offchain_data_finalized;
+ALTER TABLE data_node.offchain_data DROP COLUMN IF EXISTS finalized;
+
+-- +migrate Up
+-- Add the 'finalized' column to 'offchain_data' table, set once the batch of the data is final on L1
+ALTER TABLE data_node.offchain_data
+    ADD COLUMN IF NOT EXISTS finalized BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create a partial index to find the finalized data of a batch range
+CREATE INDEX IF NOT EXISTS idx_offchain_data_finalized ON data_node.offchain_data(batch_num) WHERE finalized;
@@ -0,0 +1,11 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.batch_commitments;
+
+-- +migrate Up
+-- Create the 'batch_commitments' table, holding the hash of the L1 transaction that sequenced every batch.
+-- Only the batches synchronized after this migration are populated
+CREATE TABLE IF NOT EXISTS data_node.batch_commitments
+(
+    batch_num  BIGINT PRIMARY KEY,
+    l1_tx_hash VARCHAR NOT NULL
+);
@@ -0,0 +1,9 @@
+-- +migrate Down
+DROP INDEX IF EXISTS data_node.idx_batch_commitments_l1_block;
+ALTER TABLE data_node.batch_commitments DROP COLUMN IF EXISTS l1_block;
+
+-- +migrate Up
+-- Keep the L1 block that sequenced every batch, to know which batches are still within the challenge window.
+-- The batches synchronized before this migration have an unknown block, stored as 0
+ALTER TABLE data_node.batch_commitments ADD COLUMN IF NOT EXISTS l1_block BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_batch_commitments_l1_block ON data_node.batch_commitments (l1_block);
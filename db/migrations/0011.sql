@@ -0,0 +1,8 @@
+-- +migrate Down
+ALTER TABLE data_node.missing_batches DROP COLUMN IF EXISTS enqueued_at;
+
+-- +migrate Up
+-- Keep when every batch key was queued to be resolved, to measure how long its resolution takes.
+-- Queuing a key again does nothing, so a retried key keeps its original time.
+-- The keys queued before this migration get the time of the migration
+ALTER TABLE data_node.missing_batches ADD COLUMN IF NOT EXISTS enqueued_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
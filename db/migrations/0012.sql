@@ -0,0 +1,15 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.failed_batches;
+
+-- +migrate Up
+-- Keep the batch keys the synchronizer gave up resolving after too many failed attempts,
+-- along with the error of the last attempt, so they no longer take room in the missing batches
+CREATE TABLE IF NOT EXISTS data_node.failed_batches
+(
+    num       BIGINT NOT NULL,
+    hash      VARCHAR(255) NOT NULL,
+    reason    TEXT NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (num, hash)
+);
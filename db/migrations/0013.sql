@@ -0,0 +1,10 @@
+-- +migrate Down
+ALTER TABLE data_node.offchain_data DROP COLUMN IF EXISTS sequence_index;
+ALTER TABLE data_node.missing_batches DROP COLUMN IF EXISTS sequence_index;
+
+-- +migrate Up
+-- Keep the position of the batch of every key in the L1 calldata of the sequence that sequenced it,
+-- which is the order the values of a batch are served in. The keys stored before this migration
+-- have no known position, and are served after the ones that have it
+ALTER TABLE data_node.offchain_data ADD COLUMN IF NOT EXISTS sequence_index INTEGER;
+ALTER TABLE data_node.missing_batches ADD COLUMN IF NOT EXISTS sequence_index INTEGER;
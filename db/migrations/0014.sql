@@ -0,0 +1,17 @@
+-- +migrate Down
+DROP TABLE IF EXISTS data_node.resolved_batches_history;
+
+-- +migrate Up
+-- Keep the missing batch keys once resolved when the history is enabled, along with when they were queued
+-- and resolved and how many attempts it took, to look into slow resolutions after the fact
+CREATE TABLE IF NOT EXISTS data_node.resolved_batches_history
+(
+    num         BIGINT NOT NULL,
+    hash        VARCHAR(255) NOT NULL,
+    enqueued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    attempts    INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS resolved_batches_history_resolved_at_idx ON data_node.resolved_batches_history (resolved_at);
+CREATE INDEX IF NOT EXISTS resolved_batches_history_num_idx ON data_node.resolved_batches_history (num);
@@ -0,0 +1,12 @@
+-- +migrate Down
+DROP INDEX IF EXISTS data_node.offchain_data_forced_batch_num_idx;
+ALTER TABLE data_node.offchain_data DROP COLUMN IF EXISTS forced;
+ALTER TABLE data_node.missing_batches DROP COLUMN IF EXISTS forced;
+
+-- +migrate Up
+-- Tag the data of the forced batches, which originate from L1 instead of the sequencer, so it can be
+-- audited apart from the regular batches. The keys stored before this migration are not tagged
+ALTER TABLE data_node.offchain_data ADD COLUMN IF NOT EXISTS forced BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE data_node.missing_batches ADD COLUMN IF NOT EXISTS forced BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS offchain_data_forced_batch_num_idx ON data_node.offchain_data (batch_num) WHERE forced;
@@ -0,0 +1,6 @@
+-- +migrate Down
+ALTER TABLE data_node.missing_batches DROP COLUMN IF EXISTS attempts;
+
+-- +migrate Up
+-- Count the failed attempts to resolve every missing batch key, so they survive the restarts of the node
+ALTER TABLE data_node.missing_batches ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
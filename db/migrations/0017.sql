@@ -0,0 +1,7 @@
+-- +migrate Down
+ALTER TABLE data_node.batch_commitments DROP COLUMN IF EXISTS key;
+
+-- +migrate Up
+-- Keep the key committed on L1 for every batch, so the data of a batch is served by its commitment rather than
+-- by the rows attributed to its number. The batches synchronized before this migration have an unknown key
+ALTER TABLE data_node.batch_commitments ADD COLUMN IF NOT EXISTS key VARCHAR;
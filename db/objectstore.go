@@ -0,0 +1,348 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrBackendsUnavailable indicates none of the storage backends could serve a read
+	ErrBackendsUnavailable = errors.New("all storage backends unavailable")
+
+	// ErrObjectDataMismatch indicates the value read from the object store does not hash to its key
+	ErrObjectDataMismatch = errors.New("object store data does not match the key")
+
+	// emptyValueKey is the key of an empty value, which is never written to the object store
+	emptyValueKey = crypto.Keccak256Hash(nil)
+)
+
+// ObjectStore keeps offchain data values outside of the database, keyed by their hash
+type ObjectStore interface {
+	Put(ctx context.Context, key common.Hash, value []byte) error
+	Get(ctx context.Context, key common.Hash) ([]byte, error)
+}
+
+// objectStoreDB is a DB that keeps the offchain data values in an object store
+// and only the metadata of the offchain data in the wrapped DB
+type objectStoreDB struct {
+	DB
+
+	store ObjectStore
+}
+
+// NewObjectStoreDB wraps the given DB so offchain data values are written to the given object store.
+// Values that were stored in the database before the object store was enabled are still served from it.
+// Reads by key fall back to the object store while the database is unavailable
+func NewObjectStoreDB(db DB, store ObjectStore) DB {
+	return &objectStoreDB{
+		DB:    db,
+		store: store,
+	}
+}
+
+// StoreOffChainData writes the values to the object store and their metadata to the database
+func (db *objectStoreDB) StoreOffChainData(ctx context.Context, ods []types.OffChainData) error {
+	metadata, err := db.putValues(ctx, ods)
+	if err != nil {
+		return err
+	}
+
+	return db.DB.StoreOffChainData(ctx, metadata)
+}
+
+// StoreOffChainDataTx stores the values in the object store, and their metadata within the given transaction.
+// The values are not removed from the object store if the transaction is rolled back
+func (db *objectStoreDB) StoreOffChainDataTx(ctx context.Context, ods []types.OffChainData, tx Tx) error {
+	metadata, err := db.putValues(ctx, ods)
+	if err != nil {
+		return err
+	}
+
+	return db.DB.StoreOffChainDataTx(ctx, metadata, tx)
+}
+
+// StoreOffChainDataPartial writes the values to the object store and their metadata to the database, skipping
+// the records whose value or metadata cannot be stored
+func (db *objectStoreDB) StoreOffChainDataPartial(
+	ctx context.Context, ods []types.OffChainData,
+) ([]types.FailedRecord, error) {
+	var (
+		metadata = make([]types.OffChainData, 0, len(ods))
+		unput    []types.FailedRecord
+	)
+
+	for _, od := range ods {
+		stored, err := db.putValues(ctx, []types.OffChainData{od})
+		if err != nil {
+			unput = append(unput, newFailedRecord(od, err))
+			continue
+		}
+
+		metadata = append(metadata, stored...)
+	}
+
+	failed, err := db.DB.StoreOffChainDataPartial(ctx, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(unput, failed...), nil
+}
+
+// putValues stores the values of the given offchain data in the object store and returns their metadata.
+// The object store overwrites objects and the database only sees the metadata, so a value that does not hash to
+// its key is rejected before it is put: it could differ from the object already stored under the key
+func (db *objectStoreDB) putValues(ctx context.Context, ods []types.OffChainData) ([]types.OffChainData, error) {
+	metadata := make([]types.OffChainData, len(ods))
+	for i, od := range ods {
+		if len(od.Value) > 0 {
+			if err := verifyOffChainData(od.Key, od.Value); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrOffChainDataMismatch, err)
+			}
+
+			if err := db.store.Put(ctx, od.Key, od.Value); err != nil {
+				return nil, fmt.Errorf("failed to store offchain data %s in the object store: %w", od.Key.Hex(), err)
+			}
+		}
+
+		metadata[i] = types.OffChainData{
+			Key:      od.Key,
+			BatchNum: od.BatchNum,
+			Index:    od.Index,
+			Forced:   od.Forced,
+		}
+	}
+
+	return metadata, nil
+}
+
+// ReplaceOffChainData replaces the value of the given key in the object store.
+// Values that were stored in the database before the object store was enabled are replaced in the database
+func (db *objectStoreDB) ReplaceOffChainData(ctx context.Context, key common.Hash, newValue []byte) error {
+	if err := verifyOffChainData(key, newValue); err != nil {
+		return err
+	}
+
+	od, err := db.DB.GetOffChainData(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if len(od.Value) > 0 {
+		return db.DB.ReplaceOffChainData(ctx, key, newValue)
+	}
+
+	if err = db.store.Put(ctx, key, newValue); err != nil {
+		return fmt.Errorf("failed to replace offchain data %s in the object store: %w", key.Hex(), err)
+	}
+
+	return nil
+}
+
+// GetOffChainData returns the value identified by the key.
+// If the database is unavailable, the value is read directly from the object store
+func (db *objectStoreDB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	od, err := db.DB.GetOffChainData(ctx, key)
+	if db.observeDB(ctx, err) {
+		od = &types.OffChainData{Key: key}
+		if storeErr := db.loadValue(ctx, od); storeErr != nil {
+			return nil, fmt.Errorf("%w (database: %w, object store: %w)", ErrBackendsUnavailable, err, storeErr)
+		}
+
+		return od, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = db.loadValue(ctx, od); err != nil {
+		return nil, err
+	}
+
+	return od, nil
+}
+
+// GetOffChainDataAsOf returns the value identified by the key if it is stored under a batch up to the given one.
+// The batch is only known by the database, so there is no fallback to the object store
+func (db *objectStoreDB) GetOffChainDataAsOf(
+	ctx context.Context, key common.Hash, maxBatchNum uint64,
+) (*types.OffChainData, error) {
+	od, err := db.DB.GetOffChainDataAsOf(ctx, key, maxBatchNum)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.loadValue(ctx, od); err != nil {
+		return nil, err
+	}
+
+	return od, nil
+}
+
+// ListOffChainData returns values identified by the given keys.
+// If the database is unavailable, the values are read directly from the object store
+func (db *objectStoreDB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	list, err := db.DB.ListOffChainData(ctx, keys)
+	if db.observeDB(ctx, err) {
+		list = make([]types.OffChainData, len(keys))
+		for i, key := range keys {
+			list[i] = types.OffChainData{Key: key}
+		}
+
+		if storeErr := db.loadValues(ctx, list); storeErr != nil {
+			return nil, fmt.Errorf("%w (database: %w, object store: %w)", ErrBackendsUnavailable, err, storeErr)
+		}
+
+		return list, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = db.loadValues(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// observeDB records the availability of the database given the error of a read,
+// and returns whether the read should fall back to the object store
+func (db *objectStoreDB) observeDB(ctx context.Context, err error) bool {
+	if err == nil || errors.Is(err, ErrStateNotSynchronized) {
+		backendAvailable.WithLabelValues(backendDatabase).Set(1)
+		return false
+	}
+
+	// A cancelled request says nothing about the database
+	if ctx.Err() != nil {
+		return false
+	}
+
+	backendAvailable.WithLabelValues(backendDatabase).Set(0)
+	return true
+}
+
+// ListOffChainDataByBatch returns a page of the values stored for the given batch
+func (db *objectStoreDB) ListOffChainDataByBatch(
+	ctx context.Context,
+	batchNum uint64,
+	offset, limit uint,
+) ([]types.OffChainData, uint64, error) {
+	list, total, err := db.DB.ListOffChainDataByBatch(ctx, batchNum, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = db.loadValues(ctx, list); err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetBatchConcatenated returns the value stored for the key committed for the given batch,
+// reading the value kept in the object store
+func (db *objectStoreDB) GetBatchConcatenated(ctx context.Context, batchNum uint64) ([]byte, error) {
+	return committedValue(ctx, db.GetCommittedKey, db.GetOffChainData, batchNum)
+}
+
+// ListOffChainDataAfterKey returns a page of the values stored for the given batch range, ordered by key.
+// A value of the object store that does not hash to its key is returned empty instead of failing the whole page,
+// so integrity scans report it along with the other mismatches
+func (db *objectStoreDB) ListOffChainDataAfterKey(
+	ctx context.Context,
+	from, to uint64,
+	after common.Hash,
+	limit uint,
+) ([]types.OffChainData, error) {
+	list, err := db.DB.ListOffChainDataAfterKey(ctx, from, to, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list {
+		if err = db.loadValue(ctx, &list[i]); err != nil && !errors.Is(err, ErrObjectDataMismatch) {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// ListOffChainDataInBatchRange returns a page of the values stored for the given batch range,
+// ordered by batch number and key
+func (db *objectStoreDB) ListOffChainDataInBatchRange(
+	ctx context.Context,
+	from, to uint64,
+	limit, offset uint,
+) ([]types.OffChainData, error) {
+	list, err := db.DB.ListOffChainDataInBatchRange(ctx, from, to, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.loadValues(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// ListForcedOffChainData returns a page of the values stored for the forced batches of the given batch range,
+// ordered by batch number, position in the sequence calldata and key
+func (db *objectStoreDB) ListForcedOffChainData(
+	ctx context.Context,
+	from, to uint64,
+	limit, offset uint,
+) ([]types.OffChainData, error) {
+	list, err := db.DB.ListForcedOffChainData(ctx, from, to, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.loadValues(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (db *objectStoreDB) loadValues(ctx context.Context, ods []types.OffChainData) error {
+	for i := range ods {
+		if err := db.loadValue(ctx, &ods[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadValue reads the value of the given offchain data from the object store and verifies it against the key.
+// Values that are already present were stored in the database and are returned as they are
+func (db *objectStoreDB) loadValue(ctx context.Context, od *types.OffChainData) error {
+	if len(od.Value) > 0 || od.Key == emptyValueKey {
+		return nil
+	}
+
+	value, err := db.store.Get(ctx, od.Key)
+	if err != nil {
+		if ctx.Err() == nil {
+			backendAvailable.WithLabelValues(backendObjectStore).Set(0)
+		}
+
+		return fmt.Errorf("failed to get offchain data %s from the object store: %w", od.Key.Hex(), err)
+	}
+
+	backendAvailable.WithLabelValues(backendObjectStore).Set(1)
+
+	if actual := crypto.Keccak256Hash(value); actual != od.Key {
+		return fmt.Errorf("%w: expected %s, got %s", ErrObjectDataMismatch, od.Key.Hex(), actual.Hex())
+	}
+
+	od.Value = value
+	return nil
+}
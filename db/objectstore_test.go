@@ -0,0 +1,394 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStoreDB_StoreOffChainData(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+
+	t.Run("stores values in the object store and metadata in the db", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		storeMock.On("Put", context.Background(), key, value).Return(nil)
+		dbMock.On("StoreOffChainData", context.Background(), []types.OffChainData{{Key: key, BatchNum: 1}}).
+			Return(nil)
+
+		err := db.NewObjectStoreDB(dbMock, storeMock).StoreOffChainData(context.Background(),
+			[]types.OffChainData{{Key: key, Value: value, BatchNum: 1}})
+		require.NoError(t, err)
+	})
+
+	t.Run("value does not hash to the key", func(t *testing.T) {
+		t.Parallel()
+
+		err := db.NewObjectStoreDB(mocks.NewDB(t), mocks.NewObjectStore(t)).StoreOffChainData(context.Background(),
+			[]types.OffChainData{{Key: key, Value: []byte("other"), BatchNum: 1}})
+		require.ErrorIs(t, err, db.ErrOffChainDataMismatch)
+		require.ErrorIs(t, err, db.ErrInvalidOffChainData)
+	})
+
+	t.Run("object store error", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		storeMock.On("Put", context.Background(), key, value).Return(errors.New("test error"))
+
+		err := db.NewObjectStoreDB(dbMock, storeMock).StoreOffChainData(context.Background(),
+			[]types.OffChainData{{Key: key, Value: value}})
+		require.ErrorContains(t, err, "test error")
+	})
+}
+
+func TestObjectStoreDB_StoreForcedOffChainData(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("forced")
+	key := crypto.Keccak256Hash(value)
+	index := uint(2)
+	metadata := []types.OffChainData{{Key: key, BatchNum: 1, Index: &index, Forced: true}}
+
+	dbMock := mocks.NewDB(t)
+	storeMock := mocks.NewObjectStore(t)
+
+	storeMock.On("Put", context.Background(), key, value).Return(nil)
+	dbMock.On("StoreOffChainData", context.Background(), metadata).Return(nil)
+	dbMock.On("ListForcedOffChainData", context.Background(), uint64(1), uint64(1), uint(10), uint(0)).
+		Return(metadata, nil)
+	storeMock.On("Get", context.Background(), key).Return(value, nil)
+
+	objectStoreDB := db.NewObjectStoreDB(dbMock, storeMock)
+
+	err := objectStoreDB.StoreOffChainData(context.Background(),
+		[]types.OffChainData{{Key: key, Value: value, BatchNum: 1, Index: &index, Forced: true}})
+	require.NoError(t, err)
+
+	list, err := objectStoreDB.ListForcedOffChainData(context.Background(), 1, 1, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, []types.OffChainData{{Key: key, Value: value, BatchNum: 1, Index: &index, Forced: true}}, list)
+}
+
+func TestObjectStoreDB_StoreOffChainDataTx(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+
+	dbMock := mocks.NewDB(t)
+	storeMock := mocks.NewObjectStore(t)
+	txMock := mocks.NewTx(t)
+
+	storeMock.On("Put", context.Background(), key, value).Return(nil)
+	dbMock.On("StoreOffChainDataTx", context.Background(), []types.OffChainData{{Key: key, BatchNum: 1}}, txMock).
+		Return(nil)
+
+	err := db.NewObjectStoreDB(dbMock, storeMock).StoreOffChainDataTx(context.Background(),
+		[]types.OffChainData{{Key: key, Value: value, BatchNum: 1}}, txMock)
+	require.NoError(t, err)
+}
+
+func TestObjectStoreDB_StoreOffChainDataPartial(t *testing.T) {
+	t.Parallel()
+
+	ods := []types.OffChainData{
+		{Key: crypto.Keccak256Hash([]byte("value1")), Value: []byte("value1"), BatchNum: 1},
+		{Key: crypto.Keccak256Hash([]byte("value2")), Value: []byte("value2"), BatchNum: 2},
+	}
+
+	dbMock := mocks.NewDB(t)
+	storeMock := mocks.NewObjectStore(t)
+
+	storeMock.On("Put", context.Background(), ods[0].Key, ods[0].Value).Return(errors.New("test error"))
+	storeMock.On("Put", context.Background(), ods[1].Key, ods[1].Value).Return(nil)
+	dbMock.On("StoreOffChainDataPartial", context.Background(),
+		[]types.OffChainData{{Key: ods[1].Key, BatchNum: 2}}).Return(nil, nil)
+
+	failed, err := db.NewObjectStoreDB(dbMock, storeMock).StoreOffChainDataPartial(context.Background(), ods)
+	require.NoError(t, err)
+	require.Equal(t, []types.FailedRecord{{
+		Key:      ods[0].Key,
+		BatchNum: 1,
+		Reason:   "failed to store offchain data " + ods[0].Key.Hex() + " in the object store: test error",
+	}}, failed)
+}
+
+func TestObjectStoreDB_ReplaceOffChainData(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+
+	t.Run("replaces the value in the object store", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		dbMock.On("GetOffChainData", context.Background(), key).
+			Return(&types.OffChainData{Key: key, BatchNum: 1}, nil)
+		storeMock.On("Put", context.Background(), key, value).Return(nil)
+
+		err := db.NewObjectStoreDB(dbMock, storeMock).ReplaceOffChainData(context.Background(), key, value)
+		require.NoError(t, err)
+	})
+
+	t.Run("replaces the value stored in the database", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		dbMock.On("GetOffChainData", context.Background(), key).
+			Return(&types.OffChainData{Key: key, Value: []byte("corrupt")}, nil)
+		dbMock.On("ReplaceOffChainData", context.Background(), key, value).Return(nil)
+
+		err := db.NewObjectStoreDB(dbMock, storeMock).ReplaceOffChainData(context.Background(), key, value)
+		require.NoError(t, err)
+	})
+
+	t.Run("value does not hash to the key", func(t *testing.T) {
+		t.Parallel()
+
+		err := db.NewObjectStoreDB(mocks.NewDB(t), mocks.NewObjectStore(t)).
+			ReplaceOffChainData(context.Background(), key, []byte("other"))
+		require.ErrorIs(t, err, db.ErrInvalidOffChainData)
+	})
+
+	t.Run("nothing stored for the key", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+
+		dbMock.On("GetOffChainData", context.Background(), key).Return(nil, db.ErrStateNotSynchronized)
+
+		err := db.NewObjectStoreDB(dbMock, mocks.NewObjectStore(t)).
+			ReplaceOffChainData(context.Background(), key, value)
+		require.ErrorIs(t, err, db.ErrStateNotSynchronized)
+	})
+}
+
+func TestObjectStoreDB_GetOffChainData(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+
+	testTable := []struct {
+		name        string
+		dbValue     []byte
+		storeValue  []byte
+		storeErr    error
+		expected    *types.OffChainData
+		expectedErr error
+	}{
+		{
+			name:       "value read from the object store",
+			storeValue: value,
+			expected:   &types.OffChainData{Key: key, Value: value, BatchNum: 1},
+		},
+		{
+			name:     "value stored in the db",
+			dbValue:  value,
+			expected: &types.OffChainData{Key: key, Value: value, BatchNum: 1},
+		},
+		{
+			name:        "value does not match the key",
+			storeValue:  []byte("other data"),
+			expectedErr: db.ErrObjectDataMismatch,
+		},
+		{
+			name:        "object store error",
+			storeErr:    errors.New("test error"),
+			expectedErr: errors.New("failed to get offchain data " + key.Hex() + " from the object store: test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			storeMock := mocks.NewObjectStore(t)
+
+			dbMock.On("GetOffChainData", context.Background(), key).
+				Return(&types.OffChainData{Key: key, Value: tt.dbValue, BatchNum: 1}, nil)
+
+			if tt.dbValue == nil {
+				storeMock.On("Get", context.Background(), key).Return(tt.storeValue, tt.storeErr)
+			}
+
+			got, err := db.NewObjectStoreDB(dbMock, storeMock).GetOffChainData(context.Background(), key)
+			switch {
+			case errors.Is(tt.expectedErr, db.ErrObjectDataMismatch):
+				require.ErrorIs(t, err, db.ErrObjectDataMismatch)
+			case tt.expectedErr != nil:
+				require.EqualError(t, err, tt.expectedErr.Error())
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestObjectStoreDB_GetOffChainDataAsOf(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+
+	t.Run("value read from the object store", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		dbMock.On("GetOffChainDataAsOf", context.Background(), key, uint64(10)).
+			Return(&types.OffChainData{Key: key, BatchNum: 5}, nil)
+		storeMock.On("Get", context.Background(), key).Return(value, nil)
+
+		got, err := db.NewObjectStoreDB(dbMock, storeMock).GetOffChainDataAsOf(context.Background(), key, 10)
+		require.NoError(t, err)
+		require.Equal(t, &types.OffChainData{Key: key, Value: value, BatchNum: 5}, got)
+	})
+
+	t.Run("no fallback to the object store", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		dbMock.On("GetOffChainDataAsOf", context.Background(), key, uint64(10)).
+			Return(nil, db.ErrStateNotSynchronized)
+
+		_, err := db.NewObjectStoreDB(dbMock, storeMock).GetOffChainDataAsOf(context.Background(), key, 10)
+		require.ErrorIs(t, err, db.ErrStateNotSynchronized)
+	})
+}
+
+func TestObjectStoreDB_ListOffChainData(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+	emptyKey := crypto.Keccak256Hash(nil)
+	keys := []common.Hash{key, emptyKey}
+
+	dbMock := mocks.NewDB(t)
+	storeMock := mocks.NewObjectStore(t)
+
+	dbMock.On("ListOffChainData", context.Background(), keys).
+		Return([]types.OffChainData{{Key: key}, {Key: emptyKey, Value: []byte{}}}, nil)
+	storeMock.On("Get", context.Background(), key).Return(value, nil).Once()
+
+	got, err := db.NewObjectStoreDB(dbMock, storeMock).ListOffChainData(context.Background(), keys)
+	require.NoError(t, err)
+	require.Equal(t, []types.OffChainData{{Key: key, Value: value}, {Key: emptyKey, Value: []byte{}}}, got)
+}
+
+func TestObjectStoreDB_ListOffChainDataAfterKey(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+	corruptedKey := crypto.Keccak256Hash([]byte("other data"))
+
+	dbMock := mocks.NewDB(t)
+	storeMock := mocks.NewObjectStore(t)
+
+	dbMock.On("ListOffChainDataAfterKey", context.Background(), uint64(1), uint64(5), common.Hash{}, uint(10)).
+		Return([]types.OffChainData{{Key: key, BatchNum: 1}, {Key: corruptedKey, BatchNum: 2}}, nil)
+	storeMock.On("Get", context.Background(), key).Return(value, nil)
+	storeMock.On("Get", context.Background(), corruptedKey).Return([]byte("corrupted"), nil)
+
+	got, err := db.NewObjectStoreDB(dbMock, storeMock).
+		ListOffChainDataAfterKey(context.Background(), 1, 5, common.Hash{}, 10)
+	require.NoError(t, err)
+	require.Equal(t, []types.OffChainData{
+		{Key: key, Value: value, BatchNum: 1},
+		{Key: corruptedKey, BatchNum: 2},
+	}, got)
+}
+
+func TestObjectStoreDB_DegradedReads(t *testing.T) {
+	t.Parallel()
+
+	value := []byte("offchaindata")
+	key := crypto.Keccak256Hash(value)
+	dbErr := errors.New("connection refused")
+
+	t.Run("get falls back to the object store", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		dbMock.On("GetOffChainData", context.Background(), key).Return(nil, dbErr)
+		storeMock.On("Get", context.Background(), key).Return(value, nil)
+
+		got, err := db.NewObjectStoreDB(dbMock, storeMock).GetOffChainData(context.Background(), key)
+		require.NoError(t, err)
+		require.Equal(t, &types.OffChainData{Key: key, Value: value}, got)
+	})
+
+	t.Run("get fails on all backends", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		storeErr := errors.New("object store down")
+		dbMock.On("GetOffChainData", context.Background(), key).Return(nil, dbErr)
+		storeMock.On("Get", context.Background(), key).Return(nil, storeErr)
+
+		_, err := db.NewObjectStoreDB(dbMock, storeMock).GetOffChainData(context.Background(), key)
+		require.ErrorIs(t, err, db.ErrBackendsUnavailable)
+		require.ErrorIs(t, err, dbErr)
+		require.ErrorIs(t, err, storeErr)
+	})
+
+	t.Run("unknown key is not looked up in the object store", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		dbMock.On("GetOffChainData", context.Background(), key).Return(nil, db.ErrStateNotSynchronized)
+
+		_, err := db.NewObjectStoreDB(dbMock, storeMock).GetOffChainData(context.Background(), key)
+		require.ErrorIs(t, err, db.ErrStateNotSynchronized)
+	})
+
+	t.Run("list falls back to the object store", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		storeMock := mocks.NewObjectStore(t)
+
+		dbMock.On("ListOffChainData", context.Background(), []common.Hash{key}).Return(nil, dbErr)
+		storeMock.On("Get", context.Background(), key).Return(value, nil)
+
+		got, err := db.NewObjectStoreDB(dbMock, storeMock).ListOffChainData(context.Background(), []common.Hash{key})
+		require.NoError(t, err)
+		require.Equal(t, []types.OffChainData{{Key: key, Value: value}}, got)
+	})
+}
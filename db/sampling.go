@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultIntegritySampleSize is the number of values checked by every integrity sample when not configured
+const DefaultIntegritySampleSize = 100
+
+// IntegrityMonitor periodically checks that a random sample of the stored values hash to their keys,
+// which gives a cheap, continuous assurance of the integrity of the storage between full scans
+type IntegrityMonitor struct {
+	db         DB
+	sampleSize uint
+	interval   time.Duration
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewIntegrityMonitor returns the monitor checking a sample of the given size of the values of the given DB
+// at every interval
+func NewIntegrityMonitor(db DB, sampleSize uint, interval time.Duration) *IntegrityMonitor {
+	if sampleSize == 0 {
+		sampleSize = DefaultIntegritySampleSize
+	}
+
+	return &IntegrityMonitor{
+		db:         db,
+		sampleSize: sampleSize,
+		interval:   interval,
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start checks a sample at every interval until the context is done or the monitor is stopped
+func (m *IntegrityMonitor) Start(ctx context.Context) {
+	m.wg.Add(1)
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, _, err := m.Sample(ctx); err != nil {
+				log.Errorf("failed to check the integrity of a sample of the stored values: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the monitor and waits for the running check to finish
+func (m *IntegrityMonitor) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+	m.wg.Wait()
+}
+
+// Sample checks the values stored after a random key, wrapping around to the lowest keys when there are not
+// enough of them, and returns the number of values checked and how many of them are corrupt.
+// Keys are hashes, so the values after a random key are a random sample
+func (m *IntegrityMonitor) Sample(ctx context.Context) (uint, uint, error) {
+	start, err := randomKey()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sample, err := m.db.ListOffChainDataAfterKey(ctx, 0, math.MaxInt64, start, m.sampleSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list the sampled values: %w", err)
+	}
+
+	if remaining := m.sampleSize - uint(len(sample)); remaining > 0 {
+		wrapped, err := m.db.ListOffChainDataAfterKey(ctx, 0, math.MaxInt64, common.Hash{}, remaining)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to list the sampled values: %w", err)
+		}
+
+		// The lowest keys may reach the random one when there are fewer values than the sample size
+		for _, od := range wrapped {
+			if od.Key.Cmp(start) > 0 {
+				break
+			}
+
+			sample = append(sample, od)
+		}
+	}
+
+	var corrupt uint
+	for _, od := range sample {
+		if computed := crypto.Keccak256Hash(od.Value); computed != od.Key {
+			log.Errorf("INTEGRITY VIOLATION: value of key %s (batch %d) hashes to %s",
+				od.Key.Hex(), od.BatchNum, computed.Hex())
+
+			corrupt++
+		}
+	}
+
+	if len(sample) > 0 {
+		sampledCorruptionRate.Set(float64(corrupt) / float64(len(sample)))
+	}
+
+	sampledCorruptValues.Add(float64(corrupt))
+
+	return uint(len(sample)), corrupt, nil
+}
+
+// randomKey returns a uniformly random key to start a sample from
+func randomKey() (common.Hash, error) {
+	var key common.Hash
+	if _, err := rand.Read(key[:]); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to generate the sample start key: %w", err)
+	}
+
+	return key, nil
+}
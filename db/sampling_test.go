@@ -0,0 +1,118 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrityMonitor_Sample(t *testing.T) {
+	t.Parallel()
+
+	valid := func(value string) types.OffChainData {
+		return types.OffChainData{Key: crypto.Keccak256Hash([]byte(value)), Value: []byte(value), BatchNum: 1}
+	}
+
+	// The lowest keys, which are always below the random start key
+	corrupt := []types.OffChainData{
+		{Key: common.HexToHash("0x01"), Value: []byte("corrupt1"), BatchNum: 2},
+		{Key: common.HexToHash("0x02"), Value: []byte("corrupt2"), BatchNum: 2},
+	}
+
+	testErr := errors.New("test error")
+
+	tests := []struct {
+		name      string
+		afterKey  []types.OffChainData
+		wrapped   []types.OffChainData
+		listErr   error
+		sampled   uint
+		corrupted uint
+		err       string
+	}{
+		{
+			name:     "all values valid",
+			afterKey: []types.OffChainData{valid("a"), valid("b"), valid("c")},
+			sampled:  3,
+		},
+		{
+			name:      "corrupt value after the start key",
+			afterKey:  []types.OffChainData{valid("a"), corrupt[0], valid("c")},
+			sampled:   3,
+			corrupted: 1,
+		},
+		{
+			name:      "sample wraps around to the lowest keys",
+			afterKey:  []types.OffChainData{valid("a")},
+			wrapped:   corrupt,
+			sampled:   3,
+			corrupted: 2,
+		},
+		{
+			name:    "list fails",
+			listErr: testErr,
+			err:     "failed to list the sampled values: test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("ListOffChainDataAfterKey", mock.Anything, uint64(0), uint64(math.MaxInt64),
+				mock.MatchedBy(func(key common.Hash) bool { return key != common.Hash{} }), uint(3)).
+				Return(tt.afterKey, tt.listErr).Once()
+
+			if tt.listErr == nil && len(tt.afterKey) < 3 {
+				dbMock.On("ListOffChainDataAfterKey", mock.Anything, uint64(0), uint64(math.MaxInt64),
+					common.Hash{}, uint(3-len(tt.afterKey))).
+					Return(tt.wrapped, nil).Once()
+			}
+
+			monitor := db.NewIntegrityMonitor(dbMock, 3, time.Minute)
+
+			sampled, corrupted, err := monitor.Sample(context.Background())
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.sampled, sampled)
+			require.Equal(t, tt.corrupted, corrupted)
+		})
+	}
+}
+
+func TestIntegrityMonitor_Stop(t *testing.T) {
+	t.Parallel()
+
+	monitor := db.NewIntegrityMonitor(mocks.NewDB(t), 0, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		monitor.Start(context.Background())
+		close(done)
+	}()
+
+	monitor.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("monitor did not stop")
+	}
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// listSchemaColumnsSQL is a query that returns the columns of every table of the data node schema
+const listSchemaColumnsSQL = `
+	SELECT table_name, column_name
+	FROM information_schema.columns
+	WHERE table_schema = 'data_node';`
+
+// ErrSchemaDrift is returned when the database schema lacks tables or columns the data node relies on
+var ErrSchemaDrift = errors.New("database schema does not match the expected one")
+
+// expectedSchema are the columns of every table of the data node schema the queries rely on
+var expectedSchema = map[string][]string{
+	"offchain_data":            {"key", "value", "batch_num", "finalized", "sequence_index", "forced"},
+	"missing_batches":          {"num", "hash", "enqueued_at", "sequence_index", "forced", "attempts"},
+	"failed_batches":           {"num", "hash", "reason", "failed_at"},
+	"sync_tasks":               {"task", "block", "processed"},
+	"batch_commitments":        {"batch_num", "l1_tx_hash", "l1_block", "key"},
+	"resolved_batches_history": {"num", "hash", "enqueued_at", "resolved_at", "attempts"},
+}
+
+// CheckSchema checks that the tables and columns the data node relies on exist, so a partially migrated
+// database fails on startup instead of on the first query using them. The error lists everything missing
+func CheckSchema(ctx context.Context, pg *sqlx.DB) error {
+	rows, err := pg.QueryContext(ctx, listSchemaColumnsSQL)
+	if err != nil {
+		return fmt.Errorf("failed to list the columns of the database schema: %w", err)
+	}
+
+	defer rows.Close()
+
+	existing := make(map[string]struct{})
+	for rows.Next() {
+		var table, column string
+		if err = rows.Scan(&table, &column); err != nil {
+			return fmt.Errorf("failed to scan the columns of the database schema: %w", err)
+		}
+
+		existing[table+"."+column] = struct{}{}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to list the columns of the database schema: %w", err)
+	}
+
+	var missing []string
+	for table, columns := range expectedSchema {
+		for _, column := range columns {
+			if _, ok := existing[table+"."+column]; !ok {
+				missing = append(missing, "data_node."+table+"."+column)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w, missing columns: %s", ErrSchemaDrift, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchema(t *testing.T) {
+	t.Parallel()
+
+	fullSchema := func() *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"table_name", "column_name"})
+		for table, columns := range expectedSchema {
+			for _, column := range columns {
+				rows.AddRow(table, column)
+			}
+		}
+
+		return rows
+	}
+
+	testErr := errors.New("test error")
+
+	tests := []struct {
+		name    string
+		rows    func() *sqlmock.Rows
+		listErr error
+		err     string
+	}{
+		{
+			name: "schema matches",
+			rows: func() *sqlmock.Rows {
+				return fullSchema().AddRow("offchain_data", "unused_column")
+			},
+		},
+		{
+			name: "columns missing",
+			rows: func() *sqlmock.Rows {
+				rows := sqlmock.NewRows([]string{"table_name", "column_name"})
+				for table, columns := range expectedSchema {
+					for _, column := range columns {
+						if table == "offchain_data" && column == "finalized" {
+							continue
+						}
+
+						if table != "failed_batches" {
+							rows.AddRow(table, column)
+						}
+					}
+				}
+
+				return rows
+			},
+			err: "database schema does not match the expected one, missing columns: " +
+				"data_node.failed_batches.failed_at, data_node.failed_batches.hash, data_node.failed_batches.num, " +
+				"data_node.failed_batches.reason, data_node.offchain_data.finalized",
+		},
+		{
+			name:    "list fails",
+			listErr: testErr,
+			err:     "failed to list the columns of the database schema: test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(listSchemaColumnsSQL))
+			if tt.listErr != nil {
+				expected.WillReturnError(tt.listErr)
+			} else {
+				expected.WillReturnRows(tt.rows())
+			}
+
+			err = CheckSchema(context.Background(), sqlx.NewDb(db, "postgres"))
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
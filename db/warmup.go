@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+// warmupStatements are the statements of the hot read path that are prepared on every connection on warm-up
+var warmupStatements = []string{
+	getOffchainDataSQL,
+	offchainDataExistsSQL,
+}
+
+// Warmup opens the given number of connections of the pool and runs the hot read path queries on each of them,
+// so the node reaches its steady-state latency before serving requests.
+// The connections are returned to the pool as idle ones, so it should not exceed the max idle connections
+func Warmup(ctx context.Context, pg *sqlx.DB, conns int) error {
+	opened := make([]*sql.Conn, 0, conns)
+	defer func() {
+		for _, conn := range opened {
+			if err := conn.Close(); err != nil {
+				log.Warnf("failed to release warmed up connection: %v", err)
+			}
+		}
+	}()
+
+	// All the connections are held until the end, otherwise the pool would keep reusing the first one
+	for i := 0; i < conns; i++ {
+		conn, err := pg.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to open connection %d: %w", i, err)
+		}
+
+		opened = append(opened, conn)
+
+		if err = warmupConn(ctx, conn); err != nil {
+			return fmt.Errorf("failed to warm up connection %d: %w", i, err)
+		}
+	}
+
+	log.Infof("warmed up %d database connections", len(opened))
+
+	return nil
+}
+
+// warmupConn prepares and runs the warm-up statements on the given connection
+func warmupConn(ctx context.Context, conn *sql.Conn) error {
+	for _, query := range warmupStatements {
+		stmt, err := conn.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		rows, err := stmt.QueryContext(ctx, common.Hash{}.Hex())
+		if err != nil {
+			_ = stmt.Close()
+			return err
+		}
+
+		if err = rows.Close(); err != nil {
+			_ = stmt.Close()
+			return err
+		}
+
+		if err = stmt.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
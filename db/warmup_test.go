@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Warmup(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		returnErr error
+	}{
+		{
+			name: "connection warmed up",
+		},
+		{
+			name:      "query fails",
+			returnErr: errors.New("test error"),
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			mock.ExpectPrepare(regexp.QuoteMeta(getOffchainDataSQL)).
+				ExpectQuery().WithArgs(common.Hash{}.Hex()).
+				WillReturnRows(sqlmock.NewRows([]string{"key", "value", "batch_num"}))
+
+			expected := mock.ExpectPrepare(regexp.QuoteMeta(offchainDataExistsSQL)).
+				ExpectQuery().WithArgs(common.Hash{}.Hex())
+			if tt.returnErr != nil {
+				expected.WillReturnError(tt.returnErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			}
+
+			err = Warmup(context.Background(), sqlx.NewDb(db, "postgres"), 1)
+			if tt.returnErr != nil {
+				require.ErrorIs(t, err, tt.returnErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package etherman
+
+import (
+	"context"
+	"time"
+
+	bananaValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/banana/polygonvalidiumetrog"
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/etrog/polygondatacommittee"
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/etrog/polygonvalidiumetrog"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// EthClientFactory creates the eth clients used to connect to L1
+type EthClientFactory interface {
+	CreateEthClient(ctx context.Context, url string) (*ethclient.Client, error)
+}
+
+// ethClientFactory is the implementation of the eth client factory
+type ethClientFactory struct{}
+
+// NewEthClientFactory is the constructor of the eth client factory
+func NewEthClientFactory() EthClientFactory {
+	return &ethClientFactory{}
+}
+
+// CreateEthClient dials the given L1 RPC url
+func (f *ethClientFactory) CreateEthClient(ctx context.Context, url string) (*ethclient.Client, error) {
+	return ethclient.DialContext(ctx, url)
+}
+
+// ethConn is a connection to L1 with the contracts bound to it
+type ethConn struct {
+	EthClient         *ethclient.Client
+	CDKValidium       *polygonvalidiumetrog.Polygonvalidiumetrog
+	CDKValidiumBanana *bananaValidium.Polygonvalidiumetrog
+	DataCommittee     *polygondatacommittee.Polygondatacommittee
+}
+
+// dial creates a new connection to the given L1 endpoint and binds the contracts to it
+func (e *etherman) dial(ctx context.Context, url string) (*ethConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Timeout.Duration)
+	defer cancel()
+
+	ethClient, err := e.factory.CreateEthClient(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	cdkValidium, err := polygonvalidiumetrog.NewPolygonvalidiumetrog(
+		common.HexToAddress(e.cfg.PolygonValidiumAddress),
+		ethClient,
+	)
+	if err != nil {
+		ethClient.Close()
+		return nil, err
+	}
+
+	cdkValidiumBanana, err := bananaValidium.NewPolygonvalidiumetrog(
+		common.HexToAddress(e.cfg.PolygonValidiumAddress),
+		ethClient,
+	)
+	if err != nil {
+		ethClient.Close()
+		return nil, err
+	}
+
+	dataCommittee, err := polygondatacommittee.NewPolygondatacommittee(
+		common.HexToAddress(e.cfg.DataCommitteeAddress),
+		ethClient,
+	)
+	if err != nil {
+		ethClient.Close()
+		return nil, err
+	}
+
+	return &ethConn{
+		EthClient:         ethClient,
+		CDKValidium:       cdkValidium,
+		CDKValidiumBanana: cdkValidiumBanana,
+		DataCommittee:     dataCommittee,
+	}, nil
+}
+
+// dialHealthy dials the endpoint of the given index and checks that L1 is reachable through it
+func (e *etherman) dialHealthy(ctx context.Context, index int) (*ethConn, error) {
+	conn, err := e.dial(ctx, e.endpoints[index])
+	if err != nil {
+		return nil, err
+	}
+
+	if err = e.ping(ctx, conn); err != nil {
+		conn.EthClient.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// connectFrom dials the endpoints in order starting from the given index, wrapping around,
+// and switches to the first healthy one. It returns whether the switch happened
+func (e *etherman) connectFrom(ctx context.Context, first int) bool {
+	for i := range e.endpoints {
+		index := (first + i) % len(e.endpoints)
+
+		conn, err := e.dialHealthy(ctx, index)
+		if err != nil {
+			log.Errorf("error connecting to %s: %v", e.endpoints[index], err)
+			continue
+		}
+
+		e.use(index, conn)
+
+		return true
+	}
+
+	return false
+}
+
+// use makes the given connection to the endpoint of the given index the current one, closing the previous one
+func (e *etherman) use(index int, conn *ethConn) {
+	if old := e.connection.Swap(conn); old != nil {
+		old.EthClient.Close()
+	}
+
+	e.active.Store(int32(index))
+	e.connected.Store(true)
+	activeEndpoint.Set(float64(index))
+
+	log.Infof("connected to %s", e.endpoints[index])
+}
+
+// failover switches to the next healthy endpoint after a request through the given connection failed.
+// It returns whether the request is worth retrying, which is the case if the connection was already
+// replaced or a new one was established. Nothing changes if the failed connection is still healthy
+func (e *etherman) failover(ctx context.Context, failed *ethConn) bool {
+	e.switchMu.Lock()
+	defer e.switchMu.Unlock()
+
+	if e.conn() != failed {
+		return true
+	}
+
+	if err := e.ping(ctx, failed); err == nil {
+		return false
+	}
+
+	e.connected.Store(false)
+
+	return e.connectFrom(ctx, int(e.active.Load())+1)
+}
+
+// withFailover runs the given request through the current connection and, if it fails and there are
+// alternate endpoints, retries it once through the endpoint the connection failed over to
+func withFailover[T any](ctx context.Context, e *etherman, request func(conn *ethConn) (T, error)) (T, error) {
+	conn := e.conn()
+
+	res, err := request(conn)
+	if err == nil || len(e.endpoints) < 2 || ctx.Err() != nil || !e.failover(ctx, conn) {
+		return res, err
+	}
+
+	return request(e.conn())
+}
+
+// IsConnected returns whether the last health check of the L1 connection succeeded
+func (e *etherman) IsConnected() bool {
+	return e.connected.Load()
+}
+
+// monitorConnection checks the health of the L1 connection on every interval
+// and re-dials it when it is lost, until the given context is done
+func (e *etherman) monitorConnection(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.checkConnection(ctx)
+		}
+	}
+}
+
+// checkConnection pings L1 through the current connection and replaces it with a new one if it is dead,
+// preferring the primary endpoint. A healthy connection to an alternate endpoint is replaced as well
+// once the primary endpoint recovers
+func (e *etherman) checkConnection(ctx context.Context) {
+	e.switchMu.Lock()
+	defer e.switchMu.Unlock()
+
+	err := e.ping(ctx, e.conn())
+	if err == nil {
+		e.connected.Store(true)
+
+		if e.active.Load() != 0 {
+			e.restorePrimary(ctx)
+		}
+
+		return
+	}
+
+	if e.connected.Swap(false) {
+		log.Warnf("connection to L1 lost: %v", err)
+	}
+
+	if !e.connectFrom(ctx, 0) {
+		log.Errorf("error reconnecting to L1: no endpoint is reachable")
+	}
+}
+
+// restorePrimary switches back to the primary endpoint if it is healthy
+func (e *etherman) restorePrimary(ctx context.Context) {
+	conn, err := e.dialHealthy(ctx, 0)
+	if err != nil {
+		log.Debugf("primary L1 endpoint %s still unreachable: %v", e.endpoints[0], err)
+		return
+	}
+
+	e.use(0, conn)
+}
+
+// ping checks that L1 is reachable through the given connection
+func (e *etherman) ping(ctx context.Context, conn *ethConn) error {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Timeout.Duration)
+	defer cancel()
+
+	_, err := conn.EthClient.BlockNumber(ctx)
+	return err
+}
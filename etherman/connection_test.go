@@ -0,0 +1,239 @@
+package etherman
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// ethService is a minimal eth namespace served in process
+type ethService struct {
+	// logQueries counts the eth_getLogs calls
+	logQueries atomic.Int32
+	// logs are returned by every eth_getLogs call
+	logs []gethTypes.Log
+}
+
+// BlockNumber returns a fixed block number
+func (s *ethService) BlockNumber() hexutil.Uint64 {
+	return 1
+}
+
+// GetCode returns a fixed contract code
+func (s *ethService) GetCode(_ common.Address, _ string) hexutil.Bytes {
+	return hexutil.Bytes{0x60}
+}
+
+// GetLogs returns the configured logs, if any
+func (s *ethService) GetLogs(_ map[string]interface{}) []gethTypes.Log {
+	s.logQueries.Add(1)
+	if s.logs == nil {
+		return []gethTypes.Log{}
+	}
+
+	return s.logs
+}
+
+// fakeEthClientFactory creates clients to in-process L1 nodes
+type fakeEthClientFactory struct {
+	servers []*rpc.Server
+	urls    []string
+	err     error
+	refused map[string]bool
+	eth     *ethService
+}
+
+func (f *fakeEthClientFactory) CreateEthClient(_ context.Context, url string) (*ethclient.Client, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if f.refused[url] {
+		return nil, errors.New("connection refused")
+	}
+
+	server := rpc.NewServer()
+	eth := f.eth
+	if eth == nil {
+		eth = &ethService{}
+	}
+
+	if err := server.RegisterName("eth", eth); err != nil {
+		return nil, err
+	}
+
+	f.servers = append(f.servers, server)
+	f.urls = append(f.urls, url)
+
+	return ethclient.NewClient(rpc.DialInProc(server)), nil
+}
+
+func TestEtherman_CheckConnection(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.L1Config{
+		Timeout: types.Duration{Duration: time.Second},
+	}
+
+	t.Run("healthy connection is kept", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{}
+		em, err := NewWithFactory(context.Background(), cfg, factory)
+		require.NoError(t, err)
+
+		e := em.(*etherman)
+		conn := e.conn()
+
+		e.checkConnection(context.Background())
+		require.True(t, e.IsConnected())
+		require.Same(t, conn, e.conn())
+		require.Len(t, factory.servers, 1)
+	})
+
+	t.Run("lost connection is re-dialed", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{}
+		em, err := NewWithFactory(context.Background(), cfg, factory)
+		require.NoError(t, err)
+
+		e := em.(*etherman)
+		conn := e.conn()
+
+		// the L1 node goes away
+		factory.servers[0].Stop()
+
+		e.checkConnection(context.Background())
+		require.True(t, e.IsConnected())
+		require.NotSame(t, conn, e.conn())
+		require.Len(t, factory.servers, 2)
+
+		_, err = e.conn().EthClient.BlockNumber(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("re-dial fails", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{}
+		em, err := NewWithFactory(context.Background(), cfg, factory)
+		require.NoError(t, err)
+
+		e := em.(*etherman)
+		conn := e.conn()
+
+		factory.servers[0].Stop()
+		factory.err = errors.New("connection refused")
+
+		e.checkConnection(context.Background())
+		require.False(t, e.IsConnected())
+		require.Same(t, conn, e.conn())
+
+		// the L1 node comes back
+		factory.err = nil
+
+		e.checkConnection(context.Background())
+		require.True(t, e.IsConnected())
+		require.NotSame(t, conn, e.conn())
+	})
+}
+
+func TestEtherman_Failover(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.L1Config{
+		RpcURL:          "primary",
+		FallbackRpcURLs: []string{"fallback1", "fallback2"},
+		Timeout:         types.Duration{Duration: time.Second},
+	}
+
+	t.Run("fallback used when the primary cannot be dialed", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{refused: map[string]bool{"primary": true}}
+		em, err := NewWithFactory(context.Background(), cfg, factory)
+		require.NoError(t, err)
+
+		e := em.(*etherman)
+		require.Equal(t, int32(1), e.active.Load())
+		require.Equal(t, []string{"fallback1"}, factory.urls)
+	})
+
+	t.Run("no endpoint can be dialed", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{err: errors.New("connection refused")}
+		_, err := NewWithFactory(context.Background(), cfg, factory)
+		require.EqualError(t, err, "connection refused")
+	})
+
+	t.Run("failed request retried through the next endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{}
+		em, err := NewWithFactory(context.Background(), cfg, factory)
+		require.NoError(t, err)
+
+		e := em.(*etherman)
+
+		// the primary L1 node goes away
+		factory.servers[0].Stop()
+
+		code, err := e.CodeAt(context.Background(), common.Address{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x60}, code)
+		require.Equal(t, int32(1), e.active.Load())
+		require.Equal(t, []string{"primary", "fallback1"}, factory.urls)
+	})
+
+	t.Run("failed request through the last endpoint wraps around", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{refused: map[string]bool{"primary": true, "fallback1": true}}
+		em, err := NewWithFactory(context.Background(), cfg, factory)
+		require.NoError(t, err)
+
+		e := em.(*etherman)
+		require.Equal(t, int32(2), e.active.Load())
+
+		factory.refused = nil
+		factory.servers[0].Stop()
+
+		_, err = e.CodeAt(context.Background(), common.Address{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, int32(0), e.active.Load())
+	})
+
+	t.Run("health check switches back to the recovered primary", func(t *testing.T) {
+		t.Parallel()
+
+		factory := &fakeEthClientFactory{refused: map[string]bool{"primary": true}}
+		em, err := NewWithFactory(context.Background(), cfg, factory)
+		require.NoError(t, err)
+
+		e := em.(*etherman)
+
+		e.checkConnection(context.Background())
+		require.Equal(t, int32(1), e.active.Load())
+
+		// the primary L1 node comes back
+		factory.refused = nil
+
+		e.checkConnection(context.Background())
+		require.True(t, e.IsConnected())
+		require.Equal(t, int32(0), e.active.Load())
+		require.Equal(t, []string{"fallback1", "primary"}, factory.urls)
+	})
+}
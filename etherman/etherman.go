@@ -2,20 +2,30 @@ package etherman
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
 
-	"github.com/0xPolygon/cdk-contracts-tooling/contracts/etrog/polygondatacommittee"
+	bananaValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/banana/polygonvalidiumetrog"
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/etrog/polygonvalidiumetrog"
 	"github.com/0xPolygon/cdk-data-availability/config"
 	"github.com/0xPolygon/cdk-data-availability/log"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
 )
 
+// maxFilterBatchNums is the maximum number of batch numbers matched by a single L1 logs query,
+// which is the limit of values per topic enforced by geth
+const maxFilterBatchNums = 1000
+
+// ErrInvalidSignatureThreshold indicates the required amount of signatures of the committee cannot be met
+var ErrInvalidSignatureThreshold = errors.New("invalid committee signature threshold")
+
 // DataCommitteeMember represents a member of the Data Committee
 type DataCommitteeMember struct {
 	Addr common.Address
@@ -24,9 +34,27 @@ type DataCommitteeMember struct {
 
 // DataCommittee represents a specific committee
 type DataCommittee struct {
-	AddressesHash      common.Hash
-	Members            []DataCommitteeMember
-	RequiredSignatures uint64
+	AddressesHash              common.Hash
+	Members                    []DataCommitteeMember
+	RequiredAmountOfSignatures uint64
+}
+
+// RequiredSignatures returns the amount of signatures required for a sequence to be accepted by the committee
+func (c *DataCommittee) RequiredSignatures() (uint64, error) {
+	if err := validateSignatureThreshold(c.RequiredAmountOfSignatures, uint64(len(c.Members))); err != nil {
+		return 0, err
+	}
+
+	return c.RequiredAmountOfSignatures, nil
+}
+
+// validateSignatureThreshold checks that the required amount of signatures can be met by the committee members
+func validateSignatureThreshold(required, members uint64) error {
+	if required > members {
+		return fmt.Errorf("%w: %d signatures required from %d members", ErrInvalidSignatureThreshold, required, members)
+	}
+
+	return nil
 }
 
 // Etherman defines functions that should be implemented by Etherman
@@ -37,7 +65,10 @@ type Etherman interface {
 	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
 
 	GetCurrentDataCommittee() (*DataCommittee, error)
+	GetDataCommitteeAtBlock(ctx context.Context, blockNumber uint64) (*DataCommittee, error)
 	GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, error)
+	GetRequiredSignatures() (uint64, error)
+	IsConnected() bool
 	TrustedSequencer(ctx context.Context) (common.Address, error)
 	WatchSetTrustedSequencer(
 		ctx context.Context,
@@ -52,74 +83,124 @@ type Etherman interface {
 		opts *bind.FilterOpts,
 		numBatch []uint64,
 	) (*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatchesIterator, error)
+	FilterSequenceBatchesByNumbers(
+		opts *bind.FilterOpts,
+		nums []uint64,
+	) ([]*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error)
+	FilterSequenceBatchesBanana(
+		ctx context.Context,
+		startBlock uint64,
+		numBatch []uint64,
+	) ([]*bananaValidium.PolygonvalidiumetrogSequenceBatches, error)
+	FilterVerifyBatches(opts *bind.FilterOpts) ([]*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches, error)
 }
 
 // etherman is the implementation of EtherMan.
 type etherman struct {
-	EthClient     *ethclient.Client
-	CDKValidium   *polygonvalidiumetrog.Polygonvalidiumetrog
-	DataCommittee *polygondatacommittee.Polygondatacommittee
+	// connection is the current connection to L1, replaced when the connection is lost
+	connection atomic.Pointer[ethConn]
+	connected  atomic.Bool
+
+	// endpoints are the L1 endpoints, the primary one first followed by the fallbacks
+	endpoints []string
+	// active is the index of the endpoint of the current connection
+	active atomic.Int32
+	// switchMu serializes the replacements of the connection
+	switchMu sync.Mutex
+
+	factory EthClientFactory
+	cfg     config.L1Config
 }
 
 // New creates a new etherman
 func New(ctx context.Context, cfg config.L1Config) (Etherman, error) {
-	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout.Duration)
-	defer cancel()
+	return NewWithFactory(ctx, cfg, NewEthClientFactory())
+}
 
-	ethClient, err := ethclient.DialContext(ctx, cfg.RpcURL)
-	if err != nil {
-		log.Errorf("error connecting to %s: %+v", cfg.RpcURL, err)
-		return nil, err
+// NewWithFactory creates a new etherman that dials L1 with the given factory, falling back to the alternate
+// endpoints in order if the primary one cannot be dialed. If the health check is enabled, the connection is
+// monitored and re-dialed while the given context is alive
+func NewWithFactory(ctx context.Context, cfg config.L1Config, factory EthClientFactory) (Etherman, error) {
+	e := &etherman{
+		endpoints: append([]string{cfg.RpcURL}, cfg.FallbackRpcURLs...),
+		factory:   factory,
+		cfg:       cfg,
 	}
 
-	cdkValidium, err := polygonvalidiumetrog.NewPolygonvalidiumetrog(
-		common.HexToAddress(cfg.PolygonValidiumAddress),
-		ethClient,
-	)
-	if err != nil {
-		return nil, err
+	var err error
+	for i, url := range e.endpoints {
+		var conn *ethConn
+		if conn, err = e.dial(ctx, url); err != nil {
+			log.Errorf("error connecting to %s: %+v", url, err)
+			continue
+		}
+
+		e.connection.Store(conn)
+		e.active.Store(int32(i))
+		e.connected.Store(true)
+		activeEndpoint.Set(float64(i))
+
+		break
 	}
 
-	dataCommittee, err := polygondatacommittee.NewPolygondatacommittee(
-		common.HexToAddress(cfg.DataCommitteeAddress),
-		ethClient,
-	)
 	if err != nil {
 		return nil, err
 	}
 
-	return &etherman{
-		EthClient:     ethClient,
-		CDKValidium:   cdkValidium,
-		DataCommittee: dataCommittee,
-	}, nil
+	if cfg.HealthCheckInterval.Duration > 0 {
+		go e.monitorConnection(ctx, cfg.HealthCheckInterval.Duration)
+	}
+
+	return e, nil
+}
+
+// conn returns the current connection to L1
+func (e *etherman) conn() *ethConn {
+	return e.connection.Load()
 }
 
 // GetTx function get ethereum tx
 func (e *etherman) GetTx(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
-	return e.EthClient.TransactionByHash(ctx, txHash)
+	var isPending bool
+
+	tx, err := withFailover(ctx, e, func(conn *ethConn) (*types.Transaction, error) {
+		tx, pending, err := conn.EthClient.TransactionByHash(ctx, txHash)
+		isPending = pending
+
+		return tx, err
+	})
+
+	return tx, isPending, err
 }
 
 // HeaderByNumber returns header by number from the eth client
 func (e *etherman) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	return e.EthClient.HeaderByNumber(ctx, number)
+	return withFailover(ctx, e, func(conn *ethConn) (*types.Header, error) {
+		return conn.EthClient.HeaderByNumber(ctx, number)
+	})
 }
 
 // BlockByNumber returns a block by the given number
 func (e *etherman) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
-	return e.EthClient.BlockByNumber(ctx, number)
+	return withFailover(ctx, e, func(conn *ethConn) (*types.Block, error) {
+		return conn.EthClient.BlockByNumber(ctx, number)
+	})
 }
 
 // CodeAt returns the contract code of the given account.
 func (e *etherman) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
-	return e.EthClient.CodeAt(ctx, account, blockNumber)
+	return withFailover(ctx, e, func(conn *ethConn) ([]byte, error) {
+		return conn.EthClient.CodeAt(ctx, account, blockNumber)
+	})
 }
 
 // TrustedSequencer gets trusted sequencer address
 func (e *etherman) TrustedSequencer(ctx context.Context) (common.Address, error) {
-	return e.CDKValidium.TrustedSequencer(&bind.CallOpts{
-		Context: ctx,
-		Pending: false,
+	return withFailover(ctx, e, func(conn *ethConn) (common.Address, error) {
+		return conn.CDKValidium.TrustedSequencer(&bind.CallOpts{
+			Context: ctx,
+			Pending: false,
+		})
 	})
 }
 
@@ -128,14 +209,18 @@ func (e *etherman) WatchSetTrustedSequencer(
 	ctx context.Context,
 	events chan *polygonvalidiumetrog.PolygonvalidiumetrogSetTrustedSequencer,
 ) (event.Subscription, error) {
-	return e.CDKValidium.WatchSetTrustedSequencer(&bind.WatchOpts{Context: ctx}, events)
+	return withFailover(ctx, e, func(conn *ethConn) (event.Subscription, error) {
+		return conn.CDKValidium.WatchSetTrustedSequencer(&bind.WatchOpts{Context: ctx}, events)
+	})
 }
 
 // TrustedSequencerURL gets trusted sequencer's RPC url
 func (e *etherman) TrustedSequencerURL(ctx context.Context) (string, error) {
-	return e.CDKValidium.TrustedSequencerURL(&bind.CallOpts{
-		Context: ctx,
-		Pending: false,
+	return withFailover(ctx, e, func(conn *ethConn) (string, error) {
+		return conn.CDKValidium.TrustedSequencerURL(&bind.CallOpts{
+			Context: ctx,
+			Pending: false,
+		})
 	})
 }
 
@@ -144,50 +229,218 @@ func (e *etherman) WatchSetTrustedSequencerURL(
 	ctx context.Context,
 	events chan *polygonvalidiumetrog.PolygonvalidiumetrogSetTrustedSequencerURL,
 ) (event.Subscription, error) {
-	return e.CDKValidium.WatchSetTrustedSequencerURL(&bind.WatchOpts{Context: ctx}, events)
+	return withFailover(ctx, e, func(conn *ethConn) (event.Subscription, error) {
+		return conn.CDKValidium.WatchSetTrustedSequencerURL(&bind.WatchOpts{Context: ctx}, events)
+	})
 }
 
 // FilterSequenceBatches retrieves filtered batches on CDK validium
 func (e *etherman) FilterSequenceBatches(opts *bind.FilterOpts,
 	numBatch []uint64) (*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatchesIterator, error) {
-	return e.CDKValidium.FilterSequenceBatches(opts, numBatch)
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return withFailover(ctx, e,
+		func(conn *ethConn) (*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatchesIterator, error) {
+			return conn.CDKValidium.FilterSequenceBatches(opts, numBatch)
+		},
+	)
+}
+
+// FilterSequenceBatchesByNumbers returns the SequenceBatches events of the sequences ending at any of the given
+// batch numbers, sorted by block. Scattered numbers are matched together, so it takes a single L1 logs query
+// per maxFilterBatchNums distinct numbers instead of one per number
+func (e *etherman) FilterSequenceBatchesByNumbers(
+	opts *bind.FilterOpts,
+	nums []uint64,
+) ([]*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error) {
+	nums = uniqueBatchNums(nums)
+
+	var events []*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches
+	for start := 0; start < len(nums); start += maxFilterBatchNums {
+		end := start + maxFilterBatchNums
+		if end > len(nums) {
+			end = len(nums)
+		}
+
+		iter, err := e.FilterSequenceBatches(opts, nums[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for iter.Next() {
+			events = append(events, iter.Event)
+		}
+
+		err = iter.Error()
+		iter.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Raw.BlockNumber != events[j].Raw.BlockNumber {
+			return events[i].Raw.BlockNumber < events[j].Raw.BlockNumber
+		}
+
+		return events[i].Raw.Index < events[j].Raw.Index
+	})
+
+	return events, nil
+}
+
+// FilterVerifyBatches returns the VerifyBatches events emitted when the batches of the validium are verified
+// on L1, sorted by block. The numBatch of an event is the last verified batch
+func (e *etherman) FilterVerifyBatches(
+	opts *bind.FilterOpts,
+) ([]*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return withFailover(ctx, e,
+		func(conn *ethConn) ([]*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches, error) {
+			iter, err := conn.CDKValidium.FilterVerifyBatches(opts, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			defer iter.Close()
+
+			var events []*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches
+			for iter.Next() {
+				events = append(events, iter.Event)
+			}
+
+			if err = iter.Error(); err != nil {
+				return nil, err
+			}
+
+			sort.Slice(events, func(i, j int) bool {
+				if events[i].Raw.BlockNumber != events[j].Raw.BlockNumber {
+					return events[i].Raw.BlockNumber < events[j].Raw.BlockNumber
+				}
+
+				return events[i].Raw.Index < events[j].Raw.Index
+			})
+
+			return events, nil
+		},
+	)
+}
+
+// uniqueBatchNums returns the sorted distinct batch numbers of the given ones
+func uniqueBatchNums(nums []uint64) []uint64 {
+	sorted := make([]uint64, len(nums))
+	copy(sorted, nums)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	unique := sorted[:0]
+	for _, num := range sorted {
+		if len(unique) == 0 || num != unique[len(unique)-1] {
+			unique = append(unique, num)
+		}
+	}
+
+	return unique
+}
+
+// FilterSequenceBatchesBanana returns the SequenceBatches events emitted by the Banana fork
+// from the given start block for the given last batch numbers of the sequences
+func (e *etherman) FilterSequenceBatchesBanana(
+	ctx context.Context,
+	startBlock uint64,
+	numBatch []uint64,
+) ([]*bananaValidium.PolygonvalidiumetrogSequenceBatches, error) {
+	return withFailover(ctx, e, func(conn *ethConn) ([]*bananaValidium.PolygonvalidiumetrogSequenceBatches, error) {
+		iter, err := conn.CDKValidiumBanana.FilterSequenceBatches(&bind.FilterOpts{
+			Context: ctx,
+			Start:   startBlock,
+		}, numBatch)
+		if err != nil {
+			return nil, err
+		}
+
+		defer iter.Close()
+
+		var events []*bananaValidium.PolygonvalidiumetrogSequenceBatches
+		for iter.Next() {
+			events = append(events, iter.Event)
+		}
+
+		return events, iter.Error()
+	})
 }
 
 // GetCurrentDataCommittee return the currently registered data committee
 func (e *etherman) GetCurrentDataCommittee() (*DataCommittee, error) {
-	addrsHash, err := e.DataCommittee.CommitteeHash(&bind.CallOpts{Pending: false})
+	return withFailover(context.Background(), e, func(conn *ethConn) (*DataCommittee, error) {
+		return getDataCommittee(conn, &bind.CallOpts{Pending: false})
+	})
+}
+
+// GetDataCommitteeAtBlock returns the data committee that was registered as of the given L1 block.
+// It reads the historical state of the contract, so the L1 node must keep the state of that block (archive node)
+func (e *etherman) GetDataCommitteeAtBlock(ctx context.Context, blockNumber uint64) (*DataCommittee, error) {
+	return withFailover(ctx, e, func(conn *ethConn) (*DataCommittee, error) {
+		return getDataCommittee(conn, &bind.CallOpts{
+			Pending:     false,
+			BlockNumber: new(big.Int).SetUint64(blockNumber),
+			Context:     ctx,
+		})
+	})
+}
+
+// GetCurrentDataCommitteeMembers return the currently registered data committee members
+func (e *etherman) GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, error) {
+	return withFailover(context.Background(), e, func(conn *ethConn) ([]DataCommitteeMember, error) {
+		return getDataCommitteeMembers(conn, &bind.CallOpts{Pending: false})
+	})
+}
+
+// getDataCommittee returns the data committee registered as of the given call options
+func getDataCommittee(conn *ethConn, opts *bind.CallOpts) (*DataCommittee, error) {
+	addrsHash, err := conn.DataCommittee.CommitteeHash(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error getting CommitteeHash from L1 SC: %w", err)
 	}
 
-	reqSign, err := e.DataCommittee.RequiredAmountOfSignatures(&bind.CallOpts{Pending: false})
+	reqSign, err := conn.DataCommittee.RequiredAmountOfSignatures(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error getting RequiredAmountOfSignatures from L1 SC: %w", err)
 	}
 
-	members, err := e.GetCurrentDataCommitteeMembers()
+	members, err := getDataCommitteeMembers(conn, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DataCommittee{
-		AddressesHash:      addrsHash,
-		RequiredSignatures: reqSign.Uint64(),
-		Members:            members,
+		AddressesHash:              addrsHash,
+		RequiredAmountOfSignatures: reqSign.Uint64(),
+		Members:                    members,
 	}, nil
 }
 
-// GetCurrentDataCommitteeMembers return the currently registered data committee members
-func (e *etherman) GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, error) {
+// getDataCommitteeMembers returns the data committee members registered as of the given call options
+func getDataCommitteeMembers(conn *ethConn, opts *bind.CallOpts) ([]DataCommitteeMember, error) {
 	members := []DataCommitteeMember{}
 
-	nMembers, err := e.DataCommittee.GetAmountOfMembers(&bind.CallOpts{Pending: false})
+	nMembers, err := conn.DataCommittee.GetAmountOfMembers(opts)
 	if err != nil {
 		return nil, fmt.Errorf("error getting GetAmountOfMembers from L1 SC: %w", err)
 	}
 
 	for i := int64(0); i < nMembers.Int64(); i++ {
-		member, err := e.DataCommittee.Members(&bind.CallOpts{Pending: false}, big.NewInt(i))
+		member, err := conn.DataCommittee.Members(opts, big.NewInt(i))
 		if err != nil {
 			return nil, fmt.Errorf("error getting Members %d from L1 SC: %w", i, err)
 		}
@@ -200,3 +453,24 @@ func (e *etherman) GetCurrentDataCommitteeMembers() ([]DataCommitteeMember, erro
 
 	return members, nil
 }
+
+// GetRequiredSignatures returns the amount of signatures required by the currently registered data committee
+func (e *etherman) GetRequiredSignatures() (uint64, error) {
+	return withFailover(context.Background(), e, func(conn *ethConn) (uint64, error) {
+		reqSign, err := conn.DataCommittee.RequiredAmountOfSignatures(&bind.CallOpts{Pending: false})
+		if err != nil {
+			return 0, fmt.Errorf("error getting RequiredAmountOfSignatures from L1 SC: %w", err)
+		}
+
+		nMembers, err := conn.DataCommittee.GetAmountOfMembers(&bind.CallOpts{Pending: false})
+		if err != nil {
+			return 0, fmt.Errorf("error getting GetAmountOfMembers from L1 SC: %w", err)
+		}
+
+		if err = validateSignatureThreshold(reqSign.Uint64(), nMembers.Uint64()); err != nil {
+			return 0, err
+		}
+
+		return reqSign.Uint64(), nil
+	})
+}
@@ -0,0 +1,165 @@
+package etherman
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/etrog/polygonvalidiumetrog"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataCommittee_RequiredSignatures(t *testing.T) {
+	t.Parallel()
+
+	members := []DataCommitteeMember{
+		{Addr: common.HexToAddress("0x1"), URL: "http://member1"},
+		{Addr: common.HexToAddress("0x2"), URL: "http://member2"},
+	}
+
+	tests := []struct {
+		name     string
+		required uint64
+		err      error
+	}{
+		{
+			name:     "threshold below committee size",
+			required: 1,
+		},
+		{
+			name:     "threshold equal to committee size",
+			required: 2,
+		},
+		{
+			name:     "threshold above committee size",
+			required: 3,
+			err:      ErrInvalidSignatureThreshold,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			committee := &DataCommittee{
+				Members:                    members,
+				RequiredAmountOfSignatures: tt.required,
+			}
+
+			required, err := committee.RequiredSignatures()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.required, required)
+			}
+		})
+	}
+}
+
+func TestEtherman_FilterSequenceBatchesByNumbers(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.L1Config{
+		Timeout: types.Duration{Duration: time.Second},
+	}
+
+	manyNums := make([]uint64, maxFilterBatchNums+1)
+	for i := range manyNums {
+		manyNums[i] = uint64(i + 1)
+	}
+
+	tests := []struct {
+		name    string
+		nums    []uint64
+		queries int32
+	}{
+		{
+			name: "no batch numbers",
+		},
+		{
+			name:    "scattered batch numbers in a single query",
+			nums:    []uint64{90, 3, 17, 3, 1200},
+			queries: 1,
+		},
+		{
+			name:    "batch numbers split in queries",
+			nums:    manyNums,
+			queries: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			factory := &fakeEthClientFactory{eth: &ethService{}}
+			em, err := NewWithFactory(context.Background(), cfg, factory)
+			require.NoError(t, err)
+
+			events, err := em.FilterSequenceBatchesByNumbers(&bind.FilterOpts{Context: context.Background()}, tt.nums)
+			require.NoError(t, err)
+			require.Empty(t, events)
+			require.Equal(t, tt.queries, factory.eth.logQueries.Load())
+		})
+	}
+}
+
+func TestEtherman_FilterVerifyBatches(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.L1Config{
+		Timeout: types.Duration{Duration: time.Second},
+	}
+
+	contractABI, err := polygonvalidiumetrog.PolygonvalidiumetrogMetaData.GetAbi()
+	require.NoError(t, err)
+
+	verifyBatchesLog := func(block uint64, index uint, numBatch uint64) gethTypes.Log {
+		return gethTypes.Log{
+			Topics: []common.Hash{
+				contractABI.Events["VerifyBatches"].ID,
+				common.BigToHash(new(big.Int).SetUint64(numBatch)),
+				common.BytesToHash(common.HexToAddress("0x1").Bytes()),
+			},
+			Data:        common.HexToHash("0x2").Bytes(),
+			BlockNumber: block,
+			Index:       index,
+		}
+	}
+
+	factory := &fakeEthClientFactory{eth: &ethService{logs: []gethTypes.Log{
+		verifyBatchesLog(20, 0, 9),
+		verifyBatchesLog(10, 1, 5),
+		verifyBatchesLog(10, 0, 3),
+	}}}
+
+	em, err := NewWithFactory(context.Background(), cfg, factory)
+	require.NoError(t, err)
+
+	events, err := em.FilterVerifyBatches(&bind.FilterOpts{Context: context.Background()})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	for i, numBatch := range []uint64{3, 5, 9} {
+		require.Equal(t, numBatch, events[i].NumBatch)
+		require.Equal(t, common.HexToAddress("0x1"), events[i].Aggregator)
+	}
+}
+
+func Test_uniqueBatchNums(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, uniqueBatchNums(nil))
+	require.Equal(t, []uint64{1, 2, 5, 9}, uniqueBatchNums([]uint64{9, 2, 2, 1, 5, 9, 1}))
+}
@@ -0,0 +1,19 @@
+package etherman
+
+import (
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsSubsystem = "etherman"
+
+var activeEndpoint = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: metricsSubsystem,
+	Name:      "active_endpoint",
+	Help:      "Index of the L1 endpoint in use, 0 being RpcURL and the next ones the FallbackRpcURLs in order",
+})
+
+func init() {
+	metrics.Register(activeEndpoint)
+}
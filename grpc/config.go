@@ -0,0 +1,19 @@
+package grpc
+
+// Config represents the configuration of the gRPC server
+type Config struct {
+	// Enabled defines if the gRPC server should be started alongside the JSON-RPC one
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host defines the network adapter that will be used to serve the gRPC requests
+	Host string `mapstructure:"Host"`
+
+	// Port defines the port to serve the gRPC requests
+	Port int `mapstructure:"Port"`
+
+	// IntegrityScanPageSize is the number of values read and checked at once by VerifyIntegrity
+	IntegrityScanPageSize uint `mapstructure:"IntegrityScanPageSize"`
+
+	// MaxIntegrityScanBatches is the maximum number of batches that can be scanned in a VerifyIntegrity call
+	MaxIntegrityScanBatches uint64 `mapstructure:"MaxIntegrityScanBatches"`
+}
@@ -0,0 +1,1023 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: dataavailability/v1/dataavailability.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OffChainData is a value stored off chain, keyed by its keccak256 hash
+type OffChainData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value    []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	BatchNum uint64 `protobuf:"varint,3,opt,name=batch_num,json=batchNum,proto3" json:"batch_num,omitempty"`
+}
+
+func (x *OffChainData) Reset() {
+	*x = OffChainData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OffChainData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OffChainData) ProtoMessage() {}
+
+func (x *OffChainData) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OffChainData.ProtoReflect.Descriptor instead.
+func (*OffChainData) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OffChainData) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *OffChainData) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *OffChainData) GetBatchNum() uint64 {
+	if x != nil {
+		return x.BatchNum
+	}
+	return 0
+}
+
+type GetOffChainDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key is the 32 bytes hash of the value
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *GetOffChainDataRequest) Reset() {
+	*x = GetOffChainDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOffChainDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOffChainDataRequest) ProtoMessage() {}
+
+func (x *GetOffChainDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOffChainDataRequest.ProtoReflect.Descriptor instead.
+func (*GetOffChainDataRequest) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetOffChainDataRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type GetOffChainDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GetOffChainDataResponse) Reset() {
+	*x = GetOffChainDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOffChainDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOffChainDataResponse) ProtoMessage() {}
+
+func (x *GetOffChainDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOffChainDataResponse.ProtoReflect.Descriptor instead.
+func (*GetOffChainDataResponse) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetOffChainDataResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ListOffChainDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// keys are the 32 bytes hashes of the values
+	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *ListOffChainDataRequest) Reset() {
+	*x = ListOffChainDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOffChainDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOffChainDataRequest) ProtoMessage() {}
+
+func (x *ListOffChainDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOffChainDataRequest.ProtoReflect.Descriptor instead.
+func (*ListOffChainDataRequest) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListOffChainDataRequest) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type ListOffChainDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []*OffChainData `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ListOffChainDataResponse) Reset() {
+	*x = ListOffChainDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListOffChainDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOffChainDataResponse) ProtoMessage() {}
+
+func (x *ListOffChainDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOffChainDataResponse.ProtoReflect.Descriptor instead.
+func (*ListOffChainDataResponse) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListOffChainDataResponse) GetData() []*OffChainData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type StreamBatchesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromBatch uint64 `protobuf:"varint,1,opt,name=from_batch,json=fromBatch,proto3" json:"from_batch,omitempty"`
+	ToBatch   uint64 `protobuf:"varint,2,opt,name=to_batch,json=toBatch,proto3" json:"to_batch,omitempty"`
+}
+
+func (x *StreamBatchesRequest) Reset() {
+	*x = StreamBatchesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamBatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBatchesRequest) ProtoMessage() {}
+
+func (x *StreamBatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBatchesRequest.ProtoReflect.Descriptor instead.
+func (*StreamBatchesRequest) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamBatchesRequest) GetFromBatch() uint64 {
+	if x != nil {
+		return x.FromBatch
+	}
+	return 0
+}
+
+func (x *StreamBatchesRequest) GetToBatch() uint64 {
+	if x != nil {
+		return x.ToBatch
+	}
+	return 0
+}
+
+// Batch is the offchain data of a single batch
+type Batch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchNum uint64          `protobuf:"varint,1,opt,name=batch_num,json=batchNum,proto3" json:"batch_num,omitempty"`
+	Data     []*OffChainData `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Batch) Reset() {
+	*x = Batch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Batch) ProtoMessage() {}
+
+func (x *Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Batch.ProtoReflect.Descriptor instead.
+func (*Batch) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Batch) GetBatchNum() uint64 {
+	if x != nil {
+		return x.BatchNum
+	}
+	return 0
+}
+
+func (x *Batch) GetData() []*OffChainData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetStorageStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStorageStatsRequest) Reset() {
+	*x = GetStorageStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStorageStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageStatsRequest) ProtoMessage() {}
+
+func (x *GetStorageStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStorageStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStorageStatsRequest) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{7}
+}
+
+type StorageStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyCount uint64 `protobuf:"varint,1,opt,name=key_count,json=keyCount,proto3" json:"key_count,omitempty"`
+	// total_bytes is the size of the values with a known batch number,
+	// not accounting for the ones kept in an object store
+	TotalBytes  uint64 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	MinBatchNum uint64 `protobuf:"varint,3,opt,name=min_batch_num,json=minBatchNum,proto3" json:"min_batch_num,omitempty"`
+	MaxBatchNum uint64 `protobuf:"varint,4,opt,name=max_batch_num,json=maxBatchNum,proto3" json:"max_batch_num,omitempty"`
+}
+
+func (x *StorageStats) Reset() {
+	*x = StorageStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageStats) ProtoMessage() {}
+
+func (x *StorageStats) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageStats.ProtoReflect.Descriptor instead.
+func (*StorageStats) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StorageStats) GetKeyCount() uint64 {
+	if x != nil {
+		return x.KeyCount
+	}
+	return 0
+}
+
+func (x *StorageStats) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *StorageStats) GetMinBatchNum() uint64 {
+	if x != nil {
+		return x.MinBatchNum
+	}
+	return 0
+}
+
+func (x *StorageStats) GetMaxBatchNum() uint64 {
+	if x != nil {
+		return x.MaxBatchNum
+	}
+	return 0
+}
+
+type VerifyIntegrityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromBatch uint64 `protobuf:"varint,1,opt,name=from_batch,json=fromBatch,proto3" json:"from_batch,omitempty"`
+	ToBatch   uint64 `protobuf:"varint,2,opt,name=to_batch,json=toBatch,proto3" json:"to_batch,omitempty"`
+}
+
+func (x *VerifyIntegrityRequest) Reset() {
+	*x = VerifyIntegrityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyIntegrityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyIntegrityRequest) ProtoMessage() {}
+
+func (x *VerifyIntegrityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyIntegrityRequest.ProtoReflect.Descriptor instead.
+func (*VerifyIntegrityRequest) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyIntegrityRequest) GetFromBatch() uint64 {
+	if x != nil {
+		return x.FromBatch
+	}
+	return 0
+}
+
+func (x *VerifyIntegrityRequest) GetToBatch() uint64 {
+	if x != nil {
+		return x.ToBatch
+	}
+	return 0
+}
+
+// IntegrityMismatch is a stored value that does not hash to its key
+type IntegrityMismatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	BatchNum uint64 `protobuf:"varint,2,opt,name=batch_num,json=batchNum,proto3" json:"batch_num,omitempty"`
+	// computed is the keccak256 hash of the stored value
+	Computed []byte `protobuf:"bytes,3,opt,name=computed,proto3" json:"computed,omitempty"`
+}
+
+func (x *IntegrityMismatch) Reset() {
+	*x = IntegrityMismatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegrityMismatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityMismatch) ProtoMessage() {}
+
+func (x *IntegrityMismatch) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityMismatch.ProtoReflect.Descriptor instead.
+func (*IntegrityMismatch) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IntegrityMismatch) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *IntegrityMismatch) GetBatchNum() uint64 {
+	if x != nil {
+		return x.BatchNum
+	}
+	return 0
+}
+
+func (x *IntegrityMismatch) GetComputed() []byte {
+	if x != nil {
+		return x.Computed
+	}
+	return nil
+}
+
+// IntegrityProgress reports the progress of an integrity scan
+type IntegrityProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// scanned_keys and mismatched_keys are counted from the start of the scan
+	ScannedKeys    uint64 `protobuf:"varint,1,opt,name=scanned_keys,json=scannedKeys,proto3" json:"scanned_keys,omitempty"`
+	MismatchedKeys uint64 `protobuf:"varint,2,opt,name=mismatched_keys,json=mismatchedKeys,proto3" json:"mismatched_keys,omitempty"`
+	// mismatches are the ones found since the previous progress report
+	Mismatches []*IntegrityMismatch `protobuf:"bytes,3,rep,name=mismatches,proto3" json:"mismatches,omitempty"`
+	// complete is set on the last progress report, once the whole range is scanned
+	Complete bool `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+}
+
+func (x *IntegrityProgress) Reset() {
+	*x = IntegrityProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegrityProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrityProgress) ProtoMessage() {}
+
+func (x *IntegrityProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_dataavailability_v1_dataavailability_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrityProgress.ProtoReflect.Descriptor instead.
+func (*IntegrityProgress) Descriptor() ([]byte, []int) {
+	return file_dataavailability_v1_dataavailability_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *IntegrityProgress) GetScannedKeys() uint64 {
+	if x != nil {
+		return x.ScannedKeys
+	}
+	return 0
+}
+
+func (x *IntegrityProgress) GetMismatchedKeys() uint64 {
+	if x != nil {
+		return x.MismatchedKeys
+	}
+	return 0
+}
+
+func (x *IntegrityProgress) GetMismatches() []*IntegrityMismatch {
+	if x != nil {
+		return x.Mismatches
+	}
+	return nil
+}
+
+func (x *IntegrityProgress) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+var File_dataavailability_v1_dataavailability_proto protoreflect.FileDescriptor
+
+var file_dataavailability_v1_dataavailability_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x64, 0x61,
+	0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76,
+	0x31, 0x22, 0x53, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x4e, 0x75, 0x6d, 0x22, 0x2a, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4f, 0x66, 0x66,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x22, 0x2f, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x2d, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x66, 0x66, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b, 0x65,
+	0x79, 0x73, 0x22, 0x51, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x50, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x08,
+	0x74, 0x6f, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x74, 0x6f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x22, 0x5b, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x75, 0x6d, 0x12, 0x35, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x18, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x94,
+	0x01, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a,
+	0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x75,
+	0x6d, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6e,
+	0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x4e, 0x75, 0x6d, 0x22, 0x52, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x49,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19,
+	0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x74, 0x6f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x22, 0x5e, 0x0a, 0x11, 0x49, 0x6e, 0x74,
+	0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x75, 0x6d, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x08, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x22, 0xc3, 0x01, 0x0a, 0x11, 0x49, 0x6e,
+	0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x63, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x4b, 0x65,
+	0x79, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x69, 0x73,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x46, 0x0a, 0x0a, 0x6d,
+	0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x4d,
+	0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x32,
+	0x98, 0x04, 0x0a, 0x10, 0x44, 0x61, 0x74, 0x61, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x6c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x66,
+	0x66, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x6f, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4f, 0x66, 0x66, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f,
+	0x66, 0x66, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x12, 0x29, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x12, 0x61, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x2b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x68, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72,
+	0x69, 0x74, 0x79, 0x12, 0x2b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x30, 0x78, 0x50, 0x6f, 0x6c, 0x79, 0x67,
+	0x6f, 0x6e, 0x2f, 0x63, 0x64, 0x6b, 0x2d, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x61, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_dataavailability_v1_dataavailability_proto_rawDescOnce sync.Once
+	file_dataavailability_v1_dataavailability_proto_rawDescData = file_dataavailability_v1_dataavailability_proto_rawDesc
+)
+
+func file_dataavailability_v1_dataavailability_proto_rawDescGZIP() []byte {
+	file_dataavailability_v1_dataavailability_proto_rawDescOnce.Do(func() {
+		file_dataavailability_v1_dataavailability_proto_rawDescData = protoimpl.X.CompressGZIP(file_dataavailability_v1_dataavailability_proto_rawDescData)
+	})
+	return file_dataavailability_v1_dataavailability_proto_rawDescData
+}
+
+var file_dataavailability_v1_dataavailability_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_dataavailability_v1_dataavailability_proto_goTypes = []any{
+	(*OffChainData)(nil),             // 0: dataavailability.v1.OffChainData
+	(*GetOffChainDataRequest)(nil),   // 1: dataavailability.v1.GetOffChainDataRequest
+	(*GetOffChainDataResponse)(nil),  // 2: dataavailability.v1.GetOffChainDataResponse
+	(*ListOffChainDataRequest)(nil),  // 3: dataavailability.v1.ListOffChainDataRequest
+	(*ListOffChainDataResponse)(nil), // 4: dataavailability.v1.ListOffChainDataResponse
+	(*StreamBatchesRequest)(nil),     // 5: dataavailability.v1.StreamBatchesRequest
+	(*Batch)(nil),                    // 6: dataavailability.v1.Batch
+	(*GetStorageStatsRequest)(nil),   // 7: dataavailability.v1.GetStorageStatsRequest
+	(*StorageStats)(nil),             // 8: dataavailability.v1.StorageStats
+	(*VerifyIntegrityRequest)(nil),   // 9: dataavailability.v1.VerifyIntegrityRequest
+	(*IntegrityMismatch)(nil),        // 10: dataavailability.v1.IntegrityMismatch
+	(*IntegrityProgress)(nil),        // 11: dataavailability.v1.IntegrityProgress
+}
+var file_dataavailability_v1_dataavailability_proto_depIdxs = []int32{
+	0,  // 0: dataavailability.v1.ListOffChainDataResponse.data:type_name -> dataavailability.v1.OffChainData
+	0,  // 1: dataavailability.v1.Batch.data:type_name -> dataavailability.v1.OffChainData
+	10, // 2: dataavailability.v1.IntegrityProgress.mismatches:type_name -> dataavailability.v1.IntegrityMismatch
+	1,  // 3: dataavailability.v1.DataAvailability.GetOffChainData:input_type -> dataavailability.v1.GetOffChainDataRequest
+	3,  // 4: dataavailability.v1.DataAvailability.ListOffChainData:input_type -> dataavailability.v1.ListOffChainDataRequest
+	5,  // 5: dataavailability.v1.DataAvailability.StreamBatches:input_type -> dataavailability.v1.StreamBatchesRequest
+	7,  // 6: dataavailability.v1.DataAvailability.GetStorageStats:input_type -> dataavailability.v1.GetStorageStatsRequest
+	9,  // 7: dataavailability.v1.DataAvailability.VerifyIntegrity:input_type -> dataavailability.v1.VerifyIntegrityRequest
+	2,  // 8: dataavailability.v1.DataAvailability.GetOffChainData:output_type -> dataavailability.v1.GetOffChainDataResponse
+	4,  // 9: dataavailability.v1.DataAvailability.ListOffChainData:output_type -> dataavailability.v1.ListOffChainDataResponse
+	6,  // 10: dataavailability.v1.DataAvailability.StreamBatches:output_type -> dataavailability.v1.Batch
+	8,  // 11: dataavailability.v1.DataAvailability.GetStorageStats:output_type -> dataavailability.v1.StorageStats
+	11, // 12: dataavailability.v1.DataAvailability.VerifyIntegrity:output_type -> dataavailability.v1.IntegrityProgress
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_dataavailability_v1_dataavailability_proto_init() }
+func file_dataavailability_v1_dataavailability_proto_init() {
+	if File_dataavailability_v1_dataavailability_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_dataavailability_v1_dataavailability_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*OffChainData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetOffChainDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetOffChainDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListOffChainDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ListOffChainDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamBatchesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Batch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStorageStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*StorageStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*VerifyIntegrityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*IntegrityMismatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dataavailability_v1_dataavailability_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*IntegrityProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_dataavailability_v1_dataavailability_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dataavailability_v1_dataavailability_proto_goTypes,
+		DependencyIndexes: file_dataavailability_v1_dataavailability_proto_depIdxs,
+		MessageInfos:      file_dataavailability_v1_dataavailability_proto_msgTypes,
+	}.Build()
+	File_dataavailability_v1_dataavailability_proto = out.File
+	file_dataavailability_v1_dataavailability_proto_rawDesc = nil
+	file_dataavailability_v1_dataavailability_proto_goTypes = nil
+	file_dataavailability_v1_dataavailability_proto_depIdxs = nil
+}
@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: dataavailability/v1/dataavailability.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DataAvailability_GetOffChainData_FullMethodName  = "/dataavailability.v1.DataAvailability/GetOffChainData"
+	DataAvailability_ListOffChainData_FullMethodName = "/dataavailability.v1.DataAvailability/ListOffChainData"
+	DataAvailability_StreamBatches_FullMethodName    = "/dataavailability.v1.DataAvailability/StreamBatches"
+	DataAvailability_GetStorageStats_FullMethodName  = "/dataavailability.v1.DataAvailability/GetStorageStats"
+	DataAvailability_VerifyIntegrity_FullMethodName  = "/dataavailability.v1.DataAvailability/VerifyIntegrity"
+)
+
+// DataAvailabilityClient is the client API for DataAvailability service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DataAvailability serves the offchain data stored by the data node.
+// It mirrors the read endpoints of the sync JSON-RPC namespace
+type DataAvailabilityClient interface {
+	// GetOffChainData returns the value stored for the given key
+	GetOffChainData(ctx context.Context, in *GetOffChainDataRequest, opts ...grpc.CallOption) (*GetOffChainDataResponse, error)
+	// ListOffChainData returns the values stored for the given keys. Keys that are not stored are left out
+	ListOffChainData(ctx context.Context, in *ListOffChainDataRequest, opts ...grpc.CallOption) (*ListOffChainDataResponse, error)
+	// StreamBatches streams the offchain data of the stored batches in the given inclusive range,
+	// ordered by batch number
+	StreamBatches(ctx context.Context, in *StreamBatchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Batch], error)
+	// GetStorageStats returns the number of stored values, the stored batch range and the total size
+	// of the values stored for it
+	GetStorageStats(ctx context.Context, in *GetStorageStatsRequest, opts ...grpc.CallOption) (*StorageStats, error)
+	// VerifyIntegrity checks that the values stored for the batches in the given inclusive range hash to their keys.
+	// The values are scanned in pages ordered by key, and the progress of the scan is streamed after every page
+	VerifyIntegrity(ctx context.Context, in *VerifyIntegrityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IntegrityProgress], error)
+}
+
+type dataAvailabilityClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDataAvailabilityClient(cc grpc.ClientConnInterface) DataAvailabilityClient {
+	return &dataAvailabilityClient{cc}
+}
+
+func (c *dataAvailabilityClient) GetOffChainData(ctx context.Context, in *GetOffChainDataRequest, opts ...grpc.CallOption) (*GetOffChainDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOffChainDataResponse)
+	err := c.cc.Invoke(ctx, DataAvailability_GetOffChainData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAvailabilityClient) ListOffChainData(ctx context.Context, in *ListOffChainDataRequest, opts ...grpc.CallOption) (*ListOffChainDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOffChainDataResponse)
+	err := c.cc.Invoke(ctx, DataAvailability_ListOffChainData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAvailabilityClient) StreamBatches(ctx context.Context, in *StreamBatchesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Batch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataAvailability_ServiceDesc.Streams[0], DataAvailability_StreamBatches_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamBatchesRequest, Batch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAvailability_StreamBatchesClient = grpc.ServerStreamingClient[Batch]
+
+func (c *dataAvailabilityClient) GetStorageStats(ctx context.Context, in *GetStorageStatsRequest, opts ...grpc.CallOption) (*StorageStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageStats)
+	err := c.cc.Invoke(ctx, DataAvailability_GetStorageStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataAvailabilityClient) VerifyIntegrity(ctx context.Context, in *VerifyIntegrityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IntegrityProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataAvailability_ServiceDesc.Streams[1], DataAvailability_VerifyIntegrity_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[VerifyIntegrityRequest, IntegrityProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAvailability_VerifyIntegrityClient = grpc.ServerStreamingClient[IntegrityProgress]
+
+// DataAvailabilityServer is the server API for DataAvailability service.
+// All implementations must embed UnimplementedDataAvailabilityServer
+// for forward compatibility.
+//
+// DataAvailability serves the offchain data stored by the data node.
+// It mirrors the read endpoints of the sync JSON-RPC namespace
+type DataAvailabilityServer interface {
+	// GetOffChainData returns the value stored for the given key
+	GetOffChainData(context.Context, *GetOffChainDataRequest) (*GetOffChainDataResponse, error)
+	// ListOffChainData returns the values stored for the given keys. Keys that are not stored are left out
+	ListOffChainData(context.Context, *ListOffChainDataRequest) (*ListOffChainDataResponse, error)
+	// StreamBatches streams the offchain data of the stored batches in the given inclusive range,
+	// ordered by batch number
+	StreamBatches(*StreamBatchesRequest, grpc.ServerStreamingServer[Batch]) error
+	// GetStorageStats returns the number of stored values, the stored batch range and the total size
+	// of the values stored for it
+	GetStorageStats(context.Context, *GetStorageStatsRequest) (*StorageStats, error)
+	// VerifyIntegrity checks that the values stored for the batches in the given inclusive range hash to their keys.
+	// The values are scanned in pages ordered by key, and the progress of the scan is streamed after every page
+	VerifyIntegrity(*VerifyIntegrityRequest, grpc.ServerStreamingServer[IntegrityProgress]) error
+	mustEmbedUnimplementedDataAvailabilityServer()
+}
+
+// UnimplementedDataAvailabilityServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDataAvailabilityServer struct{}
+
+func (UnimplementedDataAvailabilityServer) GetOffChainData(context.Context, *GetOffChainDataRequest) (*GetOffChainDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOffChainData not implemented")
+}
+func (UnimplementedDataAvailabilityServer) ListOffChainData(context.Context, *ListOffChainDataRequest) (*ListOffChainDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOffChainData not implemented")
+}
+func (UnimplementedDataAvailabilityServer) StreamBatches(*StreamBatchesRequest, grpc.ServerStreamingServer[Batch]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBatches not implemented")
+}
+func (UnimplementedDataAvailabilityServer) GetStorageStats(context.Context, *GetStorageStatsRequest) (*StorageStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorageStats not implemented")
+}
+func (UnimplementedDataAvailabilityServer) VerifyIntegrity(*VerifyIntegrityRequest, grpc.ServerStreamingServer[IntegrityProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method VerifyIntegrity not implemented")
+}
+func (UnimplementedDataAvailabilityServer) mustEmbedUnimplementedDataAvailabilityServer() {}
+func (UnimplementedDataAvailabilityServer) testEmbeddedByValue()                          {}
+
+// UnsafeDataAvailabilityServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DataAvailabilityServer will
+// result in compilation errors.
+type UnsafeDataAvailabilityServer interface {
+	mustEmbedUnimplementedDataAvailabilityServer()
+}
+
+func RegisterDataAvailabilityServer(s grpc.ServiceRegistrar, srv DataAvailabilityServer) {
+	// If the following call pancis, it indicates UnimplementedDataAvailabilityServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DataAvailability_ServiceDesc, srv)
+}
+
+func _DataAvailability_GetOffChainData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOffChainDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAvailabilityServer).GetOffChainData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAvailability_GetOffChainData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAvailabilityServer).GetOffChainData(ctx, req.(*GetOffChainDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAvailability_ListOffChainData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOffChainDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAvailabilityServer).ListOffChainData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAvailability_ListOffChainData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAvailabilityServer).ListOffChainData(ctx, req.(*ListOffChainDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAvailability_StreamBatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBatchesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataAvailabilityServer).StreamBatches(m, &grpc.GenericServerStream[StreamBatchesRequest, Batch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAvailability_StreamBatchesServer = grpc.ServerStreamingServer[Batch]
+
+func _DataAvailability_GetStorageStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataAvailabilityServer).GetStorageStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataAvailability_GetStorageStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataAvailabilityServer).GetStorageStats(ctx, req.(*GetStorageStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataAvailability_VerifyIntegrity_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VerifyIntegrityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataAvailabilityServer).VerifyIntegrity(m, &grpc.GenericServerStream[VerifyIntegrityRequest, IntegrityProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataAvailability_VerifyIntegrityServer = grpc.ServerStreamingServer[IntegrityProgress]
+
+// DataAvailability_ServiceDesc is the grpc.ServiceDesc for DataAvailability service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DataAvailability_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dataavailability.v1.DataAvailability",
+	HandlerType: (*DataAvailabilityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOffChainData",
+			Handler:    _DataAvailability_GetOffChainData_Handler,
+		},
+		{
+			MethodName: "ListOffChainData",
+			Handler:    _DataAvailability_ListOffChainData_Handler,
+		},
+		{
+			MethodName: "GetStorageStats",
+			Handler:    _DataAvailability_GetStorageStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBatches",
+			Handler:       _DataAvailability_StreamBatches_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "VerifyIntegrity",
+			Handler:       _DataAvailability_VerifyIntegrity_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dataavailability/v1/dataavailability.proto",
+}
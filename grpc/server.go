@@ -0,0 +1,349 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/grpc/pb"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxListKeys is the maximum number of keys that can be requested in a ListOffChainData call,
+	// the same as in the sync JSON-RPC namespace
+	maxListKeys = 100
+
+	// maxStreamBatches is the maximum number of batches that can be requested in a StreamBatches call
+	maxStreamBatches = 10000
+
+	// streamPageSize is the number of values of a batch read from the database at once while streaming
+	streamPageSize = 100
+
+	// defaultIntegrityScanPageSize is the number of values checked at once by VerifyIntegrity when not configured
+	defaultIntegrityScanPageSize = 1000
+
+	// defaultMaxIntegrityScanBatches is the maximum number of batches scanned by VerifyIntegrity when not configured
+	defaultMaxIntegrityScanBatches = 100000
+
+	// integrityLogInterval is how often the progress of an integrity scan is logged
+	integrityLogInterval = 30 * time.Second
+)
+
+// Server serves the offchain data over gRPC
+type Server struct {
+	pb.UnimplementedDataAvailabilityServer
+
+	config Config
+	db     db.DB
+	srv    *gogrpc.Server
+}
+
+// NewServer returns the gRPC server of the offchain data stored in the given DB
+func NewServer(cfg Config, db db.DB) *Server {
+	if cfg.IntegrityScanPageSize == 0 {
+		cfg.IntegrityScanPageSize = defaultIntegrityScanPageSize
+	}
+
+	if cfg.MaxIntegrityScanBatches == 0 {
+		cfg.MaxIntegrityScanBatches = defaultMaxIntegrityScanBatches
+	}
+
+	s := &Server{
+		config: cfg,
+		db:     db,
+		srv:    gogrpc.NewServer(),
+	}
+
+	pb.RegisterDataAvailabilityServer(s.srv, s)
+
+	return s
+}
+
+// Start starts serving the gRPC requests. It blocks until the server is stopped
+func (s *Server) Start() error {
+	address := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Errorf("failed to create tcp listener for grpc: %v", err)
+		return err
+	}
+
+	return s.Serve(lis)
+}
+
+// Serve serves the gRPC requests accepted by the given listener. It blocks until the server is stopped
+func (s *Server) Serve(lis net.Listener) error {
+	log.Infof("grpc server started: %s", lis.Addr())
+	if err := s.srv.Serve(lis); err != nil {
+		log.Errorf("closed grpc connection: %v", err)
+		return err
+	}
+
+	log.Infof("grpc server stopped")
+	return nil
+}
+
+// Stop stops accepting requests and waits for the in-flight ones to finish, or cancels them
+// when the given context is done
+func (s *Server) Stop(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.srv.Stop()
+		return ctx.Err()
+	}
+}
+
+// GetOffChainData returns the value stored for the given key
+func (s *Server) GetOffChainData(
+	ctx context.Context, req *pb.GetOffChainDataRequest,
+) (*pb.GetOffChainDataResponse, error) {
+	key, err := toHash(req.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.db.GetOffChainData(ctx, key)
+	if err != nil {
+		log.Errorf("failed to get the offchain requested data from the DB: %v", err)
+		return nil, toStatusError(err, "failed to get the requested data")
+	}
+
+	return &pb.GetOffChainDataResponse{Value: data.Value}, nil
+}
+
+// ListOffChainData returns the values stored for the given keys
+func (s *Server) ListOffChainData(
+	ctx context.Context, req *pb.ListOffChainDataRequest,
+) (*pb.ListOffChainDataResponse, error) {
+	if len(req.GetKeys()) > maxListKeys {
+		log.Errorf("too many keys requested in ListOffChainData: %d", len(req.GetKeys()))
+		return nil, status.Error(codes.InvalidArgument, "too many keys requested")
+	}
+
+	keys := make([]common.Hash, len(req.GetKeys()))
+	for i, k := range req.GetKeys() {
+		key, err := toHash(k)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = key
+	}
+
+	list, err := s.db.ListOffChainData(ctx, keys)
+	if err != nil {
+		log.Errorf("failed to list the requested data from the DB: %v", err)
+		return nil, toStatusError(err, "failed to list the requested data")
+	}
+
+	return &pb.ListOffChainDataResponse{Data: toProtoData(list)}, nil
+}
+
+// StreamBatches streams the offchain data of the stored batches in the given inclusive range
+func (s *Server) StreamBatches(req *pb.StreamBatchesRequest, stream pb.DataAvailability_StreamBatchesServer) error {
+	from, to := req.GetFromBatch(), req.GetToBatch()
+	if from > to {
+		return status.Errorf(codes.InvalidArgument, "invalid batch range %d to %d", from, to)
+	}
+
+	if to-from >= maxStreamBatches {
+		return status.Errorf(codes.InvalidArgument, "too many batches requested, at most %d", maxStreamBatches)
+	}
+
+	ctx := stream.Context()
+
+	batchNums, err := s.db.GetDistinctBatchNums(ctx, from, to)
+	if err != nil {
+		log.Errorf("failed to get the batch numbers from %d to %d from the DB: %v", from, to, err)
+		return toStatusError(err, "failed to stream the requested batches")
+	}
+
+	for _, batchNum := range batchNums {
+		data, err := s.listBatch(ctx, batchNum)
+		if err != nil {
+			log.Errorf("failed to list the offchain data of batch %d from the DB: %v", batchNum, err)
+			return toStatusError(err, "failed to stream the requested batches")
+		}
+
+		// Batches known to be missing have no data yet
+		if len(data) == 0 {
+			continue
+		}
+
+		if err = stream.Send(&pb.Batch{BatchNum: batchNum, Data: toProtoData(data)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetStorageStats returns the number of stored values, the stored batch range and its size
+func (s *Server) GetStorageStats(ctx context.Context, _ *pb.GetStorageStatsRequest) (*pb.StorageStats, error) {
+	count, err := s.db.CountOffchainData(ctx)
+	if err != nil {
+		log.Errorf("failed to count the offchain data from the DB: %v", err)
+		return nil, toStatusError(err, "failed to get the storage stats")
+	}
+
+	first, last, err := s.db.GetBatchNumRange(ctx)
+	if err != nil {
+		log.Errorf("failed to get the batch range from the DB: %v", err)
+		return nil, toStatusError(err, "failed to get the storage stats")
+	}
+
+	stats := &pb.StorageStats{
+		KeyCount:    count,
+		MinBatchNum: first,
+		MaxBatchNum: last,
+	}
+
+	if last > 0 {
+		if stats.TotalBytes, err = s.db.GetBatchRangeDataSize(ctx, first, last); err != nil {
+			log.Errorf("failed to get the data size from the DB: %v", err)
+			return nil, toStatusError(err, "failed to get the storage stats")
+		}
+	}
+
+	return stats, nil
+}
+
+// VerifyIntegrity checks that the values stored for the batches in the given inclusive range hash to their keys.
+// Only a page of values is held in memory at once, and the progress is streamed after every page
+func (s *Server) VerifyIntegrity(
+	req *pb.VerifyIntegrityRequest, stream pb.DataAvailability_VerifyIntegrityServer,
+) error {
+	from, to := req.GetFromBatch(), req.GetToBatch()
+	if from > to {
+		return status.Errorf(codes.InvalidArgument, "invalid batch range %d to %d", from, to)
+	}
+
+	if to-from >= s.config.MaxIntegrityScanBatches {
+		return status.Errorf(codes.InvalidArgument, "too many batches requested, at most %d",
+			s.config.MaxIntegrityScanBatches)
+	}
+
+	var (
+		ctx      = stream.Context()
+		progress = &pb.IntegrityProgress{}
+		after    common.Hash
+		lastLog  = time.Now()
+	)
+
+	for {
+		page, err := s.db.ListOffChainDataAfterKey(ctx, from, to, after, s.config.IntegrityScanPageSize)
+		if err != nil {
+			log.Errorf("failed to list the offchain data of batches %d to %d from the DB: %v", from, to, err)
+			return toStatusError(err, "failed to verify the integrity of the requested batches")
+		}
+
+		progress.Mismatches = nil
+		for _, od := range page {
+			if computed := crypto.Keccak256Hash(od.Value); computed != od.Key {
+				progress.Mismatches = append(progress.Mismatches, &pb.IntegrityMismatch{
+					Key:      od.Key.Bytes(),
+					BatchNum: od.BatchNum,
+					Computed: computed.Bytes(),
+				})
+			}
+		}
+
+		progress.ScannedKeys += uint64(len(page))
+		progress.MismatchedKeys += uint64(len(progress.Mismatches))
+		progress.Complete = uint(len(page)) < s.config.IntegrityScanPageSize
+
+		if err = stream.Send(progress); err != nil {
+			return err
+		}
+
+		if progress.Complete {
+			log.Infof("integrity scan of batches %d to %d done: %d keys scanned, %d mismatched",
+				from, to, progress.ScannedKeys, progress.MismatchedKeys)
+
+			return nil
+		}
+
+		if time.Since(lastLog) >= integrityLogInterval {
+			log.Infof("integrity scan of batches %d to %d in progress: %d keys scanned, %d mismatched",
+				from, to, progress.ScannedKeys, progress.MismatchedKeys)
+
+			lastLog = time.Now()
+		}
+
+		after = page[len(page)-1].Key
+	}
+}
+
+// listBatch returns all the offchain data stored for the given batch, reading it page by page
+func (s *Server) listBatch(ctx context.Context, batchNum uint64) ([]types.OffChainData, error) {
+	var all []types.OffChainData
+
+	for offset := uint(0); ; offset += streamPageSize {
+		page, total, err := s.db.ListOffChainDataByBatch(ctx, batchNum, offset, streamPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, page...)
+		if len(page) < streamPageSize || uint64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// toHash returns the hash of the given key, or an InvalidArgument error if it is not 32 bytes long
+func toHash(key []byte) (common.Hash, error) {
+	if len(key) != common.HashLength {
+		return common.Hash{}, status.Errorf(codes.InvalidArgument, "invalid key length %d, expected %d",
+			len(key), common.HashLength)
+	}
+
+	return common.BytesToHash(key), nil
+}
+
+// toProtoData converts the given offchain data to its protobuf message
+func toProtoData(list []types.OffChainData) []*pb.OffChainData {
+	data := make([]*pb.OffChainData, len(list))
+	for i, od := range list {
+		data[i] = &pb.OffChainData{
+			Key:      od.Key.Bytes(),
+			Value:    od.Value,
+			BatchNum: od.BatchNum,
+		}
+	}
+
+	return data
+}
+
+// toStatusError returns the gRPC status error with the given message for the given DB error
+func toStatusError(err error, msg string) error {
+	switch {
+	case errors.Is(err, db.ErrStateNotSynchronized):
+		return status.Error(codes.NotFound, msg)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, msg)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, msg)
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
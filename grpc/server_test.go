@@ -0,0 +1,333 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/grpc"
+	"github.com/0xPolygon/cdk-data-availability/grpc/pb"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T, dbMock db.DB) pb.DataAvailabilityClient {
+	t.Helper()
+
+	return newTestClientWithConfig(t, grpc.Config{}, dbMock)
+}
+
+func newTestClientWithConfig(t *testing.T, cfg grpc.Config, dbMock db.DB) pb.DataAvailabilityClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(cfg, dbMock)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	conn, err := gogrpc.NewClient("passthrough:///bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+		require.NoError(t, server.Stop(context.Background()))
+	})
+
+	return pb.NewDataAvailabilityClient(conn)
+}
+
+func TestServer_GetOffChainData(t *testing.T) {
+	t.Parallel()
+
+	key := common.HexToHash("0x1")
+
+	tests := []struct {
+		name     string
+		key      []byte
+		dbErr    error
+		value    []byte
+		wantCode codes.Code
+	}{
+		{
+			name:  "value found",
+			key:   key.Bytes(),
+			value: []byte("value"),
+		},
+		{
+			name:     "value not found",
+			key:      key.Bytes(),
+			dbErr:    db.ErrStateNotSynchronized,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "db error",
+			key:      key.Bytes(),
+			dbErr:    errors.New("test error"),
+			wantCode: codes.Internal,
+		},
+		{
+			name:     "invalid key",
+			key:      []byte("short"),
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			if tt.wantCode != codes.InvalidArgument {
+				dbMock.On("GetOffChainData", mock.Anything, key).
+					Return(&types.OffChainData{Key: key, Value: tt.value}, tt.dbErr)
+			}
+
+			resp, err := newTestClient(t, dbMock).GetOffChainData(context.Background(),
+				&pb.GetOffChainDataRequest{Key: tt.key})
+			if tt.wantCode != codes.OK {
+				require.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.value, resp.GetValue())
+		})
+	}
+}
+
+func TestServer_ListOffChainData(t *testing.T) {
+	t.Parallel()
+
+	keys := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+
+	t.Run("values found", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainData", mock.Anything, keys).
+			Return([]types.OffChainData{{Key: keys[0], Value: []byte("value1"), BatchNum: 1}}, nil)
+
+		resp, err := newTestClient(t, dbMock).ListOffChainData(context.Background(),
+			&pb.ListOffChainDataRequest{Keys: [][]byte{keys[0].Bytes(), keys[1].Bytes()}})
+		require.NoError(t, err)
+		require.Len(t, resp.GetData(), 1)
+		require.Equal(t, keys[0].Bytes(), resp.GetData()[0].GetKey())
+		require.Equal(t, []byte("value1"), resp.GetData()[0].GetValue())
+		require.Equal(t, uint64(1), resp.GetData()[0].GetBatchNum())
+	})
+
+	t.Run("too many keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newTestClient(t, mocks.NewDB(t)).ListOffChainData(context.Background(),
+			&pb.ListOffChainDataRequest{Keys: make([][]byte, 101)})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_StreamBatches(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams the stored batches", func(t *testing.T) {
+		t.Parallel()
+
+		page := make([]types.OffChainData, 100)
+		for i := range page {
+			page[i] = types.OffChainData{Key: common.BigToHash(common.Big1), Value: []byte("value"), BatchNum: 1}
+		}
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(1), uint64(3)).Return([]uint64{1, 2, 3}, nil)
+		dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(1), uint(0), uint(100)).Return(page, uint64(101), nil)
+		dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(1), uint(100), uint(100)).
+			Return(page[:1], uint64(101), nil)
+		// batch 2 is missing
+		dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(2), uint(0), uint(100)).
+			Return([]types.OffChainData{}, uint64(0), nil)
+		dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(3), uint(0), uint(100)).
+			Return(page[:2], uint64(2), nil)
+
+		stream, err := newTestClient(t, dbMock).StreamBatches(context.Background(),
+			&pb.StreamBatchesRequest{FromBatch: 1, ToBatch: 3})
+		require.NoError(t, err)
+
+		var batches []*pb.Batch
+		for {
+			batch, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			require.NoError(t, err)
+			batches = append(batches, batch)
+		}
+
+		require.Len(t, batches, 2)
+		require.Equal(t, uint64(1), batches[0].GetBatchNum())
+		require.Len(t, batches[0].GetData(), 101)
+		require.Equal(t, uint64(3), batches[1].GetBatchNum())
+		require.Len(t, batches[1].GetData(), 2)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		for _, req := range []*pb.StreamBatchesRequest{
+			{FromBatch: 2, ToBatch: 1},
+			{FromBatch: 1, ToBatch: 10001},
+		} {
+			stream, err := newTestClient(t, mocks.NewDB(t)).StreamBatches(context.Background(), req)
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func TestServer_GetStorageStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stats of the stored batches", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("CountOffchainData", mock.Anything).Return(uint64(10), nil)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(2), uint64(5), nil)
+		dbMock.On("GetBatchRangeDataSize", mock.Anything, uint64(2), uint64(5)).Return(uint64(1024), nil)
+
+		stats, err := newTestClient(t, dbMock).GetStorageStats(context.Background(), &pb.GetStorageStatsRequest{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), stats.GetKeyCount())
+		require.Equal(t, uint64(1024), stats.GetTotalBytes())
+		require.Equal(t, uint64(2), stats.GetMinBatchNum())
+		require.Equal(t, uint64(5), stats.GetMaxBatchNum())
+	})
+
+	t.Run("empty storage", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("CountOffchainData", mock.Anything).Return(uint64(0), nil)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(0), uint64(0), nil)
+
+		stats, err := newTestClient(t, dbMock).GetStorageStats(context.Background(), &pb.GetStorageStatsRequest{})
+		require.NoError(t, err)
+		require.Zero(t, stats.GetTotalBytes())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("CountOffchainData", mock.Anything).Return(uint64(0), errors.New("test error"))
+
+		_, err := newTestClient(t, dbMock).GetStorageStats(context.Background(), &pb.GetStorageStatsRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestServer_VerifyIntegrity(t *testing.T) {
+	t.Parallel()
+
+	cfg := grpc.Config{IntegrityScanPageSize: 2, MaxIntegrityScanBatches: 10}
+
+	valid := func(value string) types.OffChainData {
+		return types.OffChainData{Key: crypto.Keccak256Hash([]byte(value)), Value: []byte(value), BatchNum: 1}
+	}
+
+	t.Run("scans the range page by page", func(t *testing.T) {
+		t.Parallel()
+
+		corrupted := types.OffChainData{Key: common.HexToHash("0x1"), Value: []byte("corrupted"), BatchNum: 2}
+		first := []types.OffChainData{corrupted, valid("a")}
+		second := []types.OffChainData{valid("b")}
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataAfterKey", mock.Anything, uint64(1), uint64(3), common.Hash{}, uint(2)).
+			Return(first, nil)
+		dbMock.On("ListOffChainDataAfterKey", mock.Anything, uint64(1), uint64(3), first[1].Key, uint(2)).
+			Return(second, nil)
+
+		stream, err := newTestClientWithConfig(t, cfg, dbMock).VerifyIntegrity(context.Background(),
+			&pb.VerifyIntegrityRequest{FromBatch: 1, ToBatch: 3})
+		require.NoError(t, err)
+
+		var reports []*pb.IntegrityProgress
+		for {
+			report, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			require.NoError(t, err)
+			reports = append(reports, report)
+		}
+
+		require.Len(t, reports, 2)
+
+		require.Equal(t, uint64(2), reports[0].GetScannedKeys())
+		require.Equal(t, uint64(1), reports[0].GetMismatchedKeys())
+		require.False(t, reports[0].GetComplete())
+		require.Len(t, reports[0].GetMismatches(), 1)
+		require.Equal(t, corrupted.Key.Bytes(), reports[0].GetMismatches()[0].GetKey())
+		require.Equal(t, uint64(2), reports[0].GetMismatches()[0].GetBatchNum())
+		require.Equal(t, crypto.Keccak256([]byte("corrupted")), reports[0].GetMismatches()[0].GetComputed())
+
+		require.Equal(t, uint64(3), reports[1].GetScannedKeys())
+		require.Equal(t, uint64(1), reports[1].GetMismatchedKeys())
+		require.True(t, reports[1].GetComplete())
+		require.Empty(t, reports[1].GetMismatches())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataAfterKey", mock.Anything, uint64(1), uint64(3), common.Hash{}, uint(2)).
+			Return(nil, errors.New("test error"))
+
+		stream, err := newTestClientWithConfig(t, cfg, dbMock).VerifyIntegrity(context.Background(),
+			&pb.VerifyIntegrityRequest{FromBatch: 1, ToBatch: 3})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		for _, req := range []*pb.VerifyIntegrityRequest{
+			{FromBatch: 2, ToBatch: 1},
+			{FromBatch: 1, ToBatch: 11},
+		} {
+			stream, err := newTestClientWithConfig(t, cfg, mocks.NewDB(t)).VerifyIntegrity(context.Background(), req)
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package metrics
+
+// Config represents the configuration of the metrics endpoint
+type Config struct {
+	// Enabled defines if the metrics endpoint should be served
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Host defines the network adapter that will be used to serve the metrics
+	Host string `mapstructure:"Host"`
+
+	// Port defines the port to serve the metrics via HTTP
+	Port int `mapstructure:"Port"`
+}
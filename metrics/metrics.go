@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// Namespace is the common prefix of all the metrics exposed by the data node
+	Namespace = "data_node"
+
+	// Endpoint is the path on which the metrics are served
+	Endpoint = "/metrics"
+
+	readHeaderTimeout = 5 * time.Second
+)
+
+// registry is the shared prometheus registry every component registers its metrics in
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Register registers the given collectors in the shared registry. It panics if any of them can't be registered
+func Register(cs ...prometheus.Collector) {
+	registry.MustRegister(cs...)
+}
+
+// Handler returns the HTTP handler exposing the metrics of the shared registry
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Server serves the metrics endpoint
+type Server struct {
+	config Config
+	srv    *http.Server
+}
+
+// NewServer returns the metrics server
+func NewServer(cfg Config) *Server {
+	return &Server{config: cfg}
+}
+
+// Start starts serving the metrics endpoint. It blocks until the server is stopped
+func (s *Server) Start() error {
+	if s.srv != nil {
+		return fmt.Errorf("metrics server already started")
+	}
+
+	address := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Errorf("failed to create tcp listener for metrics: %v", err)
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Endpoint, Handler())
+
+	s.srv = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	log.Infof("metrics server started: %s%s", address, Endpoint)
+	if err = s.srv.Serve(lis); err != nil {
+		if err == http.ErrServerClosed {
+			log.Infof("metrics server stopped")
+			return nil
+		}
+		log.Errorf("closed metrics http connection: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Stop shuts the metrics server down
+func (s *Server) Stop() error {
+	if s.srv != nil {
+		if err := s.srv.Shutdown(context.Background()); err != nil {
+			return err
+		}
+		s.srv = nil
+	}
+
+	return nil
+}
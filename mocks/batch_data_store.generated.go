@@ -0,0 +1,158 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// BatchDataStore is an autogenerated mock type for the BatchDataStore type
+type BatchDataStore struct {
+	mock.Mock
+}
+
+type BatchDataStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BatchDataStore) EXPECT() *BatchDataStore_Expecter {
+	return &BatchDataStore_Expecter{mock: &_m.Mock}
+}
+
+// GetCommittedKey provides a mock function with given fields: ctx, batchNum
+func (_m *BatchDataStore) GetCommittedKey(ctx context.Context, batchNum uint64) (common.Hash, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommittedKey")
+	}
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (common.Hash, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) common.Hash); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchDataStore_GetCommittedKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommittedKey'
+type BatchDataStore_GetCommittedKey_Call struct {
+	*mock.Call
+}
+
+// GetCommittedKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *BatchDataStore_Expecter) GetCommittedKey(ctx interface{}, batchNum interface{}) *BatchDataStore_GetCommittedKey_Call {
+	return &BatchDataStore_GetCommittedKey_Call{Call: _e.mock.On("GetCommittedKey", ctx, batchNum)}
+}
+
+func (_c *BatchDataStore_GetCommittedKey_Call) Run(run func(ctx context.Context, batchNum uint64)) *BatchDataStore_GetCommittedKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *BatchDataStore_GetCommittedKey_Call) Return(_a0 common.Hash, _a1 error) *BatchDataStore_GetCommittedKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BatchDataStore_GetCommittedKey_Call) RunAndReturn(run func(context.Context, uint64) (common.Hash, error)) *BatchDataStore_GetCommittedKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOffChainData provides a mock function with given fields: ctx, key
+func (_m *BatchDataStore) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOffChainData")
+	}
+
+	var r0 *types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.OffChainData, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.OffChainData); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchDataStore_GetOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOffChainData'
+type BatchDataStore_GetOffChainData_Call struct {
+	*mock.Call
+}
+
+// GetOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *BatchDataStore_Expecter) GetOffChainData(ctx interface{}, key interface{}) *BatchDataStore_GetOffChainData_Call {
+	return &BatchDataStore_GetOffChainData_Call{Call: _e.mock.On("GetOffChainData", ctx, key)}
+}
+
+func (_c *BatchDataStore_GetOffChainData_Call) Run(run func(ctx context.Context, key common.Hash)) *BatchDataStore_GetOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *BatchDataStore_GetOffChainData_Call) Return(_a0 *types.OffChainData, _a1 error) *BatchDataStore_GetOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BatchDataStore_GetOffChainData_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.OffChainData, error)) *BatchDataStore_GetOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBatchDataStore creates a new instance of BatchDataStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBatchDataStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BatchDataStore {
+	mock := &BatchDataStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -25,6 +25,65 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// GetBatchRoot provides a mock function with given fields: ctx, batchNum
+func (_m *Client) GetBatchRoot(ctx context.Context, batchNum uint64) (*types.BatchRoot, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchRoot")
+	}
+
+	var r0 *types.BatchRoot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.BatchRoot, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.BatchRoot); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BatchRoot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetBatchRoot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchRoot'
+type Client_GetBatchRoot_Call struct {
+	*mock.Call
+}
+
+// GetBatchRoot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *Client_Expecter) GetBatchRoot(ctx interface{}, batchNum interface{}) *Client_GetBatchRoot_Call {
+	return &Client_GetBatchRoot_Call{Call: _e.mock.On("GetBatchRoot", ctx, batchNum)}
+}
+
+func (_c *Client_GetBatchRoot_Call) Run(run func(ctx context.Context, batchNum uint64)) *Client_GetBatchRoot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *Client_GetBatchRoot_Call) Return(_a0 *types.BatchRoot, _a1 error) *Client_GetBatchRoot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetBatchRoot_Call) RunAndReturn(run func(context.Context, uint64) (*types.BatchRoot, error)) *Client_GetBatchRoot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOffChainData provides a mock function with given fields: ctx, hash
 func (_m *Client) GetOffChainData(ctx context.Context, hash common.Hash) ([]byte, error) {
 	ret := _m.Called(ctx, hash)
@@ -201,6 +260,67 @@ func (_c *Client_ListOffChainData_Call) RunAndReturn(run func(context.Context, [
 	return _c
 }
 
+// ListOffChainDataByBatch provides a mock function with given fields: ctx, batchNum, offset, limit
+func (_m *Client) ListOffChainDataByBatch(ctx context.Context, batchNum uint64, offset uint, limit uint) (*types.OffChainDataPage, error) {
+	ret := _m.Called(ctx, batchNum, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainDataByBatch")
+	}
+
+	var r0 *types.OffChainDataPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint, uint) (*types.OffChainDataPage, error)); ok {
+		return rf(ctx, batchNum, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint, uint) *types.OffChainDataPage); ok {
+		r0 = rf(ctx, batchNum, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OffChainDataPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint, uint) error); ok {
+		r1 = rf(ctx, batchNum, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ListOffChainDataByBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainDataByBatch'
+type Client_ListOffChainDataByBatch_Call struct {
+	*mock.Call
+}
+
+// ListOffChainDataByBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+//   - offset uint
+//   - limit uint
+func (_e *Client_Expecter) ListOffChainDataByBatch(ctx interface{}, batchNum interface{}, offset interface{}, limit interface{}) *Client_ListOffChainDataByBatch_Call {
+	return &Client_ListOffChainDataByBatch_Call{Call: _e.mock.On("ListOffChainDataByBatch", ctx, batchNum, offset, limit)}
+}
+
+func (_c *Client_ListOffChainDataByBatch_Call) Run(run func(ctx context.Context, batchNum uint64, offset uint, limit uint)) *Client_ListOffChainDataByBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *Client_ListOffChainDataByBatch_Call) Return(_a0 *types.OffChainDataPage, _a1 error) *Client_ListOffChainDataByBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ListOffChainDataByBatch_Call) RunAndReturn(run func(context.Context, uint64, uint, uint) (*types.OffChainDataPage, error)) *Client_ListOffChainDataByBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SignSequence provides a mock function with given fields: ctx, signedSequence
 func (_m *Client) SignSequence(ctx context.Context, signedSequence types.SignedSequence) ([]byte, error) {
 	ret := _m.Called(ctx, signedSequence)
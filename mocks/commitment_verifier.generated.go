@@ -0,0 +1,215 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// CommitmentVerifier is an autogenerated mock type for the CommitmentVerifier type
+type CommitmentVerifier struct {
+	mock.Mock
+}
+
+type CommitmentVerifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CommitmentVerifier) EXPECT() *CommitmentVerifier_Expecter {
+	return &CommitmentVerifier_Expecter{mock: &_m.Mock}
+}
+
+// CheckKeyBatchConsistency provides a mock function with given fields: ctx, batchNum
+func (_m *CommitmentVerifier) CheckKeyBatchConsistency(ctx context.Context, batchNum uint64) (*types.KeyBatchConsistency, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckKeyBatchConsistency")
+	}
+
+	var r0 *types.KeyBatchConsistency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.KeyBatchConsistency, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.KeyBatchConsistency); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.KeyBatchConsistency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommitmentVerifier_CheckKeyBatchConsistency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckKeyBatchConsistency'
+type CommitmentVerifier_CheckKeyBatchConsistency_Call struct {
+	*mock.Call
+}
+
+// CheckKeyBatchConsistency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *CommitmentVerifier_Expecter) CheckKeyBatchConsistency(ctx interface{}, batchNum interface{}) *CommitmentVerifier_CheckKeyBatchConsistency_Call {
+	return &CommitmentVerifier_CheckKeyBatchConsistency_Call{Call: _e.mock.On("CheckKeyBatchConsistency", ctx, batchNum)}
+}
+
+func (_c *CommitmentVerifier_CheckKeyBatchConsistency_Call) Run(run func(ctx context.Context, batchNum uint64)) *CommitmentVerifier_CheckKeyBatchConsistency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *CommitmentVerifier_CheckKeyBatchConsistency_Call) Return(_a0 *types.KeyBatchConsistency, _a1 error) *CommitmentVerifier_CheckKeyBatchConsistency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommitmentVerifier_CheckKeyBatchConsistency_Call) RunAndReturn(run func(context.Context, uint64) (*types.KeyBatchConsistency, error)) *CommitmentVerifier_CheckKeyBatchConsistency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RepairKeyBatchConsistency provides a mock function with given fields: ctx, batchNum
+func (_m *CommitmentVerifier) RepairKeyBatchConsistency(ctx context.Context, batchNum uint64) (*types.KeyBatchRepair, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairKeyBatchConsistency")
+	}
+
+	var r0 *types.KeyBatchRepair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.KeyBatchRepair, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.KeyBatchRepair); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.KeyBatchRepair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommitmentVerifier_RepairKeyBatchConsistency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairKeyBatchConsistency'
+type CommitmentVerifier_RepairKeyBatchConsistency_Call struct {
+	*mock.Call
+}
+
+// RepairKeyBatchConsistency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *CommitmentVerifier_Expecter) RepairKeyBatchConsistency(ctx interface{}, batchNum interface{}) *CommitmentVerifier_RepairKeyBatchConsistency_Call {
+	return &CommitmentVerifier_RepairKeyBatchConsistency_Call{Call: _e.mock.On("RepairKeyBatchConsistency", ctx, batchNum)}
+}
+
+func (_c *CommitmentVerifier_RepairKeyBatchConsistency_Call) Run(run func(ctx context.Context, batchNum uint64)) *CommitmentVerifier_RepairKeyBatchConsistency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *CommitmentVerifier_RepairKeyBatchConsistency_Call) Return(_a0 *types.KeyBatchRepair, _a1 error) *CommitmentVerifier_RepairKeyBatchConsistency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommitmentVerifier_RepairKeyBatchConsistency_Call) RunAndReturn(run func(context.Context, uint64) (*types.KeyBatchRepair, error)) *CommitmentVerifier_RepairKeyBatchConsistency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyBatchCommitment provides a mock function with given fields: ctx, batchNum
+func (_m *CommitmentVerifier) VerifyBatchCommitment(ctx context.Context, batchNum uint64) (*types.BatchCommitment, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyBatchCommitment")
+	}
+
+	var r0 *types.BatchCommitment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.BatchCommitment, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.BatchCommitment); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BatchCommitment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CommitmentVerifier_VerifyBatchCommitment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyBatchCommitment'
+type CommitmentVerifier_VerifyBatchCommitment_Call struct {
+	*mock.Call
+}
+
+// VerifyBatchCommitment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *CommitmentVerifier_Expecter) VerifyBatchCommitment(ctx interface{}, batchNum interface{}) *CommitmentVerifier_VerifyBatchCommitment_Call {
+	return &CommitmentVerifier_VerifyBatchCommitment_Call{Call: _e.mock.On("VerifyBatchCommitment", ctx, batchNum)}
+}
+
+func (_c *CommitmentVerifier_VerifyBatchCommitment_Call) Run(run func(ctx context.Context, batchNum uint64)) *CommitmentVerifier_VerifyBatchCommitment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *CommitmentVerifier_VerifyBatchCommitment_Call) Return(_a0 *types.BatchCommitment, _a1 error) *CommitmentVerifier_VerifyBatchCommitment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CommitmentVerifier_VerifyBatchCommitment_Call) RunAndReturn(run func(context.Context, uint64) (*types.BatchCommitment, error)) *CommitmentVerifier_VerifyBatchCommitment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCommitmentVerifier creates a new instance of CommitmentVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommitmentVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommitmentVerifier {
+	mock := &CommitmentVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
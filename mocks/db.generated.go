@@ -7,8 +7,12 @@ import (
 
 	common "github.com/ethereum/go-ethereum/common"
 
+	db "github.com/0xPolygon/cdk-data-availability/db"
+
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/0xPolygon/cdk-data-availability/types"
 )
 
@@ -25,73 +29,83 @@ func (_m *DB) EXPECT() *DB_Expecter {
 	return &DB_Expecter{mock: &_m.Mock}
 }
 
-// CountOffchainData provides a mock function with given fields: ctx
-func (_m *DB) CountOffchainData(ctx context.Context) (uint64, error) {
-	ret := _m.Called(ctx)
+// AllExist provides a mock function with given fields: ctx, keys
+func (_m *DB) AllExist(ctx context.Context, keys []common.Hash) (bool, []common.Hash, error) {
+	ret := _m.Called(ctx, keys)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountOffchainData")
+		panic("no return value specified for AllExist")
 	}
 
-	var r0 uint64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
-		return rf(ctx)
+	var r0 bool
+	var r1 []common.Hash
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) (bool, []common.Hash, error)); ok {
+		return rf(ctx, keys)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) bool); ok {
+		r0 = rf(ctx, keys)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) []common.Hash); ok {
+		r1 = rf(ctx, keys)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]common.Hash)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, []common.Hash) error); ok {
+		r2 = rf(ctx, keys)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// DB_CountOffchainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOffchainData'
-type DB_CountOffchainData_Call struct {
+// DB_AllExist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllExist'
+type DB_AllExist_Call struct {
 	*mock.Call
 }
 
-// CountOffchainData is a helper method to define mock.On call
+// AllExist is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *DB_Expecter) CountOffchainData(ctx interface{}) *DB_CountOffchainData_Call {
-	return &DB_CountOffchainData_Call{Call: _e.mock.On("CountOffchainData", ctx)}
+//   - keys []common.Hash
+func (_e *DB_Expecter) AllExist(ctx interface{}, keys interface{}) *DB_AllExist_Call {
+	return &DB_AllExist_Call{Call: _e.mock.On("AllExist", ctx, keys)}
 }
 
-func (_c *DB_CountOffchainData_Call) Run(run func(ctx context.Context)) *DB_CountOffchainData_Call {
+func (_c *DB_AllExist_Call) Run(run func(ctx context.Context, keys []common.Hash)) *DB_AllExist_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].([]common.Hash))
 	})
 	return _c
 }
 
-func (_c *DB_CountOffchainData_Call) Return(_a0 uint64, _a1 error) *DB_CountOffchainData_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *DB_AllExist_Call) Return(_a0 bool, _a1 []common.Hash, _a2 error) *DB_AllExist_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *DB_CountOffchainData_Call) RunAndReturn(run func(context.Context) (uint64, error)) *DB_CountOffchainData_Call {
+func (_c *DB_AllExist_Call) RunAndReturn(run func(context.Context, []common.Hash) (bool, []common.Hash, error)) *DB_AllExist_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteMissingBatchKeys provides a mock function with given fields: ctx, bks
-func (_m *DB) DeleteMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
-	ret := _m.Called(ctx, bks)
+// ArchiveMissingBatchKeys provides a mock function with given fields: ctx, resolved
+func (_m *DB) ArchiveMissingBatchKeys(ctx context.Context, resolved []types.ResolvedBatch) error {
+	ret := _m.Called(ctx, resolved)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteMissingBatchKeys")
+		panic("no return value specified for ArchiveMissingBatchKeys")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []types.BatchKey) error); ok {
-		r0 = rf(ctx, bks)
+	if rf, ok := ret.Get(0).(func(context.Context, []types.ResolvedBatch) error); ok {
+		r0 = rf(ctx, resolved)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -99,115 +113,106 @@ func (_m *DB) DeleteMissingBatchKeys(ctx context.Context, bks []types.BatchKey)
 	return r0
 }
 
-// DB_DeleteMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMissingBatchKeys'
-type DB_DeleteMissingBatchKeys_Call struct {
+// DB_ArchiveMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveMissingBatchKeys'
+type DB_ArchiveMissingBatchKeys_Call struct {
 	*mock.Call
 }
 
-// DeleteMissingBatchKeys is a helper method to define mock.On call
+// ArchiveMissingBatchKeys is a helper method to define mock.On call
 //   - ctx context.Context
-//   - bks []types.BatchKey
-func (_e *DB_Expecter) DeleteMissingBatchKeys(ctx interface{}, bks interface{}) *DB_DeleteMissingBatchKeys_Call {
-	return &DB_DeleteMissingBatchKeys_Call{Call: _e.mock.On("DeleteMissingBatchKeys", ctx, bks)}
+//   - resolved []types.ResolvedBatch
+func (_e *DB_Expecter) ArchiveMissingBatchKeys(ctx interface{}, resolved interface{}) *DB_ArchiveMissingBatchKeys_Call {
+	return &DB_ArchiveMissingBatchKeys_Call{Call: _e.mock.On("ArchiveMissingBatchKeys", ctx, resolved)}
 }
 
-func (_c *DB_DeleteMissingBatchKeys_Call) Run(run func(ctx context.Context, bks []types.BatchKey)) *DB_DeleteMissingBatchKeys_Call {
+func (_c *DB_ArchiveMissingBatchKeys_Call) Run(run func(ctx context.Context, resolved []types.ResolvedBatch)) *DB_ArchiveMissingBatchKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]types.BatchKey))
+		run(args[0].(context.Context), args[1].([]types.ResolvedBatch))
 	})
 	return _c
 }
 
-func (_c *DB_DeleteMissingBatchKeys_Call) Return(_a0 error) *DB_DeleteMissingBatchKeys_Call {
+func (_c *DB_ArchiveMissingBatchKeys_Call) Return(_a0 error) *DB_ArchiveMissingBatchKeys_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *DB_DeleteMissingBatchKeys_Call) RunAndReturn(run func(context.Context, []types.BatchKey) error) *DB_DeleteMissingBatchKeys_Call {
+func (_c *DB_ArchiveMissingBatchKeys_Call) RunAndReturn(run func(context.Context, []types.ResolvedBatch) error) *DB_ArchiveMissingBatchKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLastProcessedBlock provides a mock function with given fields: ctx, task
-func (_m *DB) GetLastProcessedBlock(ctx context.Context, task string) (uint64, error) {
-	ret := _m.Called(ctx, task)
+// ArchiveMissingBatchKeysTx provides a mock function with given fields: ctx, resolved, tx
+func (_m *DB) ArchiveMissingBatchKeysTx(ctx context.Context, resolved []types.ResolvedBatch, tx db.Tx) error {
+	ret := _m.Called(ctx, resolved, tx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastProcessedBlock")
-	}
-
-	var r0 uint64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (uint64, error)); ok {
-		return rf(ctx, task)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
-		r0 = rf(ctx, task)
-	} else {
-		r0 = ret.Get(0).(uint64)
+		panic("no return value specified for ArchiveMissingBatchKeysTx")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, task)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.ResolvedBatch, db.Tx) error); ok {
+		r0 = rf(ctx, resolved, tx)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// DB_GetLastProcessedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastProcessedBlock'
-type DB_GetLastProcessedBlock_Call struct {
+// DB_ArchiveMissingBatchKeysTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveMissingBatchKeysTx'
+type DB_ArchiveMissingBatchKeysTx_Call struct {
 	*mock.Call
 }
 
-// GetLastProcessedBlock is a helper method to define mock.On call
+// ArchiveMissingBatchKeysTx is a helper method to define mock.On call
 //   - ctx context.Context
-//   - task string
-func (_e *DB_Expecter) GetLastProcessedBlock(ctx interface{}, task interface{}) *DB_GetLastProcessedBlock_Call {
-	return &DB_GetLastProcessedBlock_Call{Call: _e.mock.On("GetLastProcessedBlock", ctx, task)}
+//   - resolved []types.ResolvedBatch
+//   - tx db.Tx
+func (_e *DB_Expecter) ArchiveMissingBatchKeysTx(ctx interface{}, resolved interface{}, tx interface{}) *DB_ArchiveMissingBatchKeysTx_Call {
+	return &DB_ArchiveMissingBatchKeysTx_Call{Call: _e.mock.On("ArchiveMissingBatchKeysTx", ctx, resolved, tx)}
 }
 
-func (_c *DB_GetLastProcessedBlock_Call) Run(run func(ctx context.Context, task string)) *DB_GetLastProcessedBlock_Call {
+func (_c *DB_ArchiveMissingBatchKeysTx_Call) Run(run func(ctx context.Context, resolved []types.ResolvedBatch, tx db.Tx)) *DB_ArchiveMissingBatchKeysTx_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].([]types.ResolvedBatch), args[2].(db.Tx))
 	})
 	return _c
 }
 
-func (_c *DB_GetLastProcessedBlock_Call) Return(_a0 uint64, _a1 error) *DB_GetLastProcessedBlock_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *DB_ArchiveMissingBatchKeysTx_Call) Return(_a0 error) *DB_ArchiveMissingBatchKeysTx_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *DB_GetLastProcessedBlock_Call) RunAndReturn(run func(context.Context, string) (uint64, error)) *DB_GetLastProcessedBlock_Call {
+func (_c *DB_ArchiveMissingBatchKeysTx_Call) RunAndReturn(run func(context.Context, []types.ResolvedBatch, db.Tx) error) *DB_ArchiveMissingBatchKeysTx_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMissingBatchKeys provides a mock function with given fields: ctx, limit
-func (_m *DB) GetMissingBatchKeys(ctx context.Context, limit uint) ([]types.BatchKey, error) {
-	ret := _m.Called(ctx, limit)
+// BeginStateTransaction provides a mock function with given fields: ctx
+func (_m *DB) BeginStateTransaction(ctx context.Context) (db.Tx, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMissingBatchKeys")
+		panic("no return value specified for BeginStateTransaction")
 	}
 
-	var r0 []types.BatchKey
+	var r0 db.Tx
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]types.BatchKey, error)); ok {
-		return rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context) (db.Tx, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint) []types.BatchKey); ok {
-		r0 = rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context) db.Tx); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.BatchKey)
+			r0 = ret.Get(0).(db.Tx)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
-		r1 = rf(ctx, limit)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -215,117 +220,102 @@ func (_m *DB) GetMissingBatchKeys(ctx context.Context, limit uint) ([]types.Batc
 	return r0, r1
 }
 
-// DB_GetMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMissingBatchKeys'
-type DB_GetMissingBatchKeys_Call struct {
+// DB_BeginStateTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginStateTransaction'
+type DB_BeginStateTransaction_Call struct {
 	*mock.Call
 }
 
-// GetMissingBatchKeys is a helper method to define mock.On call
+// BeginStateTransaction is a helper method to define mock.On call
 //   - ctx context.Context
-//   - limit uint
-func (_e *DB_Expecter) GetMissingBatchKeys(ctx interface{}, limit interface{}) *DB_GetMissingBatchKeys_Call {
-	return &DB_GetMissingBatchKeys_Call{Call: _e.mock.On("GetMissingBatchKeys", ctx, limit)}
+func (_e *DB_Expecter) BeginStateTransaction(ctx interface{}) *DB_BeginStateTransaction_Call {
+	return &DB_BeginStateTransaction_Call{Call: _e.mock.On("BeginStateTransaction", ctx)}
 }
 
-func (_c *DB_GetMissingBatchKeys_Call) Run(run func(ctx context.Context, limit uint)) *DB_GetMissingBatchKeys_Call {
+func (_c *DB_BeginStateTransaction_Call) Run(run func(ctx context.Context)) *DB_BeginStateTransaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *DB_GetMissingBatchKeys_Call) Return(_a0 []types.BatchKey, _a1 error) *DB_GetMissingBatchKeys_Call {
+func (_c *DB_BeginStateTransaction_Call) Return(_a0 db.Tx, _a1 error) *DB_BeginStateTransaction_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DB_GetMissingBatchKeys_Call) RunAndReturn(run func(context.Context, uint) ([]types.BatchKey, error)) *DB_GetMissingBatchKeys_Call {
+func (_c *DB_BeginStateTransaction_Call) RunAndReturn(run func(context.Context) (db.Tx, error)) *DB_BeginStateTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOffChainData provides a mock function with given fields: ctx, key
-func (_m *DB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
-	ret := _m.Called(ctx, key)
+// CorrectOffChainDataBatchNums provides a mock function with given fields: ctx, corrections
+func (_m *DB) CorrectOffChainDataBatchNums(ctx context.Context, corrections []types.KeyBatchCorrection) error {
+	ret := _m.Called(ctx, corrections)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOffChainData")
-	}
-
-	var r0 *types.OffChainData
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.OffChainData, error)); ok {
-		return rf(ctx, key)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.OffChainData); ok {
-		r0 = rf(ctx, key)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.OffChainData)
-		}
+		panic("no return value specified for CorrectOffChainDataBatchNums")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
-		r1 = rf(ctx, key)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.KeyBatchCorrection) error); ok {
+		r0 = rf(ctx, corrections)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// DB_GetOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOffChainData'
-type DB_GetOffChainData_Call struct {
+// DB_CorrectOffChainDataBatchNums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CorrectOffChainDataBatchNums'
+type DB_CorrectOffChainDataBatchNums_Call struct {
 	*mock.Call
 }
 
-// GetOffChainData is a helper method to define mock.On call
+// CorrectOffChainDataBatchNums is a helper method to define mock.On call
 //   - ctx context.Context
-//   - key common.Hash
-func (_e *DB_Expecter) GetOffChainData(ctx interface{}, key interface{}) *DB_GetOffChainData_Call {
-	return &DB_GetOffChainData_Call{Call: _e.mock.On("GetOffChainData", ctx, key)}
+//   - corrections []types.KeyBatchCorrection
+func (_e *DB_Expecter) CorrectOffChainDataBatchNums(ctx interface{}, corrections interface{}) *DB_CorrectOffChainDataBatchNums_Call {
+	return &DB_CorrectOffChainDataBatchNums_Call{Call: _e.mock.On("CorrectOffChainDataBatchNums", ctx, corrections)}
 }
 
-func (_c *DB_GetOffChainData_Call) Run(run func(ctx context.Context, key common.Hash)) *DB_GetOffChainData_Call {
+func (_c *DB_CorrectOffChainDataBatchNums_Call) Run(run func(ctx context.Context, corrections []types.KeyBatchCorrection)) *DB_CorrectOffChainDataBatchNums_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(common.Hash))
+		run(args[0].(context.Context), args[1].([]types.KeyBatchCorrection))
 	})
 	return _c
 }
 
-func (_c *DB_GetOffChainData_Call) Return(_a0 *types.OffChainData, _a1 error) *DB_GetOffChainData_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *DB_CorrectOffChainDataBatchNums_Call) Return(_a0 error) *DB_CorrectOffChainDataBatchNums_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *DB_GetOffChainData_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.OffChainData, error)) *DB_GetOffChainData_Call {
+func (_c *DB_CorrectOffChainDataBatchNums_Call) RunAndReturn(run func(context.Context, []types.KeyBatchCorrection) error) *DB_CorrectOffChainDataBatchNums_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListOffChainData provides a mock function with given fields: ctx, keys
-func (_m *DB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
-	ret := _m.Called(ctx, keys)
+// CountOffchainData provides a mock function with given fields: ctx
+func (_m *DB) CountOffchainData(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListOffChainData")
+		panic("no return value specified for CountOffchainData")
 	}
 
-	var r0 []types.OffChainData
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([]types.OffChainData, error)); ok {
-		return rf(ctx, keys)
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) []types.OffChainData); ok {
-		r0 = rf(ctx, keys)
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]types.OffChainData)
-		}
+		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) error); ok {
-		r1 = rf(ctx, keys)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -333,89 +323,100 @@ func (_m *DB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types
 	return r0, r1
 }
 
-// DB_ListOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainData'
-type DB_ListOffChainData_Call struct {
+// DB_CountOffchainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOffchainData'
+type DB_CountOffchainData_Call struct {
 	*mock.Call
 }
 
-// ListOffChainData is a helper method to define mock.On call
+// CountOffchainData is a helper method to define mock.On call
 //   - ctx context.Context
-//   - keys []common.Hash
-func (_e *DB_Expecter) ListOffChainData(ctx interface{}, keys interface{}) *DB_ListOffChainData_Call {
-	return &DB_ListOffChainData_Call{Call: _e.mock.On("ListOffChainData", ctx, keys)}
+func (_e *DB_Expecter) CountOffchainData(ctx interface{}) *DB_CountOffchainData_Call {
+	return &DB_CountOffchainData_Call{Call: _e.mock.On("CountOffchainData", ctx)}
 }
 
-func (_c *DB_ListOffChainData_Call) Run(run func(ctx context.Context, keys []common.Hash)) *DB_ListOffChainData_Call {
+func (_c *DB_CountOffchainData_Call) Run(run func(ctx context.Context)) *DB_CountOffchainData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]common.Hash))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *DB_ListOffChainData_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainData_Call {
+func (_c *DB_CountOffchainData_Call) Return(_a0 uint64, _a1 error) *DB_CountOffchainData_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DB_ListOffChainData_Call) RunAndReturn(run func(context.Context, []common.Hash) ([]types.OffChainData, error)) *DB_ListOffChainData_Call {
+func (_c *DB_CountOffchainData_Call) RunAndReturn(run func(context.Context) (uint64, error)) *DB_CountOffchainData_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StoreLastProcessedBlock provides a mock function with given fields: ctx, block, task
-func (_m *DB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
-	ret := _m.Called(ctx, block, task)
+// CountOffchainDataByBatch provides a mock function with given fields: ctx, from, to
+func (_m *DB) CountOffchainDataByBatch(ctx context.Context, from uint64, to uint64) (map[uint64]uint64, error) {
+	ret := _m.Called(ctx, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StoreLastProcessedBlock")
+		panic("no return value specified for CountOffchainDataByBatch")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
-		r0 = rf(ctx, block, task)
+	var r0 map[uint64]uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (map[uint64]uint64, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) map[uint64]uint64); ok {
+		r0 = rf(ctx, from, to)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uint64]uint64)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// DB_StoreLastProcessedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreLastProcessedBlock'
-type DB_StoreLastProcessedBlock_Call struct {
+// DB_CountOffchainDataByBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOffchainDataByBatch'
+type DB_CountOffchainDataByBatch_Call struct {
 	*mock.Call
 }
 
-// StoreLastProcessedBlock is a helper method to define mock.On call
+// CountOffchainDataByBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - block uint64
-//   - task string
-func (_e *DB_Expecter) StoreLastProcessedBlock(ctx interface{}, block interface{}, task interface{}) *DB_StoreLastProcessedBlock_Call {
-	return &DB_StoreLastProcessedBlock_Call{Call: _e.mock.On("StoreLastProcessedBlock", ctx, block, task)}
+//   - from uint64
+//   - to uint64
+func (_e *DB_Expecter) CountOffchainDataByBatch(ctx interface{}, from interface{}, to interface{}) *DB_CountOffchainDataByBatch_Call {
+	return &DB_CountOffchainDataByBatch_Call{Call: _e.mock.On("CountOffchainDataByBatch", ctx, from, to)}
 }
 
-func (_c *DB_StoreLastProcessedBlock_Call) Run(run func(ctx context.Context, block uint64, task string)) *DB_StoreLastProcessedBlock_Call {
+func (_c *DB_CountOffchainDataByBatch_Call) Run(run func(ctx context.Context, from uint64, to uint64)) *DB_CountOffchainDataByBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint64), args[2].(string))
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
 	})
 	return _c
 }
 
-func (_c *DB_StoreLastProcessedBlock_Call) Return(_a0 error) *DB_StoreLastProcessedBlock_Call {
-	_c.Call.Return(_a0)
+func (_c *DB_CountOffchainDataByBatch_Call) Return(_a0 map[uint64]uint64, _a1 error) *DB_CountOffchainDataByBatch_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *DB_StoreLastProcessedBlock_Call) RunAndReturn(run func(context.Context, uint64, string) error) *DB_StoreLastProcessedBlock_Call {
+func (_c *DB_CountOffchainDataByBatch_Call) RunAndReturn(run func(context.Context, uint64, uint64) (map[uint64]uint64, error)) *DB_CountOffchainDataByBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StoreMissingBatchKeys provides a mock function with given fields: ctx, bks
-func (_m *DB) StoreMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+// DeleteMissingBatchKeys provides a mock function with given fields: ctx, bks
+func (_m *DB) DeleteMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
 	ret := _m.Called(ctx, bks)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StoreMissingBatchKeys")
+		panic("no return value specified for DeleteMissingBatchKeys")
 	}
 
 	var r0 error
@@ -428,46 +429,46 @@ func (_m *DB) StoreMissingBatchKeys(ctx context.Context, bks []types.BatchKey) e
 	return r0
 }
 
-// DB_StoreMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreMissingBatchKeys'
-type DB_StoreMissingBatchKeys_Call struct {
+// DB_DeleteMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMissingBatchKeys'
+type DB_DeleteMissingBatchKeys_Call struct {
 	*mock.Call
 }
 
-// StoreMissingBatchKeys is a helper method to define mock.On call
+// DeleteMissingBatchKeys is a helper method to define mock.On call
 //   - ctx context.Context
 //   - bks []types.BatchKey
-func (_e *DB_Expecter) StoreMissingBatchKeys(ctx interface{}, bks interface{}) *DB_StoreMissingBatchKeys_Call {
-	return &DB_StoreMissingBatchKeys_Call{Call: _e.mock.On("StoreMissingBatchKeys", ctx, bks)}
+func (_e *DB_Expecter) DeleteMissingBatchKeys(ctx interface{}, bks interface{}) *DB_DeleteMissingBatchKeys_Call {
+	return &DB_DeleteMissingBatchKeys_Call{Call: _e.mock.On("DeleteMissingBatchKeys", ctx, bks)}
 }
 
-func (_c *DB_StoreMissingBatchKeys_Call) Run(run func(ctx context.Context, bks []types.BatchKey)) *DB_StoreMissingBatchKeys_Call {
+func (_c *DB_DeleteMissingBatchKeys_Call) Run(run func(ctx context.Context, bks []types.BatchKey)) *DB_DeleteMissingBatchKeys_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].([]types.BatchKey))
 	})
 	return _c
 }
 
-func (_c *DB_StoreMissingBatchKeys_Call) Return(_a0 error) *DB_StoreMissingBatchKeys_Call {
+func (_c *DB_DeleteMissingBatchKeys_Call) Return(_a0 error) *DB_DeleteMissingBatchKeys_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *DB_StoreMissingBatchKeys_Call) RunAndReturn(run func(context.Context, []types.BatchKey) error) *DB_StoreMissingBatchKeys_Call {
+func (_c *DB_DeleteMissingBatchKeys_Call) RunAndReturn(run func(context.Context, []types.BatchKey) error) *DB_DeleteMissingBatchKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StoreOffChainData provides a mock function with given fields: ctx, od
-func (_m *DB) StoreOffChainData(ctx context.Context, od []types.OffChainData) error {
-	ret := _m.Called(ctx, od)
+// DeleteMissingBatchKeysTx provides a mock function with given fields: ctx, bks, tx
+func (_m *DB) DeleteMissingBatchKeysTx(ctx context.Context, bks []types.BatchKey, tx db.Tx) error {
+	ret := _m.Called(ctx, bks, tx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StoreOffChainData")
+		panic("no return value specified for DeleteMissingBatchKeysTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []types.OffChainData) error); ok {
-		r0 = rf(ctx, od)
+	if rf, ok := ret.Get(0).(func(context.Context, []types.BatchKey, db.Tx) error); ok {
+		r0 = rf(ctx, bks, tx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -475,7 +476,2039 @@ func (_m *DB) StoreOffChainData(ctx context.Context, od []types.OffChainData) er
 	return r0
 }
 
-// DB_StoreOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreOffChainData'
+// DB_DeleteMissingBatchKeysTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMissingBatchKeysTx'
+type DB_DeleteMissingBatchKeysTx_Call struct {
+	*mock.Call
+}
+
+// DeleteMissingBatchKeysTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bks []types.BatchKey
+//   - tx db.Tx
+func (_e *DB_Expecter) DeleteMissingBatchKeysTx(ctx interface{}, bks interface{}, tx interface{}) *DB_DeleteMissingBatchKeysTx_Call {
+	return &DB_DeleteMissingBatchKeysTx_Call{Call: _e.mock.On("DeleteMissingBatchKeysTx", ctx, bks, tx)}
+}
+
+func (_c *DB_DeleteMissingBatchKeysTx_Call) Run(run func(ctx context.Context, bks []types.BatchKey, tx db.Tx)) *DB_DeleteMissingBatchKeysTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.BatchKey), args[2].(db.Tx))
+	})
+	return _c
+}
+
+func (_c *DB_DeleteMissingBatchKeysTx_Call) Return(_a0 error) *DB_DeleteMissingBatchKeysTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_DeleteMissingBatchKeysTx_Call) RunAndReturn(run func(context.Context, []types.BatchKey, db.Tx) error) *DB_DeleteMissingBatchKeysTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FilterMissingBatchKeys provides a mock function with given fields: ctx, bks
+func (_m *DB) FilterMissingBatchKeys(ctx context.Context, bks []types.BatchKey) ([]types.BatchKey, error) {
+	ret := _m.Called(ctx, bks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterMissingBatchKeys")
+	}
+
+	var r0 []types.BatchKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.BatchKey) ([]types.BatchKey, error)); ok {
+		return rf(ctx, bks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.BatchKey) []types.BatchKey); ok {
+		r0 = rf(ctx, bks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.BatchKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.BatchKey) error); ok {
+		r1 = rf(ctx, bks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_FilterMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterMissingBatchKeys'
+type DB_FilterMissingBatchKeys_Call struct {
+	*mock.Call
+}
+
+// FilterMissingBatchKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bks []types.BatchKey
+func (_e *DB_Expecter) FilterMissingBatchKeys(ctx interface{}, bks interface{}) *DB_FilterMissingBatchKeys_Call {
+	return &DB_FilterMissingBatchKeys_Call{Call: _e.mock.On("FilterMissingBatchKeys", ctx, bks)}
+}
+
+func (_c *DB_FilterMissingBatchKeys_Call) Run(run func(ctx context.Context, bks []types.BatchKey)) *DB_FilterMissingBatchKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.BatchKey))
+	})
+	return _c
+}
+
+func (_c *DB_FilterMissingBatchKeys_Call) Return(_a0 []types.BatchKey, _a1 error) *DB_FilterMissingBatchKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_FilterMissingBatchKeys_Call) RunAndReturn(run func(context.Context, []types.BatchKey) ([]types.BatchKey, error)) *DB_FilterMissingBatchKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDuplicateValues provides a mock function with given fields: ctx
+func (_m *DB) FindDuplicateValues(ctx context.Context) ([]types.DuplicateGroup, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicateValues")
+	}
+
+	var r0 []types.DuplicateGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]types.DuplicateGroup, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []types.DuplicateGroup); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.DuplicateGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_FindDuplicateValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDuplicateValues'
+type DB_FindDuplicateValues_Call struct {
+	*mock.Call
+}
+
+// FindDuplicateValues is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) FindDuplicateValues(ctx interface{}) *DB_FindDuplicateValues_Call {
+	return &DB_FindDuplicateValues_Call{Call: _e.mock.On("FindDuplicateValues", ctx)}
+}
+
+func (_c *DB_FindDuplicateValues_Call) Run(run func(ctx context.Context)) *DB_FindDuplicateValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_FindDuplicateValues_Call) Return(_a0 []types.DuplicateGroup, _a1 error) *DB_FindDuplicateValues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_FindDuplicateValues_Call) RunAndReturn(run func(context.Context) ([]types.DuplicateGroup, error)) *DB_FindDuplicateValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchConcatenated provides a mock function with given fields: ctx, batchNum
+func (_m *DB) GetBatchConcatenated(ctx context.Context, batchNum uint64) ([]byte, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchConcatenated")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]byte, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []byte); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetBatchConcatenated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchConcatenated'
+type DB_GetBatchConcatenated_Call struct {
+	*mock.Call
+}
+
+// GetBatchConcatenated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *DB_Expecter) GetBatchConcatenated(ctx interface{}, batchNum interface{}) *DB_GetBatchConcatenated_Call {
+	return &DB_GetBatchConcatenated_Call{Call: _e.mock.On("GetBatchConcatenated", ctx, batchNum)}
+}
+
+func (_c *DB_GetBatchConcatenated_Call) Run(run func(ctx context.Context, batchNum uint64)) *DB_GetBatchConcatenated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetBatchConcatenated_Call) Return(_a0 []byte, _a1 error) *DB_GetBatchConcatenated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetBatchConcatenated_Call) RunAndReturn(run func(context.Context, uint64) ([]byte, error)) *DB_GetBatchConcatenated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchDataSize provides a mock function with given fields: ctx, batchNum
+func (_m *DB) GetBatchDataSize(ctx context.Context, batchNum uint64) (uint64, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchDataSize")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (uint64, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) uint64); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetBatchDataSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchDataSize'
+type DB_GetBatchDataSize_Call struct {
+	*mock.Call
+}
+
+// GetBatchDataSize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *DB_Expecter) GetBatchDataSize(ctx interface{}, batchNum interface{}) *DB_GetBatchDataSize_Call {
+	return &DB_GetBatchDataSize_Call{Call: _e.mock.On("GetBatchDataSize", ctx, batchNum)}
+}
+
+func (_c *DB_GetBatchDataSize_Call) Run(run func(ctx context.Context, batchNum uint64)) *DB_GetBatchDataSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetBatchDataSize_Call) Return(_a0 uint64, _a1 error) *DB_GetBatchDataSize_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetBatchDataSize_Call) RunAndReturn(run func(context.Context, uint64) (uint64, error)) *DB_GetBatchDataSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchNumRange provides a mock function with given fields: ctx
+func (_m *DB) GetBatchNumRange(ctx context.Context) (uint64, uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchNumRange")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) uint64); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DB_GetBatchNumRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchNumRange'
+type DB_GetBatchNumRange_Call struct {
+	*mock.Call
+}
+
+// GetBatchNumRange is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) GetBatchNumRange(ctx interface{}) *DB_GetBatchNumRange_Call {
+	return &DB_GetBatchNumRange_Call{Call: _e.mock.On("GetBatchNumRange", ctx)}
+}
+
+func (_c *DB_GetBatchNumRange_Call) Run(run func(ctx context.Context)) *DB_GetBatchNumRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_GetBatchNumRange_Call) Return(_a0 uint64, _a1 uint64, _a2 error) *DB_GetBatchNumRange_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *DB_GetBatchNumRange_Call) RunAndReturn(run func(context.Context) (uint64, uint64, error)) *DB_GetBatchNumRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatchRangeDataSize provides a mock function with given fields: ctx, from, to
+func (_m *DB) GetBatchRangeDataSize(ctx context.Context, from uint64, to uint64) (uint64, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchRangeDataSize")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (uint64, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) uint64); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetBatchRangeDataSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchRangeDataSize'
+type DB_GetBatchRangeDataSize_Call struct {
+	*mock.Call
+}
+
+// GetBatchRangeDataSize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from uint64
+//   - to uint64
+func (_e *DB_Expecter) GetBatchRangeDataSize(ctx interface{}, from interface{}, to interface{}) *DB_GetBatchRangeDataSize_Call {
+	return &DB_GetBatchRangeDataSize_Call{Call: _e.mock.On("GetBatchRangeDataSize", ctx, from, to)}
+}
+
+func (_c *DB_GetBatchRangeDataSize_Call) Run(run func(ctx context.Context, from uint64, to uint64)) *DB_GetBatchRangeDataSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetBatchRangeDataSize_Call) Return(_a0 uint64, _a1 error) *DB_GetBatchRangeDataSize_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetBatchRangeDataSize_Call) RunAndReturn(run func(context.Context, uint64, uint64) (uint64, error)) *DB_GetBatchRangeDataSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommittedKey provides a mock function with given fields: ctx, batchNum
+func (_m *DB) GetCommittedKey(ctx context.Context, batchNum uint64) (common.Hash, error) {
+	ret := _m.Called(ctx, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommittedKey")
+	}
+
+	var r0 common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (common.Hash, error)); ok {
+		return rf(ctx, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) common.Hash); ok {
+		r0 = rf(ctx, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetCommittedKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommittedKey'
+type DB_GetCommittedKey_Call struct {
+	*mock.Call
+}
+
+// GetCommittedKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+func (_e *DB_Expecter) GetCommittedKey(ctx interface{}, batchNum interface{}) *DB_GetCommittedKey_Call {
+	return &DB_GetCommittedKey_Call{Call: _e.mock.On("GetCommittedKey", ctx, batchNum)}
+}
+
+func (_c *DB_GetCommittedKey_Call) Run(run func(ctx context.Context, batchNum uint64)) *DB_GetCommittedKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetCommittedKey_Call) Return(_a0 common.Hash, _a1 error) *DB_GetCommittedKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetCommittedKey_Call) RunAndReturn(run func(context.Context, uint64) (common.Hash, error)) *DB_GetCommittedKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDistinctBatchNums provides a mock function with given fields: ctx, from, to
+func (_m *DB) GetDistinctBatchNums(ctx context.Context, from uint64, to uint64) ([]uint64, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDistinctBatchNums")
+	}
+
+	var r0 []uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]uint64, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []uint64); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetDistinctBatchNums_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDistinctBatchNums'
+type DB_GetDistinctBatchNums_Call struct {
+	*mock.Call
+}
+
+// GetDistinctBatchNums is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from uint64
+//   - to uint64
+func (_e *DB_Expecter) GetDistinctBatchNums(ctx interface{}, from interface{}, to interface{}) *DB_GetDistinctBatchNums_Call {
+	return &DB_GetDistinctBatchNums_Call{Call: _e.mock.On("GetDistinctBatchNums", ctx, from, to)}
+}
+
+func (_c *DB_GetDistinctBatchNums_Call) Run(run func(ctx context.Context, from uint64, to uint64)) *DB_GetDistinctBatchNums_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetDistinctBatchNums_Call) Return(_a0 []uint64, _a1 error) *DB_GetDistinctBatchNums_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetDistinctBatchNums_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]uint64, error)) *DB_GetDistinctBatchNums_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirstBatchSequencedAfter provides a mock function with given fields: ctx, l1Block
+func (_m *DB) GetFirstBatchSequencedAfter(ctx context.Context, l1Block uint64) (uint64, error) {
+	ret := _m.Called(ctx, l1Block)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirstBatchSequencedAfter")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (uint64, error)); ok {
+		return rf(ctx, l1Block)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) uint64); ok {
+		r0 = rf(ctx, l1Block)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, l1Block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetFirstBatchSequencedAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstBatchSequencedAfter'
+type DB_GetFirstBatchSequencedAfter_Call struct {
+	*mock.Call
+}
+
+// GetFirstBatchSequencedAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - l1Block uint64
+func (_e *DB_Expecter) GetFirstBatchSequencedAfter(ctx interface{}, l1Block interface{}) *DB_GetFirstBatchSequencedAfter_Call {
+	return &DB_GetFirstBatchSequencedAfter_Call{Call: _e.mock.On("GetFirstBatchSequencedAfter", ctx, l1Block)}
+}
+
+func (_c *DB_GetFirstBatchSequencedAfter_Call) Run(run func(ctx context.Context, l1Block uint64)) *DB_GetFirstBatchSequencedAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetFirstBatchSequencedAfter_Call) Return(_a0 uint64, _a1 error) *DB_GetFirstBatchSequencedAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetFirstBatchSequencedAfter_Call) RunAndReturn(run func(context.Context, uint64) (uint64, error)) *DB_GetFirstBatchSequencedAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirstStoredBatchNum provides a mock function with given fields: ctx
+func (_m *DB) GetFirstStoredBatchNum(ctx context.Context) (uint64, bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirstStoredBatchNum")
+	}
+
+	var r0 uint64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) bool); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DB_GetFirstStoredBatchNum_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirstStoredBatchNum'
+type DB_GetFirstStoredBatchNum_Call struct {
+	*mock.Call
+}
+
+// GetFirstStoredBatchNum is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) GetFirstStoredBatchNum(ctx interface{}) *DB_GetFirstStoredBatchNum_Call {
+	return &DB_GetFirstStoredBatchNum_Call{Call: _e.mock.On("GetFirstStoredBatchNum", ctx)}
+}
+
+func (_c *DB_GetFirstStoredBatchNum_Call) Run(run func(ctx context.Context)) *DB_GetFirstStoredBatchNum_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_GetFirstStoredBatchNum_Call) Return(_a0 uint64, _a1 bool, _a2 error) *DB_GetFirstStoredBatchNum_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *DB_GetFirstStoredBatchNum_Call) RunAndReturn(run func(context.Context) (uint64, bool, error)) *DB_GetFirstStoredBatchNum_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastProcessedBlock provides a mock function with given fields: ctx, task
+func (_m *DB) GetLastProcessedBlock(ctx context.Context, task string) (uint64, error) {
+	ret := _m.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastProcessedBlock")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uint64, error)); ok {
+		return rf(ctx, task)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
+		r0 = rf(ctx, task)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, task)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetLastProcessedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastProcessedBlock'
+type DB_GetLastProcessedBlock_Call struct {
+	*mock.Call
+}
+
+// GetLastProcessedBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task string
+func (_e *DB_Expecter) GetLastProcessedBlock(ctx interface{}, task interface{}) *DB_GetLastProcessedBlock_Call {
+	return &DB_GetLastProcessedBlock_Call{Call: _e.mock.On("GetLastProcessedBlock", ctx, task)}
+}
+
+func (_c *DB_GetLastProcessedBlock_Call) Run(run func(ctx context.Context, task string)) *DB_GetLastProcessedBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *DB_GetLastProcessedBlock_Call) Return(_a0 uint64, _a1 error) *DB_GetLastProcessedBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetLastProcessedBlock_Call) RunAndReturn(run func(context.Context, string) (uint64, error)) *DB_GetLastProcessedBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMissingBatchKey provides a mock function with given fields: ctx, hash
+func (_m *DB) GetMissingBatchKey(ctx context.Context, hash common.Hash) (*types.BatchKey, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMissingBatchKey")
+	}
+
+	var r0 *types.BatchKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.BatchKey, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.BatchKey); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BatchKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetMissingBatchKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMissingBatchKey'
+type DB_GetMissingBatchKey_Call struct {
+	*mock.Call
+}
+
+// GetMissingBatchKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash common.Hash
+func (_e *DB_Expecter) GetMissingBatchKey(ctx interface{}, hash interface{}) *DB_GetMissingBatchKey_Call {
+	return &DB_GetMissingBatchKey_Call{Call: _e.mock.On("GetMissingBatchKey", ctx, hash)}
+}
+
+func (_c *DB_GetMissingBatchKey_Call) Run(run func(ctx context.Context, hash common.Hash)) *DB_GetMissingBatchKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_GetMissingBatchKey_Call) Return(_a0 *types.BatchKey, _a1 error) *DB_GetMissingBatchKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetMissingBatchKey_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.BatchKey, error)) *DB_GetMissingBatchKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMissingBatchKeys provides a mock function with given fields: ctx, limit
+func (_m *DB) GetMissingBatchKeys(ctx context.Context, limit uint) ([]types.BatchKey, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMissingBatchKeys")
+	}
+
+	var r0 []types.BatchKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]types.BatchKey, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []types.BatchKey); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.BatchKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMissingBatchKeys'
+type DB_GetMissingBatchKeys_Call struct {
+	*mock.Call
+}
+
+// GetMissingBatchKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint
+func (_e *DB_Expecter) GetMissingBatchKeys(ctx interface{}, limit interface{}) *DB_GetMissingBatchKeys_Call {
+	return &DB_GetMissingBatchKeys_Call{Call: _e.mock.On("GetMissingBatchKeys", ctx, limit)}
+}
+
+func (_c *DB_GetMissingBatchKeys_Call) Run(run func(ctx context.Context, limit uint)) *DB_GetMissingBatchKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_GetMissingBatchKeys_Call) Return(_a0 []types.BatchKey, _a1 error) *DB_GetMissingBatchKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetMissingBatchKeys_Call) RunAndReturn(run func(context.Context, uint) ([]types.BatchKey, error)) *DB_GetMissingBatchKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMissingBatchKeysInRange provides a mock function with given fields: ctx, from, to
+func (_m *DB) GetMissingBatchKeysInRange(ctx context.Context, from uint64, to uint64) ([]types.BatchKey, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMissingBatchKeysInRange")
+	}
+
+	var r0 []types.BatchKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]types.BatchKey, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []types.BatchKey); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.BatchKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetMissingBatchKeysInRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMissingBatchKeysInRange'
+type DB_GetMissingBatchKeysInRange_Call struct {
+	*mock.Call
+}
+
+// GetMissingBatchKeysInRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from uint64
+//   - to uint64
+func (_e *DB_Expecter) GetMissingBatchKeysInRange(ctx interface{}, from interface{}, to interface{}) *DB_GetMissingBatchKeysInRange_Call {
+	return &DB_GetMissingBatchKeysInRange_Call{Call: _e.mock.On("GetMissingBatchKeysInRange", ctx, from, to)}
+}
+
+func (_c *DB_GetMissingBatchKeysInRange_Call) Run(run func(ctx context.Context, from uint64, to uint64)) *DB_GetMissingBatchKeysInRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetMissingBatchKeysInRange_Call) Return(_a0 []types.BatchKey, _a1 error) *DB_GetMissingBatchKeysInRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetMissingBatchKeysInRange_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]types.BatchKey, error)) *DB_GetMissingBatchKeysInRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOffChainData provides a mock function with given fields: ctx, key
+func (_m *DB) GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOffChainData")
+	}
+
+	var r0 *types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.OffChainData, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.OffChainData); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOffChainData'
+type DB_GetOffChainData_Call struct {
+	*mock.Call
+}
+
+// GetOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *DB_Expecter) GetOffChainData(ctx interface{}, key interface{}) *DB_GetOffChainData_Call {
+	return &DB_GetOffChainData_Call{Call: _e.mock.On("GetOffChainData", ctx, key)}
+}
+
+func (_c *DB_GetOffChainData_Call) Run(run func(ctx context.Context, key common.Hash)) *DB_GetOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_GetOffChainData_Call) Return(_a0 *types.OffChainData, _a1 error) *DB_GetOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetOffChainData_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.OffChainData, error)) *DB_GetOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOffChainDataAsOf provides a mock function with given fields: ctx, key, maxBatchNum
+func (_m *DB) GetOffChainDataAsOf(ctx context.Context, key common.Hash, maxBatchNum uint64) (*types.OffChainData, error) {
+	ret := _m.Called(ctx, key, maxBatchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOffChainDataAsOf")
+	}
+
+	var r0 *types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint64) (*types.OffChainData, error)); ok {
+		return rf(ctx, key, maxBatchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint64) *types.OffChainData); ok {
+		r0 = rf(ctx, key, maxBatchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash, uint64) error); ok {
+		r1 = rf(ctx, key, maxBatchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_GetOffChainDataAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOffChainDataAsOf'
+type DB_GetOffChainDataAsOf_Call struct {
+	*mock.Call
+}
+
+// GetOffChainDataAsOf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+//   - maxBatchNum uint64
+func (_e *DB_Expecter) GetOffChainDataAsOf(ctx interface{}, key interface{}, maxBatchNum interface{}) *DB_GetOffChainDataAsOf_Call {
+	return &DB_GetOffChainDataAsOf_Call{Call: _e.mock.On("GetOffChainDataAsOf", ctx, key, maxBatchNum)}
+}
+
+func (_c *DB_GetOffChainDataAsOf_Call) Run(run func(ctx context.Context, key common.Hash, maxBatchNum uint64)) *DB_GetOffChainDataAsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_GetOffChainDataAsOf_Call) Return(_a0 *types.OffChainData, _a1 error) *DB_GetOffChainDataAsOf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_GetOffChainDataAsOf_Call) RunAndReturn(run func(context.Context, common.Hash, uint64) (*types.OffChainData, error)) *DB_GetOffChainDataAsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementBatchAttempts provides a mock function with given fields: ctx, key
+func (_m *DB) IncrementBatchAttempts(ctx context.Context, key types.BatchKey) (uint, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementBatchAttempts")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.BatchKey) (uint, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.BatchKey) uint); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.BatchKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_IncrementBatchAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementBatchAttempts'
+type DB_IncrementBatchAttempts_Call struct {
+	*mock.Call
+}
+
+// IncrementBatchAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key types.BatchKey
+func (_e *DB_Expecter) IncrementBatchAttempts(ctx interface{}, key interface{}) *DB_IncrementBatchAttempts_Call {
+	return &DB_IncrementBatchAttempts_Call{Call: _e.mock.On("IncrementBatchAttempts", ctx, key)}
+}
+
+func (_c *DB_IncrementBatchAttempts_Call) Run(run func(ctx context.Context, key types.BatchKey)) *DB_IncrementBatchAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.BatchKey))
+	})
+	return _c
+}
+
+func (_c *DB_IncrementBatchAttempts_Call) Return(_a0 uint, _a1 error) *DB_IncrementBatchAttempts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_IncrementBatchAttempts_Call) RunAndReturn(run func(context.Context, types.BatchKey) (uint, error)) *DB_IncrementBatchAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertMissingBatchKeys provides a mock function with given fields: ctx, bks
+func (_m *DB) InsertMissingBatchKeys(ctx context.Context, bks []types.BatchKey) (uint64, error) {
+	ret := _m.Called(ctx, bks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertMissingBatchKeys")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.BatchKey) (uint64, error)); ok {
+		return rf(ctx, bks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.BatchKey) uint64); ok {
+		r0 = rf(ctx, bks)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.BatchKey) error); ok {
+		r1 = rf(ctx, bks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_InsertMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertMissingBatchKeys'
+type DB_InsertMissingBatchKeys_Call struct {
+	*mock.Call
+}
+
+// InsertMissingBatchKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bks []types.BatchKey
+func (_e *DB_Expecter) InsertMissingBatchKeys(ctx interface{}, bks interface{}) *DB_InsertMissingBatchKeys_Call {
+	return &DB_InsertMissingBatchKeys_Call{Call: _e.mock.On("InsertMissingBatchKeys", ctx, bks)}
+}
+
+func (_c *DB_InsertMissingBatchKeys_Call) Run(run func(ctx context.Context, bks []types.BatchKey)) *DB_InsertMissingBatchKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.BatchKey))
+	})
+	return _c
+}
+
+func (_c *DB_InsertMissingBatchKeys_Call) Return(_a0 uint64, _a1 error) *DB_InsertMissingBatchKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_InsertMissingBatchKeys_Call) RunAndReturn(run func(context.Context, []types.BatchKey) (uint64, error)) *DB_InsertMissingBatchKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFailedBatches provides a mock function with given fields: ctx
+func (_m *DB) ListFailedBatches(ctx context.Context) ([]types.FailedBatch, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFailedBatches")
+	}
+
+	var r0 []types.FailedBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]types.FailedBatch, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []types.FailedBatch); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.FailedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListFailedBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFailedBatches'
+type DB_ListFailedBatches_Call struct {
+	*mock.Call
+}
+
+// ListFailedBatches is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) ListFailedBatches(ctx interface{}) *DB_ListFailedBatches_Call {
+	return &DB_ListFailedBatches_Call{Call: _e.mock.On("ListFailedBatches", ctx)}
+}
+
+func (_c *DB_ListFailedBatches_Call) Run(run func(ctx context.Context)) *DB_ListFailedBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_ListFailedBatches_Call) Return(_a0 []types.FailedBatch, _a1 error) *DB_ListFailedBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListFailedBatches_Call) RunAndReturn(run func(context.Context) ([]types.FailedBatch, error)) *DB_ListFailedBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListForcedOffChainData provides a mock function with given fields: ctx, from, to, limit, offset
+func (_m *DB) ListForcedOffChainData(ctx context.Context, from uint64, to uint64, limit uint, offset uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, from, to, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListForcedOffChainData")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, from, to, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, from, to, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint, uint) error); ok {
+		r1 = rf(ctx, from, to, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListForcedOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListForcedOffChainData'
+type DB_ListForcedOffChainData_Call struct {
+	*mock.Call
+}
+
+// ListForcedOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from uint64
+//   - to uint64
+//   - limit uint
+//   - offset uint
+func (_e *DB_Expecter) ListForcedOffChainData(ctx interface{}, from interface{}, to interface{}, limit interface{}, offset interface{}) *DB_ListForcedOffChainData_Call {
+	return &DB_ListForcedOffChainData_Call{Call: _e.mock.On("ListForcedOffChainData", ctx, from, to, limit, offset)}
+}
+
+func (_c *DB_ListForcedOffChainData_Call) Run(run func(ctx context.Context, from uint64, to uint64, limit uint, offset uint)) *DB_ListForcedOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(uint), args[4].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListForcedOffChainData_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListForcedOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListForcedOffChainData_Call) RunAndReturn(run func(context.Context, uint64, uint64, uint, uint) ([]types.OffChainData, error)) *DB_ListForcedOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOffChainData provides a mock function with given fields: ctx, keys
+func (_m *DB) ListOffChainData(ctx context.Context, keys []common.Hash) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainData")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([]types.OffChainData, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) []types.OffChainData); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainData'
+type DB_ListOffChainData_Call struct {
+	*mock.Call
+}
+
+// ListOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []common.Hash
+func (_e *DB_Expecter) ListOffChainData(ctx interface{}, keys interface{}) *DB_ListOffChainData_Call {
+	return &DB_ListOffChainData_Call{Call: _e.mock.On("ListOffChainData", ctx, keys)}
+}
+
+func (_c *DB_ListOffChainData_Call) Run(run func(ctx context.Context, keys []common.Hash)) *DB_ListOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_ListOffChainData_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListOffChainData_Call) RunAndReturn(run func(context.Context, []common.Hash) ([]types.OffChainData, error)) *DB_ListOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOffChainDataAfterKey provides a mock function with given fields: ctx, from, to, after, limit
+func (_m *DB) ListOffChainDataAfterKey(ctx context.Context, from uint64, to uint64, after common.Hash, limit uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, from, to, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainDataAfterKey")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, common.Hash, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, from, to, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, common.Hash, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, from, to, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, common.Hash, uint) error); ok {
+		r1 = rf(ctx, from, to, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListOffChainDataAfterKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainDataAfterKey'
+type DB_ListOffChainDataAfterKey_Call struct {
+	*mock.Call
+}
+
+// ListOffChainDataAfterKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from uint64
+//   - to uint64
+//   - after common.Hash
+//   - limit uint
+func (_e *DB_Expecter) ListOffChainDataAfterKey(ctx interface{}, from interface{}, to interface{}, after interface{}, limit interface{}) *DB_ListOffChainDataAfterKey_Call {
+	return &DB_ListOffChainDataAfterKey_Call{Call: _e.mock.On("ListOffChainDataAfterKey", ctx, from, to, after, limit)}
+}
+
+func (_c *DB_ListOffChainDataAfterKey_Call) Run(run func(ctx context.Context, from uint64, to uint64, after common.Hash, limit uint)) *DB_ListOffChainDataAfterKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(common.Hash), args[4].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListOffChainDataAfterKey_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainDataAfterKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListOffChainDataAfterKey_Call) RunAndReturn(run func(context.Context, uint64, uint64, common.Hash, uint) ([]types.OffChainData, error)) *DB_ListOffChainDataAfterKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOffChainDataByBatch provides a mock function with given fields: ctx, batchNum, offset, limit
+func (_m *DB) ListOffChainDataByBatch(ctx context.Context, batchNum uint64, offset uint, limit uint) ([]types.OffChainData, uint64, error) {
+	ret := _m.Called(ctx, batchNum, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainDataByBatch")
+	}
+
+	var r0 []types.OffChainData
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint, uint) ([]types.OffChainData, uint64, error)); ok {
+		return rf(ctx, batchNum, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, batchNum, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint, uint) uint64); ok {
+		r1 = rf(ctx, batchNum, offset, limit)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, uint, uint) error); ok {
+		r2 = rf(ctx, batchNum, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DB_ListOffChainDataByBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainDataByBatch'
+type DB_ListOffChainDataByBatch_Call struct {
+	*mock.Call
+}
+
+// ListOffChainDataByBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNum uint64
+//   - offset uint
+//   - limit uint
+func (_e *DB_Expecter) ListOffChainDataByBatch(ctx interface{}, batchNum interface{}, offset interface{}, limit interface{}) *DB_ListOffChainDataByBatch_Call {
+	return &DB_ListOffChainDataByBatch_Call{Call: _e.mock.On("ListOffChainDataByBatch", ctx, batchNum, offset, limit)}
+}
+
+func (_c *DB_ListOffChainDataByBatch_Call) Run(run func(ctx context.Context, batchNum uint64, offset uint, limit uint)) *DB_ListOffChainDataByBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListOffChainDataByBatch_Call) Return(_a0 []types.OffChainData, _a1 uint64, _a2 error) *DB_ListOffChainDataByBatch_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *DB_ListOffChainDataByBatch_Call) RunAndReturn(run func(context.Context, uint64, uint, uint) ([]types.OffChainData, uint64, error)) *DB_ListOffChainDataByBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOffChainDataInBatchRange provides a mock function with given fields: ctx, from, to, limit, offset
+func (_m *DB) ListOffChainDataInBatchRange(ctx context.Context, from uint64, to uint64, limit uint, offset uint) ([]types.OffChainData, error) {
+	ret := _m.Called(ctx, from, to, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOffChainDataInBatchRange")
+	}
+
+	var r0 []types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint, uint) ([]types.OffChainData, error)); ok {
+		return rf(ctx, from, to, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint, uint) []types.OffChainData); ok {
+		r0 = rf(ctx, from, to, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint, uint) error); ok {
+		r1 = rf(ctx, from, to, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_ListOffChainDataInBatchRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOffChainDataInBatchRange'
+type DB_ListOffChainDataInBatchRange_Call struct {
+	*mock.Call
+}
+
+// ListOffChainDataInBatchRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from uint64
+//   - to uint64
+//   - limit uint
+//   - offset uint
+func (_e *DB_Expecter) ListOffChainDataInBatchRange(ctx interface{}, from interface{}, to interface{}, limit interface{}, offset interface{}) *DB_ListOffChainDataInBatchRange_Call {
+	return &DB_ListOffChainDataInBatchRange_Call{Call: _e.mock.On("ListOffChainDataInBatchRange", ctx, from, to, limit, offset)}
+}
+
+func (_c *DB_ListOffChainDataInBatchRange_Call) Run(run func(ctx context.Context, from uint64, to uint64, limit uint, offset uint)) *DB_ListOffChainDataInBatchRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(uint), args[4].(uint))
+	})
+	return _c
+}
+
+func (_c *DB_ListOffChainDataInBatchRange_Call) Return(_a0 []types.OffChainData, _a1 error) *DB_ListOffChainDataInBatchRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_ListOffChainDataInBatchRange_Call) RunAndReturn(run func(context.Context, uint64, uint64, uint, uint) ([]types.OffChainData, error)) *DB_ListOffChainDataInBatchRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFinalized provides a mock function with given fields: ctx, upToBatch
+func (_m *DB) MarkFinalized(ctx context.Context, upToBatch uint64) error {
+	ret := _m.Called(ctx, upToBatch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFinalized")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, upToBatch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_MarkFinalized_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFinalized'
+type DB_MarkFinalized_Call struct {
+	*mock.Call
+}
+
+// MarkFinalized is a helper method to define mock.On call
+//   - ctx context.Context
+//   - upToBatch uint64
+func (_e *DB_Expecter) MarkFinalized(ctx interface{}, upToBatch interface{}) *DB_MarkFinalized_Call {
+	return &DB_MarkFinalized_Call{Call: _e.mock.On("MarkFinalized", ctx, upToBatch)}
+}
+
+func (_c *DB_MarkFinalized_Call) Run(run func(ctx context.Context, upToBatch uint64)) *DB_MarkFinalized_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_MarkFinalized_Call) Return(_a0 error) *DB_MarkFinalized_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_MarkFinalized_Call) RunAndReturn(run func(context.Context, uint64) error) *DB_MarkFinalized_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OffChainDataExists provides a mock function with given fields: ctx, key
+func (_m *DB) OffChainDataExists(ctx context.Context, key common.Hash) (bool, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OffChainDataExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (bool, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_OffChainDataExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OffChainDataExists'
+type DB_OffChainDataExists_Call struct {
+	*mock.Call
+}
+
+// OffChainDataExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *DB_Expecter) OffChainDataExists(ctx interface{}, key interface{}) *DB_OffChainDataExists_Call {
+	return &DB_OffChainDataExists_Call{Call: _e.mock.On("OffChainDataExists", ctx, key)}
+}
+
+func (_c *DB_OffChainDataExists_Call) Run(run func(ctx context.Context, key common.Hash)) *DB_OffChainDataExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *DB_OffChainDataExists_Call) Return(_a0 bool, _a1 error) *DB_OffChainDataExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_OffChainDataExists_Call) RunAndReturn(run func(context.Context, common.Hash) (bool, error)) *DB_OffChainDataExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneFinalized provides a mock function with given fields: ctx, upToBatch
+func (_m *DB) PruneFinalized(ctx context.Context, upToBatch uint64) (uint64, error) {
+	ret := _m.Called(ctx, upToBatch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneFinalized")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (uint64, error)); ok {
+		return rf(ctx, upToBatch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) uint64); ok {
+		r0 = rf(ctx, upToBatch)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, upToBatch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_PruneFinalized_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneFinalized'
+type DB_PruneFinalized_Call struct {
+	*mock.Call
+}
+
+// PruneFinalized is a helper method to define mock.On call
+//   - ctx context.Context
+//   - upToBatch uint64
+func (_e *DB_Expecter) PruneFinalized(ctx interface{}, upToBatch interface{}) *DB_PruneFinalized_Call {
+	return &DB_PruneFinalized_Call{Call: _e.mock.On("PruneFinalized", ctx, upToBatch)}
+}
+
+func (_c *DB_PruneFinalized_Call) Run(run func(ctx context.Context, upToBatch uint64)) *DB_PruneFinalized_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_PruneFinalized_Call) Return(_a0 uint64, _a1 error) *DB_PruneFinalized_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_PruneFinalized_Call) RunAndReturn(run func(context.Context, uint64) (uint64, error)) *DB_PruneFinalized_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneResolvedBatches provides a mock function with given fields: ctx, before
+func (_m *DB) PruneResolvedBatches(ctx context.Context, before time.Time) (uint64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneResolvedBatches")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (uint64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) uint64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_PruneResolvedBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneResolvedBatches'
+type DB_PruneResolvedBatches_Call struct {
+	*mock.Call
+}
+
+// PruneResolvedBatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *DB_Expecter) PruneResolvedBatches(ctx interface{}, before interface{}) *DB_PruneResolvedBatches_Call {
+	return &DB_PruneResolvedBatches_Call{Call: _e.mock.On("PruneResolvedBatches", ctx, before)}
+}
+
+func (_c *DB_PruneResolvedBatches_Call) Run(run func(ctx context.Context, before time.Time)) *DB_PruneResolvedBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DB_PruneResolvedBatches_Call) Return(_a0 uint64, _a1 error) *DB_PruneResolvedBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_PruneResolvedBatches_Call) RunAndReturn(run func(context.Context, time.Time) (uint64, error)) *DB_PruneResolvedBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceOffChainData provides a mock function with given fields: ctx, key, newValue
+func (_m *DB) ReplaceOffChainData(ctx context.Context, key common.Hash, newValue []byte) error {
+	ret := _m.Called(ctx, key, newValue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceOffChainData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, []byte) error); ok {
+		r0 = rf(ctx, key, newValue)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_ReplaceOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceOffChainData'
+type DB_ReplaceOffChainData_Call struct {
+	*mock.Call
+}
+
+// ReplaceOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+//   - newValue []byte
+func (_e *DB_Expecter) ReplaceOffChainData(ctx interface{}, key interface{}, newValue interface{}) *DB_ReplaceOffChainData_Call {
+	return &DB_ReplaceOffChainData_Call{Call: _e.mock.On("ReplaceOffChainData", ctx, key, newValue)}
+}
+
+func (_c *DB_ReplaceOffChainData_Call) Run(run func(ctx context.Context, key common.Hash, newValue []byte)) *DB_ReplaceOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *DB_ReplaceOffChainData_Call) Return(_a0 error) *DB_ReplaceOffChainData_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_ReplaceOffChainData_Call) RunAndReturn(run func(context.Context, common.Hash, []byte) error) *DB_ReplaceOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueFailedBatch provides a mock function with given fields: ctx, key
+func (_m *DB) RequeueFailedBatch(ctx context.Context, key types.BatchKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueFailedBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.BatchKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_RequeueFailedBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueFailedBatch'
+type DB_RequeueFailedBatch_Call struct {
+	*mock.Call
+}
+
+// RequeueFailedBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key types.BatchKey
+func (_e *DB_Expecter) RequeueFailedBatch(ctx interface{}, key interface{}) *DB_RequeueFailedBatch_Call {
+	return &DB_RequeueFailedBatch_Call{Call: _e.mock.On("RequeueFailedBatch", ctx, key)}
+}
+
+func (_c *DB_RequeueFailedBatch_Call) Run(run func(ctx context.Context, key types.BatchKey)) *DB_RequeueFailedBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.BatchKey))
+	})
+	return _c
+}
+
+func (_c *DB_RequeueFailedBatch_Call) Return(_a0 error) *DB_RequeueFailedBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_RequeueFailedBatch_Call) RunAndReturn(run func(context.Context, types.BatchKey) error) *DB_RequeueFailedBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunMaintenance provides a mock function with given fields: ctx
+func (_m *DB) RunMaintenance(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunMaintenance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_RunMaintenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunMaintenance'
+type DB_RunMaintenance_Call struct {
+	*mock.Call
+}
+
+// RunMaintenance is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) RunMaintenance(ctx interface{}) *DB_RunMaintenance_Call {
+	return &DB_RunMaintenance_Call{Call: _e.mock.On("RunMaintenance", ctx)}
+}
+
+func (_c *DB_RunMaintenance_Call) Run(run func(ctx context.Context)) *DB_RunMaintenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_RunMaintenance_Call) Return(_a0 error) *DB_RunMaintenance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_RunMaintenance_Call) RunAndReturn(run func(context.Context) error) *DB_RunMaintenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreFailedBatch provides a mock function with given fields: ctx, key, reason
+func (_m *DB) StoreFailedBatch(ctx context.Context, key types.BatchKey, reason string) error {
+	ret := _m.Called(ctx, key, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreFailedBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.BatchKey, string) error); ok {
+		r0 = rf(ctx, key, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreFailedBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreFailedBatch'
+type DB_StoreFailedBatch_Call struct {
+	*mock.Call
+}
+
+// StoreFailedBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key types.BatchKey
+//   - reason string
+func (_e *DB_Expecter) StoreFailedBatch(ctx interface{}, key interface{}, reason interface{}) *DB_StoreFailedBatch_Call {
+	return &DB_StoreFailedBatch_Call{Call: _e.mock.On("StoreFailedBatch", ctx, key, reason)}
+}
+
+func (_c *DB_StoreFailedBatch_Call) Run(run func(ctx context.Context, key types.BatchKey, reason string)) *DB_StoreFailedBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.BatchKey), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DB_StoreFailedBatch_Call) Return(_a0 error) *DB_StoreFailedBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreFailedBatch_Call) RunAndReturn(run func(context.Context, types.BatchKey, string) error) *DB_StoreFailedBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreL1TxHash provides a mock function with given fields: ctx, from, keys, txHash, l1Block
+func (_m *DB) StoreL1TxHash(ctx context.Context, from uint64, keys []common.Hash, txHash common.Hash, l1Block uint64) error {
+	ret := _m.Called(ctx, from, keys, txHash, l1Block)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreL1TxHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []common.Hash, common.Hash, uint64) error); ok {
+		r0 = rf(ctx, from, keys, txHash, l1Block)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreL1TxHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreL1TxHash'
+type DB_StoreL1TxHash_Call struct {
+	*mock.Call
+}
+
+// StoreL1TxHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from uint64
+//   - keys []common.Hash
+//   - txHash common.Hash
+//   - l1Block uint64
+func (_e *DB_Expecter) StoreL1TxHash(ctx interface{}, from interface{}, keys interface{}, txHash interface{}, l1Block interface{}) *DB_StoreL1TxHash_Call {
+	return &DB_StoreL1TxHash_Call{Call: _e.mock.On("StoreL1TxHash", ctx, from, keys, txHash, l1Block)}
+}
+
+func (_c *DB_StoreL1TxHash_Call) Run(run func(ctx context.Context, from uint64, keys []common.Hash, txHash common.Hash, l1Block uint64)) *DB_StoreL1TxHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].([]common.Hash), args[3].(common.Hash), args[4].(uint64))
+	})
+	return _c
+}
+
+func (_c *DB_StoreL1TxHash_Call) Return(_a0 error) *DB_StoreL1TxHash_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreL1TxHash_Call) RunAndReturn(run func(context.Context, uint64, []common.Hash, common.Hash, uint64) error) *DB_StoreL1TxHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreLastProcessedBlock provides a mock function with given fields: ctx, block, task
+func (_m *DB) StoreLastProcessedBlock(ctx context.Context, block uint64, task string) error {
+	ret := _m.Called(ctx, block, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreLastProcessedBlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
+		r0 = rf(ctx, block, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreLastProcessedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreLastProcessedBlock'
+type DB_StoreLastProcessedBlock_Call struct {
+	*mock.Call
+}
+
+// StoreLastProcessedBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - block uint64
+//   - task string
+func (_e *DB_Expecter) StoreLastProcessedBlock(ctx interface{}, block interface{}, task interface{}) *DB_StoreLastProcessedBlock_Call {
+	return &DB_StoreLastProcessedBlock_Call{Call: _e.mock.On("StoreLastProcessedBlock", ctx, block, task)}
+}
+
+func (_c *DB_StoreLastProcessedBlock_Call) Run(run func(ctx context.Context, block uint64, task string)) *DB_StoreLastProcessedBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DB_StoreLastProcessedBlock_Call) Return(_a0 error) *DB_StoreLastProcessedBlock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreLastProcessedBlock_Call) RunAndReturn(run func(context.Context, uint64, string) error) *DB_StoreLastProcessedBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreMissingBatchKeys provides a mock function with given fields: ctx, bks
+func (_m *DB) StoreMissingBatchKeys(ctx context.Context, bks []types.BatchKey) error {
+	ret := _m.Called(ctx, bks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreMissingBatchKeys")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.BatchKey) error); ok {
+		r0 = rf(ctx, bks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreMissingBatchKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreMissingBatchKeys'
+type DB_StoreMissingBatchKeys_Call struct {
+	*mock.Call
+}
+
+// StoreMissingBatchKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bks []types.BatchKey
+func (_e *DB_Expecter) StoreMissingBatchKeys(ctx interface{}, bks interface{}) *DB_StoreMissingBatchKeys_Call {
+	return &DB_StoreMissingBatchKeys_Call{Call: _e.mock.On("StoreMissingBatchKeys", ctx, bks)}
+}
+
+func (_c *DB_StoreMissingBatchKeys_Call) Run(run func(ctx context.Context, bks []types.BatchKey)) *DB_StoreMissingBatchKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.BatchKey))
+	})
+	return _c
+}
+
+func (_c *DB_StoreMissingBatchKeys_Call) Return(_a0 error) *DB_StoreMissingBatchKeys_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreMissingBatchKeys_Call) RunAndReturn(run func(context.Context, []types.BatchKey) error) *DB_StoreMissingBatchKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreOffChainData provides a mock function with given fields: ctx, od
+func (_m *DB) StoreOffChainData(ctx context.Context, od []types.OffChainData) error {
+	ret := _m.Called(ctx, od)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreOffChainData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.OffChainData) error); ok {
+		r0 = rf(ctx, od)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreOffChainData'
 type DB_StoreOffChainData_Call struct {
 	*mock.Call
 }
@@ -504,6 +2537,169 @@ func (_c *DB_StoreOffChainData_Call) RunAndReturn(run func(context.Context, []ty
 	return _c
 }
 
+// StoreOffChainDataPartial provides a mock function with given fields: ctx, od
+func (_m *DB) StoreOffChainDataPartial(ctx context.Context, od []types.OffChainData) ([]types.FailedRecord, error) {
+	ret := _m.Called(ctx, od)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreOffChainDataPartial")
+	}
+
+	var r0 []types.FailedRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.OffChainData) ([]types.FailedRecord, error)); ok {
+		return rf(ctx, od)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []types.OffChainData) []types.FailedRecord); ok {
+		r0 = rf(ctx, od)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.FailedRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []types.OffChainData) error); ok {
+		r1 = rf(ctx, od)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_StoreOffChainDataPartial_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreOffChainDataPartial'
+type DB_StoreOffChainDataPartial_Call struct {
+	*mock.Call
+}
+
+// StoreOffChainDataPartial is a helper method to define mock.On call
+//   - ctx context.Context
+//   - od []types.OffChainData
+func (_e *DB_Expecter) StoreOffChainDataPartial(ctx interface{}, od interface{}) *DB_StoreOffChainDataPartial_Call {
+	return &DB_StoreOffChainDataPartial_Call{Call: _e.mock.On("StoreOffChainDataPartial", ctx, od)}
+}
+
+func (_c *DB_StoreOffChainDataPartial_Call) Run(run func(ctx context.Context, od []types.OffChainData)) *DB_StoreOffChainDataPartial_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.OffChainData))
+	})
+	return _c
+}
+
+func (_c *DB_StoreOffChainDataPartial_Call) Return(_a0 []types.FailedRecord, _a1 error) *DB_StoreOffChainDataPartial_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_StoreOffChainDataPartial_Call) RunAndReturn(run func(context.Context, []types.OffChainData) ([]types.FailedRecord, error)) *DB_StoreOffChainDataPartial_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StoreOffChainDataTx provides a mock function with given fields: ctx, od, tx
+func (_m *DB) StoreOffChainDataTx(ctx context.Context, od []types.OffChainData, tx db.Tx) error {
+	ret := _m.Called(ctx, od, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreOffChainDataTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []types.OffChainData, db.Tx) error); ok {
+		r0 = rf(ctx, od, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DB_StoreOffChainDataTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StoreOffChainDataTx'
+type DB_StoreOffChainDataTx_Call struct {
+	*mock.Call
+}
+
+// StoreOffChainDataTx is a helper method to define mock.On call
+//   - ctx context.Context
+//   - od []types.OffChainData
+//   - tx db.Tx
+func (_e *DB_Expecter) StoreOffChainDataTx(ctx interface{}, od interface{}, tx interface{}) *DB_StoreOffChainDataTx_Call {
+	return &DB_StoreOffChainDataTx_Call{Call: _e.mock.On("StoreOffChainDataTx", ctx, od, tx)}
+}
+
+func (_c *DB_StoreOffChainDataTx_Call) Run(run func(ctx context.Context, od []types.OffChainData, tx db.Tx)) *DB_StoreOffChainDataTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]types.OffChainData), args[2].(db.Tx))
+	})
+	return _c
+}
+
+func (_c *DB_StoreOffChainDataTx_Call) Return(_a0 error) *DB_StoreOffChainDataTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DB_StoreOffChainDataTx_Call) RunAndReturn(run func(context.Context, []types.OffChainData, db.Tx) error) *DB_StoreOffChainDataTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TotalStoredBytes provides a mock function with given fields: ctx
+func (_m *DB) TotalStoredBytes(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalStoredBytes")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB_TotalStoredBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalStoredBytes'
+type DB_TotalStoredBytes_Call struct {
+	*mock.Call
+}
+
+// TotalStoredBytes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DB_Expecter) TotalStoredBytes(ctx interface{}) *DB_TotalStoredBytes_Call {
+	return &DB_TotalStoredBytes_Call{Call: _e.mock.On("TotalStoredBytes", ctx)}
+}
+
+func (_c *DB_TotalStoredBytes_Call) Run(run func(ctx context.Context)) *DB_TotalStoredBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DB_TotalStoredBytes_Call) Return(_a0 uint64, _a1 error) *DB_TotalStoredBytes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DB_TotalStoredBytes_Call) RunAndReturn(run func(context.Context) (uint64, error)) *DB_TotalStoredBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDB creates a new instance of DB. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDB(t interface {
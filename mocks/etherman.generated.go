@@ -5,7 +5,10 @@ package mocks
 import (
 	big "math/big"
 
+	bananapolygonvalidiumetrog "github.com/0xPolygon/cdk-contracts-tooling/contracts/banana/polygonvalidiumetrog"
+
 	bind "github.com/ethereum/go-ethereum/accounts/abi/bind"
+
 	common "github.com/ethereum/go-ethereum/common"
 
 	context "context"
@@ -212,6 +215,183 @@ func (_c *Etherman_FilterSequenceBatches_Call) RunAndReturn(run func(*bind.Filte
 	return _c
 }
 
+// FilterSequenceBatchesBanana provides a mock function with given fields: ctx, startBlock, numBatch
+func (_m *Etherman) FilterSequenceBatchesBanana(ctx context.Context, startBlock uint64, numBatch []uint64) ([]*bananapolygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error) {
+	ret := _m.Called(ctx, startBlock, numBatch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterSequenceBatchesBanana")
+	}
+
+	var r0 []*bananapolygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []uint64) ([]*bananapolygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error)); ok {
+		return rf(ctx, startBlock, numBatch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []uint64) []*bananapolygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches); ok {
+		r0 = rf(ctx, startBlock, numBatch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bananapolygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []uint64) error); ok {
+		r1 = rf(ctx, startBlock, numBatch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_FilterSequenceBatchesBanana_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterSequenceBatchesBanana'
+type Etherman_FilterSequenceBatchesBanana_Call struct {
+	*mock.Call
+}
+
+// FilterSequenceBatchesBanana is a helper method to define mock.On call
+//   - ctx context.Context
+//   - startBlock uint64
+//   - numBatch []uint64
+func (_e *Etherman_Expecter) FilterSequenceBatchesBanana(ctx interface{}, startBlock interface{}, numBatch interface{}) *Etherman_FilterSequenceBatchesBanana_Call {
+	return &Etherman_FilterSequenceBatchesBanana_Call{Call: _e.mock.On("FilterSequenceBatchesBanana", ctx, startBlock, numBatch)}
+}
+
+func (_c *Etherman_FilterSequenceBatchesBanana_Call) Run(run func(ctx context.Context, startBlock uint64, numBatch []uint64)) *Etherman_FilterSequenceBatchesBanana_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].([]uint64))
+	})
+	return _c
+}
+
+func (_c *Etherman_FilterSequenceBatchesBanana_Call) Return(_a0 []*bananapolygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, _a1 error) *Etherman_FilterSequenceBatchesBanana_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_FilterSequenceBatchesBanana_Call) RunAndReturn(run func(context.Context, uint64, []uint64) ([]*bananapolygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error)) *Etherman_FilterSequenceBatchesBanana_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FilterSequenceBatchesByNumbers provides a mock function with given fields: opts, nums
+func (_m *Etherman) FilterSequenceBatchesByNumbers(opts *bind.FilterOpts, nums []uint64) ([]*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error) {
+	ret := _m.Called(opts, nums)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterSequenceBatchesByNumbers")
+	}
+
+	var r0 []*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bind.FilterOpts, []uint64) ([]*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error)); ok {
+		return rf(opts, nums)
+	}
+	if rf, ok := ret.Get(0).(func(*bind.FilterOpts, []uint64) []*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches); ok {
+		r0 = rf(opts, nums)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bind.FilterOpts, []uint64) error); ok {
+		r1 = rf(opts, nums)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_FilterSequenceBatchesByNumbers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterSequenceBatchesByNumbers'
+type Etherman_FilterSequenceBatchesByNumbers_Call struct {
+	*mock.Call
+}
+
+// FilterSequenceBatchesByNumbers is a helper method to define mock.On call
+//   - opts *bind.FilterOpts
+//   - nums []uint64
+func (_e *Etherman_Expecter) FilterSequenceBatchesByNumbers(opts interface{}, nums interface{}) *Etherman_FilterSequenceBatchesByNumbers_Call {
+	return &Etherman_FilterSequenceBatchesByNumbers_Call{Call: _e.mock.On("FilterSequenceBatchesByNumbers", opts, nums)}
+}
+
+func (_c *Etherman_FilterSequenceBatchesByNumbers_Call) Run(run func(opts *bind.FilterOpts, nums []uint64)) *Etherman_FilterSequenceBatchesByNumbers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*bind.FilterOpts), args[1].([]uint64))
+	})
+	return _c
+}
+
+func (_c *Etherman_FilterSequenceBatchesByNumbers_Call) Return(_a0 []*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, _a1 error) *Etherman_FilterSequenceBatchesByNumbers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_FilterSequenceBatchesByNumbers_Call) RunAndReturn(run func(*bind.FilterOpts, []uint64) ([]*polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches, error)) *Etherman_FilterSequenceBatchesByNumbers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FilterVerifyBatches provides a mock function with given fields: opts
+func (_m *Etherman) FilterVerifyBatches(opts *bind.FilterOpts) ([]*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches, error) {
+	ret := _m.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterVerifyBatches")
+	}
+
+	var r0 []*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*bind.FilterOpts) ([]*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches, error)); ok {
+		return rf(opts)
+	}
+	if rf, ok := ret.Get(0).(func(*bind.FilterOpts) []*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*bind.FilterOpts) error); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_FilterVerifyBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterVerifyBatches'
+type Etherman_FilterVerifyBatches_Call struct {
+	*mock.Call
+}
+
+// FilterVerifyBatches is a helper method to define mock.On call
+//   - opts *bind.FilterOpts
+func (_e *Etherman_Expecter) FilterVerifyBatches(opts interface{}) *Etherman_FilterVerifyBatches_Call {
+	return &Etherman_FilterVerifyBatches_Call{Call: _e.mock.On("FilterVerifyBatches", opts)}
+}
+
+func (_c *Etherman_FilterVerifyBatches_Call) Run(run func(opts *bind.FilterOpts)) *Etherman_FilterVerifyBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*bind.FilterOpts))
+	})
+	return _c
+}
+
+func (_c *Etherman_FilterVerifyBatches_Call) Return(_a0 []*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches, _a1 error) *Etherman_FilterVerifyBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_FilterVerifyBatches_Call) RunAndReturn(run func(*bind.FilterOpts) ([]*polygonvalidiumetrog.PolygonvalidiumetrogVerifyBatches, error)) *Etherman_FilterVerifyBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCurrentDataCommittee provides a mock function with given fields:
 func (_m *Etherman) GetCurrentDataCommittee() (*etherman.DataCommittee, error) {
 	ret := _m.Called()
@@ -326,6 +506,120 @@ func (_c *Etherman_GetCurrentDataCommitteeMembers_Call) RunAndReturn(run func()
 	return _c
 }
 
+// GetDataCommitteeAtBlock provides a mock function with given fields: ctx, blockNumber
+func (_m *Etherman) GetDataCommitteeAtBlock(ctx context.Context, blockNumber uint64) (*etherman.DataCommittee, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDataCommitteeAtBlock")
+	}
+
+	var r0 *etherman.DataCommittee
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*etherman.DataCommittee, error)); ok {
+		return rf(ctx, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *etherman.DataCommittee); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*etherman.DataCommittee)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_GetDataCommitteeAtBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDataCommitteeAtBlock'
+type Etherman_GetDataCommitteeAtBlock_Call struct {
+	*mock.Call
+}
+
+// GetDataCommitteeAtBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+func (_e *Etherman_Expecter) GetDataCommitteeAtBlock(ctx interface{}, blockNumber interface{}) *Etherman_GetDataCommitteeAtBlock_Call {
+	return &Etherman_GetDataCommitteeAtBlock_Call{Call: _e.mock.On("GetDataCommitteeAtBlock", ctx, blockNumber)}
+}
+
+func (_c *Etherman_GetDataCommitteeAtBlock_Call) Run(run func(ctx context.Context, blockNumber uint64)) *Etherman_GetDataCommitteeAtBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *Etherman_GetDataCommitteeAtBlock_Call) Return(_a0 *etherman.DataCommittee, _a1 error) *Etherman_GetDataCommitteeAtBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_GetDataCommitteeAtBlock_Call) RunAndReturn(run func(context.Context, uint64) (*etherman.DataCommittee, error)) *Etherman_GetDataCommitteeAtBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRequiredSignatures provides a mock function with given fields:
+func (_m *Etherman) GetRequiredSignatures() (uint64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRequiredSignatures")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Etherman_GetRequiredSignatures_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRequiredSignatures'
+type Etherman_GetRequiredSignatures_Call struct {
+	*mock.Call
+}
+
+// GetRequiredSignatures is a helper method to define mock.On call
+func (_e *Etherman_Expecter) GetRequiredSignatures() *Etherman_GetRequiredSignatures_Call {
+	return &Etherman_GetRequiredSignatures_Call{Call: _e.mock.On("GetRequiredSignatures")}
+}
+
+func (_c *Etherman_GetRequiredSignatures_Call) Run(run func()) *Etherman_GetRequiredSignatures_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Etherman_GetRequiredSignatures_Call) Return(_a0 uint64, _a1 error) *Etherman_GetRequiredSignatures_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Etherman_GetRequiredSignatures_Call) RunAndReturn(run func() (uint64, error)) *Etherman_GetRequiredSignatures_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTx provides a mock function with given fields: ctx, txHash
 func (_m *Etherman) GetTx(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
 	ret := _m.Called(ctx, txHash)
@@ -451,6 +745,51 @@ func (_c *Etherman_HeaderByNumber_Call) RunAndReturn(run func(context.Context, *
 	return _c
 }
 
+// IsConnected provides a mock function with given fields:
+func (_m *Etherman) IsConnected() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsConnected")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Etherman_IsConnected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsConnected'
+type Etherman_IsConnected_Call struct {
+	*mock.Call
+}
+
+// IsConnected is a helper method to define mock.On call
+func (_e *Etherman_Expecter) IsConnected() *Etherman_IsConnected_Call {
+	return &Etherman_IsConnected_Call{Call: _e.mock.On("IsConnected")}
+}
+
+func (_c *Etherman_IsConnected_Call) Run(run func()) *Etherman_IsConnected_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Etherman_IsConnected_Call) Return(_a0 bool) *Etherman_IsConnected_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Etherman_IsConnected_Call) RunAndReturn(run func() bool) *Etherman_IsConnected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TrustedSequencer provides a mock function with given fields: ctx
 func (_m *Etherman) TrustedSequencer(ctx context.Context) (common.Address, error) {
 	ret := _m.Called(ctx)
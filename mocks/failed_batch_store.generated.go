@@ -0,0 +1,143 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// FailedBatchStore is an autogenerated mock type for the FailedBatchStore type
+type FailedBatchStore struct {
+	mock.Mock
+}
+
+type FailedBatchStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FailedBatchStore) EXPECT() *FailedBatchStore_Expecter {
+	return &FailedBatchStore_Expecter{mock: &_m.Mock}
+}
+
+// ListFailedBatches provides a mock function with given fields: ctx
+func (_m *FailedBatchStore) ListFailedBatches(ctx context.Context) ([]types.FailedBatch, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFailedBatches")
+	}
+
+	var r0 []types.FailedBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]types.FailedBatch, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []types.FailedBatch); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.FailedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FailedBatchStore_ListFailedBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFailedBatches'
+type FailedBatchStore_ListFailedBatches_Call struct {
+	*mock.Call
+}
+
+// ListFailedBatches is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *FailedBatchStore_Expecter) ListFailedBatches(ctx interface{}) *FailedBatchStore_ListFailedBatches_Call {
+	return &FailedBatchStore_ListFailedBatches_Call{Call: _e.mock.On("ListFailedBatches", ctx)}
+}
+
+func (_c *FailedBatchStore_ListFailedBatches_Call) Run(run func(ctx context.Context)) *FailedBatchStore_ListFailedBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *FailedBatchStore_ListFailedBatches_Call) Return(_a0 []types.FailedBatch, _a1 error) *FailedBatchStore_ListFailedBatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FailedBatchStore_ListFailedBatches_Call) RunAndReturn(run func(context.Context) ([]types.FailedBatch, error)) *FailedBatchStore_ListFailedBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueFailedBatch provides a mock function with given fields: ctx, key
+func (_m *FailedBatchStore) RequeueFailedBatch(ctx context.Context, key types.BatchKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueFailedBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.BatchKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailedBatchStore_RequeueFailedBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueFailedBatch'
+type FailedBatchStore_RequeueFailedBatch_Call struct {
+	*mock.Call
+}
+
+// RequeueFailedBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key types.BatchKey
+func (_e *FailedBatchStore_Expecter) RequeueFailedBatch(ctx interface{}, key interface{}) *FailedBatchStore_RequeueFailedBatch_Call {
+	return &FailedBatchStore_RequeueFailedBatch_Call{Call: _e.mock.On("RequeueFailedBatch", ctx, key)}
+}
+
+func (_c *FailedBatchStore_RequeueFailedBatch_Call) Run(run func(ctx context.Context, key types.BatchKey)) *FailedBatchStore_RequeueFailedBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.BatchKey))
+	})
+	return _c
+}
+
+func (_c *FailedBatchStore_RequeueFailedBatch_Call) Return(_a0 error) *FailedBatchStore_RequeueFailedBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FailedBatchStore_RequeueFailedBatch_Call) RunAndReturn(run func(context.Context, types.BatchKey) error) *FailedBatchStore_RequeueFailedBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFailedBatchStore creates a new instance of FailedBatchStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFailedBatchStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FailedBatchStore {
+	mock := &FailedBatchStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// Fetcher is an autogenerated mock type for the Fetcher type
+type Fetcher struct {
+	mock.Mock
+}
+
+type Fetcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Fetcher) EXPECT() *Fetcher_Expecter {
+	return &Fetcher_Expecter{mock: &_m.Mock}
+}
+
+// FetchOffChainData provides a mock function with given fields: ctx, key
+func (_m *Fetcher) FetchOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOffChainData")
+	}
+
+	var r0 *types.OffChainData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.OffChainData, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.OffChainData); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OffChainData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Fetcher_FetchOffChainData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FetchOffChainData'
+type Fetcher_FetchOffChainData_Call struct {
+	*mock.Call
+}
+
+// FetchOffChainData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *Fetcher_Expecter) FetchOffChainData(ctx interface{}, key interface{}) *Fetcher_FetchOffChainData_Call {
+	return &Fetcher_FetchOffChainData_Call{Call: _e.mock.On("FetchOffChainData", ctx, key)}
+}
+
+func (_c *Fetcher_FetchOffChainData_Call) Run(run func(ctx context.Context, key common.Hash)) *Fetcher_FetchOffChainData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Fetcher_FetchOffChainData_Call) Return(_a0 *types.OffChainData, _a1 error) *Fetcher_FetchOffChainData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Fetcher_FetchOffChainData_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.OffChainData, error)) *Fetcher_FetchOffChainData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFetcher creates a new instance of Fetcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFetcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Fetcher {
+	mock := &Fetcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
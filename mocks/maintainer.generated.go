@@ -0,0 +1,82 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Maintainer is an autogenerated mock type for the Maintainer type
+type Maintainer struct {
+	mock.Mock
+}
+
+type Maintainer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Maintainer) EXPECT() *Maintainer_Expecter {
+	return &Maintainer_Expecter{mock: &_m.Mock}
+}
+
+// RunMaintenance provides a mock function with given fields: ctx
+func (_m *Maintainer) RunMaintenance(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunMaintenance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Maintainer_RunMaintenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunMaintenance'
+type Maintainer_RunMaintenance_Call struct {
+	*mock.Call
+}
+
+// RunMaintenance is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Maintainer_Expecter) RunMaintenance(ctx interface{}) *Maintainer_RunMaintenance_Call {
+	return &Maintainer_RunMaintenance_Call{Call: _e.mock.On("RunMaintenance", ctx)}
+}
+
+func (_c *Maintainer_RunMaintenance_Call) Run(run func(ctx context.Context)) *Maintainer_RunMaintenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Maintainer_RunMaintenance_Call) Return(_a0 error) *Maintainer_RunMaintenance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Maintainer_RunMaintenance_Call) RunAndReturn(run func(context.Context) error) *Maintainer_RunMaintenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMaintainer creates a new instance of Maintainer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMaintainer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Maintainer {
+	mock := &Maintainer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
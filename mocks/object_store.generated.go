@@ -0,0 +1,145 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ObjectStore is an autogenerated mock type for the ObjectStore type
+type ObjectStore struct {
+	mock.Mock
+}
+
+type ObjectStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ObjectStore) EXPECT() *ObjectStore_Expecter {
+	return &ObjectStore_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *ObjectStore) Get(ctx context.Context, key common.Hash) ([]byte, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]byte, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []byte); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ObjectStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ObjectStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+func (_e *ObjectStore_Expecter) Get(ctx interface{}, key interface{}) *ObjectStore_Get_Call {
+	return &ObjectStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *ObjectStore_Get_Call) Run(run func(ctx context.Context, key common.Hash)) *ObjectStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *ObjectStore_Get_Call) Return(_a0 []byte, _a1 error) *ObjectStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ObjectStore_Get_Call) RunAndReturn(run func(context.Context, common.Hash) ([]byte, error)) *ObjectStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, key, value
+func (_m *ObjectStore) Put(ctx context.Context, key common.Hash, value []byte) error {
+	ret := _m.Called(ctx, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, []byte) error); ok {
+		r0 = rf(ctx, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ObjectStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type ObjectStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key common.Hash
+//   - value []byte
+func (_e *ObjectStore_Expecter) Put(ctx interface{}, key interface{}, value interface{}) *ObjectStore_Put_Call {
+	return &ObjectStore_Put_Call{Call: _e.mock.On("Put", ctx, key, value)}
+}
+
+func (_c *ObjectStore_Put_Call) Run(run func(ctx context.Context, key common.Hash, value []byte)) *ObjectStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *ObjectStore_Put_Call) Return(_a0 error) *ObjectStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ObjectStore_Put_Call) RunAndReturn(run func(context.Context, common.Hash, []byte) error) *ObjectStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewObjectStore creates a new instance of ObjectStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewObjectStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ObjectStore {
+	mock := &ObjectStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	sequencer "github.com/0xPolygon/cdk-data-availability/sequencer"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SequencerClient is an autogenerated mock type for the SequencerClient type
+type SequencerClient struct {
+	mock.Mock
+}
+
+type SequencerClient_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SequencerClient) EXPECT() *SequencerClient_Expecter {
+	return &SequencerClient_Expecter{mock: &_m.Mock}
+}
+
+// GetData provides a mock function with given fields: ctx, url, batchNum
+func (_m *SequencerClient) GetData(ctx context.Context, url string, batchNum uint64) (*sequencer.SeqBatch, error) {
+	ret := _m.Called(ctx, url, batchNum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetData")
+	}
+
+	var r0 *sequencer.SeqBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) (*sequencer.SeqBatch, error)); ok {
+		return rf(ctx, url, batchNum)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) *sequencer.SeqBatch); ok {
+		r0 = rf(ctx, url, batchNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sequencer.SeqBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(ctx, url, batchNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SequencerClient_GetData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetData'
+type SequencerClient_GetData_Call struct {
+	*mock.Call
+}
+
+// GetData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - batchNum uint64
+func (_e *SequencerClient_Expecter) GetData(ctx interface{}, url interface{}, batchNum interface{}) *SequencerClient_GetData_Call {
+	return &SequencerClient_GetData_Call{Call: _e.mock.On("GetData", ctx, url, batchNum)}
+}
+
+func (_c *SequencerClient_GetData_Call) Run(run func(ctx context.Context, url string, batchNum uint64)) *SequencerClient_GetData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *SequencerClient_GetData_Call) Return(_a0 *sequencer.SeqBatch, _a1 error) *SequencerClient_GetData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SequencerClient_GetData_Call) RunAndReturn(run func(context.Context, string, uint64) (*sequencer.SeqBatch, error)) *SequencerClient_GetData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSequencerClient creates a new instance of SequencerClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSequencerClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SequencerClient {
+	mock := &SequencerClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
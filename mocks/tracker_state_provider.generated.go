@@ -0,0 +1,80 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	sequencer "github.com/0xPolygon/cdk-data-availability/sequencer"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TrackerStateProvider is an autogenerated mock type for the TrackerStateProvider type
+type TrackerStateProvider struct {
+	mock.Mock
+}
+
+type TrackerStateProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *TrackerStateProvider) EXPECT() *TrackerStateProvider_Expecter {
+	return &TrackerStateProvider_Expecter{mock: &_m.Mock}
+}
+
+// Snapshot provides a mock function with given fields:
+func (_m *TrackerStateProvider) Snapshot() sequencer.TrackerSnapshot {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Snapshot")
+	}
+
+	var r0 sequencer.TrackerSnapshot
+	if rf, ok := ret.Get(0).(func() sequencer.TrackerSnapshot); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(sequencer.TrackerSnapshot)
+	}
+
+	return r0
+}
+
+// TrackerStateProvider_Snapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Snapshot'
+type TrackerStateProvider_Snapshot_Call struct {
+	*mock.Call
+}
+
+// Snapshot is a helper method to define mock.On call
+func (_e *TrackerStateProvider_Expecter) Snapshot() *TrackerStateProvider_Snapshot_Call {
+	return &TrackerStateProvider_Snapshot_Call{Call: _e.mock.On("Snapshot")}
+}
+
+func (_c *TrackerStateProvider_Snapshot_Call) Run(run func()) *TrackerStateProvider_Snapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *TrackerStateProvider_Snapshot_Call) Return(_a0 sequencer.TrackerSnapshot) *TrackerStateProvider_Snapshot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TrackerStateProvider_Snapshot_Call) RunAndReturn(run func() sequencer.TrackerSnapshot) *TrackerStateProvider_Snapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewTrackerStateProvider creates a new instance of TrackerStateProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTrackerStateProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TrackerStateProvider {
+	mock := &TrackerStateProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,28 @@
+package s3
+
+// Config represents the configuration of the S3-compatible object storage
+type Config struct {
+	// Enabled defines if offchain data values should be stored in the object storage instead of the database
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Endpoint is the host (and optional port) of the S3-compatible service
+	Endpoint string `mapstructure:"Endpoint"`
+
+	// Region of the bucket
+	Region string `mapstructure:"Region"`
+
+	// Bucket where the values are stored
+	Bucket string `mapstructure:"Bucket"`
+
+	// Prefix is prepended to the key of every stored object
+	Prefix string `mapstructure:"Prefix"`
+
+	// AccessKeyID used to authenticate against the service
+	AccessKeyID string `mapstructure:"AccessKeyID"`
+
+	// SecretAccessKey used to authenticate against the service
+	SecretAccessKey string `mapstructure:"SecretAccessKey"`
+
+	// UseSSL defines if the service is reached over https
+	UseSSL bool `mapstructure:"UseSSL"`
+}
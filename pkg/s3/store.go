@@ -0,0 +1,73 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// ErrObjectNotFound is returned when there is no object stored for the given key
+var ErrObjectNotFound = errors.New("object not found")
+
+// Store keeps offchain data values as objects of an S3-compatible bucket, keyed by their hash
+type Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// New creates a new Store
+func New(cfg Config) (*Store, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the s3 client: %w", err)
+	}
+
+	return &Store{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: cfg.Prefix,
+	}, nil
+}
+
+// Put stores the value under the given key
+func (s *Store) Put(ctx context.Context, key common.Hash, value []byte) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.objectName(key), bytes.NewReader(value), int64(len(value)),
+		minio.PutObjectOptions{ContentType: "application/octet-stream"})
+
+	return err
+}
+
+// Get returns the value stored under the given key
+func (s *Store) Get(ctx context.Context, key common.Hash) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.objectName(key), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	value, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrObjectNotFound
+		}
+
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (s *Store) objectName(key common.Hash) string {
+	return s.prefix + key.Hex()
+}
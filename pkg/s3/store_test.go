@@ -0,0 +1,101 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lock    sync.Mutex
+		objects = make(map[string][]byte)
+	)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			objects[r.URL.Path] = decodeChunkedPayload(t, r, body)
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+				return
+			}
+
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer svr.Close()
+
+	store, err := New(Config{
+		Endpoint:        strings.TrimPrefix(svr.URL, "http://"),
+		Region:          "us-east-1",
+		Bucket:          "bucket",
+		Prefix:          "data/",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+	})
+	require.NoError(t, err)
+
+	key := common.BytesToHash([]byte("key"))
+
+	require.NoError(t, store.Put(context.Background(), key, []byte("value")))
+	require.Equal(t, []byte("value"), objects["/bucket/data/"+key.Hex()])
+
+	got, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), got)
+
+	_, err = store.Get(context.Background(), common.BytesToHash([]byte("missing")))
+	require.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+// decodeChunkedPayload strips the chunk signatures of a streaming signed upload
+func decodeChunkedPayload(t *testing.T, r *http.Request, body []byte) []byte {
+	t.Helper()
+
+	if r.Header.Get("X-Amz-Content-Sha256") != "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" {
+		return body
+	}
+
+	var decoded []byte
+	for {
+		header, rest, ok := strings.Cut(string(body), "\r\n")
+		require.True(t, ok)
+
+		sizeHex, _, _ := strings.Cut(header, ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		require.NoError(t, err)
+
+		if size == 0 {
+			return decoded
+		}
+
+		decoded = append(decoded, rest[:size]...)
+		body = []byte(rest[size+2:])
+	}
+}
@@ -0,0 +1,115 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BatchDataPath is the path the concatenated data of a batch is served under over plain HTTP, followed by
+// the batch number, e.g. /batches/42
+const BatchDataPath = "/batches/"
+
+// batchDataMethod is the method the batch data reads are configured as, for their timeout and consistency
+const batchDataMethod = "sync_getBatchConcatenated"
+
+// BatchDataSource returns the data of a batch, which is the value stored for the key committed on L1 for it
+type BatchDataSource interface {
+	GetBatchConcatenated(ctx context.Context, batchNum uint64) ([]byte, error)
+}
+
+// SetBatchDataSource serves the concatenated data of the batches of the given source under BatchDataPath.
+// Unlike sync_getBatchConcatenated, the blob is served as it is and supports Range requests, so clients
+// can fetch a slice of a huge batch or resume an interrupted download. It must be set before starting
+func (s *Server) SetBatchDataSource(source BatchDataSource) {
+	s.batchData = source
+}
+
+// handleBatchData serves the concatenated data of the batch of the request path. The Range, If-Range and
+// HEAD requests are handled by http.ServeContent, which answers 206 Partial Content with the Content-Range
+// of the requested slice. The ETag is the hash of the whole blob, so a resumed download fails over to the
+// whole blob if the data changed in between
+func (s *Server) handleBatchData(w http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		s.writeBatchDataError(w, req, start, http.StatusMethodNotAllowed, "method "+req.Method+" not allowed")
+		return
+	}
+
+	batchNum, err := strconv.ParseUint(strings.TrimPrefix(req.URL.Path, BatchDataPath), 10, 64) //nolint:mnd
+	if err != nil {
+		s.writeBatchDataError(w, req, start, http.StatusBadRequest, "invalid batch number")
+		return
+	}
+
+	consistency, err := s.readConsistency(req, batchDataMethod)
+	if err != nil {
+		s.writeBatchDataError(w, req, start, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := db.WithConsistency(req.Context(), consistency)
+	if timeout := s.methodTimeout(batchDataMethod); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	blob, err := s.batchData.GetBatchConcatenated(ctx, batchNum)
+	if err != nil {
+		if errors.Is(err, db.ErrStateNotSynchronized) {
+			s.writeBatchDataError(w, req, start, http.StatusNotFound, fmt.Sprintf("batch %d not found", batchNum))
+			return
+		}
+
+		log.Errorf("failed to get the concatenated data of batch %d: %v", batchNum, err)
+		s.writeBatchDataError(w, req, start, http.StatusInternalServerError, "failed to get the requested batch data")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", `"`+crypto.Keccak256Hash(blob).Hex()+`"`)
+
+	sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+	http.ServeContent(sw, req, "", time.Time{}, bytes.NewReader(blob))
+	combinedLog(req, start, sw.status, sw.written)
+}
+
+// writeBatchDataError answers a batch data request with the given status and message
+func (s *Server) writeBatchDataError(w http.ResponseWriter, req *http.Request, start time.Time, status int, msg string) {
+	http.Error(w, msg, status)
+	combinedLog(req, start, status, len(msg))
+}
+
+// statusResponseWriter keeps the status and the body size of the response it writes
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status  int
+	written int
+}
+
+// WriteHeader keeps the status of the response
+func (w *statusResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write counts the written part of the response body
+func (w *statusResponseWriter) Write(data []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(data)
+	w.written += n
+
+	return n, err
+}
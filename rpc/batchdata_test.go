@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+type batchDataSourceMock struct {
+	blobs map[uint64][]byte
+	err   error
+}
+
+func (m *batchDataSourceMock) GetBatchConcatenated(_ context.Context, batchNum uint64) ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	blob, ok := m.blobs[batchNum]
+	if !ok {
+		return nil, db.ErrStateNotSynchronized
+	}
+
+	return blob, nil
+}
+
+func TestServer_handleBatchData(t *testing.T) {
+	t.Parallel()
+
+	blob := []byte("0123456789")
+	etag := `"` + crypto.Keccak256Hash(blob).Hex() + `"`
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		headers        map[string]string
+		sourceErr      error
+		expectedStatus int
+		expectedBody   string
+		expectedRange  string
+	}{
+		{
+			name:           "whole blob",
+			method:         http.MethodGet,
+			path:           "/batches/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   string(blob),
+		},
+		{
+			name:           "range",
+			method:         http.MethodGet,
+			path:           "/batches/1",
+			headers:        map[string]string{"Range": "bytes=2-4"},
+			expectedStatus: http.StatusPartialContent,
+			expectedBody:   "234",
+			expectedRange:  "bytes 2-4/10",
+		},
+		{
+			name:           "range resumed with the same etag",
+			method:         http.MethodGet,
+			path:           "/batches/1",
+			headers:        map[string]string{"Range": "bytes=8-", "If-Range": etag},
+			expectedStatus: http.StatusPartialContent,
+			expectedBody:   "89",
+			expectedRange:  "bytes 8-9/10",
+		},
+		{
+			name:           "range resumed with a stale etag",
+			method:         http.MethodGet,
+			path:           "/batches/1",
+			headers:        map[string]string{"Range": "bytes=8-", "If-Range": `"stale"`},
+			expectedStatus: http.StatusOK,
+			expectedBody:   string(blob),
+		},
+		{
+			name:           "unsatisfiable range",
+			method:         http.MethodGet,
+			path:           "/batches/1",
+			headers:        map[string]string{"Range": "bytes=20-30"},
+			expectedStatus: http.StatusRequestedRangeNotSatisfiable,
+		},
+		{
+			name:           "head",
+			method:         http.MethodHead,
+			path:           "/batches/1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid batch number",
+			method:         http.MethodGet,
+			path:           "/batches/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid batch number\n",
+		},
+		{
+			name:           "batch not found",
+			method:         http.MethodGet,
+			path:           "/batches/2",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "batch 2 not found\n",
+		},
+		{
+			name:           "source failed",
+			method:         http.MethodGet,
+			path:           "/batches/1",
+			sourceErr:      errors.New("test error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "failed to get the requested batch data\n",
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodPost,
+			path:           "/batches/1",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "method POST not allowed\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := NewServer(Config{}, nil)
+			server.SetBatchDataSource(&batchDataSourceMock{
+				blobs: map[uint64][]byte{1: blob},
+				err:   tt.sourceErr,
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			server.handleBatchData(rec, req)
+
+			require.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusRequestedRangeNotSatisfiable {
+				return
+			}
+
+			require.Equal(t, tt.expectedBody, rec.Body.String())
+			require.Equal(t, tt.expectedRange, rec.Header().Get("Content-Range"))
+
+			if tt.expectedStatus == http.StatusOK || tt.expectedStatus == http.StatusPartialContent {
+				require.Equal(t, etag, rec.Header().Get("ETag"))
+				require.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
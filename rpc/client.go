@@ -8,6 +8,16 @@ import (
 	"net/http"
 )
 
+// StatusCodeError is returned when the JSON RPC server replies with an unexpected HTTP status code
+type StatusCodeError struct {
+	StatusCode int
+}
+
+// Error returns the error message.
+func (e *StatusCodeError) Error() string {
+	return fmt.Sprintf("invalid status code, expected: %v, found: %v", http.StatusOK, e.StatusCode)
+}
+
 // JSONRPCCall calls JSONRPCCallWithContext with the default context
 func JSONRPCCall(url, method string, params ...interface{}) (Response, error) {
 	return JSONRPCCallWithContext(context.Background(), url, method, params...)
@@ -17,12 +27,18 @@ func JSONRPCCall(url, method string, params ...interface{}) (Response, error) {
 // the provided method and parameters, which is compatible with the Ethereum
 // JSON RPC Server.
 func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters ...interface{}) (Response, error) {
+	return JSONRPCCallWithClient(ctx, http.DefaultClient, url, method, parameters...)
+}
+
+// JSONRPCCallWithClient executes a 2.0 JSON RPC HTTP Post Request like JSONRPCCallWithContext,
+// but sends it through the provided http client so its connections can be reused across calls
+func JSONRPCCallWithClient(ctx context.Context, client *http.Client, url, method string, parameters ...interface{}) (Response, error) {
 	httpReq, err := BuildJsonHTTPRequest(ctx, url, method, parameters...)
 	if err != nil {
 		return Response{}, err
 	}
 
-	httpRes, err := http.DefaultClient.Do(httpReq)
+	httpRes, err := client.Do(httpReq)
 	if err != nil {
 		return Response{}, err
 	}
@@ -32,7 +48,7 @@ func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters
 	}
 
 	if httpRes.StatusCode != http.StatusOK {
-		return Response{}, fmt.Errorf("invalid status code, expected: %v, found: %v", http.StatusOK, httpRes.StatusCode)
+		return Response{}, &StatusCodeError{StatusCode: httpRes.StatusCode}
 	}
 
 	var res Response
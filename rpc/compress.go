@@ -0,0 +1,180 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// EncodingGzip compresses the responses with gzip, which every HTTP client supports
+	EncodingGzip = "gzip"
+
+	// EncodingZstd compresses the responses with zstd, which is faster and compresses better than gzip
+	EncodingZstd = "zstd"
+)
+
+// encoder compresses a whole response body
+type encoder func(body []byte) ([]byte, error)
+
+// compressor negotiates the encoding of the responses with the clients, compressing the large ones
+type compressor struct {
+	// encodings are the supported encodings in order of preference
+	encodings []string
+	encoders  map[string]encoder
+	minSize   int
+}
+
+// newCompressor returns the compressor of the given encodings, or nil if none is enabled.
+// It fails on an unknown encoding
+func newCompressor(encodings []string, minSize int) (*compressor, error) {
+	if len(encodings) == 0 {
+		return nil, nil
+	}
+
+	c := &compressor{
+		encoders: make(map[string]encoder, len(encodings)),
+		minSize:  minSize,
+	}
+
+	for _, name := range encodings {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := c.encoders[name]; ok {
+			continue
+		}
+
+		switch name {
+		case EncodingGzip:
+			c.encoders[name] = gzipEncode
+		case EncodingZstd:
+			// The encoder is safe for concurrent use when only compressing whole bodies with EncodeAll
+			zw, err := zstd.NewWriter(nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the zstd encoder: %w", err)
+			}
+
+			c.encoders[name] = func(body []byte) ([]byte, error) {
+				return zw.EncodeAll(body, nil), nil
+			}
+		default:
+			return nil, fmt.Errorf("unsupported compression encoding %q", name)
+		}
+
+		c.encodings = append(c.encodings, name)
+	}
+
+	return c, nil
+}
+
+// handler compresses the responses of the given handler. The whole response is buffered, which the JSON-RPC
+// handler does anyway, so its size is known before choosing whether to compress it
+func (c *compressor) handler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := c.negotiate(req.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next(w, req)
+			return
+		}
+
+		buf := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next(buf, req)
+
+		body := buf.body.Bytes()
+		if len(body) >= c.minSize {
+			compressed, err := c.encoders[encoding](body)
+			if err != nil {
+				log.Errorf("failed to compress the response with %s: %v", encoding, err)
+			} else {
+				body = compressed
+				w.Header().Set("Content-Encoding", encoding)
+			}
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(buf.status)
+
+		if _, err := w.Write(body); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// negotiate returns the most preferred enabled encoding the client accepts, or an empty string if it accepts none.
+// Quality values are only used to exclude encodings, with q=0, the order of preference is the configured one
+func (c *compressor) negotiate(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		accepted[name] = q > 0
+	}
+
+	for _, encoding := range c.encodings {
+		if ok, listed := accepted[encoding]; listed {
+			if ok {
+				return encoding
+			}
+
+			continue
+		}
+
+		if accepted["*"] {
+			return encoding
+		}
+	}
+
+	return ""
+}
+
+// gzipEncode compresses the given body with gzip
+func gzipEncode(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// bufferedResponseWriter holds the status and the body of a response until it is compressed
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader keeps the status of the response
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// Write buffers the given part of the response body
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
@@ -0,0 +1,145 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newCompressor(t *testing.T) {
+	t.Parallel()
+
+	c, err := newCompressor(nil, 0)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	c, err = newCompressor([]string{"ZSTD", " gzip", "zstd"}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{EncodingZstd, EncodingGzip}, c.encodings)
+
+	_, err = newCompressor([]string{"br"}, 0)
+	require.EqualError(t, err, `unsupported compression encoding "br"`)
+}
+
+func Test_compressorNegotiate(t *testing.T) {
+	t.Parallel()
+
+	c, err := newCompressor([]string{EncodingZstd, EncodingGzip}, 0)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		want           string
+	}{
+		{name: "nothing accepted"},
+		{name: "preferred encoding accepted", acceptEncoding: "gzip, deflate, zstd", want: EncodingZstd},
+		{name: "only gzip accepted", acceptEncoding: "gzip", want: EncodingGzip},
+		{name: "preferred encoding excluded", acceptEncoding: "gzip;q=0.5, zstd;q=0", want: EncodingGzip},
+		{name: "any encoding accepted", acceptEncoding: "*", want: EncodingZstd},
+		{name: "any but the preferred one", acceptEncoding: "zstd;q=0, *", want: EncodingGzip},
+		{name: "unsupported encoding", acceptEncoding: "br", want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, c.negotiate(tt.acceptEncoding))
+		})
+	}
+}
+
+func Test_compressorHandler(t *testing.T) {
+	t.Parallel()
+
+	large := `{"jsonrpc":"2.0","id":1,"result":"` + strings.Repeat("ab", 1024) + `"}`
+	small := `{"jsonrpc":"2.0","id":1,"result":"ab"}`
+
+	c, err := newCompressor([]string{EncodingZstd, EncodingGzip}, 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		body           string
+		encoding       string
+	}{
+		{name: "gzip", acceptEncoding: "gzip", body: large, encoding: EncodingGzip},
+		{name: "zstd", acceptEncoding: "gzip, zstd", body: large, encoding: EncodingZstd},
+		{name: "small response not compressed", acceptEncoding: "gzip", body: small},
+		{name: "compression not accepted", body: large},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := c.handler(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(tt.body))
+				require.NoError(t, err)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			require.Equal(t, "Accept-Encoding", resp.Header.Get("Vary"))
+			require.Equal(t, tt.encoding, resp.Header.Get("Content-Encoding"))
+
+			var body io.Reader = resp.Body
+			switch tt.encoding {
+			case EncodingGzip:
+				body, err = gzip.NewReader(resp.Body)
+				require.NoError(t, err)
+			case EncodingZstd:
+				zr, err := zstd.NewReader(resp.Body)
+				require.NoError(t, err)
+				defer zr.Close()
+				body = zr
+			}
+
+			got, err := io.ReadAll(body)
+			require.NoError(t, err)
+			require.Equal(t, tt.body, string(got))
+		})
+	}
+
+	t.Run("error status kept", func(t *testing.T) {
+		t.Parallel()
+
+		handler := c.handler(func(w http.ResponseWriter, _ *http.Request) {
+			handleError(w, bytes.ErrTooLarge)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Equal(t, bytes.ErrTooLarge.Error(), rec.Body.String())
+	})
+}
@@ -21,4 +21,37 @@ type Config struct {
 	// MaxRequestsPerIPAndSecond defines how much requests a single IP can
 	// send within a single second
 	MaxRequestsPerIPAndSecond float64 `mapstructure:"MaxRequestsPerIPAndSecond"`
+
+	// MaxRequestBodySize is the size in bytes from which the request bodies are rejected without being read
+	// further, single and batch requests alike. 0 means no limit
+	MaxRequestBodySize int64 `mapstructure:"MaxRequestBodySize"`
+
+	// EnableAdminAPI exposes the "da" namespace, whose endpoints are meant for operators and auditors
+	// and query L1 on every call
+	EnableAdminAPI bool `mapstructure:"EnableAdminAPI"`
+
+	// AccessLogLevel is the level (debug, info or warn) at which every handled call is logged with its
+	// method, sizes, duration and status. Params are never logged, only their hash. Empty disables it
+	AccessLogLevel string `mapstructure:"AccessLogLevel"`
+
+	// DefaultMethodTimeout is how long a call may run before its context is canceled and a timeout error
+	// is returned, unless overridden for its method. 0 means no timeout
+	DefaultMethodTimeout types.Duration `mapstructure:"DefaultMethodTimeout"`
+
+	// MethodTimeouts overrides DefaultMethodTimeout for the given methods, e.g. sync_listOffChainData.
+	// Method names are matched case insensitively. A 0 timeout disables it for the method
+	MethodTimeouts map[string]types.Duration `mapstructure:"MethodTimeouts"`
+
+	// CompressionEncodings are the encodings (gzip or zstd) the responses can be compressed with, in order of
+	// preference. The first one accepted by the client, as of its Accept-Encoding header, is used. Empty disables it
+	CompressionEncodings []string `mapstructure:"CompressionEncodings"`
+
+	// CompressionMinSize is the size in bytes from which the responses are compressed, the smaller ones are not
+	// worth it
+	CompressionMinSize int `mapstructure:"CompressionMinSize"`
+
+	// StrongConsistencyMethods are the methods whose reads are served by the primary database when a read replica
+	// is configured, e.g. the ones clients call right after a write. The other methods read from the replica,
+	// unless the call asks for strong consistency with the X-Read-Consistency header
+	StrongConsistencyMethods []string `mapstructure:"StrongConsistencyMethods"`
 }
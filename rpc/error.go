@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/types"
 )
 
+// Error codes returned by the RPC endpoints. The codes between -32768 and -32000 follow the JSON-RPC 2.0
+// specification, while the server error codes from -32001 describe the domain errors of the data node
 const (
 	// DefaultErrorCode rpc default error code
 	DefaultErrorCode = -32000
@@ -14,21 +17,64 @@ const (
 	RevertedErrorCode = 3
 	// InvalidRequestErrorCode error code for invalid requests
 	InvalidRequestErrorCode = -32600
+	// MethodNotFoundErrorCode error code for methods that do not exist or are not available
+	MethodNotFoundErrorCode = -32601
 	// NotFoundErrorCode error code for not found objects
-	NotFoundErrorCode = -32601
+	//
+	// Deprecated: it collides with the JSON-RPC method not found code,
+	// use MethodNotFoundErrorCode or BatchNotFoundErrorCode instead
+	NotFoundErrorCode = MethodNotFoundErrorCode
 	// InvalidParamsErrorCode error code for invalid parameters
 	InvalidParamsErrorCode = -32602
 	// ParserErrorCode error code for parsing errors
 	ParserErrorCode = -32700
 	// AccessDeniedCode error code when requests are denied
 	AccessDeniedCode = -32800
+
+	// StateNotSynchronizedErrorCode error code for data that is not synchronized by the node yet
+	StateNotSynchronizedErrorCode = -32001
+	// BatchNotFoundErrorCode error code for batches whose data could not be found
+	BatchNotFoundErrorCode = -32002
+	// DataMismatchErrorCode error code for data that does not match its key
+	DataMismatchErrorCode = -32003
+	// TimeoutErrorCode error code for calls that exceeded their server side timeout
+	TimeoutErrorCode = -32004
+	// RequestTooLargeErrorCode error code for request bodies exceeding the maximum size
+	RequestTooLargeErrorCode = -32005
 )
 
 var (
 	// errInvalidJSONReq denotes error that is returned when invalid JSON request is received
 	errInvalidJSONReq = errors.New("invalid json request")
+
+	// errorCodes maps the typed errors of the storage to their error codes
+	errorCodes = []struct {
+		err  error
+		code int
+	}{
+		{err: db.ErrStateNotSynchronized, code: StateNotSynchronizedErrorCode},
+		{err: db.ErrObjectDataMismatch, code: DataMismatchErrorCode},
+		{err: db.ErrOffChainDataMismatch, code: DataMismatchErrorCode},
+	}
 )
 
+// ErrorCodeFor returns the error code of the given error, DefaultErrorCode if the error is not a known one.
+// The code of an Error is kept as it is
+func ErrorCodeFor(err error) int {
+	var rpcErr Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode()
+	}
+
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec.code
+		}
+	}
+
+	return DefaultErrorCode
+}
+
 // Error interface
 type Error interface {
 	Error() string
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodeFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{
+			name: "unknown error",
+			err:  errors.New("test error"),
+			code: DefaultErrorCode,
+		},
+		{
+			name: "state not synchronized",
+			err:  db.ErrStateNotSynchronized,
+			code: StateNotSynchronizedErrorCode,
+		},
+		{
+			name: "wrapped state not synchronized",
+			err:  fmt.Errorf("failed to get data: %w", db.ErrStateNotSynchronized),
+			code: StateNotSynchronizedErrorCode,
+		},
+		{
+			name: "object data mismatch",
+			err:  db.ErrObjectDataMismatch,
+			code: DataMismatchErrorCode,
+		},
+		{
+			name: "offchain data mismatch",
+			err:  fmt.Errorf("failed to store data: %w", db.ErrOffChainDataMismatch),
+			code: DataMismatchErrorCode,
+		},
+		{
+			name: "rpc error keeps its code",
+			err:  NewRPCError(BatchNotFoundErrorCode, "no data found"),
+			code: BatchNotFoundErrorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.code, ErrorCodeFor(tt.err))
+		})
+	}
+}
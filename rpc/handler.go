@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -37,6 +38,21 @@ type handleRequest struct {
 	Request
 	wsConn      *websocket.Conn
 	HttpRequest *http.Request
+	// ctx is the context given to the methods taking one, canceled when the call times out
+	ctx context.Context
+}
+
+// context returns the context of the call, falling back to the one of the http request
+func (r handleRequest) context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+
+	if r.HttpRequest != nil {
+		return r.HttpRequest.Context()
+	}
+
+	return context.Background()
 }
 
 // Handler manage services to handle jsonrpc requests
@@ -58,6 +74,7 @@ type handleRequest struct {
 // the public methods must follow the conventions:
 // - return interface{}, rpcError
 // - if the method depend on a Web Socket connection, it must be the first parameters as f(*websocket.Conn)
+// - if the method takes a context.Context as first parameter, it is canceled when the call times out
 // - parameter types must match the type of the data provided for the method
 //
 // check the `eth.go` file for more example on how the methods are implemented
@@ -106,11 +123,16 @@ func (h *Handler) Handle(req handleRequest) Response {
 	funcHasMoreThanOneInputParams := len(fd.reqt) > 1
 	firstFuncParamIsWebSocketConn := false
 	firstFuncParamIsHttpRequest := false
+	firstFuncParamIsContext := false
 	if funcHasMoreThanOneInputParams {
 		firstFuncParamIsWebSocketConn = fd.reqt[1].AssignableTo(reflect.TypeOf(&websocket.Conn{}))
 		firstFuncParamIsHttpRequest = fd.reqt[1].AssignableTo(reflect.TypeOf(&http.Request{}))
+		firstFuncParamIsContext = fd.reqt[1] == contextType
 	}
-	if requestHasWebSocketConn && firstFuncParamIsWebSocketConn {
+	if firstFuncParamIsContext {
+		inArgs[1] = reflect.ValueOf(req.context())
+		inArgsOffset++
+	} else if requestHasWebSocketConn && firstFuncParamIsWebSocketConn {
 		inArgs[1] = reflect.ValueOf(req.wsConn)
 		inArgsOffset++
 	} else if firstFuncParamIsHttpRequest {
@@ -222,7 +244,7 @@ func (h *Handler) getFnHandler(req Request) (*serviceData, *funcData, Error) {
 
 	callName := strings.SplitN(req.Method, "_", endpointNameParts)
 	if len(callName) != endpointNameParts {
-		return nil, nil, NewRPCError(NotFoundErrorCode, methodNotFoundErrorMessage)
+		return nil, nil, NewRPCError(MethodNotFoundErrorCode, methodNotFoundErrorMessage)
 	}
 
 	serviceName, funcName := callName[0], callName[1]
@@ -230,11 +252,11 @@ func (h *Handler) getFnHandler(req Request) (*serviceData, *funcData, Error) {
 	service, ok := h.serviceMap[serviceName]
 	if !ok {
 		log.Infof("Method %s not found", req.Method)
-		return nil, nil, NewRPCError(NotFoundErrorCode, methodNotFoundErrorMessage)
+		return nil, nil, NewRPCError(MethodNotFoundErrorCode, methodNotFoundErrorMessage)
 	}
 	fd, ok := service.funcMap[funcName]
 	if !ok {
-		return nil, nil, NewRPCError(NotFoundErrorCode, methodNotFoundErrorMessage)
+		return nil, nil, NewRPCError(MethodNotFoundErrorCode, methodNotFoundErrorMessage)
 	}
 	return service, fd, nil
 }
@@ -275,7 +297,10 @@ func validateFunc(funcName string, fv reflect.Value) (inNum int, reqt []reflect.
 	return
 }
 
-var rpcErrType = reflect.TypeOf((*Error)(nil)).Elem()
+var (
+	rpcErrType  = reflect.TypeOf((*Error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
 
 func isRPCErrorType(t reflect.Type) bool {
 	return t.Implements(rpcErrType)
@@ -9,19 +9,41 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/log"
 	"github.com/didip/tollbooth/v6"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// ReadConsistencyHeader is the header a call can ask for the consistency of its reads with, eventual or strong
+const ReadConsistencyHeader = "X-Read-Consistency"
+
 // Server is an API backend to handle RPC requests
 type Server struct {
 	config  Config
 	handler *Handler
-	srv     *http.Server
+	// mu guards srv and done, as the server is started and shut down from different goroutines
+	mu  sync.Mutex
+	srv *http.Server
+	// done is closed once the started server stops serving
+	done      chan struct{}
+	accessLog accessLogFunc
+	// timeouts are the per method timeouts keyed by lower case method name
+	timeouts map[string]time.Duration
+	// strongMethods are the lower case names of the methods reading with strong consistency
+	strongMethods map[string]bool
+	// batchData serves the concatenated data of the batches over plain HTTP, nil if not served
+	batchData BatchDataSource
 }
 
+// accessLogFunc writes a structured log line at the configured access log level
+type accessLogFunc func(msg string, kv ...interface{})
+
 // Service implementation of a service an it's name
 type Service struct {
 	Name    string
@@ -39,9 +61,22 @@ func NewServer(
 		handler.registerService(service)
 	}
 
+	timeouts := make(map[string]time.Duration, len(cfg.MethodTimeouts))
+	for method, timeout := range cfg.MethodTimeouts {
+		timeouts[strings.ToLower(method)] = timeout.Duration
+	}
+
+	strongMethods := make(map[string]bool, len(cfg.StrongConsistencyMethods))
+	for _, method := range cfg.StrongConsistencyMethods {
+		strongMethods[strings.ToLower(method)] = true
+	}
+
 	srv := &Server{
-		config:  cfg,
-		handler: handler,
+		config:        cfg,
+		handler:       handler,
+		accessLog:     newAccessLogFunc(cfg.AccessLogLevel),
+		timeouts:      timeouts,
+		strongMethods: strongMethods,
 	}
 	return srv
 }
@@ -53,10 +88,19 @@ func (s *Server) Start() error {
 
 // startHTTP starts a server to respond http requests
 func (s *Server) startHTTP() error {
-	if s.srv != nil {
+	s.mu.Lock()
+	started := s.srv != nil
+	s.mu.Unlock()
+
+	if started {
 		return fmt.Errorf("server already started")
 	}
 
+	compressor, err := newCompressor(s.config.CompressionEncodings, s.config.CompressionMinSize)
+	if err != nil {
+		return err
+	}
+
 	address := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 
 	lis, err := net.Listen("tcp", address)
@@ -65,19 +109,36 @@ func (s *Server) startHTTP() error {
 		return err
 	}
 
+	handle := s.handle
+	if compressor != nil {
+		handle = compressor.handler(handle)
+	}
+
 	mux := http.NewServeMux()
 
 	lmt := tollbooth.NewLimiter(s.config.MaxRequestsPerIPAndSecond, nil)
-	mux.Handle("/", tollbooth.LimitFuncHandler(lmt, s.handle))
+	mux.Handle("/", tollbooth.LimitFuncHandler(lmt, handle))
+
+	// The blobs are not compressed, as the ranges are byte offsets into the blob as it is stored
+	if s.batchData != nil {
+		mux.Handle(BatchDataPath, tollbooth.LimitFuncHandler(lmt, s.handleBatchData))
+	}
 
-	s.srv = &http.Server{
+	srv := &http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: s.config.ReadTimeout.Duration,
 		ReadTimeout:       s.config.ReadTimeout.Duration,
 		WriteTimeout:      s.config.WriteTimeout.Duration,
 	}
+	done := make(chan struct{})
+	defer close(done)
+
+	s.mu.Lock()
+	s.srv, s.done = srv, done
+	s.mu.Unlock()
+
 	log.Infof("http server started: %s", address)
-	if err := s.srv.Serve(lis); err != nil {
+	if err := srv.Serve(lis); err != nil {
 		if err == http.ErrServerClosed {
 			log.Infof("http server stopped")
 			return nil
@@ -88,8 +149,34 @@ func (s *Server) startHTTP() error {
 	return nil
 }
 
+// Shutdown stops accepting new requests and waits for the in-flight ones to finish and the server to stop
+// serving, until the given context is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	srv, done := s.srv, s.done
+	s.mu.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Stop shutdown the rpc server
 func (s *Server) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.srv != nil {
 		if err := s.srv.Shutdown(context.Background()); err != nil {
 			return err
@@ -133,8 +220,18 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if s.config.MaxRequestBodySize > 0 {
+		req.Body = http.MaxBytesReader(w, req.Body, s.config.MaxRequestBodySize)
+	}
+
 	data, err := io.ReadAll(req.Body)
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			s.handleRequestTooLarge(w, maxBytesErr.Limit)
+			return
+		}
+
 		s.handleInvalidRequest(w, err)
 		return
 	}
@@ -171,14 +268,15 @@ func (s *Server) handleSingleRequest(httpRequest *http.Request, w http.ResponseW
 		handleError(w, err)
 		return 0
 	}
-	req := handleRequest{Request: request, HttpRequest: httpRequest}
-	response := s.handler.Handle(req)
+	start := time.Now()
+	response := s.call(httpRequest, request)
 
 	respBytes, err := json.Marshal(response)
 	if err != nil {
 		handleError(w, err)
 		return 0
 	}
+	s.logCall(request, response, len(respBytes), time.Since(start))
 
 	_, err = w.Write(respBytes)
 	if err != nil {
@@ -198,8 +296,12 @@ func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWr
 	responses := make([]Response, 0, len(requests))
 
 	for _, request := range requests {
-		req := handleRequest{Request: request, HttpRequest: httpRequest}
-		response := s.handler.Handle(req)
+		start := time.Now()
+		response := s.call(httpRequest, request)
+		if s.accessLog != nil {
+			respBytes, _ := json.Marshal(response)
+			s.logCall(request, response, len(respBytes), time.Since(start))
+		}
 		responses = append(responses, response)
 	}
 
@@ -212,6 +314,75 @@ func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWr
 	return len(respBytes)
 }
 
+// methodTimeout returns the timeout of the given method, 0 if it has none
+func (s *Server) methodTimeout(method string) time.Duration {
+	if timeout, ok := s.timeouts[strings.ToLower(method)]; ok {
+		return timeout
+	}
+
+	return s.config.DefaultMethodTimeout.Duration
+}
+
+// readConsistency returns the consistency of the reads of the given call: the one asked for by the
+// X-Read-Consistency header if any, strong for the configured methods and eventual otherwise
+func (s *Server) readConsistency(httpRequest *http.Request, method string) (db.Consistency, error) {
+	if header := httpRequest.Header.Get(ReadConsistencyHeader); header != "" {
+		return db.ParseConsistency(header)
+	}
+
+	if s.strongMethods[strings.ToLower(method)] {
+		return db.ConsistencyStrong, nil
+	}
+
+	return db.ConsistencyEventual, nil
+}
+
+// call handles the given request within the timeout of its method. When the timeout is exceeded the context
+// given to the method is canceled and a timeout error is returned without waiting for the method to return
+func (s *Server) call(httpRequest *http.Request, request Request) Response {
+	consistency, err := s.readConsistency(httpRequest, request.Method)
+	if err != nil {
+		return NewResponse(request, nil, NewRPCError(InvalidRequestErrorCode, err.Error()))
+	}
+
+	ctx := db.WithConsistency(httpRequest.Context(), consistency)
+
+	timeout := s.methodTimeout(request.Method)
+	if timeout <= 0 {
+		return s.handler.Handle(handleRequest{Request: request, HttpRequest: httpRequest, ctx: ctx})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan Response, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("panic handling %s: %v", request.Method, r)
+				done <- NewResponse(request, nil, NewRPCError(DefaultErrorCode, "runtime error"))
+			}
+		}()
+
+		done <- s.handler.Handle(handleRequest{Request: request, HttpRequest: httpRequest, ctx: ctx})
+	}()
+
+	select {
+	case response := <-done:
+		return response
+	case <-ctx.Done():
+		// the method may have returned right at the timeout
+		select {
+		case response := <-done:
+			return response
+		default:
+		}
+
+		log.Warnf("call to %s canceled after %v: %v", request.Method, timeout, ctx.Err())
+		return NewResponse(request, nil, NewRPCError(TimeoutErrorCode, "request timed out"))
+	}
+}
+
 func (s *Server) parseRequest(data []byte) (Request, error) {
 	var req Request
 
@@ -236,6 +407,26 @@ func (s *Server) handleInvalidRequest(w http.ResponseWriter, err error) {
 	handleError(w, err)
 }
 
+// handleRequestTooLarge answers a request whose body exceeds the given maximum size with a JSON-RPC error,
+// as the request id is unknown without reading the body
+func (s *Server) handleRequestTooLarge(w http.ResponseWriter, limit int64) {
+	log.Warnf("rejected a request body exceeding the maximum size of %d bytes", limit)
+
+	response := NewResponse(Request{JSONRPC: "2.0"}, nil, NewRPCError(RequestTooLargeErrorCode,
+		"request body exceeds the maximum size of %d bytes", limit))
+
+	respBytes, err := json.Marshal(response)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	if _, err = w.Write(respBytes); err != nil {
+		log.Error(err)
+	}
+}
+
 func handleError(w http.ResponseWriter, err error) {
 	log.Error(err)
 	w.WriteHeader(http.StatusInternalServerError)
@@ -245,6 +436,56 @@ func handleError(w http.ResponseWriter, err error) {
 	}
 }
 
+// newAccessLogFunc returns the logger matching the given level, or nil when access logging is disabled
+func newAccessLogFunc(level string) accessLogFunc {
+	logger := log.WithFields("component", "rpc-access")
+
+	switch strings.ToLower(level) {
+	case "":
+		return nil
+	case "debug":
+		return logger.Debugw
+	case "info":
+		return logger.Infow
+	case "warn":
+		return logger.Warnw
+	default:
+		log.Warnf("unknown rpc access log level %q, access logging disabled", level)
+		return nil
+	}
+}
+
+// logCall writes the access log line of a single call, if access logging is enabled
+func (s *Server) logCall(req Request, resp Response, respSize int, duration time.Duration) {
+	if s.accessLog == nil {
+		return
+	}
+
+	s.accessLog("rpc call", accessLogFields(req, resp, respSize, duration)...)
+}
+
+// accessLogFields builds the access log fields of a call. The params may carry sensitive
+// data, so only their size and hash are recorded
+func accessLogFields(req Request, resp Response, respSize int, duration time.Duration) []interface{} {
+	status := "ok"
+	if resp.Error != nil {
+		status = strconv.Itoa(resp.Error.Code)
+	}
+
+	fields := []interface{}{
+		"method", req.Method,
+		"requestSize", len(req.Params),
+		"responseSize", respSize,
+		"duration", duration,
+		"status", status,
+	}
+	if len(req.Params) > 0 {
+		fields = append(fields, "paramsHash", crypto.Keccak256Hash(req.Params).Hex())
+	}
+
+	return fields
+}
+
 func combinedLog(r *http.Request, start time.Time, httpStatus, dataLen int) {
 	log.Infof("%s - - %s \"%s %s %s\" %d %d \"%s\" \"%s\"",
 		r.RemoteAddr,
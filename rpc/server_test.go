@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/stretchr/testify/require"
 )
 
@@ -155,9 +158,336 @@ func Test_ServerHandleRequest(t *testing.T) {
 	})
 }
 
+func Test_ServerShutdown(t *testing.T) {
+	cfg := Config{Host: "localhost", Port: 8081}
+	server := NewServer(cfg, []Service{{Name: "sleeper", Service: &sleeperService{}}})
+
+	started := make(chan error, 1)
+	go func() {
+		started <- server.Start()
+	}()
+
+	// Allow some time for the server to start
+	time.Sleep(100 * time.Millisecond)
+
+	served := make(chan error, 1)
+	go func() {
+		req, err := BuildJsonHTTPRequest(
+			context.Background(), fmt.Sprintf("http://%s:%d", cfg.Host, cfg.Port), "sleeper_sleep", "200ms",
+		)
+		if err != nil {
+			served <- err
+			return
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			err = resp.Body.Close()
+		}
+		served <- err
+	}()
+
+	// Allow some time for the request to be in flight
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, server.Shutdown(context.Background()))
+
+	// The in-flight request was answered instead of having its connection closed
+	require.NoError(t, <-served)
+	require.NoError(t, <-started)
+}
+
+func Test_ServerAccessLog(t *testing.T) {
+	const (
+		funcName   = "greeter_handleReq"
+		paramValue = "John Doe"
+	)
+
+	type logLine struct {
+		msg    string
+		fields map[string]interface{}
+	}
+
+	var lines []logLine
+	server := NewServer(Config{}, []Service{{Name: "greeter", Service: &greeterService{}}})
+	server.accessLog = func(msg string, kv ...interface{}) {
+		fields := make(map[string]interface{}, len(kv)/2)
+		for i := 0; i+1 < len(kv); i += 2 {
+			fields[kv[i].(string)] = kv[i+1]
+		}
+		lines = append(lines, logLine{msg: msg, fields: fields})
+	}
+
+	t.Run("single request is logged without its params", func(t *testing.T) {
+		lines = nil
+
+		req, err := BuildJsonHTTPRequest(context.Background(), "http://localhost", funcName, paramValue)
+		require.NoError(t, err)
+
+		respRecorder := httptest.NewRecorder()
+		server.handle(respRecorder, req)
+		require.Equal(t, http.StatusOK, respRecorder.Code)
+
+		require.Len(t, lines, 1)
+		require.Equal(t, "rpc call", lines[0].msg)
+		require.Equal(t, funcName, lines[0].fields["method"])
+		require.Equal(t, "ok", lines[0].fields["status"])
+		require.Equal(t, respRecorder.Body.Len(), lines[0].fields["responseSize"])
+		require.NotZero(t, lines[0].fields["requestSize"])
+		require.Contains(t, lines[0].fields, "paramsHash")
+		require.Contains(t, lines[0].fields, "duration")
+		for _, v := range lines[0].fields {
+			require.NotContains(t, fmt.Sprint(v), paramValue)
+		}
+	})
+
+	t.Run("each call of a batch is logged", func(t *testing.T) {
+		lines = nil
+
+		params, err := json.Marshal([]interface{}{paramValue})
+		require.NoError(t, err)
+
+		reqBody, err := json.Marshal([]Request{
+			{JSONRPC: "2.0", ID: float64(1), Method: funcName, Params: params},
+			{JSONRPC: "2.0", ID: float64(2), Method: "greeter_unknown", Params: params},
+		})
+		require.NoError(t, err)
+
+		httpReq, err := BuildJsonHttpRequestWithBody(context.Background(), "http://localhost", reqBody)
+		require.NoError(t, err)
+
+		server.handle(httptest.NewRecorder(), httpReq)
+
+		require.Len(t, lines, 2)
+		require.Equal(t, "ok", lines[0].fields["status"])
+		require.Equal(t, "greeter_unknown", lines[1].fields["method"])
+		require.Equal(t, fmt.Sprint(MethodNotFoundErrorCode), lines[1].fields["status"])
+	})
+}
+
+func Test_ServerMethodTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(Config{
+		DefaultMethodTimeout: types.NewDuration(time.Second),
+		MethodTimeouts: map[string]types.Duration{
+			"sleeper_sleep":     types.NewDuration(50 * time.Millisecond),
+			"greeter_handlereq": types.NewDuration(0),
+		},
+	}, []Service{
+		{Name: "greeter", Service: &greeterService{}},
+		{Name: "sleeper", Service: &sleeperService{}},
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		param   interface{}
+		result  string
+		errCode int
+	}{
+		{
+			name:   "call within the method timeout",
+			method: "sleeper_sleep",
+			param:  "0s",
+			result: `"slept"`,
+		},
+		{
+			name:    "call exceeding the method timeout",
+			method:  "sleeper_sleep",
+			param:   "1m",
+			errCode: TimeoutErrorCode,
+		},
+		{
+			name:   "method without timeout",
+			method: "greeter_handleReq",
+			param:  "John",
+			result: `"Hello, John!"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := BuildJsonHTTPRequest(context.Background(), "http://localhost", tt.method, tt.param)
+			require.NoError(t, err)
+
+			respRecorder := httptest.NewRecorder()
+			server.handle(respRecorder, req)
+			require.Equal(t, http.StatusOK, respRecorder.Code)
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(respRecorder.Body.Bytes(), &resp))
+
+			if tt.errCode != 0 {
+				require.NotNil(t, resp.Error)
+				require.Equal(t, tt.errCode, resp.Error.Code)
+			} else {
+				require.Nil(t, resp.Error)
+				require.JSONEq(t, tt.result, string(resp.Result))
+			}
+		})
+	}
+}
+
+func Test_ServerMaxRequestBodySize(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(Config{MaxRequestBodySize: 100}, []Service{{Name: "greeter", Service: &greeterService{}}})
+
+	tests := []struct {
+		name  string
+		param string
+		code  int
+	}{
+		{
+			name:  "body within the maximum size",
+			param: "John",
+			code:  http.StatusOK,
+		},
+		{
+			name:  "body exceeding the maximum size",
+			param: strings.Repeat("John", 100),
+			code:  http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := BuildJsonHTTPRequest(context.Background(), "http://localhost", "greeter_handleReq", tt.param)
+			require.NoError(t, err)
+
+			respRecorder := httptest.NewRecorder()
+			server.handle(respRecorder, req)
+			require.Equal(t, tt.code, respRecorder.Code)
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(respRecorder.Body.Bytes(), &resp))
+
+			if tt.code == http.StatusOK {
+				require.Nil(t, resp.Error)
+				require.JSONEq(t, `"Hello, John!"`, string(resp.Result))
+			} else {
+				require.NotNil(t, resp.Error)
+				require.Equal(t, RequestTooLargeErrorCode, resp.Error.Code)
+				require.Equal(t, "request body exceeds the maximum size of 100 bytes", resp.Error.Message)
+			}
+		})
+	}
+}
+
+func Test_ServerReadConsistency(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer(Config{StrongConsistencyMethods: []string{"sync_getOffChainData"}},
+		[]Service{{Name: "greeter", Service: &greeterService{}}})
+
+	tests := []struct {
+		name        string
+		method      string
+		header      string
+		consistency db.Consistency
+		errCode     int
+	}{
+		{
+			name:        "eventual by default",
+			method:      "sync_listOffChainData",
+			consistency: db.ConsistencyEventual,
+		},
+		{
+			name:        "strong for the configured methods",
+			method:      "SYNC_getOffChainData",
+			consistency: db.ConsistencyStrong,
+		},
+		{
+			name:        "strong asked by the call",
+			method:      "sync_listOffChainData",
+			header:      "Strong",
+			consistency: db.ConsistencyStrong,
+		},
+		{
+			name:        "eventual asked by the call",
+			method:      "sync_getOffChainData",
+			header:      "eventual",
+			consistency: db.ConsistencyEventual,
+		},
+		{
+			name:    "unknown consistency",
+			method:  "greeter_handleReq",
+			header:  "linearizable",
+			errCode: InvalidRequestErrorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := BuildJsonHTTPRequest(context.Background(), "http://localhost", tt.method, "John")
+			require.NoError(t, err)
+
+			if tt.header != "" {
+				req.Header.Set(ReadConsistencyHeader, tt.header)
+			}
+
+			if tt.errCode == 0 {
+				consistency, err := server.readConsistency(req, tt.method)
+				require.NoError(t, err)
+				require.Equal(t, tt.consistency, consistency)
+
+				return
+			}
+
+			respRecorder := httptest.NewRecorder()
+			server.handle(respRecorder, req)
+
+			var resp Response
+			require.NoError(t, json.Unmarshal(respRecorder.Body.Bytes(), &resp))
+			require.NotNil(t, resp.Error)
+			require.Equal(t, tt.errCode, resp.Error.Code)
+		})
+	}
+}
+
+func Test_newAccessLogFunc(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, newAccessLogFunc(""))
+	require.Nil(t, newAccessLogFunc("verbose"))
+	require.NotNil(t, newAccessLogFunc("debug"))
+	require.NotNil(t, newAccessLogFunc("Info"))
+	require.NotNil(t, newAccessLogFunc("warn"))
+}
+
 type greeterService struct{}
 
 // Mock implementation of a service method
 func (s *greeterService) HandleReq(name string) (interface{}, Error) {
 	return fmt.Sprintf("Hello, %s!", name), nil
 }
+
+type sleeperService struct{}
+
+// Sleep waits for the given duration or until the call is canceled
+func (s *sleeperService) Sleep(ctx context.Context, duration string) (interface{}, Error) {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return nil, NewRPCError(InvalidParamsErrorCode, err.Error())
+	}
+
+	select {
+	case <-time.After(d):
+		return "slept", nil
+	case <-ctx.Done():
+		return nil, NewRPCError(DefaultErrorCode, ctx.Err().Error())
+	}
+}
@@ -3,11 +3,55 @@ package sequencer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrSequencerDataMismatch is returned when the batch data returned by the sequencer
+	// does not hash to the expected key
+	ErrSequencerDataMismatch = errors.New("sequencer data does not match the expected key")
+
+	// ErrInvalidSequencerResponse is returned when the batch returned by the sequencer
+	// does not match the expected schema
+	ErrInvalidSequencerResponse = errors.New("invalid sequencer response")
+
+	// ErrSequencerBatchMismatch is returned when the batch returned by the sequencer
+	// is not the requested one
+	ErrSequencerBatchMismatch = errors.New("sequencer batch does not match the requested number")
+)
+
+var (
+	// requiredSeqBatchFields are the fields of a batch the data node relies on
+	requiredSeqBatchFields = []string{"number", "accInputHash", "batchL2Data"}
+
+	// knownSeqBatchFields are all the fields of a batch returned by zkevm_getBatchByNumber
+	knownSeqBatchFields = map[string]struct{}{
+		"number":              {},
+		"forcedBatchNumber":   {},
+		"coinbase":            {},
+		"stateRoot":           {},
+		"globalExitRoot":      {},
+		"mainnetExitRoot":     {},
+		"rollupExitRoot":      {},
+		"localExitRoot":       {},
+		"accInputHash":        {},
+		"timestamp":           {},
+		"sendSequencesTxHash": {},
+		"verifyBatchTxHash":   {},
+		"closed":              {},
+		"blocks":              {},
+		"transactions":        {},
+		"batchL2Data":         {},
+	}
 )
 
 // SeqBatch structure
@@ -17,21 +61,128 @@ type SeqBatch struct {
 	BatchL2Data  types.ArgBytes  `json:"batchL2Data"`
 }
 
-// GetData returns batch data from the trusted sequencer
-func GetData(ctx context.Context, url string, batchNum uint64) (*SeqBatch, error) {
-	response, err := rpc.JSONRPCCallWithContext(ctx, url, "zkevm_getBatchByNumber", batchNum, true)
+// VerifyKey checks that the batch data hashes to the expected key
+func (b *SeqBatch) VerifyKey(expected common.Hash) error {
+	if actual := crypto.Keccak256Hash(b.BatchL2Data); actual != expected {
+		return fmt.Errorf("%w: expected %s, got %s", ErrSequencerDataMismatch, expected.Hex(), actual.Hex())
+	}
+
+	return nil
+}
+
+// SequencerClient fetches the batches from a trusted sequencer
+type SequencerClient interface {
+	GetData(ctx context.Context, url string, batchNum uint64) (*SeqBatch, error)
+}
+
+// httpSequencerClient is the SequencerClient calling the sequencer JSON-RPC over http
+type httpSequencerClient struct {
+	client   *http.Client
+	strict   bool
+	dataPath string
+}
+
+// NewSequencerClient returns a SequencerClient using the given http client.
+// If strict is set, the batches are validated as in GetData. If dataPath is set, the batches are requested
+// at the sequencer URL extended with it, see ValidateDataPath
+func NewSequencerClient(client *http.Client, strict bool, dataPath string) SequencerClient {
+	return &httpSequencerClient{client: client, strict: strict, dataPath: dataPath}
+}
+
+// GetData returns batch data from the sequencer at the given url
+func (c *httpSequencerClient) GetData(ctx context.Context, url string, batchNum uint64) (*SeqBatch, error) {
+	endpoint, err := dataURL(url, c.dataPath, batchNum)
 	if err != nil {
+		return nil, fmt.Errorf("failed to build the data url of batch %d: %w", batchNum, err)
+	}
+
+	return getData(ctx, c.client, url, endpoint, batchNum, c.strict)
+}
+
+// GetData returns batch data from the trusted sequencer using the given http client.
+// If strict is set, the batch is rejected unless it has all the required fields and only known ones
+func GetData(ctx context.Context, client *http.Client, url string, batchNum uint64, strict bool) (*SeqBatch, error) {
+	return getData(ctx, client, url, url, batchNum, strict)
+}
+
+// getData requests the batch at the given endpoint of the sequencer. The metrics are labeled with the sequencer
+// url rather than the endpoint, which may contain the batch number
+func getData(
+	ctx context.Context, client *http.Client, url, endpoint string, batchNum uint64, strict bool,
+) (*SeqBatch, error) {
+	start := time.Now()
+
+	response, err := rpc.JSONRPCCallWithClient(ctx, client, endpoint, "zkevm_getBatchByNumber", batchNum, true)
+	if err != nil {
+		status := transportErrorStatus
+
+		var statusErr *rpc.StatusCodeError
+		if errors.As(err, &statusErr) {
+			status = strconv.Itoa(statusErr.StatusCode)
+		}
+
+		observeFetch(url, status, start, err)
 		return nil, err
 	}
 
+	status := strconv.Itoa(http.StatusOK)
+	fetchBytes.WithLabelValues(url).Add(float64(len(response.Result)))
+
 	if response.Error != nil {
-		return nil, fmt.Errorf("%d - %s", response.Error.Code, response.Error.Message)
+		err = fmt.Errorf("%d - %s", response.Error.Code, response.Error.Message)
+		observeFetch(url, status, start, err)
+		return nil, err
+	}
+
+	if strict {
+		if err = validateSeqBatch(response.Result); err != nil {
+			observeFetch(url, status, start, err)
+			return nil, err
+		}
 	}
 
 	var result SeqBatch
 	if err = json.Unmarshal(response.Result, &result); err != nil {
+		observeFetch(url, status, start, err)
+		return nil, err
+	}
+
+	if uint64(result.Number) != batchNum {
+		err = fmt.Errorf("%w: requested %d, got %d", ErrSequencerBatchMismatch, batchNum, uint64(result.Number))
+		observeFetch(url, status, start, err)
 		return nil, err
 	}
 
+	observeFetch(url, status, start, nil)
 	return &result, nil
 }
+
+// validateSeqBatch checks that the given batch has all the required fields, and no unknown ones
+func validateSeqBatch(data json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSequencerResponse, err)
+	}
+
+	for name := range fields {
+		if _, ok := knownSeqBatchFields[name]; !ok {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidSequencerResponse, name)
+		}
+	}
+
+	for _, name := range requiredSeqBatchFields {
+		if value, ok := fields[name]; !ok || string(value) == "null" {
+			return fmt.Errorf("%w: missing field %q", ErrInvalidSequencerResponse, name)
+		}
+	}
+
+	return nil
+}
+
+// observeFetch records the latency and the outcome of a fetch from the sequencer
+func observeFetch(url, status string, start time.Time, err error) {
+	fetchDuration.WithLabelValues(url, status).Observe(time.Since(start).Seconds())
+	if err != nil {
+		fetchErrors.WithLabelValues(url, status).Inc()
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +24,7 @@ func Test_GetData(t *testing.T) {
 		name         string
 		batchNum     uint64
 		result       string
+		strict       bool
 		expectedData *SeqBatch
 		statusCode   int
 		err          error
@@ -41,6 +44,78 @@ func Test_GetData(t *testing.T) {
 				BatchL2Data:  []byte("l2data"),
 			},
 		},
+		{
+			name:     "successfully got data in strict mode",
+			batchNum: 10,
+			strict:   true,
+			result: fmt.Sprintf(
+				`{"result":{"number":"%s","accInputHash":"%s","batchL2Data":"%s","closed":true}}`,
+				types.ArgUint64(10).Hex(),
+				common.BytesToHash([]byte("somedata")),
+				types.ArgBytes("l2data").Hex(),
+			),
+			expectedData: &SeqBatch{
+				Number:       10,
+				AccInputHash: common.BytesToHash([]byte("somedata")),
+				BatchL2Data:  []byte("l2data"),
+			},
+		},
+		{
+			name:     "unknown field in strict mode",
+			batchNum: 10,
+			strict:   true,
+			result: fmt.Sprintf(
+				`{"result":{"number":"%s","accInputHash":"%s","batchL2Data":"%s","l2Data":"%s"}}`,
+				types.ArgUint64(10).Hex(),
+				common.BytesToHash([]byte("somedata")),
+				types.ArgBytes("l2data").Hex(),
+				types.ArgBytes("l2data").Hex(),
+			),
+			err: errors.New(`invalid sequencer response: unknown field "l2Data"`),
+		},
+		{
+			name:     "missing field in strict mode",
+			batchNum: 10,
+			strict:   true,
+			result: fmt.Sprintf(
+				`{"result":{"number":"%s","batchL2Data":"%s"}}`,
+				types.ArgUint64(10).Hex(),
+				types.ArgBytes("l2data").Hex(),
+			),
+			err: errors.New(`invalid sequencer response: missing field "accInputHash"`),
+		},
+		{
+			name:     "null field in strict mode",
+			batchNum: 10,
+			strict:   true,
+			result: fmt.Sprintf(
+				`{"result":{"number":"%s","accInputHash":"%s","batchL2Data":null}}`,
+				types.ArgUint64(10).Hex(),
+				common.BytesToHash([]byte("somedata")),
+			),
+			err: errors.New(`invalid sequencer response: missing field "batchL2Data"`),
+		},
+		{
+			name:     "batch number mismatch",
+			batchNum: 10,
+			result: fmt.Sprintf(
+				`{"result":{"number":"%s","accInputHash":"%s","batchL2Data":"%s"}}`,
+				types.ArgUint64(11).Hex(),
+				common.BytesToHash([]byte("somedata")),
+				types.ArgBytes("l2data").Hex(),
+			),
+			err: errors.New("sequencer batch does not match the requested number: requested 10, got 11"),
+		},
+		{
+			name:     "missing batch number",
+			batchNum: 10,
+			result: fmt.Sprintf(
+				`{"result":{"accInputHash":"%s","batchL2Data":"%s"}}`,
+				common.BytesToHash([]byte("somedata")),
+				types.ArgBytes("l2data").Hex(),
+			),
+			err: errors.New("sequencer batch does not match the requested number: requested 10, got 0"),
+		},
 		{
 			name:     "error returned by server",
 			batchNum: 10,
@@ -88,14 +163,36 @@ func Test_GetData(t *testing.T) {
 			}))
 			defer svr.Close()
 
-			got, err := GetData(context.Background(), svr.URL, tt.batchNum)
+			got, err := GetData(context.Background(), svr.Client(), svr.URL, tt.batchNum, tt.strict)
 			if tt.err != nil {
 				require.Error(t, err)
 				require.EqualError(t, tt.err, err.Error())
+
+				status := "200"
+				if tt.statusCode > 0 {
+					status = fmt.Sprint(tt.statusCode)
+				}
+				require.Equal(t, float64(1), testutil.ToFloat64(fetchErrors.WithLabelValues(svr.URL, status)))
 			} else {
 				require.NoError(t, err)
 				require.Equal(t, tt.expectedData, got)
+				require.Equal(t, float64(len(tt.result)-len(`{"result":}`)),
+					testutil.ToFloat64(fetchBytes.WithLabelValues(svr.URL)))
 			}
 		})
 	}
 }
+
+func TestSeqBatch_VerifyKey(t *testing.T) {
+	t.Parallel()
+
+	batch := &SeqBatch{BatchL2Data: []byte("l2data")}
+
+	require.NoError(t, batch.VerifyKey(crypto.Keccak256Hash([]byte("l2data"))))
+
+	wrongKey := common.BytesToHash([]byte("wrong"))
+	err := batch.VerifyKey(wrongKey)
+	require.ErrorIs(t, err, ErrSequencerDataMismatch)
+	require.ErrorContains(t, err, wrongKey.Hex())
+	require.ErrorContains(t, err, crypto.Keccak256Hash([]byte("l2data")).Hex())
+}
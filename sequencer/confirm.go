@@ -0,0 +1,121 @@
+package sequencer
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// confirmationCheckInterval is how often the pending sequencer changes are checked for confirmation
+const confirmationCheckInterval = 12 * time.Second
+
+// settingChange is a change of a sequencer setting seen on L1
+type settingChange[T comparable] struct {
+	value T
+	// block is the L1 block of the change, the head when it was polled
+	block uint64
+	// removed is whether the event of the change was reorged out
+	removed bool
+}
+
+// pendingChange is a change of a sequencer setting waiting for its block to be confirmed
+type pendingChange[T comparable] struct {
+	value T
+	block uint64
+}
+
+// nextPending returns the pending change after the given change is seen. A change back to the applied value
+// or the removal of the pending one discards it, while seeing the pending value again keeps its first block
+func nextPending[T comparable](pending *pendingChange[T], applied T, change settingChange[T]) *pendingChange[T] {
+	switch {
+	case change.removed:
+		if pending != nil && pending.value == change.value && pending.block == change.block {
+			return nil
+		}
+
+		return pending
+	case change.value == applied:
+		return nil
+	case pending != nil && pending.value == change.value:
+		return pending
+	default:
+		return &pendingChange[T]{value: change.value, block: change.block}
+	}
+}
+
+// confirmed returns whether the given pending change is confirmed at the given head
+func (st *Tracker) confirmed(block, head uint64) bool {
+	return head >= block+st.confirmations
+}
+
+// observedBlock returns the block a polled change is seen at, which is the current head.
+// No block is needed when the changes are applied right away
+func (st *Tracker) observedBlock(ctx context.Context) (uint64, error) {
+	if st.confirmations == 0 {
+		return 0, nil
+	}
+
+	return st.head(ctx)
+}
+
+// head returns the current L1 block
+func (st *Tracker) head(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, st.timeout)
+	defer cancel()
+
+	header, err := st.em.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Number.Uint64(), nil
+}
+
+// hasPending returns whether there is an address or URL change waiting for confirmation
+func (st *Tracker) hasPending() bool {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return st.pendingAddr != nil || st.pendingURL != nil
+}
+
+// confirmationTicker returns the channel ticking when the pending changes must be checked,
+// nil if the changes are applied right away
+func (st *Tracker) confirmationTicker() (<-chan time.Time, func()) {
+	if st.confirmations == 0 {
+		return nil, func() {}
+	}
+
+	ticker := time.NewTicker(st.confirmInterval)
+
+	return ticker.C, ticker.Stop
+}
+
+// applyConfirmed applies the pending changes whose block is confirmed by the current head
+func (st *Tracker) applyConfirmed(ctx context.Context) {
+	if !st.hasPending() {
+		return
+	}
+
+	head, err := st.head(ctx)
+	if err != nil {
+		log.Errorf("failed to get the L1 head to confirm the sequencer changes: %v", err)
+		return
+	}
+
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if st.pendingAddr != nil && st.confirmed(st.pendingAddr.block, head) {
+		log.Infof("new trusted sequencer address confirmed: %v", st.pendingAddr.value)
+		st.addr = st.pendingAddr.value
+		st.pendingAddr = nil
+	}
+
+	if st.pendingURL != nil && st.confirmed(st.pendingURL.block, head) {
+		log.Infof("new trusted sequencer url confirmed: %v", st.pendingURL.value)
+		st.url = st.pendingURL.value
+		st.pendingURL = nil
+	}
+}
@@ -0,0 +1,138 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type headEtherman struct {
+	etherman.Etherman
+	head uint64
+}
+
+func (e *headEtherman) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(e.head)}, nil
+}
+
+func Test_nextPending(t *testing.T) {
+	t.Parallel()
+
+	pending := &pendingChange[string]{value: "new", block: 10}
+
+	tests := []struct {
+		name    string
+		pending *pendingChange[string]
+		change  settingChange[string]
+		want    *pendingChange[string]
+	}{
+		{
+			name:   "new change becomes pending",
+			change: settingChange[string]{value: "new", block: 10},
+			want:   pending,
+		},
+		{
+			name:    "same value keeps the first block",
+			pending: pending,
+			change:  settingChange[string]{value: "new", block: 12},
+			want:    pending,
+		},
+		{
+			name:    "another value replaces the pending change",
+			pending: pending,
+			change:  settingChange[string]{value: "newer", block: 12},
+			want:    &pendingChange[string]{value: "newer", block: 12},
+		},
+		{
+			name:    "change back to the applied value discards it",
+			pending: pending,
+			change:  settingChange[string]{value: "applied", block: 12},
+		},
+		{
+			name:    "removal of the pending change discards it",
+			pending: pending,
+			change:  settingChange[string]{value: "new", block: 10, removed: true},
+		},
+		{
+			name:    "removal of another change keeps it",
+			pending: pending,
+			change:  settingChange[string]{value: "new", block: 9, removed: true},
+			want:    pending,
+		},
+		{
+			name:   "removal without pending change is ignored",
+			change: settingChange[string]{value: "new", block: 10, removed: true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, nextPending(tt.pending, "applied", tt.change))
+		})
+	}
+}
+
+func TestTracker_ConfirmChanges(t *testing.T) {
+	t.Parallel()
+
+	var (
+		initialAddr = common.BytesToAddress([]byte("initial"))
+		updatedAddr = common.BytesToAddress([]byte("updated"))
+	)
+
+	em := &headEtherman{head: 100}
+	st := &Tracker{em: em, addr: initialAddr, url: "initial", confirmations: 5}
+
+	st.handleAddrChange(settingChange[common.Address]{value: updatedAddr, block: 100})
+	st.handleUrlChange(settingChange[string]{value: "updated", block: 102})
+
+	snapshot := st.Snapshot()
+	require.Equal(t, initialAddr, snapshot.Addr)
+	require.Equal(t, "initial", snapshot.URL)
+	require.Equal(t, uint64(5), snapshot.Confirmations)
+	require.Equal(t, &PendingChange{Value: updatedAddr.Hex(), Block: 100}, snapshot.PendingAddr)
+	require.Equal(t, &PendingChange{Value: "updated", Block: 102}, snapshot.PendingURL)
+
+	// not confirmed yet
+	em.head = 104
+	st.applyConfirmed(context.Background())
+	require.Equal(t, initialAddr, st.GetAddr())
+
+	// only the address is confirmed
+	em.head = 105
+	st.applyConfirmed(context.Background())
+	require.Equal(t, updatedAddr, st.GetAddr())
+	require.Equal(t, "initial", st.GetUrl())
+
+	// the URL change is reorged out before it is confirmed
+	st.handleUrlChange(settingChange[string]{value: "updated", block: 102, removed: true})
+	em.head = 110
+	st.applyConfirmed(context.Background())
+	require.Equal(t, "initial", st.GetUrl())
+
+	snapshot = st.Snapshot()
+	require.Nil(t, snapshot.PendingAddr)
+	require.Nil(t, snapshot.PendingURL)
+}
+
+func TestTracker_ApplyChangesWithoutConfirmations(t *testing.T) {
+	t.Parallel()
+
+	st := &Tracker{url: "initial"}
+
+	st.handleUrlChange(settingChange[string]{value: "updated", block: 10, removed: true})
+	require.Equal(t, "initial", st.GetUrl())
+
+	st.handleUrlChange(settingChange[string]{value: "updated"})
+	require.Equal(t, "updated", st.GetUrl())
+	require.Nil(t, st.Snapshot().PendingURL)
+}
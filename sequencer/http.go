@@ -0,0 +1,35 @@
+package sequencer
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+)
+
+// NewHTTPClient creates an http client with a pooled transport configured from the given settings.
+// The client is meant to be created once and shared by all calls to the sequencer
+func NewHTTPClient(cfg config.HTTPClientConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+
+	transport.MaxIdleConns = cfg.MaxIdleConns
+	transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	transport.DisableKeepAlives = cfg.DisableKeepAlives
+
+	if cfg.IdleConnTimeout.Duration > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout.Duration
+	}
+
+	if cfg.TLSHandshakeTimeout.Duration > 0 {
+		transport.TLSHandshakeTimeout = cfg.TLSHandshakeTimeout.Duration
+	}
+
+	if cfg.TLSInsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, //nolint:gosec
+		}
+	}
+
+	return &http.Client{Transport: transport}
+}
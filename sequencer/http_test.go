@@ -0,0 +1,37 @@
+package sequencer
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	client := NewHTTPClient(config.HTTPClientConfig{
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   5,
+		MaxConnsPerHost:       20,
+		IdleConnTimeout:       types.NewDuration(time.Second * 30),
+		TLSHandshakeTimeout:   types.NewDuration(time.Second * 3),
+		TLSInsecureSkipVerify: true,
+	})
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.Equal(t, 10, transport.MaxIdleConns)
+	require.Equal(t, 5, transport.MaxIdleConnsPerHost)
+	require.Equal(t, 20, transport.MaxConnsPerHost)
+	require.Equal(t, time.Second*30, transport.IdleConnTimeout)
+	require.Equal(t, time.Second*3, transport.TLSHandshakeTimeout)
+	require.False(t, transport.DisableKeepAlives)
+	require.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+
+	// The default transport must not be modified
+	require.NotSame(t, http.DefaultTransport, transport)
+}
@@ -0,0 +1,69 @@
+package sequencer
+
+import (
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsSubsystem = "sequencer"
+
+	// transportErrorStatus labels the fetches that didn't get any HTTP response from the sequencer
+	transportErrorStatus = "error"
+)
+
+var (
+	fetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "fetch_duration_seconds",
+		Help:      "Duration of the batch data fetches from the trusted sequencer",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"url", "status"})
+
+	fetchErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "fetch_errors_total",
+		Help:      "Number of failed batch data fetches from the trusted sequencer",
+	}, []string{"url", "status"})
+
+	fetchBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "fetch_bytes_total",
+		Help:      "Number of bytes fetched from the trusted sequencer",
+	}, []string{"url"})
+
+	subscriptions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "subscriptions_total",
+		Help:      "Number of L1 subscriptions established by the tracker to watch the sequencer settings",
+	}, []string{"watch"})
+
+	subscriptionErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "subscription_errors_total",
+		Help:      "Number of failed attempts of the tracker to subscribe to the sequencer setting changes",
+	}, []string{"watch"})
+
+	resubscribes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "resubscribes_total",
+		Help:      "Number of tracker subscriptions that failed and were established again",
+	}, []string{"watch"})
+
+	watchHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "watch_healthy",
+		Help:      "Whether the tracker watch of a sequencer setting is healthy and not churning (1) or not (0)",
+	}, []string{"watch"})
+)
+
+func init() {
+	metrics.Register(fetchDuration, fetchErrors, fetchBytes, subscriptions, subscriptionErrors, resubscribes, watchHealthy)
+}
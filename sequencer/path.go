@@ -0,0 +1,81 @@
+package sequencer
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// batchNumPlaceholder is replaced by the requested batch number in the data path template
+const batchNumPlaceholder = "{batchNum}"
+
+// ErrInvalidDataPath is returned when the sequencer data path template cannot be used to build a request URL
+var ErrInvalidDataPath = errors.New("invalid sequencer data path")
+
+// ValidateDataPath checks that the given sequencer data path template is a path and/or query, starting with
+// "/" or "?", whose only placeholder is {batchNum}. An empty template is valid, the sequencer URL is then used as is
+func ValidateDataPath(template string) error {
+	if template == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(template, "/") && !strings.HasPrefix(template, "?") {
+		return fmt.Errorf("%w %q: must start with \"/\" or \"?\"", ErrInvalidDataPath, template)
+	}
+
+	rendered := renderDataPath(template, 0)
+	if strings.ContainsAny(rendered, "{}") {
+		return fmt.Errorf("%w %q: the only supported placeholder is %s", ErrInvalidDataPath, template, batchNumPlaceholder)
+	}
+
+	ref, err := url.Parse(rendered)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidDataPath, template, err)
+	}
+
+	if ref.Scheme != "" || ref.Host != "" || ref.Fragment != "" {
+		return fmt.Errorf("%w %q: must only have a path and a query", ErrInvalidDataPath, template)
+	}
+
+	return nil
+}
+
+// dataURL returns the URL the given batch is requested at, which is the sequencer URL extended with
+// the rendered data path template. The template is expected to be valid
+func dataURL(sequencerURL, template string, batchNum uint64) (string, error) {
+	if template == "" {
+		return sequencerURL, nil
+	}
+
+	u, err := url.Parse(sequencerURL)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(renderDataPath(template, batchNum))
+	if err != nil {
+		return "", err
+	}
+
+	if ref.Path != "" {
+		u.Path = strings.TrimSuffix(u.Path, "/") + ref.Path
+		u.RawPath = ""
+	}
+
+	if ref.RawQuery != "" {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+
+		u.RawQuery += ref.RawQuery
+	}
+
+	return u.String(), nil
+}
+
+// renderDataPath replaces the placeholders of the data path template with the given batch number
+func renderDataPath(template string, batchNum uint64) string {
+	return strings.ReplaceAll(template, batchNumPlaceholder, strconv.FormatUint(batchNum, 10))
+}
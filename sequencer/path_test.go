@@ -0,0 +1,121 @@
+package sequencer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDataPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		valid    bool
+	}{
+		{name: "empty", valid: true},
+		{name: "path", template: "/rpc", valid: true},
+		{name: "path with batch number", template: "/batches/{batchNum}", valid: true},
+		{name: "query with batch number", template: "?batch={batchNum}", valid: true},
+		{name: "path and query", template: "/rpc?batch={batchNum}&full=true", valid: true},
+		{name: "relative path", template: "rpc"},
+		{name: "unknown placeholder", template: "/batches/{number}"},
+		{name: "unclosed placeholder", template: "/batches/{batchNum"},
+		{name: "absolute url", template: "//example.com/rpc"},
+		{name: "fragment", template: "/rpc#batch"},
+		{name: "invalid escape", template: "/rpc%zz"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateDataPath(tt.template)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidDataPath)
+			}
+		})
+	}
+}
+
+func Test_dataURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		sequencerURL string
+		template     string
+		want         string
+	}{
+		{
+			name:         "no template",
+			sequencerURL: "http://sequencer:8123",
+			want:         "http://sequencer:8123",
+		},
+		{
+			name:         "path appended",
+			sequencerURL: "http://sequencer:8123/",
+			template:     "/batches/{batchNum}",
+			want:         "http://sequencer:8123/batches/10",
+		},
+		{
+			name:         "path appended to the sequencer path",
+			sequencerURL: "http://sequencer:8123/api",
+			template:     "/batches/{batchNum}",
+			want:         "http://sequencer:8123/api/batches/10",
+		},
+		{
+			name:         "query merged",
+			sequencerURL: "http://sequencer:8123/rpc?key=secret",
+			template:     "?batch={batchNum}",
+			want:         "http://sequencer:8123/rpc?key=secret&batch=10",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := dataURL(tt.sequencerURL, tt.template, 10)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSequencerClient_GetDataAtPath(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/batches/10", r.URL.Path)
+		require.Equal(t, "full=true", r.URL.RawQuery)
+
+		var req rpc.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "zkevm_getBatchByNumber", req.Method)
+
+		_, err := fmt.Fprint(w, `{"result":{"number":"0xa","batchL2Data":"0x01"}}`)
+		require.NoError(t, err)
+	}))
+	defer svr.Close()
+
+	client := NewSequencerClient(svr.Client(), false, "/batches/{batchNum}?full=true")
+
+	got, err := client.GetData(context.Background(), svr.URL, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), uint64(got.Number))
+	require.Equal(t, []byte{0x01}, []byte(got.BatchL2Data))
+}
@@ -0,0 +1,166 @@
+package sequencer
+
+import (
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TrackerSnapshot is a point in time view of the tracker state, meant for diagnostics
+type TrackerSnapshot struct {
+	Addr      common.Address `json:"addr"`
+	URL       string         `json:"url"`
+	Tracking  bool           `json:"tracking"`
+	Polling   bool           `json:"polling"`
+	AddrWatch WatchSnapshot  `json:"addrWatch"`
+	URLWatch  WatchSnapshot  `json:"urlWatch"`
+	// Confirmations is the number of L1 blocks a change must be confirmed by before it is applied
+	Confirmations uint64 `json:"confirmations"`
+	// PendingAddr is the address change waiting for confirmation, if any
+	PendingAddr *PendingChange `json:"pendingAddr,omitempty"`
+	// PendingURL is the URL change waiting for confirmation, if any
+	PendingURL *PendingChange `json:"pendingUrl,omitempty"`
+}
+
+// PendingChange is a sequencer setting change seen on L1 but not applied yet
+type PendingChange struct {
+	Value string `json:"value"`
+	// Block is the L1 block the change was seen at
+	Block uint64 `json:"block"`
+}
+
+// WatchSnapshot is the state of the subscription (or polling loop) watching one of the sequencer settings.
+// Zero times mean it never happened
+type WatchSnapshot struct {
+	// Healthy is true when the last subscription or poll attempt succeeded
+	Healthy bool `json:"healthy"`
+	// LastEvent is when the last change was received
+	LastEvent time.Time `json:"lastEvent"`
+	// LastCheck is when the watch was last (re)subscribed or polled successfully
+	LastCheck time.Time `json:"lastCheck"`
+	// Resubscribes is the number of times the subscription failed and was established again
+	Resubscribes uint64 `json:"resubscribes"`
+	// LastError is the last subscription or poll error, if it has not recovered since
+	LastError string `json:"lastError,omitempty"`
+	// Churning is true when the subscription was established again more often than allowed within
+	// the churn window, which keeps the watch unhealthy even if the last subscription succeeded
+	Churning bool `json:"churning"`
+}
+
+// watch is the state of one of the watched sequencer settings
+type watch struct {
+	// name labels the metrics of the watch
+	name     string
+	snapshot WatchSnapshot
+	// resubscribedAt are the times of the resubscribes within the churn window
+	resubscribedAt []time.Time
+}
+
+// Snapshot returns the current state of the tracker
+func (st *Tracker) Snapshot() TrackerSnapshot {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	snapshot := TrackerSnapshot{
+		Addr:          st.addr,
+		URL:           st.url,
+		Tracking:      st.trackChanges,
+		Polling:       st.usePolling,
+		AddrWatch:     st.addrWatch.snapshot,
+		URLWatch:      st.urlWatch.snapshot,
+		Confirmations: st.confirmations,
+	}
+
+	if st.pendingAddr != nil {
+		snapshot.PendingAddr = &PendingChange{Value: st.pendingAddr.value.Hex(), Block: st.pendingAddr.block}
+	}
+
+	if st.pendingURL != nil {
+		snapshot.PendingURL = &PendingChange{Value: st.pendingURL.value, Block: st.pendingURL.block}
+	}
+
+	return snapshot
+}
+
+// watchSucceeded records a successful subscription or poll of the given watch.
+// The watch stays unhealthy while it is churning
+func (st *Tracker) watchSucceeded(w *watch) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	now := time.Now()
+	st.updateChurn(w, now)
+
+	w.snapshot.Healthy = !w.snapshot.Churning
+	w.snapshot.LastCheck = now
+	w.snapshot.LastError = ""
+	watchHealthy.WithLabelValues(w.name).Set(boolToFloat(w.snapshot.Healthy))
+}
+
+// watchFailed records a failed subscription or poll of the given watch
+func (st *Tracker) watchFailed(w *watch, err error) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	w.snapshot.Healthy = false
+	w.snapshot.LastError = err.Error()
+	watchHealthy.WithLabelValues(w.name).Set(0)
+}
+
+// watchResubscribing records that the subscription of the given watch failed and is being established again
+func (st *Tracker) watchResubscribing(w *watch, err error) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if st.churnThreshold > 0 {
+		now := time.Now()
+		w.resubscribedAt = append(w.resubscribedAt, now)
+		st.updateChurn(w, now)
+	}
+
+	if w.snapshot.Churning {
+		log.Warnf("sequencer %s subscription churning: %d resubscribes in the last %s",
+			w.name, len(w.resubscribedAt), st.churnWindow)
+	}
+
+	w.snapshot.Healthy = false
+	w.snapshot.LastError = err.Error()
+	w.snapshot.Resubscribes++
+	resubscribes.WithLabelValues(w.name).Inc()
+	watchHealthy.WithLabelValues(w.name).Set(0)
+}
+
+// updateChurn forgets the resubscribes of the given watch that fell out of the churn window, and flags it
+// as churning if more than the allowed number are left. It must be called with the lock held
+func (st *Tracker) updateChurn(w *watch, now time.Time) {
+	if st.churnThreshold == 0 {
+		return
+	}
+
+	recent := w.resubscribedAt[:0]
+	for _, at := range w.resubscribedAt {
+		if now.Sub(at) < st.churnWindow {
+			recent = append(recent, at)
+		}
+	}
+
+	w.resubscribedAt = recent
+	w.snapshot.Churning = uint(len(recent)) > st.churnThreshold
+}
+
+// watchEvent records a change received by the given watch
+func (st *Tracker) watchEvent(w *watch) {
+	st.lock.Lock()
+	w.snapshot.LastEvent = time.Now()
+	st.lock.Unlock()
+}
+
+// boolToFloat returns 1 for true and 0 for false, the value of a boolean gauge
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
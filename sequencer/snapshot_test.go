@@ -0,0 +1,74 @@
+package sequencer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_WatchChurn(t *testing.T) {
+	t.Parallel()
+
+	subscriptionErr := errors.New("subscription lost")
+
+	t.Run("churning watch stays unhealthy", func(t *testing.T) {
+		t.Parallel()
+
+		st := &Tracker{addrWatch: watch{name: addrWatchName}, churnThreshold: 2, churnWindow: time.Hour}
+
+		for i := 0; i < 2; i++ {
+			st.watchResubscribing(&st.addrWatch, subscriptionErr)
+			st.watchSucceeded(&st.addrWatch)
+		}
+
+		snapshot := st.Snapshot().AddrWatch
+		require.True(t, snapshot.Healthy)
+		require.False(t, snapshot.Churning)
+
+		st.watchResubscribing(&st.addrWatch, subscriptionErr)
+		st.watchSucceeded(&st.addrWatch)
+
+		snapshot = st.Snapshot().AddrWatch
+		require.False(t, snapshot.Healthy)
+		require.True(t, snapshot.Churning)
+		require.Equal(t, uint64(3), snapshot.Resubscribes)
+		require.Empty(t, snapshot.LastError)
+	})
+
+	t.Run("churn recovers once the window passes", func(t *testing.T) {
+		t.Parallel()
+
+		st := &Tracker{urlWatch: watch{name: urlWatchName}, churnThreshold: 1, churnWindow: time.Hour}
+
+		st.watchResubscribing(&st.urlWatch, subscriptionErr)
+		st.watchResubscribing(&st.urlWatch, subscriptionErr)
+		require.True(t, st.Snapshot().URLWatch.Churning)
+
+		for i := range st.urlWatch.resubscribedAt {
+			st.urlWatch.resubscribedAt[i] = st.urlWatch.resubscribedAt[i].Add(-time.Hour)
+		}
+
+		st.watchSucceeded(&st.urlWatch)
+
+		snapshot := st.Snapshot().URLWatch
+		require.True(t, snapshot.Healthy)
+		require.False(t, snapshot.Churning)
+		require.Empty(t, st.urlWatch.resubscribedAt)
+	})
+
+	t.Run("check disabled", func(t *testing.T) {
+		t.Parallel()
+
+		st := &Tracker{addrWatch: watch{name: addrWatchName}}
+
+		for i := 0; i < 10; i++ {
+			st.watchResubscribing(&st.addrWatch, subscriptionErr)
+		}
+
+		st.watchSucceeded(&st.addrWatch)
+		require.True(t, st.Snapshot().AddrWatch.Healthy)
+		require.Empty(t, st.addrWatch.resubscribedAt)
+	})
+}
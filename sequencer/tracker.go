@@ -2,6 +2,8 @@ package sequencer
 
 import (
 	"context"
+	"net/url"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +20,10 @@ import (
 const (
 	// maxConnectionRetries is the maximum number of retries to connect to the RPC node before failing.
 	maxConnectionRetries = 5
+
+	// addrWatchName and urlWatchName label the metrics of the sequencer address and URL watches
+	addrWatchName = "addr"
+	urlWatchName  = "url"
 )
 
 // Tracker watches the contract for relevant changes to the sequencer
@@ -31,13 +37,33 @@ type Tracker struct {
 	trackChanges bool
 	usePolling   bool
 	pollInterval time.Duration
-	wg           sync.WaitGroup
-	lock         sync.Mutex
-	startOnce    sync.Once
+	urlAllowlist []string
+	client       SequencerClient
+	addrWatch    watch
+	urlWatch     watch
+
+	churnThreshold uint
+	churnWindow    time.Duration
+
+	confirmations   uint64
+	confirmInterval time.Duration
+	pendingAddr     *pendingChange[common.Address]
+	pendingURL      *pendingChange[string]
+
+	wg        sync.WaitGroup
+	lock      sync.Mutex
+	startOnce sync.Once
 }
 
-// NewTracker creates a new Tracker
+// NewTracker creates a new Tracker fetching the batches from the sequencer over http
 func NewTracker(cfg config.L1Config, em etherman.Etherman) *Tracker {
+	client := NewSequencerClient(NewHTTPClient(cfg.SequencerHTTP), cfg.StrictSequencerResponse, cfg.SequencerDataPath)
+
+	return NewTrackerWithClient(cfg, em, client)
+}
+
+// NewTrackerWithClient creates a new Tracker fetching the batches from the sequencer with the given client
+func NewTrackerWithClient(cfg config.L1Config, em etherman.Etherman, client SequencerClient) *Tracker {
 	pollInterval := time.Minute
 	if cfg.TrackSequencerPollInterval.Seconds() > 0 {
 		pollInterval = cfg.TrackSequencerPollInterval.Duration
@@ -51,6 +77,16 @@ func NewTracker(cfg config.L1Config, em etherman.Etherman) *Tracker {
 		trackChanges: cfg.TrackSequencer,
 		usePolling:   strings.HasPrefix(cfg.RpcURL, "http"), // If http(s), use polling instead of sockets
 		pollInterval: pollInterval,
+		urlAllowlist: cfg.SequencerURLAllowlist,
+		client:       client,
+		addrWatch:    watch{name: addrWatchName},
+		urlWatch:     watch{name: urlWatchName},
+
+		churnThreshold: cfg.SequencerResubscribeThreshold,
+		churnWindow:    cfg.SequencerResubscribeWindow.Duration,
+
+		confirmations:   cfg.SequencerChangeConfirmations,
+		confirmInterval: confirmationCheckInterval,
 	}
 }
 
@@ -80,6 +116,27 @@ func (st *Tracker) setUrl(url string) {
 	st.lock.Unlock()
 }
 
+// isUrlAllowed checks the host of the given sequencer URL against the configured allowlist.
+// Every URL is allowed when the allowlist is empty
+func (st *Tracker) isUrlAllowed(rawUrl string) bool {
+	if len(st.urlAllowlist) == 0 {
+		return true
+	}
+
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Hostname() == "" {
+		return false
+	}
+
+	for _, pattern := range st.urlAllowlist {
+		if matched, err := path.Match(pattern, u.Hostname()); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Start starts the SequencerTracker
 func (st *Tracker) Start(parentCtx context.Context) {
 	st.startOnce.Do(func() {
@@ -102,7 +159,11 @@ func (st *Tracker) Start(parentCtx context.Context) {
 		}
 
 		log.Infof("current sequencer url: %s", url)
-		st.setUrl(url)
+		if st.isUrlAllowed(url) {
+			st.setUrl(url)
+		} else {
+			log.Errorf("sequencer url %s is not in the allowlist, ignoring it", url)
+		}
 
 		if st.trackChanges {
 			log.Info("sequencer tracking enabled")
@@ -114,7 +175,7 @@ func (st *Tracker) Start(parentCtx context.Context) {
 }
 
 func (st *Tracker) trackAddrChanges(ctx context.Context) {
-	addrChan := make(chan common.Address, 1)
+	addrChan := make(chan settingChange[common.Address], 1)
 
 	if st.usePolling {
 		go st.pollAddrChanges(ctx, addrChan)
@@ -122,13 +183,15 @@ func (st *Tracker) trackAddrChanges(ctx context.Context) {
 		go st.subscribeOnAddrChanges(ctx, addrChan)
 	}
 
+	confirmations, stopConfirmations := st.confirmationTicker()
+	defer stopConfirmations()
+
 	for {
 		select {
-		case addr := <-addrChan:
-			if st.GetAddr().Cmp(addr) != 0 {
-				log.Infof("new trusted sequencer address: %v", addr)
-				st.setAddr(addr)
-			}
+		case change := <-addrChan:
+			st.handleAddrChange(change)
+		case <-confirmations:
+			st.applyConfirmed(ctx)
 		case <-ctx.Done():
 			if ctx.Err() != nil && ctx.Err() != context.DeadlineExceeded {
 				log.Warnf("context cancelled: %v", ctx.Err())
@@ -140,7 +203,31 @@ func (st *Tracker) trackAddrChanges(ctx context.Context) {
 	}
 }
 
-func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- common.Address) {
+// handleAddrChange applies the given address change, or keeps it pending until it is confirmed
+func (st *Tracker) handleAddrChange(change settingChange[common.Address]) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if st.confirmations == 0 {
+		if !change.removed && st.addr.Cmp(change.value) != 0 {
+			log.Infof("new trusted sequencer address: %v", change.value)
+			st.addr = change.value
+		}
+
+		return
+	}
+
+	pending := nextPending(st.pendingAddr, st.addr, change)
+	if pending == nil && st.pendingAddr != nil {
+		log.Warnf("discarding unconfirmed trusted sequencer address %v", st.pendingAddr.value)
+	} else if pending != nil && pending != st.pendingAddr {
+		log.Infof("new trusted sequencer address %v pending confirmation of block %d", pending.value, pending.block)
+	}
+
+	st.pendingAddr = pending
+}
+
+func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- settingChange[common.Address]) {
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -153,12 +240,16 @@ func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- c
 		if err := backoff.Exponential(func() (err error) {
 			if sub, err = st.em.WatchSetTrustedSequencer(ctx, events); err != nil {
 				log.Errorf("error subscribing to trusted sequencer event, retrying: %v", err)
+				subscriptionErrors.WithLabelValues(st.addrWatch.name).Inc()
 			}
 
 			return err
 		}, maxConnectionRetries, st.retry); err != nil {
 			log.Fatalf("failed subscribing to trusted sequencer event: %v. Check ws(s) availability.", err)
 		}
+
+		subscriptions.WithLabelValues(st.addrWatch.name).Inc()
+		st.watchSucceeded(&st.addrWatch)
 	}
 
 	initSubscription()
@@ -166,11 +257,17 @@ func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- c
 	for {
 		select {
 		case e := <-events:
-			addrChan <- e.NewTrustedSequencer
+			st.watchEvent(&st.addrWatch)
+			addrChan <- settingChange[common.Address]{
+				value:   e.NewTrustedSequencer,
+				block:   e.Raw.BlockNumber,
+				removed: e.Raw.Removed,
+			}
 		case <-ctx.Done():
 			return
 		case err := <-sub.Err():
 			log.Warnf("subscription error, resubscribing: %v", err)
+			st.watchResubscribing(&st.addrWatch, err)
 			initSubscription()
 		case <-st.stop:
 			if sub != nil {
@@ -181,7 +278,7 @@ func (st *Tracker) subscribeOnAddrChanges(ctx context.Context, addrChan chan<- c
 	}
 }
 
-func (st *Tracker) pollAddrChanges(ctx context.Context, addrChan chan<- common.Address) {
+func (st *Tracker) pollAddrChanges(ctx context.Context, addrChan chan<- settingChange[common.Address]) {
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -192,12 +289,28 @@ func (st *Tracker) pollAddrChanges(ctx context.Context, addrChan chan<- common.A
 			addr, err := st.em.TrustedSequencer(ctx)
 			if err != nil {
 				log.Errorf("failed to get sequencer addr: %v", err)
+				st.watchFailed(&st.addrWatch, err)
+				break
+			}
+
+			st.watchSucceeded(&st.addrWatch)
+
+			changed := st.GetAddr().Cmp(addr) != 0
+			if !changed && !st.hasPending() {
+				break
+			}
+
+			block, err := st.observedBlock(ctx)
+			if err != nil {
+				log.Errorf("failed to get the L1 head of the sequencer addr change: %v", err)
 				break
 			}
 
-			if st.GetAddr().Cmp(addr) != 0 {
-				addrChan <- addr
+			if changed {
+				st.watchEvent(&st.addrWatch)
 			}
+
+			addrChan <- settingChange[common.Address]{value: addr, block: block}
 		case <-ctx.Done():
 			ticker.Stop()
 			return
@@ -209,7 +322,7 @@ func (st *Tracker) pollAddrChanges(ctx context.Context, addrChan chan<- common.A
 }
 
 func (st *Tracker) trackUrlChanges(ctx context.Context) {
-	urlChan := make(chan string, 1)
+	urlChan := make(chan settingChange[string], 1)
 
 	if st.usePolling {
 		go st.pollUrlChanges(ctx, urlChan)
@@ -217,13 +330,20 @@ func (st *Tracker) trackUrlChanges(ctx context.Context) {
 		go st.subscribeOnUrlChanges(ctx, urlChan)
 	}
 
+	confirmations, stopConfirmations := st.confirmationTicker()
+	defer stopConfirmations()
+
 	for {
 		select {
-		case url := <-urlChan:
-			if st.GetUrl() != url {
-				log.Infof("new trusted sequencer url: %v", url)
-				st.setUrl(url)
+		case change := <-urlChan:
+			if !change.removed && change.value != st.GetUrl() && !st.isUrlAllowed(change.value) {
+				log.Errorf("new trusted sequencer url %v is not in the allowlist, keeping %v", change.value, st.GetUrl())
+				continue
 			}
+
+			st.handleUrlChange(change)
+		case <-confirmations:
+			st.applyConfirmed(ctx)
 		case <-ctx.Done():
 			if ctx.Err() != nil && ctx.Err() != context.DeadlineExceeded {
 				log.Warnf("context cancelled: %v", ctx.Err())
@@ -235,7 +355,31 @@ func (st *Tracker) trackUrlChanges(ctx context.Context) {
 	}
 }
 
-func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- string) {
+// handleUrlChange applies the given URL change, or keeps it pending until it is confirmed
+func (st *Tracker) handleUrlChange(change settingChange[string]) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if st.confirmations == 0 {
+		if !change.removed && st.url != change.value {
+			log.Infof("new trusted sequencer url: %v", change.value)
+			st.url = change.value
+		}
+
+		return
+	}
+
+	pending := nextPending(st.pendingURL, st.url, change)
+	if pending == nil && st.pendingURL != nil {
+		log.Warnf("discarding unconfirmed trusted sequencer url %v", st.pendingURL.value)
+	} else if pending != nil && pending != st.pendingURL {
+		log.Infof("new trusted sequencer url %v pending confirmation of block %d", pending.value, pending.block)
+	}
+
+	st.pendingURL = pending
+}
+
+func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- settingChange[string]) {
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -248,12 +392,16 @@ func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- str
 		if err := backoff.Exponential(func() (err error) {
 			if sub, err = st.em.WatchSetTrustedSequencerURL(ctx, events); err != nil {
 				log.Errorf("error subscribing to trusted sequencer URL event, retrying: %v", err)
+				subscriptionErrors.WithLabelValues(st.urlWatch.name).Inc()
 			}
 
 			return err
 		}, maxConnectionRetries, st.retry); err != nil {
 			log.Fatalf("failed subscribing to trusted sequencer URL event: %v. Check ws(s) availability.", err)
 		}
+
+		subscriptions.WithLabelValues(st.urlWatch.name).Inc()
+		st.watchSucceeded(&st.urlWatch)
 	}
 
 	initSubscription()
@@ -261,11 +409,17 @@ func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- str
 	for {
 		select {
 		case e := <-events:
-			urlChan <- e.NewTrustedSequencerURL
+			st.watchEvent(&st.urlWatch)
+			urlChan <- settingChange[string]{
+				value:   e.NewTrustedSequencerURL,
+				block:   e.Raw.BlockNumber,
+				removed: e.Raw.Removed,
+			}
 		case <-ctx.Done():
 			return
 		case err := <-sub.Err():
 			log.Warnf("subscription error, resubscribing: %v", err)
+			st.watchResubscribing(&st.urlWatch, err)
 			initSubscription()
 		case <-st.stop:
 			if sub != nil {
@@ -276,7 +430,7 @@ func (st *Tracker) subscribeOnUrlChanges(ctx context.Context, urlChan chan<- str
 	}
 }
 
-func (st *Tracker) pollUrlChanges(ctx context.Context, urlChan chan<- string) {
+func (st *Tracker) pollUrlChanges(ctx context.Context, urlChan chan<- settingChange[string]) {
 	st.wg.Add(1)
 	defer st.wg.Done()
 
@@ -287,12 +441,28 @@ func (st *Tracker) pollUrlChanges(ctx context.Context, urlChan chan<- string) {
 			url, err := st.em.TrustedSequencerURL(ctx)
 			if err != nil {
 				log.Errorf("failed to get sequencer URL: %v", err)
+				st.watchFailed(&st.urlWatch, err)
+				break
+			}
+
+			st.watchSucceeded(&st.urlWatch)
+
+			changed := st.GetUrl() != url
+			if !changed && !st.hasPending() {
+				break
+			}
+
+			block, err := st.observedBlock(ctx)
+			if err != nil {
+				log.Errorf("failed to get the L1 head of the sequencer URL change: %v", err)
 				break
 			}
 
-			if st.GetUrl() != url {
-				urlChan <- url
+			if changed {
+				st.watchEvent(&st.urlWatch)
 			}
+
+			urlChan <- settingChange[string]{value: url, block: block}
 		case <-ctx.Done():
 			ticker.Stop()
 			return
@@ -305,7 +475,7 @@ func (st *Tracker) pollUrlChanges(ctx context.Context, urlChan chan<- string) {
 
 // GetSequenceBatch returns sequence batch for given batch number
 func (st *Tracker) GetSequenceBatch(ctx context.Context, batchNum uint64) (*SeqBatch, error) {
-	return GetData(ctx, st.GetUrl(), batchNum)
+	return st.client.GetData(ctx, st.GetUrl(), batchNum)
 }
 
 // Stop stops the SequencerTracker
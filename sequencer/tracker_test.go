@@ -2,6 +2,7 @@ package sequencer_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -93,6 +94,19 @@ func TestTracker(t *testing.T) {
 			return tracker.GetAddr() == updatedAddress && tracker.GetUrl() == updatedURL
 		})
 
+		snapshot := tracker.Snapshot()
+		require.Equal(t, updatedAddress, snapshot.Addr)
+		require.Equal(t, updatedURL, snapshot.URL)
+		require.True(t, snapshot.Tracking)
+		require.False(t, snapshot.Polling)
+		for _, watch := range []sequencer.WatchSnapshot{snapshot.AddrWatch, snapshot.URLWatch} {
+			require.True(t, watch.Healthy)
+			require.False(t, watch.LastCheck.IsZero())
+			require.False(t, watch.LastEvent.IsZero())
+			require.Zero(t, watch.Resubscribes)
+			require.Empty(t, watch.LastError)
+		}
+
 		tracker.Stop()
 
 		urlsSubscription.AssertExpectations(t)
@@ -137,6 +151,57 @@ func TestTracker(t *testing.T) {
 		etherman.AssertExpectations(t)
 	})
 
+	t.Run("with url allowlist", func(t *testing.T) {
+		var (
+			allowedURL = "http://sequencer.example.com:8545"
+			blockedURL = "http://attacker.com:8545"
+			urlPolled  = make(chan struct{}, 1)
+		)
+
+		ctx := context.Background()
+
+		etherman := mocks.NewEtherman(t)
+
+		etherman.On("TrustedSequencer", mock.Anything).Return(initialAddress, nil)
+		etherman.On("TrustedSequencerURL", mock.Anything).Return(allowedURL, nil).Once()
+		etherman.On("TrustedSequencerURL", mock.Anything).
+			Run(func(args mock.Arguments) {
+				select {
+				case urlPolled <- struct{}{}:
+				default:
+				}
+			}).
+			Return(blockedURL, nil)
+
+		tracker := sequencer.NewTracker(config.L1Config{
+			RpcURL:                     "http://127.0.0.1:8545",
+			Timeout:                    types.NewDuration(time.Second * 10),
+			RetryPeriod:                types.NewDuration(time.Millisecond),
+			TrackSequencerPollInterval: types.NewDuration(time.Millisecond * 100),
+			TrackSequencer:             true,
+			SequencerURLAllowlist:      []string{"*.example.com"},
+		}, etherman)
+
+		tracker.Start(ctx)
+
+		require.Equal(t, allowedURL, tracker.GetUrl())
+
+		select {
+		case <-urlPolled:
+		case <-time.After(time.Second * 5):
+			t.Fatal("sequencer url was not polled")
+		}
+
+		// Give the tracker a moment to process the polled url
+		time.Sleep(time.Millisecond * 200)
+
+		require.Equal(t, allowedURL, tracker.GetUrl())
+
+		tracker.Stop()
+
+		etherman.AssertExpectations(t)
+	})
+
 	t.Run("with disabled tracker", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -158,12 +223,48 @@ func TestTracker(t *testing.T) {
 		require.Equal(t, initialAddress, tracker.GetAddr())
 		require.Equal(t, initialURL, tracker.GetUrl())
 
+		snapshot := tracker.Snapshot()
+		require.False(t, snapshot.Tracking)
+		require.Equal(t, sequencer.WatchSnapshot{}, snapshot.AddrWatch)
+		require.Equal(t, sequencer.WatchSnapshot{}, snapshot.URLWatch)
+
 		tracker.Stop()
 
 		etherman.AssertExpectations(t)
 	})
 }
 
+func TestTracker_GetSequenceBatch(t *testing.T) {
+	const url = "127.0.0.1:8585"
+
+	ctx := context.Background()
+
+	etherman := mocks.NewEtherman(t)
+	etherman.On("TrustedSequencer", mock.Anything).Return(common.Address{}, nil)
+	etherman.On("TrustedSequencerURL", mock.Anything).Return(url, nil)
+
+	batch := &sequencer.SeqBatch{Number: 1, BatchL2Data: []byte("data")}
+
+	client := mocks.NewSequencerClient(t)
+	client.On("GetData", mock.Anything, url, uint64(1)).Return(batch, nil).Once()
+	client.On("GetData", mock.Anything, url, uint64(2)).Return(nil, errors.New("error")).Once()
+
+	tracker := sequencer.NewTrackerWithClient(config.L1Config{
+		Timeout: types.NewDuration(time.Second * 10),
+	}, etherman, client)
+
+	tracker.Start(ctx)
+
+	actual, err := tracker.GetSequenceBatch(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, batch, actual)
+
+	_, err = tracker.GetSequenceBatch(ctx, 2)
+	require.ErrorContains(t, err, "error")
+
+	tracker.Stop()
+}
+
 func eventually(t *testing.T, num int, f func() bool) {
 	t.Helper()
 
@@ -0,0 +1,215 @@
+package da
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// APIDA is the namespace of the da service
+const APIDA = "da"
+
+// CommitmentVerifier checks the stored data of a batch against its commitment on L1
+type CommitmentVerifier interface {
+	VerifyBatchCommitment(ctx context.Context, batchNum uint64) (*types.BatchCommitment, error)
+	CheckKeyBatchConsistency(ctx context.Context, batchNum uint64) (*types.KeyBatchConsistency, error)
+	RepairKeyBatchConsistency(ctx context.Context, batchNum uint64) (*types.KeyBatchRepair, error)
+}
+
+// TrackerStateProvider exposes the internal state of the sequencer tracker
+type TrackerStateProvider interface {
+	Snapshot() sequencer.TrackerSnapshot
+}
+
+// FailedBatchStore lists and requeues the batch keys the synchronizer gave up resolving
+type FailedBatchStore interface {
+	ListFailedBatches(ctx context.Context) ([]types.FailedBatch, error)
+	RequeueFailedBatch(ctx context.Context, key types.BatchKey) error
+}
+
+// BatchDataStore returns the key committed on L1 for a batch and the value stored for it
+type BatchDataStore interface {
+	GetCommittedKey(ctx context.Context, batchNum uint64) (common.Hash, error)
+	GetOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error)
+}
+
+// Maintainer vacuums the tables of the data node and refreshes their statistics
+type Maintainer interface {
+	RunMaintenance(ctx context.Context) error
+}
+
+// Endpoints contains implementations for the "da" RPC endpoints, meant for operators and auditors
+type Endpoints struct {
+	verifier    CommitmentVerifier
+	tracker     TrackerStateProvider
+	failed      FailedBatchStore
+	batches     BatchDataStore
+	maintenance Maintainer
+}
+
+// NewEndpoints returns Endpoints
+func NewEndpoints(
+	verifier CommitmentVerifier,
+	tracker TrackerStateProvider,
+	failed FailedBatchStore,
+	batches BatchDataStore,
+	maintenance Maintainer,
+) *Endpoints {
+	return &Endpoints{
+		verifier:    verifier,
+		tracker:     tracker,
+		failed:      failed,
+		batches:     batches,
+		maintenance: maintenance,
+	}
+}
+
+// VerifyBatchCommitment checks the stored data of the given batch against the hash committed on L1
+func (d *Endpoints) VerifyBatchCommitment(batchNum types.ArgUint64) (interface{}, rpc.Error) {
+	commitment, err := d.verifier.VerifyBatchCommitment(context.Background(), uint64(batchNum))
+	if err != nil {
+		log.Errorf("failed to verify the commitment of batch %d: %v", batchNum, err)
+
+		if errors.Is(err, synchronizer.ErrSequenceNotFound) {
+			return nil, rpc.NewRPCError(rpc.BatchNotFoundErrorCode, "batch not sequenced on L1")
+		}
+
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to verify the batch commitment")
+	}
+
+	if !commitment.Matches {
+		log.Warnf("stored data of batch %d does not match its commitment. Committed: %s, computed: %s",
+			batchNum, commitment.Committed.Hex(), commitment.Computed.Hex())
+	}
+
+	return commitment, nil
+}
+
+// CheckKeyBatchConsistency checks that the keys stored under the given batch number are the key committed
+// on L1 for the batch, reporting the misattributed ones
+func (d *Endpoints) CheckKeyBatchConsistency(batchNum types.ArgUint64) (interface{}, rpc.Error) {
+	consistency, err := d.verifier.CheckKeyBatchConsistency(context.Background(), uint64(batchNum))
+	if err != nil {
+		log.Errorf("failed to check the key consistency of batch %d: %v", batchNum, err)
+
+		if errors.Is(err, synchronizer.ErrSequenceNotFound) {
+			return nil, rpc.NewRPCError(rpc.BatchNotFoundErrorCode, "batch not sequenced on L1")
+		}
+
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to check the key consistency of the batch")
+	}
+
+	if !consistency.Consistent {
+		log.Warnf("batch %d has %d stored keys not committed for it", batchNum, len(consistency.MisattributedKeys))
+	}
+
+	return consistency, nil
+}
+
+// RepairKeyBatchConsistency moves the keys stored under the given batch number but committed for another batch
+// to that batch, and the committed key of the batch to it, reporting the corrections made
+func (d *Endpoints) RepairKeyBatchConsistency(batchNum types.ArgUint64) (interface{}, rpc.Error) {
+	repair, err := d.verifier.RepairKeyBatchConsistency(context.Background(), uint64(batchNum))
+	if err != nil {
+		log.Errorf("failed to repair the key consistency of batch %d: %v", batchNum, err)
+
+		if errors.Is(err, synchronizer.ErrSequenceNotFound) {
+			return nil, rpc.NewRPCError(rpc.BatchNotFoundErrorCode, "batch not sequenced on L1")
+		}
+
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to repair the key consistency of the batch")
+	}
+
+	if len(repair.UnresolvedKeys) > 0 {
+		log.Warnf("batch %d has %d stored keys not committed for any batch of its sequence",
+			batchNum, len(repair.UnresolvedKeys))
+	}
+
+	return repair, nil
+}
+
+// GetTrackerState returns a snapshot of the sequencer tracker state, to diagnose why sequencer changes
+// are not picked up
+func (d *Endpoints) GetTrackerState() (interface{}, rpc.Error) {
+	return d.tracker.Snapshot(), nil
+}
+
+// ListFailedBatches returns the batch keys the synchronizer gave up resolving, with the reason of the last failure
+func (d *Endpoints) ListFailedBatches() (interface{}, rpc.Error) {
+	failed, err := d.failed.ListFailedBatches(context.Background())
+	if err != nil {
+		log.Errorf("failed to list the failed batches: %v", err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to list the failed batches")
+	}
+
+	return failed, nil
+}
+
+// RequeueFailedBatch moves the given failed batch key back to the missing batches, so the synchronizer
+// tries to resolve it again
+func (d *Endpoints) RequeueFailedBatch(batchNum types.ArgUint64, hash common.Hash) (interface{}, rpc.Error) {
+	key := types.BatchKey{Number: uint64(batchNum), Hash: hash}
+	if err := d.failed.RequeueFailedBatch(context.Background(), key); err != nil {
+		log.Errorf("failed to requeue failed batch %d, key %s: %v", batchNum, hash.Hex(), err)
+
+		if errors.Is(err, db.ErrStateNotSynchronized) {
+			return nil, rpc.NewRPCError(rpc.BatchNotFoundErrorCode, "batch key not found in the failed batches")
+		}
+
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to requeue the failed batch")
+	}
+
+	return nil, nil
+}
+
+// GetBatchRoot returns the root of the data stored for the given batch, see types.ComputeBatchRoot. The root
+// covers the key committed on L1 for the batch and the value stored for it, wherever the value is attributed.
+// Comparing the roots of the committee members detects a member whose storage diverged without exchanging the
+// data. The root of a batch whose committed key is unknown or whose value is not stored is zero
+func (d *Endpoints) GetBatchRoot(batchNum types.ArgUint64) (interface{}, rpc.Error) {
+	ctx := context.Background()
+
+	var ods []types.OffChainData
+
+	key, err := d.batches.GetCommittedKey(ctx, uint64(batchNum))
+	if err == nil {
+		var od *types.OffChainData
+		if od, err = d.batches.GetOffChainData(ctx, key); err == nil {
+			ods = append(ods, types.OffChainData{Key: key, Value: od.Value})
+		}
+	}
+
+	if err != nil && !errors.Is(err, db.ErrStateNotSynchronized) {
+		log.Errorf("failed to get the committed data of batch %d: %v", batchNum, err)
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the batch root")
+	}
+
+	return &types.BatchRoot{
+		BatchNum: batchNum,
+		Root:     types.ComputeBatchRoot(ods),
+		Values:   uint64(len(ods)),
+	}, nil
+}
+
+// RunMaintenance vacuums the tables of the data node and refreshes their statistics, e.g. after a large prune.
+// It returns once the maintenance is done
+func (d *Endpoints) RunMaintenance() (interface{}, rpc.Error) {
+	if err := d.maintenance.RunMaintenance(context.Background()); err != nil {
+		log.Errorf("failed to run the database maintenance: %v", err)
+
+		if errors.Is(err, db.ErrMaintenanceRunning) {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "database maintenance already running")
+		}
+
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to run the database maintenance")
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,426 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/synchronizer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpoints_VerifyBatchCommitment(t *testing.T) {
+	t.Parallel()
+
+	commitment := &types.BatchCommitment{
+		BatchNum:  5,
+		L1TxHash:  common.HexToHash("0x01"),
+		Committed: common.HexToHash("0x02"),
+		Computed:  common.HexToHash("0x02"),
+		Matches:   true,
+	}
+
+	tests := []struct {
+		name       string
+		commitment *types.BatchCommitment
+		verifyErr  error
+		err        error
+		errCode    int
+	}{
+		{
+			name:       "commitment verified",
+			commitment: commitment,
+		},
+		{
+			name:       "commitment does not match",
+			commitment: &types.BatchCommitment{BatchNum: 5, Committed: common.HexToHash("0x02")},
+		},
+		{
+			name:      "batch not sequenced",
+			verifyErr: fmt.Errorf("%w: 5", synchronizer.ErrSequenceNotFound),
+			err:       errors.New("batch not sequenced on L1"),
+			errCode:   rpc.BatchNotFoundErrorCode,
+		},
+		{
+			name:      "verifier returns error",
+			verifyErr: errors.New("test error"),
+			err:       errors.New("failed to verify the batch commitment"),
+			errCode:   rpc.DefaultErrorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifierMock := mocks.NewCommitmentVerifier(t)
+			verifierMock.On("VerifyBatchCommitment", context.Background(), uint64(5)).
+				Return(tt.commitment, tt.verifyErr)
+
+			got, err := NewEndpoints(verifierMock, nil, nil, nil, nil).VerifyBatchCommitment(5)
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+				require.Equal(t, tt.errCode, err.ErrorCode())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.commitment, got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_GetTrackerState(t *testing.T) {
+	t.Parallel()
+
+	snapshot := sequencer.TrackerSnapshot{
+		Addr:     common.HexToAddress("0x01"),
+		URL:      "http://sequencer:8545",
+		Tracking: true,
+		AddrWatch: sequencer.WatchSnapshot{
+			Healthy:      true,
+			LastCheck:    time.Unix(100, 0),
+			Resubscribes: 2,
+		},
+		URLWatch: sequencer.WatchSnapshot{
+			LastError: "subscription lost",
+		},
+	}
+
+	trackerMock := mocks.NewTrackerStateProvider(t)
+	trackerMock.On("Snapshot").Return(snapshot)
+
+	got, err := NewEndpoints(nil, trackerMock, nil, nil, nil).GetTrackerState()
+	require.NoError(t, err)
+	require.Equal(t, snapshot, got)
+}
+
+func TestEndpoints_CheckKeyBatchConsistency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		consistency *types.KeyBatchConsistency
+		checkErr    error
+		err         error
+		errCode     int
+	}{
+		{
+			name: "consistent batch",
+			consistency: &types.KeyBatchConsistency{
+				BatchNum:           5,
+				CommittedKey:       common.HexToHash("0x02"),
+				CommittedKeyStored: true,
+				MisattributedKeys:  []common.Hash{},
+				Consistent:         true,
+			},
+		},
+		{
+			name: "misattributed keys",
+			consistency: &types.KeyBatchConsistency{
+				BatchNum:          5,
+				CommittedKey:      common.HexToHash("0x02"),
+				MisattributedKeys: []common.Hash{common.HexToHash("0x03")},
+			},
+		},
+		{
+			name:     "batch not sequenced",
+			checkErr: fmt.Errorf("%w: 5", synchronizer.ErrSequenceNotFound),
+			err:      errors.New("batch not sequenced on L1"),
+			errCode:  rpc.BatchNotFoundErrorCode,
+		},
+		{
+			name:     "verifier returns error",
+			checkErr: errors.New("test error"),
+			err:      errors.New("failed to check the key consistency of the batch"),
+			errCode:  rpc.DefaultErrorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifierMock := mocks.NewCommitmentVerifier(t)
+			verifierMock.On("CheckKeyBatchConsistency", context.Background(), uint64(5)).
+				Return(tt.consistency, tt.checkErr)
+
+			got, err := NewEndpoints(verifierMock, nil, nil, nil, nil).CheckKeyBatchConsistency(5)
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+				require.Equal(t, tt.errCode, err.ErrorCode())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.consistency, got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_RepairKeyBatchConsistency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		repair    *types.KeyBatchRepair
+		repairErr error
+		err       error
+		errCode   int
+	}{
+		{
+			name: "keys moved",
+			repair: &types.KeyBatchRepair{
+				BatchNum: 5,
+				Corrections: []types.KeyBatchCorrection{
+					{Key: common.HexToHash("0x03"), From: 5, To: 6},
+				},
+				UnresolvedKeys: []common.Hash{common.HexToHash("0x04")},
+			},
+		},
+		{
+			name:      "batch not sequenced",
+			repairErr: fmt.Errorf("%w: 5", synchronizer.ErrSequenceNotFound),
+			err:       errors.New("batch not sequenced on L1"),
+			errCode:   rpc.BatchNotFoundErrorCode,
+		},
+		{
+			name:      "verifier returns error",
+			repairErr: errors.New("test error"),
+			err:       errors.New("failed to repair the key consistency of the batch"),
+			errCode:   rpc.DefaultErrorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifierMock := mocks.NewCommitmentVerifier(t)
+			verifierMock.On("RepairKeyBatchConsistency", context.Background(), uint64(5)).
+				Return(tt.repair, tt.repairErr)
+
+			got, err := NewEndpoints(verifierMock, nil, nil, nil, nil).RepairKeyBatchConsistency(5)
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+				require.Equal(t, tt.errCode, err.ErrorCode())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.repair, got)
+			}
+		})
+	}
+}
+
+func TestEndpoints_ListFailedBatches(t *testing.T) {
+	t.Parallel()
+
+	failed := []types.FailedBatch{
+		{Number: 10, Hash: common.HexToHash("0x1"), Reason: "no data found", FailedAt: time.Unix(1000, 0)},
+	}
+
+	t.Run("failed batches listed", func(t *testing.T) {
+		t.Parallel()
+
+		storeMock := mocks.NewFailedBatchStore(t)
+		storeMock.On("ListFailedBatches", context.Background()).Return(failed, nil)
+
+		got, err := NewEndpoints(nil, nil, storeMock, nil, nil).ListFailedBatches()
+		require.NoError(t, err)
+		require.Equal(t, failed, got)
+	})
+
+	t.Run("store returns error", func(t *testing.T) {
+		t.Parallel()
+
+		storeMock := mocks.NewFailedBatchStore(t)
+		storeMock.On("ListFailedBatches", context.Background()).Return(nil, errors.New("test error"))
+
+		_, err := NewEndpoints(nil, nil, storeMock, nil, nil).ListFailedBatches()
+		require.Equal(t, rpc.DefaultErrorCode, err.ErrorCode())
+		require.Equal(t, "failed to list the failed batches", err.Error())
+	})
+}
+
+func TestEndpoints_RequeueFailedBatch(t *testing.T) {
+	t.Parallel()
+
+	key := types.BatchKey{Number: 10, Hash: common.HexToHash("0x1")}
+
+	tests := []struct {
+		name       string
+		requeueErr error
+		err        error
+		errCode    int
+	}{
+		{
+			name: "batch requeued",
+		},
+		{
+			name:       "not a failed batch",
+			requeueErr: fmt.Errorf("%w: batch 10", db.ErrStateNotSynchronized),
+			err:        errors.New("batch key not found in the failed batches"),
+			errCode:    rpc.BatchNotFoundErrorCode,
+		},
+		{
+			name:       "store returns error",
+			requeueErr: errors.New("test error"),
+			err:        errors.New("failed to requeue the failed batch"),
+			errCode:    rpc.DefaultErrorCode,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			storeMock := mocks.NewFailedBatchStore(t)
+			storeMock.On("RequeueFailedBatch", context.Background(), key).Return(tt.requeueErr)
+
+			_, err := NewEndpoints(nil, nil, storeMock, nil, nil).RequeueFailedBatch(types.ArgUint64(key.Number), key.Hash)
+			if tt.err != nil {
+				require.Equal(t, tt.errCode, err.ErrorCode())
+				require.Equal(t, tt.err.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEndpoints_GetBatchRoot(t *testing.T) {
+	t.Parallel()
+
+	key := crypto.Keccak256Hash([]byte("value1"))
+	od := &types.OffChainData{Key: key, Value: []byte("value1"), BatchNum: 5}
+
+	t.Run("root computed over the committed key", func(t *testing.T) {
+		t.Parallel()
+
+		storeMock := mocks.NewBatchDataStore(t)
+		storeMock.On("GetCommittedKey", context.Background(), uint64(5)).Return(key, nil).Once()
+		storeMock.On("GetOffChainData", context.Background(), key).Return(od, nil).Once()
+
+		got, err := NewEndpoints(nil, nil, nil, storeMock, nil).GetBatchRoot(5)
+		require.NoError(t, err)
+		require.Equal(t, &types.BatchRoot{
+			BatchNum: 5,
+			Root:     types.ComputeBatchRoot([]types.OffChainData{{Key: key, Value: []byte("value1")}}),
+			Values:   1,
+		}, got)
+	})
+
+	t.Run("value stored under another batch", func(t *testing.T) {
+		t.Parallel()
+
+		storeMock := mocks.NewBatchDataStore(t)
+		storeMock.On("GetCommittedKey", context.Background(), uint64(5)).Return(key, nil).Once()
+		storeMock.On("GetOffChainData", context.Background(), key).
+			Return(&types.OffChainData{Key: key, Value: []byte("value1"), BatchNum: 3}, nil).Once()
+
+		got, err := NewEndpoints(nil, nil, nil, storeMock, nil).GetBatchRoot(5)
+		require.NoError(t, err)
+		require.Equal(t, &types.BatchRoot{
+			BatchNum: 5,
+			Root:     types.ComputeBatchRoot([]types.OffChainData{{Key: key, Value: []byte("value1")}}),
+			Values:   1,
+		}, got)
+	})
+
+	t.Run("committed key unknown", func(t *testing.T) {
+		t.Parallel()
+
+		storeMock := mocks.NewBatchDataStore(t)
+		storeMock.On("GetCommittedKey", context.Background(), uint64(5)).
+			Return(common.Hash{}, db.ErrStateNotSynchronized).Once()
+
+		got, err := NewEndpoints(nil, nil, nil, storeMock, nil).GetBatchRoot(5)
+		require.NoError(t, err)
+		require.Equal(t, &types.BatchRoot{BatchNum: 5}, got)
+	})
+
+	t.Run("no data stored", func(t *testing.T) {
+		t.Parallel()
+
+		storeMock := mocks.NewBatchDataStore(t)
+		storeMock.On("GetCommittedKey", context.Background(), uint64(5)).Return(key, nil).Once()
+		storeMock.On("GetOffChainData", context.Background(), key).Return(nil, db.ErrStateNotSynchronized).Once()
+
+		got, err := NewEndpoints(nil, nil, nil, storeMock, nil).GetBatchRoot(5)
+		require.NoError(t, err)
+		require.Equal(t, &types.BatchRoot{BatchNum: 5}, got)
+	})
+
+	t.Run("store returns error", func(t *testing.T) {
+		t.Parallel()
+
+		storeMock := mocks.NewBatchDataStore(t)
+		storeMock.On("GetCommittedKey", context.Background(), uint64(5)).Return(key, nil).Once()
+		storeMock.On("GetOffChainData", context.Background(), key).Return(nil, errors.New("test error")).Once()
+
+		_, err := NewEndpoints(nil, nil, nil, storeMock, nil).GetBatchRoot(5)
+		require.Equal(t, rpc.DefaultErrorCode, err.ErrorCode())
+		require.Equal(t, "failed to get the batch root", err.Error())
+	})
+}
+
+func TestEndpoints_RunMaintenance(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name      string
+		returnErr error
+		err       string
+	}{
+		{
+			name: "maintenance run",
+		},
+		{
+			name:      "maintenance already running",
+			returnErr: db.ErrMaintenanceRunning,
+			err:       "database maintenance already running",
+		},
+		{
+			name:      "maintenance failed",
+			returnErr: errors.New("test error"),
+			err:       "failed to run the database maintenance",
+		},
+	}
+
+	for _, tt := range testTable {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			maintainerMock := mocks.NewMaintainer(t)
+			maintainerMock.On("RunMaintenance", context.Background()).Return(tt.returnErr)
+
+			_, err := NewEndpoints(nil, nil, nil, nil, maintainerMock).RunMaintenance()
+			if tt.err != "" {
+				require.Equal(t, rpc.DefaultErrorCode, err.ErrorCode())
+				require.Equal(t, tt.err, err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package datacom
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 
 	"github.com/0xPolygon/cdk-data-availability/db"
@@ -18,24 +19,62 @@ const APIDATACOM = "datacom"
 
 // Endpoints contains implementations for the "datacom" RPC endpoints
 type Endpoints struct {
-	db               db.DB
+	db               db.BlobStore
 	privateKey       *ecdsa.PrivateKey
+	keys             *KeySelector
+	blsKey           *types.BLSPrivateKey
 	sequencerTracker *sequencer.Tracker
+	chainID          uint64
+	maxBatches       uint64
+	maxBlobSize      uint64
+	validators       []SequenceValidator
 }
 
-// NewEndpoints returns Endpoints
-func NewEndpoints(db db.DB, pk *ecdsa.PrivateKey, st *sequencer.Tracker) *Endpoints {
+// NewEndpoints returns Endpoints. If the chain ID is not 0, only banana sequences bound to it are signed.
+// Sequences with more than maxBatches batches, or banana sequences with a batch of more than maxBlobSize bytes,
+// are rejected, 0 meaning no limit. If the BLS key is not nil, sequences are signed with it instead of the
+// ECDSA private key
+func NewEndpoints(
+	db db.BlobStore,
+	pk *ecdsa.PrivateKey,
+	blsKey *types.BLSPrivateKey,
+	st *sequencer.Tracker,
+	chainID uint64,
+	maxBatches uint64,
+	maxBlobSize uint64,
+) *Endpoints {
 	return &Endpoints{
 		db:               db,
 		privateKey:       pk,
+		blsKey:           blsKey,
 		sequencerTracker: st,
+		chainID:          chainID,
+		maxBatches:       maxBatches,
+		maxBlobSize:      maxBlobSize,
+		validators:       []SequenceValidator{SignatureValidator{}},
 	}
 }
 
+// AddValidator adds a validator the sequences must pass before their data is stored and they are signed.
+// The validators run in the order they were added, after the built-in SignatureValidator
+func (d *Endpoints) AddValidator(validator SequenceValidator) {
+	d.validators = append(d.validators, validator)
+}
+
+// SetKeySelector makes the sequences be signed with the key selected by the given selector instead of the
+// private key, to rotate it without downtime
+func (d *Endpoints) SetKeySelector(keys *KeySelector) {
+	d.keys = keys
+}
+
 // SignSequence generates the concatenation of hashes of the batch data of the sequence and sign it.
 // After storing the data that will be sent hashed to the contract, it returns the signature.
 // This endpoint is only accessible to the sequencer
 func (d *Endpoints) SignSequence(signedSequence types.SignedSequence) (interface{}, rpc.Error) {
+	if err := signedSequence.Sequence.Validate(d.maxBatches); err != nil {
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+	}
+
 	return d.signSequence(&signedSequence)
 }
 
@@ -43,6 +82,16 @@ func (d *Endpoints) SignSequence(signedSequence types.SignedSequence) (interface
 // After storing the data that will be sent hashed to the contract, it returns the signature.
 // This endpoint is only accessible to the sequencer
 func (d *Endpoints) SignSequenceBanana(signedSequence types.SignedSequenceBanana) (interface{}, rpc.Error) {
+	err := signedSequence.Verify(d.chainID, d.maxBatches, d.sequencerTracker.GetAddr(), d.maxBlobSize)
+	switch {
+	case errors.Is(err, types.ErrSignerRecovery):
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to verify sender")
+	case errors.Is(err, types.ErrUnauthorizedSigner):
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "unauthorized")
+	case err != nil:
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+	}
+
 	log.Debugf("signing sequence, hash to sign: %s", common.BytesToHash(signedSequence.Sequence.HashToSign()))
 	return d.signSequence(&signedSequence)
 }
@@ -58,17 +107,52 @@ func (d *Endpoints) signSequence(signedSequence types.SignedSequenceInterface) (
 		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "unauthorized")
 	}
 
+	// Every validator must accept the sequence before anything is stored
+	ctx := db.WithAuditSource(context.Background(), "datacom")
+	ods := signedSequence.OffChainData()
+	for _, validator := range d.validators {
+		if err = validator.ValidateSequence(ctx, signedSequence, ods); err != nil {
+			log.Warnf("sequence rejected by validator %T: %v", validator, err)
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, fmt.Sprintf("sequence rejected: %v", err))
+		}
+	}
+
 	// Store off-chain data by hash (hash(L2Data): L2Data)
-	if err = d.db.StoreOffChainData(context.Background(), signedSequence.OffChainData()); err != nil {
+	if err = d.db.StoreOffChainData(ctx, ods); err != nil {
 		return nil, rpc.NewRPCError(rpc.DefaultErrorCode,
 			fmt.Errorf("failed to store offchain data. Error: %w", err).Error())
 	}
 
 	// Sign
-	signature, err := signedSequence.Sign(d.privateKey)
+	var signature types.ArgBytes
+	if d.blsKey != nil {
+		signature, err = signedSequence.SignBLS(d.blsKey)
+	} else {
+		signature, err = d.signECDSA(signedSequence)
+	}
 	if err != nil {
 		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Errorf("failed to sign. Error: %w", err).Error())
 	}
 	// Return signature
 	return signature, nil
 }
+
+// signECDSA signs the given sequence with the private key, or the one selected if the key is rotated
+func (d *Endpoints) signECDSA(signedSequence types.SignedSequenceInterface) (types.ArgBytes, error) {
+	privateKey := d.privateKey
+	if d.keys != nil {
+		var err error
+		if privateKey, err = d.keys.Key(); err != nil {
+			return nil, err
+		}
+	}
+
+	signature, err := signedSequence.Sign(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("sequence signed with key %s", keyAddress(privateKey).Hex())
+
+	return signature, nil
+}
@@ -26,6 +26,8 @@ func TestDataCom_SignSequence(t *testing.T) {
 		sender                   *ecdsa.PrivateKey
 		signer                   *ecdsa.PrivateKey
 		sequence                 types.Sequence
+		validator                SequenceValidator
+		malleate                 bool
 		expectedError            string
 	}
 
@@ -66,6 +68,9 @@ func TestDataCom_SignSequence(t *testing.T) {
 		if cfg.sender != nil {
 			signature, err := cfg.sequence.Sign(cfg.sender)
 			require.NoError(t, err)
+			if cfg.malleate {
+				signature = malleate(signature)
+			}
 			signedSequence = &types.SignedSequence{
 				Sequence:  cfg.sequence,
 				Signature: signature,
@@ -81,7 +86,10 @@ func TestDataCom_SignSequence(t *testing.T) {
 			signer = cfg.signer
 		}
 
-		dce := NewEndpoints(dbMock, signer, sqr)
+		dce := NewEndpoints(dbMock, signer, nil, sqr, 0, 0, 0)
+		if cfg.validator != nil {
+			dce.AddValidator(cfg.validator)
+		}
 
 		sig, err := dce.SignSequence(*signedSequence)
 		if cfg.expectedError != "" {
@@ -122,6 +130,60 @@ func TestDataCom_SignSequence(t *testing.T) {
 		})
 	})
 
+	t.Run("Rejected by a validator", func(t *testing.T) {
+		t.Parallel()
+
+		// nothing is stored for a rejected sequence
+		testFn(t, testConfig{
+			sender:        otherPrivateKey,
+			expectedError: "sequence rejected: too many batches for the policy",
+			validator: SequenceValidatorFunc(
+				func(_ context.Context, _ types.SignedSequenceInterface, ods []types.OffChainData) error {
+					if len(ods) > 1 {
+						return errors.New("too many batches for the policy")
+					}
+
+					return nil
+				}),
+			sequence: types.Sequence{
+				types.ArgBytes{0, 1},
+				types.ArgBytes{2, 3},
+			},
+		})
+	})
+
+	t.Run("Malleated signature rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// the malleated signature recovers the sequencer, but nothing is stored for it
+		testFn(t, testConfig{
+			sender:        otherPrivateKey,
+			malleate:      true,
+			expectedError: "sequence rejected: sequence signature is not canonical",
+			sequence: types.Sequence{
+				types.ArgBytes{0, 1},
+				types.ArgBytes{2, 3},
+			},
+		})
+	})
+
+	t.Run("Accepted by a validator", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:                   otherPrivateKey,
+			storeOffChainDataReturns: []interface{}{nil},
+			validator: SequenceValidatorFunc(
+				func(context.Context, types.SignedSequenceInterface, []types.OffChainData) error {
+					return nil
+				}),
+			sequence: types.Sequence{
+				types.ArgBytes{0, 1},
+				types.ArgBytes{2, 3},
+			},
+		})
+	})
+
 	t.Run("Fail to store off chain data", func(t *testing.T) {
 		t.Parallel()
 
@@ -178,6 +240,10 @@ func TestDataCom_SignSequenceBanana(t *testing.T) {
 		sender                   *ecdsa.PrivateKey
 		signer                   *ecdsa.PrivateKey
 		sequence                 types.SequenceBanana
+		chainID                  uint64
+		maxBatches               uint64
+		maxBlobSize              uint64
+		blsKey                   *types.BLSPrivateKey
 		expectedError            string
 	}
 
@@ -236,7 +302,7 @@ func TestDataCom_SignSequenceBanana(t *testing.T) {
 			signer = cfg.signer
 		}
 
-		dce := NewEndpoints(dbMock, signer, sqr)
+		dce := NewEndpoints(dbMock, signer, cfg.blsKey, sqr, cfg.chainID, cfg.maxBatches, cfg.maxBlobSize)
 
 		sig, err := dce.SignSequenceBanana(*signedSequence)
 		if cfg.expectedError != "" {
@@ -244,6 +310,12 @@ func TestDataCom_SignSequenceBanana(t *testing.T) {
 		} else {
 			require.NoError(t, err)
 			require.NotEmpty(t, sig)
+
+			if cfg.blsKey != nil {
+				blsSig, ok := sig.(types.ArgBytes)
+				require.True(t, ok)
+				require.NoError(t, types.VerifyBLS(cfg.blsKey.PublicKey(), cfg.sequence.HashToSign(), blsSig))
+			}
 		}
 
 		sqr.Stop()
@@ -309,4 +381,97 @@ func TestDataCom_SignSequenceBanana(t *testing.T) {
 			sequence:                 types.SequenceBanana{},
 		})
 	})
+
+	t.Run("Happy path - sequence bound to the chain signed", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:                   trustedSequencerKey,
+			storeOffChainDataReturns: []interface{}{nil},
+			sequence:                 types.SequenceBanana{ChainID: 1},
+			chainID:                  1,
+		})
+	})
+
+	t.Run("Sequence bound to another chain", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:        trustedSequencerKey,
+			expectedError: "sequence bound to chain ID 2, expected 1",
+			sequence:      types.SequenceBanana{ChainID: 2},
+			chainID:       1,
+		})
+	})
+
+	t.Run("Sequence not bound to the chain", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:        trustedSequencerKey,
+			expectedError: "sequence bound to chain ID 0, expected 1",
+			sequence:      types.SequenceBanana{},
+			chainID:       1,
+		})
+	})
+
+	t.Run("Happy path - sequence bound to a chain signed without chain ID", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:                   trustedSequencerKey,
+			storeOffChainDataReturns: []interface{}{nil},
+			sequence:                 types.SequenceBanana{ChainID: 2},
+		})
+	})
+
+	t.Run("Happy path - sequence signed with BLS", func(t *testing.T) {
+		t.Parallel()
+
+		blsKey, err := types.GenerateBLSKey()
+		require.NoError(t, err)
+
+		sequence := types.SequenceBanana{ChainID: 1}
+
+		testFn(t, testConfig{
+			sender:                   trustedSequencerKey,
+			storeOffChainDataReturns: []interface{}{nil},
+			sequence:                 sequence,
+			chainID:                  1,
+			blsKey:                   blsKey,
+		})
+	})
+
+	t.Run("Sequence with too many batches", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:        trustedSequencerKey,
+			expectedError: "too many batches in sequence: got 3, the maximum is 2",
+			sequence:      types.SequenceBanana{Batches: make([]types.Batch, 3)},
+			maxBatches:    2,
+		})
+	})
+
+	t.Run("Sequence with a batch too large", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:        trustedSequencerKey,
+			expectedError: "batch data exceeds the maximum blob size: batch 0 holds 3 bytes, the maximum is 2",
+			sequence:      types.SequenceBanana{Batches: []types.Batch{{L2Data: types.ArgBytes{1, 2, 3}}}},
+			maxBlobSize:   2,
+		})
+	})
+
+	t.Run("Happy path - sequence at the batch limit signed", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			sender:                   trustedSequencerKey,
+			storeOffChainDataReturns: []interface{}{nil},
+			sequence:                 types.SequenceBanana{Batches: make([]types.Batch, 2)},
+			maxBatches:               2,
+		})
+	})
 }
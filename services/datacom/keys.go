@@ -0,0 +1,147 @@
+package datacom
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// committeeRefreshInterval is how often the committee members are read from L1 to select the signing key
+const committeeRefreshInterval = time.Minute
+
+// ErrNoRegisteredKey is returned when none of the signing keys is a registered committee member
+var ErrNoRegisteredKey = errors.New("none of the signing keys is a registered committee member")
+
+// CommitteeMembersProvider returns the members currently registered in the data committee
+type CommitteeMembersProvider interface {
+	GetCurrentDataCommitteeMembers() ([]etherman.DataCommitteeMember, error)
+}
+
+// KeySelector selects the key the sequences are signed with among several candidates, which allows rotating
+// the key of the committee member without downtime. The key registered in the committee is used. When it is
+// replaced by a key that is not a candidate, or removed, it is still used for a grace period
+type KeySelector struct {
+	candidates []*ecdsa.PrivateKey
+	committee  CommitteeMembersProvider
+	grace      time.Duration
+	now        func() time.Time
+
+	mu sync.Mutex
+	// registered is the last candidate seen registered, and registeredAt when it was last seen
+	registered   *ecdsa.PrivateKey
+	registeredAt time.Time
+	// deregistered is set when registered was not a committee member on the last check
+	deregistered bool
+	checkedAt    time.Time
+	// refreshing is set while a caller reads the committee, so the others keep using the current key
+	refreshing bool
+}
+
+// NewKeySelector returns the selector of the given candidate keys, the first one being used when the committee
+// cannot be read on startup. A deregistered key is still used for the given grace period
+func NewKeySelector(
+	candidates []*ecdsa.PrivateKey, committee CommitteeMembersProvider, grace time.Duration,
+) *KeySelector {
+	return &KeySelector{
+		candidates: candidates,
+		committee:  committee,
+		grace:      grace,
+		now:        time.Now,
+	}
+}
+
+// Key returns the key the sequences are signed with. The committee is read from L1 without holding the lock,
+// so a slow L1 does not block the callers that can keep using the current key. Until the first read completes,
+// there is no current key, so every caller reads the committee
+func (s *KeySelector) Key() (*ecdsa.PrivateKey, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	due := s.checkedAt.IsZero() || (!s.refreshing && now.Sub(s.checkedAt) >= committeeRefreshInterval)
+	if due {
+		s.refreshing = true
+	}
+	s.mu.Unlock()
+
+	if due {
+		members, err := s.committee.GetCurrentDataCommitteeMembers()
+
+		s.mu.Lock()
+		s.refresh(now, members, err)
+		s.refreshing = false
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.registered == nil:
+		return nil, ErrNoRegisteredKey
+	case s.deregistered && now.Sub(s.registeredAt) > s.grace:
+		return nil, fmt.Errorf("%w: key %s was deregistered more than %s ago",
+			ErrNoRegisteredKey, keyAddress(s.registered).Hex(), s.grace)
+	}
+
+	return s.registered, nil
+}
+
+// refresh selects the candidate registered among the given committee members, read at the given time.
+// It must be called with the lock held, and a read older than the last applied one is discarded
+func (s *KeySelector) refresh(now time.Time, members []etherman.DataCommitteeMember, err error) {
+	if now.Before(s.checkedAt) {
+		return
+	}
+
+	s.checkedAt = now
+
+	if err != nil {
+		if s.registered == nil && len(s.candidates) > 0 {
+			s.registered, s.registeredAt = s.candidates[0], now
+		}
+
+		log.Warnf("failed to get the committee members to select the signing key, keeping the current one: %v", err)
+		return
+	}
+
+	addrs := make(map[common.Address]struct{}, len(members))
+	for _, member := range members {
+		addrs[member.Addr] = struct{}{}
+	}
+
+	for _, candidate := range s.candidates {
+		if _, ok := addrs[keyAddress(candidate)]; !ok {
+			continue
+		}
+
+		if candidate != s.registered {
+			if s.registered != nil {
+				log.Infof("signing key rotated from %s to %s", keyAddress(s.registered).Hex(), keyAddress(candidate).Hex())
+			} else {
+				log.Infof("signing with key %s, registered in the committee", keyAddress(candidate).Hex())
+			}
+		}
+
+		s.registered, s.registeredAt, s.deregistered = candidate, now, false
+		return
+	}
+
+	if s.registered != nil && !s.deregistered {
+		log.Warnf("signing key %s is no longer a committee member, still signing with it for %s",
+			keyAddress(s.registered).Hex(), s.grace)
+	}
+
+	s.deregistered = true
+}
+
+// keyAddress returns the address of the given key
+func keyAddress(key *ecdsa.PrivateKey) common.Address {
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
@@ -0,0 +1,165 @@
+package datacom
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySelector_Key(t *testing.T) {
+	t.Parallel()
+
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	members := func(keys ...*ecdsa.PrivateKey) []etherman.DataCommitteeMember {
+		list := make([]etherman.DataCommitteeMember, len(keys))
+		for i, key := range keys {
+			list[i] = etherman.DataCommitteeMember{Addr: keyAddress(key), URL: "http://member"}
+		}
+
+		return list
+	}
+
+	t.Run("rotation with grace period", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		selector := NewKeySelector([]*ecdsa.PrivateKey{oldKey, newKey}, ethermanMock, time.Hour)
+
+		now := time.Now()
+		selector.now = func() time.Time { return now }
+
+		// The old key is registered
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(members(oldKey), nil).Once()
+
+		key, err := selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, oldKey, key)
+
+		// The committee is not read again until the refresh interval elapses
+		now = now.Add(committeeRefreshInterval / 2)
+
+		key, err = selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, oldKey, key)
+
+		// The new key gets registered
+		now = now.Add(committeeRefreshInterval)
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(members(newKey), nil).Once()
+
+		key, err = selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, newKey, key)
+
+		// The committee cannot be read, the registered key is kept
+		now = now.Add(committeeRefreshInterval)
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(nil, errors.New("test error")).Once()
+
+		key, err = selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, newKey, key)
+
+		// The new key is deregistered, it is still used during the grace period
+		now = now.Add(committeeRefreshInterval)
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(members(), nil).Once()
+
+		key, err = selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, newKey, key)
+
+		// The grace period elapses
+		now = now.Add(time.Hour)
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(members(), nil).Once()
+
+		_, err = selector.Key()
+		require.ErrorIs(t, err, ErrNoRegisteredKey)
+	})
+
+	t.Run("slow committee read does not block the current key", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		selector := NewKeySelector([]*ecdsa.PrivateKey{oldKey, newKey}, ethermanMock, time.Hour)
+
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(members(oldKey), nil).Once()
+
+		key, err := selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, oldKey, key)
+
+		// The refresh interval elapses and the committee read hangs until released
+		var (
+			start      = time.Now().Add(committeeRefreshInterval)
+			reading    = make(chan struct{})
+			release    = make(chan struct{})
+			done       = make(chan struct{})
+			refreshed  *ecdsa.PrivateKey
+			refreshErr error
+		)
+
+		selector.now = func() time.Time { return start }
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(members(newKey), nil).Once().
+			Run(func(mock.Arguments) {
+				close(reading)
+				<-release
+			})
+
+		go func() {
+			defer close(done)
+
+			refreshed, refreshErr = selector.Key()
+		}()
+
+		<-reading
+
+		key, err = selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, oldKey, key)
+
+		close(release)
+		<-done
+
+		require.NoError(t, refreshErr)
+		require.Equal(t, newKey, refreshed)
+
+		key, err = selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, newKey, key)
+	})
+
+	t.Run("no key registered", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(members(), nil).Once()
+
+		selector := NewKeySelector([]*ecdsa.PrivateKey{oldKey, newKey}, ethermanMock, time.Hour)
+
+		_, err := selector.Key()
+		require.ErrorIs(t, err, ErrNoRegisteredKey)
+	})
+
+	t.Run("committee not readable on startup", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("GetCurrentDataCommitteeMembers").Return(nil, errors.New("test error")).Once()
+
+		selector := NewKeySelector([]*ecdsa.PrivateKey{oldKey, newKey}, ethermanMock, time.Hour)
+
+		key, err := selector.Key()
+		require.NoError(t, err)
+		require.Equal(t, oldKey, key)
+	})
+}
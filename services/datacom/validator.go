@@ -0,0 +1,70 @@
+package datacom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrNonCanonicalSignature is returned when the signature of a sequence is not the canonical one over its hash
+var ErrNonCanonicalSignature = errors.New("sequence signature is not canonical")
+
+// SequenceValidator checks a signed sequence from the sequencer before its data is stored and the sequence
+// is signed, on top of the checks against the committee. It allows the operators to enforce their own
+// policies, e.g. to only sign sequences whose data they could re-derive independently
+type SequenceValidator interface {
+	// ValidateSequence returns an error if the given sequence, whose data to store is the given one,
+	// must be rejected
+	ValidateSequence(ctx context.Context, sequence types.SignedSequenceInterface, ods []types.OffChainData) error
+}
+
+// SequenceValidatorFunc is a function used as a SequenceValidator
+type SequenceValidatorFunc func(
+	ctx context.Context, sequence types.SignedSequenceInterface, ods []types.OffChainData,
+) error
+
+// ValidateSequence calls the function
+func (f SequenceValidatorFunc) ValidateSequence(
+	ctx context.Context, sequence types.SignedSequenceInterface, ods []types.OffChainData,
+) error {
+	return f(ctx, sequence, ods)
+}
+
+// SignatureValidator recomputes the hash the sequence is signed over and checks that its signature is the
+// canonical one over it, with a recovery id of 27 or 28 and S in the lower half of the curve order as the L1
+// contracts require. A malleated signature recovers the same signer, so the sender check alone accepts it.
+// It is always the first validator
+type SignatureValidator struct{}
+
+// ValidateSequence returns ErrNonCanonicalSignature if the signature of the sequence is not canonical
+func (SignatureValidator) ValidateSequence(
+	_ context.Context, sequence types.SignedSequenceInterface, _ []types.OffChainData,
+) error {
+	signature := sequence.GetSignature()
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: %d bytes long", ErrNonCanonicalSignature, len(signature))
+	}
+
+	if v := signature[crypto.RecoveryIDOffset]; v != 27 && v != 28 { //nolint:mnd
+		return fmt.Errorf("%w: recovery id %d", ErrNonCanonicalSignature, v)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	sig[crypto.RecoveryIDOffset] -= 27
+
+	hash := sequence.HashToSign()
+	pubKey, err := crypto.Ecrecover(hash, sig)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNonCanonicalSignature, err)
+	}
+
+	if !crypto.VerifySignature(pubKey, hash, sig[:crypto.RecoveryIDOffset]) {
+		return fmt.Errorf("%w: S is not in the lower half of the curve order", ErrNonCanonicalSignature)
+	}
+
+	return nil
+}
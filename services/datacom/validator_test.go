@@ -0,0 +1,95 @@
+package datacom
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// malleate returns the other valid signature over the same hash, which recovers the same signer
+func malleate(signature []byte) []byte {
+	s := new(big.Int).SetBytes(signature[32:64])
+	s.Sub(crypto.S256().Params().N, s)
+
+	malleated := make([]byte, len(signature))
+	copy(malleated, signature)
+	s.FillBytes(malleated[32:64])
+	malleated[crypto.RecoveryIDOffset] = 27 + 28 - malleated[crypto.RecoveryIDOffset]
+
+	return malleated
+}
+
+func TestSignatureValidator_ValidateSequence(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sequence := types.Sequence{types.ArgBytes{0, 1}, types.ArgBytes{2, 3}}
+
+	signature, err := sequence.Sign(privateKey)
+	require.NoError(t, err)
+
+	wrongRecoveryID := make([]byte, len(signature))
+	copy(wrongRecoveryID, signature)
+	wrongRecoveryID[crypto.RecoveryIDOffset] = 1
+
+	tests := []struct {
+		name      string
+		signature []byte
+		expected  string
+	}{
+		{
+			name:      "canonical signature",
+			signature: signature,
+		},
+		{
+			name:      "malleated signature",
+			signature: malleate(signature),
+			expected:  "sequence signature is not canonical: S is not in the lower half of the curve order",
+		},
+		{
+			name:      "recovery id not 27 or 28",
+			signature: wrongRecoveryID,
+			expected:  "sequence signature is not canonical: recovery id 1",
+		},
+		{
+			name:      "wrong length",
+			signature: signature[:64],
+			expected:  "sequence signature is not canonical: 64 bytes long",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			signed := &types.SignedSequence{Sequence: sequence, Signature: tt.signature}
+
+			err := SignatureValidator{}.ValidateSequence(context.Background(), signed, signed.OffChainData())
+			if tt.expected != "" {
+				require.ErrorIs(t, err, ErrNonCanonicalSignature)
+				require.EqualError(t, err, tt.expected)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("malleated signature recovers the same signer", func(t *testing.T) {
+		t.Parallel()
+
+		signed := &types.SignedSequence{Sequence: sequence, Signature: malleate(signature)}
+
+		signer, err := signed.Signer()
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), signer)
+	})
+}
@@ -15,16 +15,23 @@ import (
 // APISTATUS is the namespace of the status service
 const APISTATUS = "status"
 
+// WriteStatus reports whether the writes of the offchain data are paused
+type WriteStatus interface {
+	WritesPaused() bool
+}
+
 // Endpoints contains implementations for the "status" RPC endpoints
 type Endpoints struct {
 	db        db.DB
+	writes    WriteStatus
 	startTime time.Time
 }
 
-// NewEndpoints returns Endpoints
-func NewEndpoints(db db.DB) *Endpoints {
+// NewEndpoints returns Endpoints. A nil write status reports the writes as never paused
+func NewEndpoints(db db.DB, writes WriteStatus) *Endpoints {
 	return &Endpoints{
 		db:        db,
+		writes:    writes,
 		startTime: time.Now(),
 	}
 }
@@ -53,5 +60,6 @@ func (s *Endpoints) GetStatus() (interface{}, rpc.Error) {
 		Uptime:                uptime,
 		KeyCount:              rowCount,
 		LastSynchronizedBlock: lastSynchronizedBlock,
+		WritesPaused:          s.writes != nil && s.writes.WritesPaused(),
 	}, nil
 }
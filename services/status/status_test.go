@@ -19,6 +19,7 @@ func TestEndpoints_GetStatus(t *testing.T) {
 		countOffchainDataErr     error
 		getLastProcessedBlock    uint64
 		getLastProcessedBlockErr error
+		writes                   WriteStatus
 		expectedError            error
 	}{
 		{
@@ -26,6 +27,18 @@ func TestEndpoints_GetStatus(t *testing.T) {
 			countOffchainData:     1,
 			getLastProcessedBlock: 2,
 		},
+		{
+			name:                  "writes paused",
+			countOffchainData:     1,
+			getLastProcessedBlock: 2,
+			writes:                writeStatus(true),
+		},
+		{
+			name:                  "writes not paused",
+			countOffchainData:     1,
+			getLastProcessedBlock: 2,
+			writes:                writeStatus(false),
+		},
 		{
 			name:                  "failed to count offchain data",
 			countOffchainDataErr:  errors.New("test error"),
@@ -54,7 +67,7 @@ func TestEndpoints_GetStatus(t *testing.T) {
 			dbMock.On("GetLastProcessedBlock", mock.Anything, mock.Anything).
 				Return(tt.getLastProcessedBlock, tt.getLastProcessedBlockErr).Maybe()
 
-			statusEndpoints := NewEndpoints(dbMock)
+			statusEndpoints := NewEndpoints(dbMock, tt.writes)
 
 			actual, err := statusEndpoints.GetStatus()
 
@@ -71,7 +84,14 @@ func TestEndpoints_GetStatus(t *testing.T) {
 				require.Equal(t, "v0.1.0", dacStatus.Version)
 				require.Equal(t, tt.countOffchainData, dacStatus.KeyCount)
 				require.Equal(t, tt.getLastProcessedBlock, dacStatus.LastSynchronizedBlock)
+				require.Equal(t, tt.writes != nil && tt.writes.WritesPaused(), dacStatus.WritesPaused)
 			}
 		})
 	}
 }
+
+type writeStatus bool
+
+func (s writeStatus) WritesPaused() bool {
+	return bool(s)
+}
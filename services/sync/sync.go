@@ -2,6 +2,7 @@ package sync
 
 import (
 	"context"
+	"errors"
 
 	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/log"
@@ -18,34 +19,45 @@ const (
 	maxListHashes = 100
 )
 
+// Fetcher fetches the offchain data of a key that is not stored yet
+type Fetcher interface {
+	FetchOffChainData(ctx context.Context, key common.Hash) (*types.OffChainData, error)
+}
+
 // Endpoints contains implementations for the "zkevm" RPC endpoints
 type Endpoints struct {
-	db db.DB
+	db      db.BlobStore
+	fetcher Fetcher
 }
 
-// NewEndpoints returns Endpoints
-func NewEndpoints(db db.DB) *Endpoints {
+// NewEndpoints returns Endpoints. If a fetcher is given, it is used to fetch the data of keys that are not stored
+func NewEndpoints(db db.BlobStore, fetcher Fetcher) *Endpoints {
 	return &Endpoints{
-		db: db,
+		db:      db,
+		fetcher: fetcher,
 	}
 }
 
 // GetOffChainData returns the image of the given hash
-func (z *Endpoints) GetOffChainData(hash types.ArgHash) (interface{}, rpc.Error) {
-	data, err := z.db.GetOffChainData(context.Background(), hash.Hash())
+func (z *Endpoints) GetOffChainData(ctx context.Context, hash types.ArgHash) (interface{}, rpc.Error) {
+	data, err := z.db.GetOffChainData(ctx, hash.Hash())
+	if errors.Is(err, db.ErrStateNotSynchronized) && z.fetcher != nil {
+		data, err = z.fetcher.FetchOffChainData(ctx, hash.Hash())
+	}
+
 	if err != nil {
 		log.Errorf("failed to get the offchain requested data from the DB: %v", err)
-		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to get the requested data")
+		return nil, rpc.NewRPCError(rpc.ErrorCodeFor(err), "failed to get the requested data")
 	}
 
 	return types.ArgBytes(data.Value), nil
 }
 
 // ListOffChainData returns the list of images of the given hashes
-func (z *Endpoints) ListOffChainData(hashes []types.ArgHash) (interface{}, rpc.Error) {
+func (z *Endpoints) ListOffChainData(ctx context.Context, hashes []types.ArgHash) (interface{}, rpc.Error) {
 	if len(hashes) > maxListHashes {
 		log.Errorf("too many hashes requested in ListOffChainData: %d", len(hashes))
-		return nil, rpc.NewRPCError(rpc.InvalidRequestErrorCode, "too many hashes requested")
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, "too many hashes requested")
 	}
 
 	keys := make([]common.Hash, len(hashes))
@@ -53,10 +65,10 @@ func (z *Endpoints) ListOffChainData(hashes []types.ArgHash) (interface{}, rpc.E
 		keys[i] = hash.Hash()
 	}
 
-	list, err := z.db.ListOffChainData(context.Background(), keys)
+	list, err := z.db.ListOffChainData(ctx, keys)
 	if err != nil {
 		log.Errorf("failed to list the requested data from the DB: %v", err)
-		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "failed to list the requested data")
+		return nil, rpc.NewRPCError(rpc.ErrorCodeFor(err), "failed to list the requested data")
 	}
 
 	listMap := make(map[common.Hash]types.ArgBytes, len(list))
@@ -66,3 +78,45 @@ func (z *Endpoints) ListOffChainData(hashes []types.ArgHash) (interface{}, rpc.E
 
 	return listMap, nil
 }
+
+// ListOffChainDataByBatch returns a page of the images stored for the given batch ordered by their position
+// in the sequence calldata and then by hash, along with the total number of images stored for the batch
+func (z *Endpoints) ListOffChainDataByBatch(
+	ctx context.Context,
+	batchNum, offset, limit types.ArgUint64,
+) (interface{}, rpc.Error) {
+	if limit == 0 || limit > maxListHashes {
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, "limit must be between 1 and %d", maxListHashes)
+	}
+
+	list, total, err := z.db.ListOffChainDataByBatch(ctx, uint64(batchNum), uint(offset), uint(limit))
+	if err != nil {
+		log.Errorf("failed to list the requested batch data from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.ErrorCodeFor(err), "failed to list the requested batch data")
+	}
+
+	items := make([]types.OffChainDataItem, len(list))
+	for i, data := range list {
+		items[i] = types.OffChainDataItem{
+			Key:   data.Key,
+			Value: data.Value,
+		}
+	}
+
+	return types.OffChainDataPage{
+		Items: items,
+		Total: total,
+	}, nil
+}
+
+// GetBatchConcatenated returns the data of the given batch, which is the value stored for the key committed
+// on L1 for it
+func (z *Endpoints) GetBatchConcatenated(ctx context.Context, batchNum types.ArgUint64) (interface{}, rpc.Error) {
+	blob, err := z.db.GetBatchConcatenated(ctx, uint64(batchNum))
+	if err != nil {
+		log.Errorf("failed to get the concatenated batch data from the DB: %v", err)
+		return nil, rpc.NewRPCError(rpc.ErrorCodeFor(err), "failed to get the requested batch data")
+	}
+
+	return types.ArgBytes(blob), nil
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/rpc"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -17,11 +19,16 @@ func TestEndpoints_GetOffChainData(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name  string
-		hash  types.ArgHash
-		data  *types.OffChainData
-		dbErr error
-		err   error
+		name       string
+		hash       types.ArgHash
+		data       *types.OffChainData
+		dbErr      error
+		withFetch  bool
+		fetchData  *types.OffChainData
+		fetchErr   error
+		err        error
+		errCode    int
+		expectData types.ArgBytes
 	}{
 		{
 			name: "successfully got offchain data",
@@ -30,6 +37,7 @@ func TestEndpoints_GetOffChainData(t *testing.T) {
 				Key:   common.Hash{},
 				Value: types.ArgBytes("offchaindata"),
 			},
+			expectData: types.ArgBytes("offchaindata"),
 		},
 		{
 			name: "db returns error",
@@ -38,8 +46,36 @@ func TestEndpoints_GetOffChainData(t *testing.T) {
 				Key:   common.Hash{},
 				Value: types.ArgBytes("offchaindata"),
 			},
-			dbErr: errors.New("test error"),
-			err:   errors.New("failed to get the requested data"),
+			dbErr:   errors.New("test error"),
+			err:     errors.New("failed to get the requested data"),
+			errCode: rpc.DefaultErrorCode,
+		},
+		{
+			name:    "missing data without fetcher",
+			hash:    types.ArgHash{},
+			dbErr:   db.ErrStateNotSynchronized,
+			err:     errors.New("failed to get the requested data"),
+			errCode: rpc.StateNotSynchronizedErrorCode,
+		},
+		{
+			name:      "missing data fetched",
+			hash:      types.ArgHash{},
+			dbErr:     db.ErrStateNotSynchronized,
+			withFetch: true,
+			fetchData: &types.OffChainData{
+				Key:   common.Hash{},
+				Value: types.ArgBytes("fetcheddata"),
+			},
+			expectData: types.ArgBytes("fetcheddata"),
+		},
+		{
+			name:      "missing data fetch fails",
+			hash:      types.ArgHash{},
+			dbErr:     db.ErrStateNotSynchronized,
+			withFetch: true,
+			fetchErr:  rpc.NewRPCError(rpc.BatchNotFoundErrorCode, "no data found"),
+			err:       errors.New("failed to get the requested data"),
+			errCode:   rpc.BatchNotFoundErrorCode,
 		},
 	}
 	for _, tt := range tests {
@@ -57,13 +93,22 @@ func TestEndpoints_GetOffChainData(t *testing.T) {
 
 			z := &Endpoints{db: dbMock}
 
-			got, err := z.GetOffChainData(tt.hash)
+			if tt.withFetch {
+				fetcherMock := mocks.NewFetcher(t)
+				fetcherMock.On("FetchOffChainData", context.Background(), tt.hash.Hash()).
+					Return(tt.fetchData, tt.fetchErr)
+
+				z.fetcher = fetcherMock
+			}
+
+			got, err := z.GetOffChainData(context.Background(), tt.hash)
 			if tt.err != nil {
 				require.Error(t, err)
 				require.EqualError(t, tt.err, err.Error())
+				require.Equal(t, tt.errCode, err.ErrorCode())
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, types.ArgBytes(tt.data.Value), got)
+				require.Equal(t, tt.expectData, got)
 			}
 		})
 	}
@@ -73,11 +118,12 @@ func TestSyncEndpoints_ListOffChainData(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name   string
-		hashes []types.ArgHash
-		data   []types.OffChainData
-		dbErr  error
-		err    error
+		name    string
+		hashes  []types.ArgHash
+		data    []types.OffChainData
+		dbErr   error
+		err     error
+		errCode int
 	}{
 		{
 			name:   "successfully got offchain data",
@@ -94,13 +140,15 @@ func TestSyncEndpoints_ListOffChainData(t *testing.T) {
 				Key:   common.BytesToHash(nil),
 				Value: types.ArgBytes("offchaindata"),
 			}},
-			dbErr: errors.New("test error"),
-			err:   errors.New("failed to list the requested data"),
+			dbErr:   errors.New("test error"),
+			err:     errors.New("failed to list the requested data"),
+			errCode: rpc.DefaultErrorCode,
 		},
 		{
-			name:   "too many hashes requested",
-			hashes: generateRandomHashes(t, maxListHashes+1),
-			err:    errors.New("too many hashes requested"),
+			name:    "too many hashes requested",
+			hashes:  generateRandomHashes(t, maxListHashes+1),
+			err:     errors.New("too many hashes requested"),
+			errCode: rpc.InvalidParamsErrorCode,
 		},
 	}
 	for _, tt := range tests {
@@ -125,10 +173,11 @@ func TestSyncEndpoints_ListOffChainData(t *testing.T) {
 
 			z := &Endpoints{db: dbMock}
 
-			got, err := z.ListOffChainData(tt.hashes)
+			got, err := z.ListOffChainData(context.Background(), tt.hashes)
 			if tt.err != nil {
 				require.Error(t, err)
 				require.ErrorContains(t, tt.err, err.Error())
+				require.Equal(t, tt.errCode, err.ErrorCode())
 			} else {
 				require.NoError(t, err)
 
@@ -143,6 +192,129 @@ func TestSyncEndpoints_ListOffChainData(t *testing.T) {
 	}
 }
 
+func TestSyncEndpoints_ListOffChainDataByBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		batchNum types.ArgUint64
+		offset   types.ArgUint64
+		limit    types.ArgUint64
+		data     []types.OffChainData
+		total    uint64
+		dbErr    error
+		err      error
+	}{
+		{
+			name:     "successfully got a page of offchain data",
+			batchNum: 1,
+			offset:   1,
+			limit:    1,
+			data: []types.OffChainData{{
+				Key:      common.BytesToHash([]byte("key2")),
+				Value:    types.ArgBytes("offchaindata"),
+				BatchNum: 1,
+			}},
+			total: 2,
+		},
+		{
+			name:     "db returns error",
+			batchNum: 1,
+			limit:    1,
+			data:     []types.OffChainData{},
+			dbErr:    errors.New("test error"),
+			err:      errors.New("failed to list the requested batch data"),
+		},
+		{
+			name:     "zero limit requested",
+			batchNum: 1,
+			err:      errors.New("limit must be between 1 and 100"),
+		},
+		{
+			name:     "too big limit requested",
+			batchNum: 1,
+			limit:    maxListHashes + 1,
+			err:      errors.New("limit must be between 1 and 100"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+
+			if tt.data != nil {
+				dbMock.On("ListOffChainDataByBatch", context.Background(),
+					uint64(tt.batchNum), uint(tt.offset), uint(tt.limit)).
+					Return(tt.data, tt.total, tt.dbErr)
+
+				defer dbMock.AssertExpectations(t)
+			}
+
+			z := &Endpoints{db: dbMock}
+
+			got, err := z.ListOffChainDataByBatch(context.Background(), tt.batchNum, tt.offset, tt.limit)
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+
+				items := make([]types.OffChainDataItem, len(tt.data))
+				for i, data := range tt.data {
+					items[i] = types.OffChainDataItem{Key: data.Key, Value: data.Value}
+				}
+
+				require.Equal(t, types.OffChainDataPage{Items: items, Total: tt.total}, got)
+			}
+		})
+	}
+}
+
+func TestSyncEndpoints_GetBatchConcatenated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		blob  []byte
+		dbErr error
+		err   error
+	}{
+		{
+			name: "successfully got the concatenated batch data",
+			blob: []byte("value1value2"),
+		},
+		{
+			name:  "db returns error",
+			dbErr: errors.New("test error"),
+			err:   errors.New("failed to get the requested batch data"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetBatchConcatenated", context.Background(), uint64(1)).Return(tt.blob, tt.dbErr)
+
+			z := &Endpoints{db: dbMock}
+
+			got, err := z.GetBatchConcatenated(context.Background(), 1)
+			if tt.err != nil {
+				require.Error(t, err)
+				require.EqualError(t, tt.err, err.Error())
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, types.ArgBytes(tt.blob), got)
+			}
+		})
+	}
+}
+
 func generateRandomHashes(t *testing.T, numOfHashes int) []types.ArgHash {
 	t.Helper()
 
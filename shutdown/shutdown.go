@@ -0,0 +1,82 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// ErrTimeout indicates a component did not stop before the shutdown deadline
+var ErrTimeout = errors.New("shutdown timed out")
+
+// StopFunc stops a component, returning early if the given context is done
+type StopFunc func(ctx context.Context) error
+
+// component is a named part of the node that is stopped on shutdown
+type component struct {
+	name string
+	stop StopFunc
+}
+
+// Orchestrator stops the registered components one after the other, in the order they were registered,
+// within a deadline for the whole shutdown
+type Orchestrator struct {
+	timeout    time.Duration
+	components []component
+}
+
+// New creates an Orchestrator that gives the components the given time to stop. 0 means no deadline
+func New(timeout time.Duration) *Orchestrator {
+	return &Orchestrator{timeout: timeout}
+}
+
+// Register adds a component to be stopped after the ones already registered
+func (o *Orchestrator) Register(name string, stop StopFunc) {
+	o.components = append(o.components, component{name: name, stop: stop})
+}
+
+// Shutdown stops the registered components in order. A component failing to stop does not prevent
+// the next ones from being stopped, but if the deadline is exceeded the shutdown is abandoned
+// and ErrTimeout is returned naming the component that did not stop in time
+func (o *Orchestrator) Shutdown(parentCtx context.Context) error {
+	ctx := parentCtx
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parentCtx, o.timeout)
+		defer cancel()
+	}
+
+	var errs []error
+	for _, c := range o.components {
+		log.Infof("stopping %s", c.name)
+
+		done := make(chan error, 1)
+		go func(c component) {
+			done <- c.stop(ctx)
+		}(c)
+
+		select {
+		case err := <-done:
+			if err != nil {
+				log.Errorf("failed to stop %s: %v", c.name, err)
+				errs = append(errs, fmt.Errorf("failed to stop %s: %w", c.name, err))
+			}
+		case <-ctx.Done():
+			log.Errorf("%s did not stop in time", c.name)
+			return fmt.Errorf("%w: %s did not stop in time", ErrTimeout, c.name)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Func adapts a stop function that cannot fail nor be interrupted to a StopFunc
+func Func(stop func()) StopFunc {
+	return func(context.Context) error {
+		stop()
+		return nil
+	}
+}
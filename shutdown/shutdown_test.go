@@ -0,0 +1,77 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrchestrator_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stops the components in order", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped []string
+
+		o := New(time.Second)
+		o.Register("first", Func(func() { stopped = append(stopped, "first") }))
+		o.Register("second", func(context.Context) error {
+			stopped = append(stopped, "second")
+			return nil
+		})
+
+		require.NoError(t, o.Shutdown(context.Background()))
+		require.Equal(t, []string{"first", "second"}, stopped)
+	})
+
+	t.Run("keeps stopping after a component fails", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped []string
+
+		o := New(time.Second)
+		o.Register("first", func(context.Context) error {
+			return errors.New("test error")
+		})
+		o.Register("second", Func(func() { stopped = append(stopped, "second") }))
+
+		err := o.Shutdown(context.Background())
+		require.ErrorContains(t, err, "failed to stop first: test error")
+		require.Equal(t, []string{"second"}, stopped)
+	})
+
+	t.Run("gives up when a component does not stop in time", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped []string
+
+		release := make(chan struct{})
+		defer close(release)
+
+		o := New(10 * time.Millisecond)
+		o.Register("hung", Func(func() { <-release }))
+		o.Register("second", Func(func() { stopped = append(stopped, "second") }))
+
+		err := o.Shutdown(context.Background())
+		require.ErrorIs(t, err, ErrTimeout)
+		require.ErrorContains(t, err, "hung did not stop in time")
+		require.Empty(t, stopped)
+	})
+
+	t.Run("passes the deadline to the components", func(t *testing.T) {
+		t.Parallel()
+
+		o := New(time.Minute)
+		o.Register("component", func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			require.True(t, ok)
+			return nil
+		})
+
+		require.NoError(t, o.Shutdown(context.Background()))
+	})
+}
@@ -0,0 +1,96 @@
+package synchronizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// attemptKey identifies a missing batch key regardless of when it was enqueued
+type attemptKey struct {
+	number uint64
+	hash   string
+}
+
+// resolveAttempts is the number of failed attempts to resolve a missing batch key, and when it can be retried
+type resolveAttempts struct {
+	failures uint
+	retryAt  time.Time
+}
+
+// newAttemptKey returns the attempt key of the given batch key
+func newAttemptKey(key types.BatchKey) attemptKey {
+	return attemptKey{number: key.Number, hash: key.Hash.Hex()}
+}
+
+// failed returns the attempts after one more failure at the given time. The key is retried after a delay
+// that doubles from the retry period with every failure, up to the given cap. A zero cap retries it right away
+func (a resolveAttempts) failed(now time.Time, retry, backoffCap time.Duration) resolveAttempts {
+	a.failures++
+
+	if backoffCap <= 0 {
+		return a
+	}
+
+	delay := retry
+	for i := uint(1); i < a.failures && delay < backoffCap; i++ {
+		delay *= 2
+	}
+
+	a.retryAt = now.Add(min(delay, backoffCap))
+
+	return a
+}
+
+// failedAttempt returns the attempts of the given key after one more failure at the given time. The failure is
+// counted by the database too, and its count wins if it is higher, e.g. when another instance resolves the same
+// keys, so the key is dead-lettered once all the instances together made enough attempts. If the count cannot
+// be stored the one in memory is used
+func (bs *BatchSynchronizer) failedAttempt(
+	ctx context.Context, key types.BatchKey, attempt resolveAttempts, now time.Time,
+) resolveAttempts {
+	attempt = attempt.failed(now, bs.retry, bs.resolveBackoffCap)
+
+	stored, err := incrementBatchAttempts(ctx, bs.db, key)
+	if err != nil {
+		log.Errorf("failed to store the attempts of batch %d: %v", key.Number, err)
+		return attempt
+	}
+
+	attempt.failures = max(attempt.failures, stored)
+
+	return attempt
+}
+
+// deadLetter moves the given key, that failed to be resolved too many times, to the failed batches so it is
+// no longer retried. It returns false if the key could not be moved, in which case it is retried
+func (bs *BatchSynchronizer) deadLetter(ctx context.Context, key types.BatchKey, failures uint, reason error) bool {
+	if err := storeFailedBatch(ctx, bs.db, key, reason.Error()); err != nil {
+		log.Errorf("failed to move batch %d, key %s to the failed batches: %v", key.Number, key.Hash.Hex(), err)
+		return false
+	}
+
+	log.Errorf("gave up resolving batch %d, key %s after %d attempts: %v", key.Number, key.Hash.Hex(), failures, reason)
+
+	deadLetteredBatches.Inc()
+	bs.queue.done(1)
+
+	return true
+}
+
+// observeAttempts exports the number of attempts it took to resolve each of the given keys, and whether they were
+// resolved on the first attempt or had to be retried
+func (bs *BatchSynchronizer) observeAttempts(keys []types.BatchKey) {
+	for _, key := range keys {
+		attempts := bs.attempts[newAttemptKey(key)].failures + 1
+
+		resolveAttemptsCount.Observe(float64(attempts))
+		if attempts == 1 {
+			resolutionOutcomes.WithLabelValues(outcomeFirstTry).Inc()
+		} else {
+			resolutionOutcomes.WithLabelValues(outcomeRetried).Inc()
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_resolveAttempts_failed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		failures   uint
+		backoffCap time.Duration
+		retryAt    time.Time
+	}{
+		{
+			name:     "no backoff",
+			failures: 3,
+		},
+		{
+			name:       "first failure waits the retry period",
+			backoffCap: time.Minute,
+			retryAt:    now.Add(time.Second),
+		},
+		{
+			name:       "delay doubles with every failure",
+			failures:   3,
+			backoffCap: time.Minute,
+			retryAt:    now.Add(8 * time.Second),
+		},
+		{
+			name:       "delay capped",
+			failures:   10,
+			backoffCap: time.Minute,
+			retryAt:    now.Add(time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := resolveAttempts{failures: tt.failures}.failed(now, time.Second, tt.backoffCap)
+			require.Equal(t, tt.failures+1, got.failures)
+			require.Equal(t, tt.retryAt, got.retryAt)
+		})
+	}
+}
+
+func TestBatchSynchronizer_HandleMissingBatchesAttempts(t *testing.T) {
+	t.Parallel()
+
+	poison := types.BatchKey{Number: 10, Hash: crypto.Keccak256Hash([]byte("unknown"))}
+	resolved := types.BatchKey{Number: 11, Hash: crypto.Keccak256Hash([]byte("batch11"))}
+
+	t.Run("dead-lettered after too many attempts", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		ethermanMock := mocks.NewEtherman(t)
+		sequencerMock := mocks.NewSequencerTracker(t)
+
+		bs := &BatchSynchronizer{
+			db:                 dbMock,
+			client:             ethermanMock,
+			sequencer:          sequencerMock,
+			committee:          NewCommitteeMapSafe(),
+			maxResolveAttempts: 2,
+		}
+
+		sequencerMock.On("GetSequenceBatch", mock.Anything, poison.Number).
+			Return(nil, errors.New("not found")).Twice()
+		ethermanMock.On("GetCurrentDataCommittee").Return(nil, errors.New("error")).Twice()
+
+		// first attempt fails, the key is kept to be retried and the resolved one is deleted alone
+		sequencerMock.On("GetSequenceBatch", mock.Anything, resolved.Number).
+			Return(&sequencer.SeqBatch{Number: 11, BatchL2Data: []byte("batch11")}, nil).Once()
+		dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+			Return([]types.BatchKey{poison, resolved}, nil).Once()
+		dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Twice()
+		txMock := mocks.NewTx(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+		dbMock.On("StoreOffChainDataTx", mock.Anything, mock.Anything, txMock).Return(nil).Once()
+		dbMock.On("DeleteMissingBatchKeysTx", mock.Anything, []types.BatchKey{resolved}, txMock).Return(nil).Once()
+		txMock.On("Commit").Return(nil).Once()
+		dbMock.On("IncrementBatchAttempts", mock.Anything, poison).Return(uint(1), nil).Once()
+
+		require.NoError(t, bs.handleMissingBatches(context.Background()))
+		require.Equal(t, uint(1), bs.attempts[newAttemptKey(poison)].failures)
+
+		// second attempt fails, the key is moved to the failed batches
+		dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+			Return([]types.BatchKey{poison}, nil).Once()
+		dbMock.On("IncrementBatchAttempts", mock.Anything, poison).Return(uint(2), nil).Once()
+		dbMock.On("StoreFailedBatch", mock.Anything, poison, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, bs.handleMissingBatches(context.Background()))
+		require.Empty(t, bs.attempts)
+	})
+
+	t.Run("retried after the backoff", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		ethermanMock := mocks.NewEtherman(t)
+		sequencerMock := mocks.NewSequencerTracker(t)
+
+		bs := &BatchSynchronizer{
+			db:                dbMock,
+			client:            ethermanMock,
+			sequencer:         sequencerMock,
+			committee:         NewCommitteeMapSafe(),
+			retry:             time.Hour,
+			resolveBackoffCap: time.Hour,
+		}
+
+		dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+			Return([]types.BatchKey{poison}, nil).Twice()
+		dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Once()
+		sequencerMock.On("GetSequenceBatch", mock.Anything, poison.Number).
+			Return(nil, errors.New("not found")).Once()
+		ethermanMock.On("GetCurrentDataCommittee").Return(nil, errors.New("error")).Once()
+		dbMock.On("IncrementBatchAttempts", mock.Anything, poison).Return(uint(1), nil).Once()
+
+		require.NoError(t, bs.handleMissingBatches(context.Background()))
+
+		// the key is not retried before its backoff is over
+		require.NoError(t, bs.handleMissingBatches(context.Background()))
+		require.Equal(t, uint(1), bs.attempts[newAttemptKey(poison)].failures)
+	})
+
+	t.Run("resumed from the stored attempts", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		ethermanMock := mocks.NewEtherman(t)
+		sequencerMock := mocks.NewSequencerTracker(t)
+
+		bs := &BatchSynchronizer{
+			db:                 dbMock,
+			client:             ethermanMock,
+			sequencer:          sequencerMock,
+			committee:          NewCommitteeMapSafe(),
+			maxResolveAttempts: 2,
+		}
+
+		// the attempt made before the restart counts, so the first failure after it moves the key
+		stored := poison
+		stored.Attempts = 1
+
+		dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+			Return([]types.BatchKey{stored}, nil).Once()
+		dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Once()
+		sequencerMock.On("GetSequenceBatch", mock.Anything, poison.Number).
+			Return(nil, errors.New("not found")).Once()
+		ethermanMock.On("GetCurrentDataCommittee").Return(nil, errors.New("error")).Once()
+		dbMock.On("IncrementBatchAttempts", mock.Anything, stored).Return(uint(2), nil).Once()
+		dbMock.On("StoreFailedBatch", mock.Anything, stored, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, bs.handleMissingBatches(context.Background()))
+		require.Empty(t, bs.attempts)
+	})
+
+	t.Run("dead-lettered on the attempts counted by the database", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		ethermanMock := mocks.NewEtherman(t)
+		sequencerMock := mocks.NewSequencerTracker(t)
+
+		bs := &BatchSynchronizer{
+			db:                 dbMock,
+			client:             ethermanMock,
+			sequencer:          sequencerMock,
+			committee:          NewCommitteeMapSafe(),
+			maxResolveAttempts: 3,
+		}
+
+		// another instance failed to resolve the key meanwhile, so this failure is the third one
+		dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+			Return([]types.BatchKey{poison}, nil).Once()
+		dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Once()
+		sequencerMock.On("GetSequenceBatch", mock.Anything, poison.Number).
+			Return(nil, errors.New("not found")).Once()
+		ethermanMock.On("GetCurrentDataCommittee").Return(nil, errors.New("error")).Once()
+		dbMock.On("IncrementBatchAttempts", mock.Anything, poison).Return(uint(3), nil).Once()
+		dbMock.On("StoreFailedBatch", mock.Anything, poison, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, bs.handleMissingBatches(context.Background()))
+		require.Empty(t, bs.attempts)
+	})
+
+	t.Run("failed to store the attempts", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		ethermanMock := mocks.NewEtherman(t)
+		sequencerMock := mocks.NewSequencerTracker(t)
+
+		bs := &BatchSynchronizer{
+			db:        dbMock,
+			client:    ethermanMock,
+			sequencer: sequencerMock,
+			committee: NewCommitteeMapSafe(),
+		}
+
+		dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+			Return([]types.BatchKey{poison}, nil).Once()
+		dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Once()
+		sequencerMock.On("GetSequenceBatch", mock.Anything, poison.Number).
+			Return(nil, errors.New("not found")).Once()
+		ethermanMock.On("GetCurrentDataCommittee").Return(nil, errors.New("error")).Once()
+		dbMock.On("IncrementBatchAttempts", mock.Anything, poison).
+			Return(uint(0), errors.New("test error")).Once()
+
+		// the attempts are still kept in memory
+		require.NoError(t, bs.handleMissingBatches(context.Background()))
+		require.Equal(t, uint(1), bs.attempts[newAttemptKey(poison)].failures)
+	})
+}
+
+func TestBatchSynchronizer_observeAttempts(t *testing.T) {
+	t.Parallel()
+
+	firstTry := types.BatchKey{Number: 1, Hash: crypto.Keccak256Hash([]byte("batch1"))}
+	retried := types.BatchKey{Number: 2, Hash: crypto.Keccak256Hash([]byte("batch2"))}
+
+	bs := &BatchSynchronizer{
+		attempts: map[attemptKey]resolveAttempts{newAttemptKey(retried): {failures: 2}},
+	}
+
+	firstTries := testutil.ToFloat64(resolutionOutcomes.WithLabelValues(outcomeFirstTry))
+	retries := testutil.ToFloat64(resolutionOutcomes.WithLabelValues(outcomeRetried))
+
+	bs.observeAttempts([]types.BatchKey{firstTry, retried})
+
+	require.Equal(t, firstTries+1, testutil.ToFloat64(resolutionOutcomes.WithLabelValues(outcomeFirstTry)))
+	require.Equal(t, retries+1, testutil.ToFloat64(resolutionOutcomes.WithLabelValues(outcomeRetried)))
+}
@@ -2,6 +2,7 @@ package synchronizer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sort"
@@ -20,31 +21,71 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/sync/singleflight"
 )
 
-const defaultBlockBatchSize = 32
+const (
+	defaultBlockBatchSize = 32
+
+	// committeeRefreshKey is the key of the single flight refreshing the committee
+	committeeRefreshKey = "committee"
+
+	// resolvedHistoryPruneInterval is how often the resolved batches history older than its retention is pruned
+	resolvedHistoryPruneInterval = time.Hour
+)
 
 // SequencerTracker is an interface that defines functions that a sequencer tracker must implement
 type SequencerTracker interface {
 	GetSequenceBatch(ctx context.Context, batchNum uint64) (*sequencer.SeqBatch, error)
 }
 
+// finalityCheckpoint is the last batch number sequenced at an L1 block
+type finalityCheckpoint struct {
+	block    uint64
+	batchNum uint64
+}
+
 // BatchSynchronizer watches for number events, checks if they are
 // "locally" stored, then retrieves and stores missing data
 type BatchSynchronizer struct {
 	client           etherman.Etherman
 	stop             chan struct{}
+	stopOnce         sync.Once
+	running          sync.WaitGroup
 	retry            time.Duration
 	rpcTimeout       time.Duration
+	fetchTimeout     time.Duration
 	blockBatchSize   uint
 	self             common.Address
 	db               db.DB
 	committee        *CommitteeMapSafe
+	committeeRefresh singleflight.Group
 	syncLock         sync.Mutex
 	reorgs           <-chan BlockReorg
 	events           chan *polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches
 	sequencer        SequencerTracker
 	rpcClientFactory client.Factory
+
+	finalizationDepth      uint64
+	finalizeOnVerification bool
+	pruneFinalized         bool
+	pendingFinality        []finalityCheckpoint
+
+	prefetchWindow uint64
+	prefetch       chan uint64
+
+	maxResolveAttempts uint
+	resolveBackoffCap  time.Duration
+	attempts           map[attemptKey]resolveAttempts
+
+	resolvedHistoryRetention time.Duration
+	resolvedHistoryPrunedAt  time.Time
+
+	scope  *batchScope
+	writes *writeCircuit
+
+	queue *resolveQueue
+	hooks *hookDispatcher
 }
 
 // NewBatchSynchronizer creates the BatchSynchronizer
@@ -61,11 +102,18 @@ func NewBatchSynchronizer(
 		log.Infof("block number size is not set, setting to default %d", defaultBlockBatchSize)
 		cfg.BlockBatchSize = defaultBlockBatchSize
 	}
+
+	scope, err := newBatchScope(cfg.BatchScope)
+	if err != nil {
+		return nil, err
+	}
+
 	synchronizer := &BatchSynchronizer{
 		client:           ethClient,
 		stop:             make(chan struct{}),
 		retry:            cfg.RetryPeriod.Duration,
 		rpcTimeout:       cfg.Timeout.Duration,
+		fetchTimeout:     cfg.FetchOnMissTimeout.Duration,
 		blockBatchSize:   cfg.BlockBatchSize,
 		self:             self,
 		db:               db,
@@ -73,39 +121,120 @@ func NewBatchSynchronizer(
 		events:           make(chan *polygonvalidiumetrog.PolygonvalidiumetrogSequenceBatches),
 		sequencer:        sequencer,
 		rpcClientFactory: rpcClientFactory,
+
+		finalizationDepth:      cfg.FinalizationDepth,
+		finalizeOnVerification: cfg.FinalizeOnVerification,
+		pruneFinalized:         cfg.PruneFinalized,
+
+		maxResolveAttempts: cfg.MaxResolveAttempts,
+		resolveBackoffCap:  cfg.ResolveBackoffCap.Duration,
+
+		resolvedHistoryRetention: cfg.ResolvedHistoryRetention.Duration,
+
+		scope:  scope,
+		writes: newWriteCircuit(cfg.WriteFailureThreshold, cfg.WriteFailureBackoff.Duration),
+
+		queue: newResolveQueue(cfg.MaxInFlightBatches),
 	}
+
+	var hook BatchResolvedHook = NoopBatchResolvedHook{}
+	if cfg.BatchResolvedWebhook != "" {
+		hook = NewWebhookHook(cfg.BatchResolvedWebhook, cfg.Timeout.Duration)
+	}
+
+	synchronizer.hooks = newHookDispatcher(hook, cfg.BatchResolvedHookConcurrency)
+
+	if cfg.PrefetchWindow > 0 {
+		synchronizer.prefetchWindow = cfg.PrefetchWindow
+		synchronizer.prefetch = make(chan uint64, 1)
+	}
+
 	return synchronizer, synchronizer.resolveCommittee()
 }
 
+// resolveCommittee refreshes the committee from L1. Concurrent refreshes share a single L1 call,
+// so a burst of resolvers finding the committee empty does not query L1 once each
 func (bs *BatchSynchronizer) resolveCommittee() error {
-	current, err := bs.client.GetCurrentDataCommittee()
-	if err != nil {
-		return err
-	}
+	_, err, _ := bs.committeeRefresh.Do(committeeRefreshKey, func() (interface{}, error) {
+		current, err := bs.client.GetCurrentDataCommittee()
+		if err != nil {
+			return nil, err
+		}
 
-	filteredMembers := make([]etherman.DataCommitteeMember, 0, len(current.Members))
-	for _, m := range current.Members {
-		if m.Addr != bs.self {
-			filteredMembers = append(filteredMembers, m)
+		filteredMembers := make([]etherman.DataCommitteeMember, 0, len(current.Members))
+		for _, m := range current.Members {
+			if m.Addr != bs.self {
+				filteredMembers = append(filteredMembers, m)
+			}
 		}
-	}
 
-	bs.committee = NewCommitteeMapSafe()
-	bs.committee.StoreBatch(filteredMembers)
-	return nil
+		// The committee is only created on the first refresh, and refreshed in place afterwards
+		// so the resolvers reading it concurrently never see it swapped
+		if bs.committee == nil {
+			bs.committee = NewCommitteeMapSafe()
+		}
+
+		bs.committee.Replace(filteredMembers)
+		return nil, nil
+	})
+
+	return err
+}
+
+// SetBatchResolvedHook sets the hook notified of the resolved batches, replacing the configured one.
+// It must be called before the synchronizer is started
+func (bs *BatchSynchronizer) SetBatchResolvedHook(hook BatchResolvedHook) {
+	bs.hooks.hook = hook
 }
 
 // Start starts the synchronizer
 func (bs *BatchSynchronizer) Start(ctx context.Context) {
 	log.Infof("starting batch synchronizer, DAC addr: %v", bs.self)
-	go bs.processMissingBatches(ctx)
-	go bs.produceEvents(ctx)
-	go bs.handleReorgs(ctx)
+	// The synchronizer decides what to resolve from what is stored, so it must see its own writes
+	ctx = db.WithConsistency(db.WithAuditSource(ctx, "synchronizer"), db.ConsistencyStrong)
+	bs.initQueue(ctx)
+	bs.hooks.start(bs.stop)
+	bs.run(ctx, bs.processMissingBatches)
+	bs.run(ctx, bs.produceEvents)
+	bs.run(ctx, bs.handleReorgs)
+
+	if bs.prefetch != nil {
+		bs.run(ctx, bs.prefetchRecent)
+	}
+}
+
+// initQueue seeds the resolve queue with the missing batches left from a previous run,
+// reading at most as many as needed to know whether the queue is saturated
+func (bs *BatchSynchronizer) initQueue(parentCtx context.Context) {
+	if bs.queue == nil || bs.queue.capacity == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, bs.rpcTimeout)
+	defer cancel()
+
+	batchKeys, err := bs.db.GetMissingBatchKeys(ctx, uint(bs.queue.capacity))
+	if err != nil {
+		log.Errorf("failed to get the missing batch keys to seed the resolve queue: %v", err)
+		return
+	}
+
+	bs.queue.sync(len(batchKeys), len(batchKeys) < int(bs.queue.capacity))
 }
 
-// Stop stops the synchronizer
+// Stop stops the synchronizer and waits for its running iterations to finish
 func (bs *BatchSynchronizer) Stop() {
-	close(bs.stop)
+	bs.stopOnce.Do(func() { close(bs.stop) })
+	bs.running.Wait()
+}
+
+// run runs the given loop in a goroutine Stop waits for, so it does not outlive the database
+func (bs *BatchSynchronizer) run(ctx context.Context, loop func(ctx context.Context)) {
+	bs.running.Add(1)
+	go func() {
+		defer bs.running.Done()
+		loop(ctx)
+	}()
 }
 
 func (bs *BatchSynchronizer) handleReorgs(ctx context.Context) {
@@ -133,6 +262,8 @@ func (bs *BatchSynchronizer) handleReorgs(ctx context.Context) {
 				log.Errorf("failed to store new start block to %d: %v", r.Number, err)
 			}
 
+			bs.dropPendingFinality(r.Number)
+
 			bs.syncLock.Unlock()
 		case <-bs.stop:
 			return
@@ -160,6 +291,12 @@ func (bs *BatchSynchronizer) filterEvents(ctx context.Context) error {
 	bs.syncLock.Lock()
 	defer bs.syncLock.Unlock()
 
+	// Stop discovering new batches until the resolver catches up
+	if bs.queue.saturated() {
+		log.Debugf("resolver is saturated, pausing the discovery of new batches")
+		return nil
+	}
+
 	start, err := getStartBlock(ctx, bs.db, L1SyncTask)
 	if err != nil {
 		return err
@@ -215,9 +352,112 @@ func (bs *BatchSynchronizer) filterEvents(ctx context.Context) error {
 			log.Errorf("failed to handleEvent: %v", err)
 			return setStartBlock(ctx, bs.db, event.Raw.BlockNumber-1, L1SyncTask)
 		}
+
+		if bs.finalizationDepth > 0 && !bs.finalizeOnVerification {
+			bs.pendingFinality = append(bs.pendingFinality, finalityCheckpoint{
+				block:    event.Raw.BlockNumber,
+				batchNum: event.NumBatch,
+			})
+		}
+	}
+
+	// Events are sorted by block, so the last one sequenced the last batch
+	if len(events) > 0 {
+		bs.notifyPrefetch(events[len(events)-1].NumBatch)
+	}
+
+	if bs.finalizeOnVerification {
+		if err = bs.trackVerifications(ctx, start, end); err != nil {
+			return err
+		}
+	}
+
+	bs.finalizeBatches(ctx, header.Number.Uint64())
+
+	if err = setStartBlock(ctx, bs.db, end, L1SyncTask); err != nil {
+		return err
 	}
 
-	return setStartBlock(ctx, bs.db, end, L1SyncTask)
+	// The head was just fetched, so the lag is exposed without querying L1 again
+	syncLag.Set(float64(blockLag(end, header.Number.Uint64())))
+
+	return nil
+}
+
+// trackVerifications adds the batches verified on L1 within the given blocks to the ones pending finality
+func (bs *BatchSynchronizer) trackVerifications(ctx context.Context, start, end uint64) error {
+	events, err := bs.client.FilterVerifyBatches(&bind.FilterOpts{
+		Context: ctx,
+		Start:   start,
+		End:     &end,
+	})
+	if err != nil {
+		log.Errorf("failed to filter VerifyBatches events: %v", err)
+		return err
+	}
+
+	for _, event := range events {
+		bs.pendingFinality = append(bs.pendingFinality, finalityCheckpoint{
+			block:    event.Raw.BlockNumber,
+			batchNum: event.NumBatch,
+		})
+	}
+
+	return nil
+}
+
+// finalizeBatches marks the offchain data of the batches sequenced (or verified, when finalizing
+// on verification) in blocks that are at least finalizationDepth blocks deep as finalized, and prunes
+// it when pruneFinalized is set
+func (bs *BatchSynchronizer) finalizeBatches(ctx context.Context, head uint64) {
+	var (
+		upToBatch uint64
+		final     int
+	)
+
+	for ; final < len(bs.pendingFinality); final++ {
+		checkpoint := bs.pendingFinality[final]
+		if checkpoint.block+bs.finalizationDepth > head {
+			break
+		}
+
+		upToBatch = max(upToBatch, checkpoint.batchNum)
+	}
+
+	if final == 0 {
+		return
+	}
+
+	if err := markFinalized(ctx, bs.db, upToBatch); err != nil {
+		log.Errorf("failed to mark offchain data up to batch %d as finalized: %v", upToBatch, err)
+		return
+	}
+
+	bs.pendingFinality = bs.pendingFinality[final:]
+
+	if !bs.pruneFinalized {
+		return
+	}
+
+	pruned, err := pruneFinalized(ctx, bs.db, upToBatch)
+	if err != nil {
+		log.Errorf("failed to prune the finalized offchain data up to batch %d: %v", upToBatch, err)
+		return
+	}
+
+	if pruned > 0 {
+		log.Infof("pruned %d finalized offchain data entries up to batch %d", pruned, upToBatch)
+	}
+}
+
+// dropPendingFinality forgets the batches sequenced at or after the given block, since they were reorged
+func (bs *BatchSynchronizer) dropPendingFinality(block uint64) {
+	for i, checkpoint := range bs.pendingFinality {
+		if checkpoint.block >= block {
+			bs.pendingFinality = bs.pendingFinality[:i]
+			return
+		}
+	}
 }
 
 func (bs *BatchSynchronizer) handleEvent(
@@ -232,23 +472,43 @@ func (bs *BatchSynchronizer) handleEvent(
 		return err
 	}
 
-	keys, err := UnpackTxData(tx.Data())
+	batches, err := UnpackSequencedBatches(tx.Data())
 	if err != nil {
 		return err
 	}
 
 	// The event has the _last_ batch number & list of hashes. Each hash is
-	// in order, so the batch number can be computed from position in array
+	// in order, so the batch number can be computed from position in array,
+	// which is also the order the values of a batch are served in
 	var batchKeys []types.BatchKey
-	for i, j := 0, len(keys)-1; i < len(keys); i, j = i+1, j-1 {
+	for i, j := 0, len(batches)-1; i < len(batches); i, j = i+1, j-1 {
+		index := uint(j) //nolint:gosec
 		batchKeys = append(batchKeys, types.BatchKey{
 			Number: event.NumBatch - uint64(i), //nolint:gosec
-			Hash:   keys[j],
+			Hash:   batches[j].Key,
+			Index:  &index,
+			Forced: batches[j].Forced,
 		})
 	}
 
 	// Store batch keys in missing_batches table that are not already present offchain_data table
-	return bs.findMissingBatches(ctx, batchKeys)
+	if err = bs.findMissingBatches(ctx, batchKeys); err != nil {
+		return err
+	}
+
+	if len(batchKeys) == 0 {
+		return nil
+	}
+
+	// Keep the L1 transaction that sequenced the batches and their committed keys, to link their data back to
+	// its commitment
+	keys := make([]common.Hash, len(batches))
+	for i, batch := range batches {
+		keys[i] = batch.Key
+	}
+
+	return storeL1TxHash(ctx, bs.db, batchKeys[len(batchKeys)-1].Number, keys,
+		event.Raw.TxHash, event.Raw.BlockNumber)
 }
 
 func (bs *BatchSynchronizer) findMissingBatches(ctx context.Context, batchKeys []types.BatchKey) error {
@@ -263,21 +523,51 @@ func (bs *BatchSynchronizer) findMissingBatches(ctx context.Context, batchKeys [
 		return fmt.Errorf("failed to list offchain data: %v", err)
 	}
 
-	hashToKeys := make(map[common.Hash]struct{})
+	hashToData := make(map[common.Hash]types.OffChainData)
 	for _, extData := range existingOffchainData {
-		hashToKeys[extData.Key] = struct{}{}
+		hashToData[extData.Key] = extData
 	}
 
 	missingData := make([]types.BatchKey, 0)
+	unnumberedData := make([]types.OffChainData, 0)
 	for _, batchKey := range batchKeys {
-		_, ok := hashToKeys[batchKey.Hash]
+		extData, ok := hashToData[batchKey.Hash]
 		if !ok {
-			missingData = append(missingData, batchKey)
+			// The batches out of scope are not served, so they are not resolved either
+			if bs.scope.contains(batchKey.Number) {
+				missingData = append(missingData, batchKey)
+			}
+
+			continue
+		}
+
+		// Data stored before its batch got sequenced doesn't know its batch number, index nor if it is forced yet
+		if extData.BatchNum == 0 || (extData.Index == nil && batchKey.Index != nil) || (batchKey.Forced && !extData.Forced) {
+			if extData.BatchNum == 0 {
+				extData.BatchNum = batchKey.Number
+			}
+
+			extData.Index = batchKey.Index
+			extData.Forced = batchKey.Forced
+			unnumberedData = append(unnumberedData, extData)
+		}
+	}
+
+	if len(unnumberedData) > 0 {
+		if err = bs.storeOffchainData(ctx, unnumberedData); err != nil {
+			return fmt.Errorf("failed to store batch numbers of offchain data: %v", err)
 		}
 	}
 
 	if len(missingData) > 0 {
-		return storeMissingBatchKeys(ctx, bs.db, missingData)
+		var inserted uint64
+		if inserted, err = storeMissingBatchKeys(ctx, bs.db, missingData); err != nil {
+			return err
+		}
+
+		// Keys that were already queued, e.g. when a block is processed again, are not new work
+		queuedBatches.Add(float64(inserted))
+		bs.queue.enqueue(int(inserted))
 	}
 
 	return nil
@@ -292,6 +582,8 @@ func (bs *BatchSynchronizer) processMissingBatches(ctx context.Context) {
 			if err := bs.handleMissingBatches(ctx); err != nil {
 				log.Error(err)
 			}
+
+			bs.pruneResolvedHistory(ctx)
 		case <-bs.stop:
 			return
 		}
@@ -306,33 +598,164 @@ func (bs *BatchSynchronizer) handleMissingBatches(ctx context.Context) error {
 		return fmt.Errorf("failed to get missing batch keys: %v", err)
 	}
 
+	bs.queue.sync(len(batchKeys), len(batchKeys) < maxUnprocessedBatch)
+
 	if len(batchKeys) == 0 {
 		return nil
 	}
 
-	data := make([]types.OffChainData, 0)
+	// Nothing is resolved while the writes are paused, as the data could not be stored anyway
+	if bs.writes.paused(time.Now()) {
+		return nil
+	}
+
+	// Only the attempts of the keys still missing are kept
+	now := time.Now()
+	attempts := make(map[attemptKey]resolveAttempts, len(bs.attempts))
+	defer func() { bs.attempts = attempts }()
+
+	if bs.attempts == nil {
+		bs.attempts = make(map[attemptKey]resolveAttempts)
+	}
+
+	due := make([]types.BatchKey, 0, len(batchKeys))
+	outOfScope := make([]types.BatchKey, 0)
 	for _, key := range batchKeys {
+		if !bs.scope.contains(key.Number) {
+			outOfScope = append(outOfScope, key)
+			continue
+		}
+
+		id := newAttemptKey(key)
+		if _, ok := bs.attempts[id]; !ok && key.Attempts > 0 {
+			// The attempts made before a restart are known but not when they failed, so the key is retried now
+			bs.attempts[id] = resolveAttempts{failures: key.Attempts}
+		}
+
+		if attempt := bs.attempts[id]; now.Before(attempt.retryAt) {
+			attempts[id] = attempt
+			continue
+		}
+
+		due = append(due, key)
+	}
+
+	// Keys queued before the scope was restricted are dropped
+	if len(outOfScope) > 0 {
+		if err = deleteMissingBatchKeys(ctx, bs.db, outOfScope); err != nil {
+			return fmt.Errorf("failed to delete batch keys out of scope: %v", err)
+		}
+
+		log.Infof("dropped %d missing batches out of scope", len(outOfScope))
+		bs.queue.done(len(outOfScope))
+	}
+
+	// Keys whose data got stored since they were queued, e.g. fetched on a miss, are not fetched again
+	if due, err = bs.skipStoredBatches(ctx, due); err != nil {
+		return err
+	}
+
+	data := make([]types.OffChainData, 0)
+	resolvedKeys := make([]types.BatchKey, 0)
+	for _, key := range due {
+		id := newAttemptKey(key)
+		attempt := bs.attempts[id]
+
 		value, err := bs.resolve(ctx, key)
 		if err != nil {
-			log.Errorf("failed to resolve batch %s: %v", key.Hash.Hex(), err)
+			// Only the first failure of a key is reported, the retries would flood the logs while it stays missing
+			if attempt.failures == 0 {
+				log.Warnf("failed to resolve batch %d, key %s, retrying: %v", key.Number, key.Hash.Hex(), err)
+			} else {
+				log.Debugf("failed to resolve batch %d, key %s after %d attempts: %v",
+					key.Number, key.Hash.Hex(), attempt.failures+1, err)
+			}
+
+			attempt = bs.failedAttempt(ctx, key, attempt, now)
+			if bs.maxResolveAttempts == 0 || attempt.failures < bs.maxResolveAttempts ||
+				!bs.deadLetter(ctx, key, attempt.failures, err) {
+				attempts[id] = attempt
+			}
+
 			continue
 		}
 		data = append(data, *value)
+		resolvedKeys = append(resolvedKeys, key)
 	}
 
 	if len(data) > 0 {
-		if err = storeOffchainData(ctx, bs.db, data); err != nil {
-			return fmt.Errorf("failed to store offchain data: %v", err)
+		// The keys that failed are kept to be retried
+		err = bs.storeResolvedData(ctx, data, resolvedKeys, func(key types.BatchKey) uint {
+			return bs.attempts[newAttemptKey(key)].failures + 1
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store the resolved offchain data: %v", err)
 		}
 
-		if err = deleteMissingBatchKeys(ctx, bs.db, batchKeys); err != nil {
-			return fmt.Errorf("failed to delete successfully resolved batch keys: %v", err)
-		}
+		bs.queue.done(len(resolvedKeys))
+		resolvedBatches.WithLabelValues(resolvedFetched).Add(float64(len(resolvedKeys)))
+		observeResolution(resolvedKeys, time.Now())
+		bs.observeAttempts(resolvedKeys)
+		bs.hooks.notify(resolvedKeys)
 	}
 
 	return nil
 }
 
+// skipStoredBatches removes the given missing batch keys whose data is already stored from the missing batches,
+// and returns the ones still to be fetched. If the check fails, all the keys are fetched
+func (bs *BatchSynchronizer) skipStoredBatches(
+	ctx context.Context, batchKeys []types.BatchKey,
+) ([]types.BatchKey, error) {
+	if len(batchKeys) == 0 {
+		return batchKeys, nil
+	}
+
+	keys := make([]common.Hash, len(batchKeys))
+	for i, key := range batchKeys {
+		keys[i] = key.Hash
+	}
+
+	_, missingKeys, err := allExist(ctx, bs.db, keys)
+	if err != nil {
+		log.Warnf("failed to check the stored missing batches, fetching all of them: %v", err)
+		return batchKeys, nil
+	}
+
+	missing := make(map[common.Hash]struct{}, len(missingKeys))
+	for _, key := range missingKeys {
+		missing[key] = struct{}{}
+	}
+
+	toFetch := make([]types.BatchKey, 0, len(missingKeys))
+	stored := make([]types.BatchKey, 0, len(batchKeys)-len(missingKeys))
+	for _, key := range batchKeys {
+		if _, ok := missing[key.Hash]; ok {
+			toFetch = append(toFetch, key)
+		} else {
+			stored = append(stored, key)
+		}
+	}
+
+	if len(stored) == 0 {
+		return toFetch, nil
+	}
+
+	err = bs.removeResolvedBatchKeys(ctx, stored, func(key types.BatchKey) uint {
+		return bs.attempts[newAttemptKey(key)].failures
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete already stored batch keys: %v", err)
+	}
+
+	log.Infof("skipped fetching %d batches whose data is already stored", len(stored))
+
+	bs.queue.done(len(stored))
+	resolvedBatches.WithLabelValues(resolvedSkipped).Add(float64(len(stored)))
+
+	return toFetch, nil
+}
+
 func (bs *BatchSynchronizer) resolve(ctx context.Context, batch types.BatchKey) (*types.OffChainData, error) {
 	// First try to get the data from the trusted sequencer
 	data := bs.trySequencer(ctx, batch)
@@ -362,9 +785,9 @@ func (bs *BatchSynchronizer) resolve(ctx context.Context, batch types.BatchKey)
 			continue // malformed committee, skip what is known to be wrong
 		}
 
-		value, err := bs.resolveWithMember(ctx, batch.Hash, member)
+		value, err := bs.resolveWithMember(ctx, batch, member)
 		if err != nil {
-			log.Warnf("error resolving, continuing: %v", err)
+			log.Debugf("error resolving, continuing: %v", err)
 			bs.committee.Delete(member.Addr)
 			continue // did not have data or errored out
 		}
@@ -372,33 +795,110 @@ func (bs *BatchSynchronizer) resolve(ctx context.Context, batch types.BatchKey)
 		return value, nil
 	}
 
-	return nil, rpc.NewRPCError(rpc.NotFoundErrorCode,
+	return nil, rpc.NewRPCError(rpc.BatchNotFoundErrorCode,
 		"no data found for number %d, key %v", batch.Number, batch.Hash.Hex())
 }
 
+// FetchOffChainData resolves the data of a key that is known to be missing from the trusted sequencer
+// and stores it, so it can be served right away instead of waiting for the missing batches to be processed
+func (bs *BatchSynchronizer) FetchOffChainData(parentCtx context.Context, key common.Hash) (*types.OffChainData, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, bs.fetchTimeout)
+	defer cancel()
+
+	batch, err := bs.db.GetMissingBatchKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	data := bs.trySequencer(ctx, *batch)
+	if data == nil {
+		return nil, rpc.NewRPCError(rpc.BatchNotFoundErrorCode,
+			"no data found for number %d, key %v", batch.Number, batch.Hash.Hex())
+	}
+
+	if err = bs.storeOffchainData(ctx, []types.OffChainData{*data}); err != nil {
+		return nil, fmt.Errorf("failed to store fetched offchain data: %w", err)
+	}
+
+	// The attempts of the resolver are not shared with the requests, the fetch is counted alone
+	err = bs.removeResolvedBatchKeys(ctx, []types.BatchKey{*batch}, func(types.BatchKey) uint { return 1 })
+	if err != nil {
+		log.Errorf("failed to delete fetched missing batch key %d: %v", batch.Number, err)
+	} else {
+		bs.queue.done(1)
+		observeResolution([]types.BatchKey{*batch}, time.Now())
+		bs.hooks.notify([]types.BatchKey{*batch})
+	}
+
+	return data, nil
+}
+
+// storeOffchainData stores the given offchain data unless the writes are paused, and records the result in the
+// write circuit. A canceled write, e.g. on shutdown, or data rejected before reaching the database are not
+// failures of the database
+func (bs *BatchSynchronizer) storeOffchainData(ctx context.Context, data []types.OffChainData) error {
+	if !bs.writes.allow(time.Now()) {
+		return ErrWritesPaused
+	}
+
+	err := storeOffchainData(ctx, bs.db, data)
+	if !errors.Is(err, context.Canceled) && !errors.Is(err, db.ErrEmptyOffChainData) {
+		bs.writes.record(time.Now(), err)
+	}
+
+	return err
+}
+
+// storeResolvedData stores the given resolved offchain data and removes their keys from the missing batches in a
+// single transaction, so a key is never removed without its data stored, nor kept once its data is stored. The
+// writes are paused and recorded in the write circuit as for storeOffchainData
+func (bs *BatchSynchronizer) storeResolvedData(
+	ctx context.Context, data []types.OffChainData, keys []types.BatchKey, attempts func(key types.BatchKey) uint,
+) error {
+	if !bs.writes.allow(time.Now()) {
+		return ErrWritesPaused
+	}
+
+	err := storeResolvedData(ctx, bs.db, data, func(ctx context.Context, tx db.Tx) error {
+		return bs.removeResolvedBatchKeysTx(ctx, keys, attempts, tx)
+	})
+	if !errors.Is(err, context.Canceled) && !errors.Is(err, db.ErrEmptyOffChainData) {
+		bs.writes.record(time.Now(), err)
+	}
+
+	return err
+}
+
+// WritesPaused returns whether the offchain data writes are paused after consecutive failures
+func (bs *BatchSynchronizer) WritesPaused() bool {
+	return bs.writes.isOpen()
+}
+
 // trySequencer returns L2Data from the trusted sequencer, but does not return errors, only logs warnings if not found.
 func (bs *BatchSynchronizer) trySequencer(ctx context.Context, batch types.BatchKey) *types.OffChainData {
 	seqBatch, err := bs.sequencer.GetSequenceBatch(ctx, batch.Number)
 	if err != nil {
-		log.Warnf("failed to get data from sequencer: %v", err)
+		log.Debugf("failed to get data from sequencer: %v", err)
 		return nil
 	}
 
-	expectKey := crypto.Keccak256Hash(seqBatch.BatchL2Data)
-	if batch.Hash != expectKey {
-		log.Warnf("number %d: sequencer gave wrong data for key: %s", batch.Number, batch.Hash.Hex())
+	if err = seqBatch.VerifyKey(batch.Hash); err != nil {
+		log.Warnf("number %d: refusing sequencer data: %v", batch.Number, err)
 		return nil
 	}
 
 	return &types.OffChainData{
-		Key:   batch.Hash,
-		Value: seqBatch.BatchL2Data,
+		Key:      batch.Hash,
+		Value:    seqBatch.BatchL2Data,
+		BatchNum: batch.Number,
+		Index:    batch.Index,
+		Forced:   batch.Forced,
 	}
 }
 
 func (bs *BatchSynchronizer) resolveWithMember(
 	parentCtx context.Context,
-	batch common.Hash,
+	batch types.BatchKey,
 	member etherman.DataCommitteeMember,
 ) (*types.OffChainData, error) {
 	cm := bs.rpcClientFactory.New(member.URL)
@@ -406,20 +906,23 @@ func (bs *BatchSynchronizer) resolveWithMember(
 	ctx, cancel := context.WithTimeout(parentCtx, bs.rpcTimeout)
 	defer cancel()
 
-	log.Debugf("trying member %v at %v for key %v", member.Addr.Hex(), member.URL, batch.Hex())
+	log.Debugf("trying member %v at %v for key %v", member.Addr.Hex(), member.URL, batch.Hash.Hex())
 
-	bytes, err := cm.GetOffChainData(ctx, batch)
+	bytes, err := cm.GetOffChainData(ctx, batch.Hash)
 	if err != nil {
 		return nil, err
 	}
 
 	expectKey := crypto.Keccak256Hash(bytes)
-	if batch.Cmp(expectKey) != 0 {
+	if batch.Hash.Cmp(expectKey) != 0 {
 		return nil, fmt.Errorf("unexpected key gotten from member: %v. Key: %v", member.Addr.Hex(), expectKey.Hex())
 	}
 
 	return &types.OffChainData{
-		Key:   batch,
-		Value: bytes,
+		Key:      batch.Hash,
+		Value:    bytes,
+		BatchNum: batch.Number,
+		Index:    batch.Index,
+		Forced:   batch.Forced,
 	}, nil
 }
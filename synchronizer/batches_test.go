@@ -3,18 +3,27 @@ package synchronizer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	elderberryValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/elderberry/polygonvalidiumetrog"
 	etrogValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/etrog/polygonvalidiumetrog"
+	"github.com/0xPolygon/cdk-data-availability/config"
+	cfgTypes "github.com/0xPolygon/cdk-data-availability/config/types"
+	"github.com/0xPolygon/cdk-data-availability/db"
 	"github.com/0xPolygon/cdk-data-availability/etherman"
 	"github.com/0xPolygon/cdk-data-availability/mocks"
 	"github.com/0xPolygon/cdk-data-availability/sequencer"
 	"github.com/0xPolygon/cdk-data-availability/types"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -72,6 +81,41 @@ func TestBatchSynchronizer_ResolveCommittee(t *testing.T) {
 
 		ethermanMock.AssertExpectations(t)
 	})
+
+	t.Run("concurrent resolutions share a single L1 call", func(t *testing.T) {
+		t.Parallel()
+
+		committee := &etherman.DataCommittee{
+			Members: []etherman.DataCommitteeMember{{
+				Addr: common.HexToAddress("0x123312415"),
+				URL:  "http://url-1",
+			}},
+		}
+
+		// The L1 call is held long enough for all the resolutions to join it
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("GetCurrentDataCommittee").Return(committee, nil).
+			WaitUntil(time.After(100 * time.Millisecond)).Once()
+
+		batchSyncronizer := &BatchSynchronizer{
+			client:    ethermanMock,
+			committee: NewCommitteeMapSafe(),
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, batchSyncronizer.resolveCommittee())
+			}()
+		}
+
+		wg.Wait()
+
+		require.Equal(t, len(committee.Members), batchSyncronizer.committee.Length())
+		ethermanMock.AssertExpectations(t)
+	})
 }
 
 func TestBatchSynchronizer_Resolve(t *testing.T) {
@@ -277,6 +321,9 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 		listOffchainDataReturns      []interface{}
 		storeMissingBatchKeysArgs    []interface{}
 		storeMissingBatchKeysReturns []interface{}
+		storeOffChainDataArgs        []interface{}
+		storeOffChainDataReturns     []interface{}
+		storeL1TxHashReturns         []interface{}
 
 		isErrorExpected bool
 	}
@@ -290,6 +337,7 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 	}
 	batchL2Data := []byte{1, 2, 3, 4, 5, 6}
 	txHash := crypto.Keccak256Hash(batchL2Data)
+	index := uint(0)
 
 	batchData := []etrogValidium.PolygonValidiumEtrogValidiumBatchData{
 		{
@@ -333,10 +381,20 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 		}
 
 		if config.storeMissingBatchKeysArgs != nil && config.storeMissingBatchKeysReturns != nil {
-			dbMock.On("StoreMissingBatchKeys", config.storeMissingBatchKeysArgs...).Return(
+			dbMock.On("InsertMissingBatchKeys", config.storeMissingBatchKeysArgs...).Return(
 				config.storeMissingBatchKeysReturns...).Once()
 		}
 
+		if config.storeOffChainDataArgs != nil && config.storeOffChainDataReturns != nil {
+			dbMock.On("StoreOffChainData", config.storeOffChainDataArgs...).Return(
+				config.storeOffChainDataReturns...).Once()
+		}
+
+		if config.storeL1TxHashReturns != nil {
+			dbMock.On("StoreL1TxHash", mock.Anything, uint64(10), []common.Hash{txHash}, event.Raw.TxHash,
+				event.Raw.BlockNumber).Return(config.storeL1TxHashReturns...).Once()
+		}
+
 		batchSynronizer := &BatchSynchronizer{
 			db:     dbMock,
 			client: ethermanMock,
@@ -426,10 +484,12 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 				[]types.BatchKey{{
 					Number: 10,
 					Hash:   txHash,
+					Index:  &index,
 				}},
 				mock.Anything,
 			},
-			storeMissingBatchKeysReturns: []interface{}{nil},
+			storeMissingBatchKeysReturns: []interface{}{uint64(1), nil},
+			storeL1TxHashReturns:         []interface{}{nil},
 			isErrorExpected:              false,
 		})
 	})
@@ -447,10 +507,12 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 				[]types.BatchKey{{
 					Number: 10,
 					Hash:   txHash,
+					Index:  &index,
 				}},
 				mock.Anything,
 			},
-			storeMissingBatchKeysReturns: []interface{}{nil},
+			storeMissingBatchKeysReturns: []interface{}{uint64(1), nil},
+			storeL1TxHashReturns:         []interface{}{nil},
 			isErrorExpected:              false,
 		})
 	})
@@ -467,10 +529,11 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 				[]types.BatchKey{{
 					Number: 10,
 					Hash:   txHash,
+					Index:  &index,
 				}},
 				mock.Anything,
 			},
-			storeMissingBatchKeysReturns: []interface{}{errors.New("error")},
+			storeMissingBatchKeysReturns: []interface{}{uint64(0), errors.New("error")},
 			getTxArgs:                    []interface{}{mock.Anything, event.Raw.TxHash},
 			getTxReturns:                 []interface{}{tx, true, nil},
 		})
@@ -482,6 +545,109 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 		testFn(t, testConfig{
 			isErrorExpected:      false,
 			listOffchainDataArgs: []interface{}{mock.Anything, []common.Hash{txHash}},
+			listOffchainDataReturns: []interface{}{
+				[]types.OffChainData{
+					{
+						Key:      txHash,
+						Value:    batchL2Data,
+						BatchNum: 10,
+						Index:    &index,
+					},
+				}, nil,
+			},
+			getTxArgs:            []interface{}{mock.Anything, event.Raw.TxHash},
+			getTxReturns:         []interface{}{tx, true, nil},
+			storeL1TxHashReturns: []interface{}{nil},
+		})
+	})
+
+	t.Run("have batch in storage - L1 tx hash store fails", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			isErrorExpected:      true,
+			listOffchainDataArgs: []interface{}{mock.Anything, []common.Hash{txHash}},
+			listOffchainDataReturns: []interface{}{
+				[]types.OffChainData{
+					{
+						Key:      txHash,
+						Value:    batchL2Data,
+						BatchNum: 10,
+						Index:    &index,
+					},
+				}, nil,
+			},
+			getTxArgs:            []interface{}{mock.Anything, event.Raw.TxHash},
+			getTxReturns:         []interface{}{tx, true, nil},
+			storeL1TxHashReturns: []interface{}{errors.New("error")},
+		})
+	})
+
+	t.Run("have batch in storage without batch number - batch number stored", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			isErrorExpected:      false,
+			listOffchainDataArgs: []interface{}{mock.Anything, []common.Hash{txHash}},
+			listOffchainDataReturns: []interface{}{
+				[]types.OffChainData{
+					{
+						Key:   txHash,
+						Value: batchL2Data,
+					},
+				}, nil,
+			},
+			storeOffChainDataArgs: []interface{}{mock.Anything,
+				[]types.OffChainData{{
+					Key:      txHash,
+					Value:    batchL2Data,
+					BatchNum: 10,
+					Index:    &index,
+				}},
+			},
+			storeOffChainDataReturns: []interface{}{nil},
+			storeL1TxHashReturns:     []interface{}{nil},
+			getTxArgs:                []interface{}{mock.Anything, event.Raw.TxHash},
+			getTxReturns:             []interface{}{tx, true, nil},
+		})
+	})
+
+	t.Run("have batch in storage without index - index stored", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			isErrorExpected:      false,
+			listOffchainDataArgs: []interface{}{mock.Anything, []common.Hash{txHash}},
+			listOffchainDataReturns: []interface{}{
+				[]types.OffChainData{
+					{
+						Key:      txHash,
+						Value:    batchL2Data,
+						BatchNum: 10,
+					},
+				}, nil,
+			},
+			storeOffChainDataArgs: []interface{}{mock.Anything,
+				[]types.OffChainData{{
+					Key:      txHash,
+					Value:    batchL2Data,
+					BatchNum: 10,
+					Index:    &index,
+				}},
+			},
+			storeOffChainDataReturns: []interface{}{nil},
+			storeL1TxHashReturns:     []interface{}{nil},
+			getTxArgs:                []interface{}{mock.Anything, event.Raw.TxHash},
+			getTxReturns:             []interface{}{tx, true, nil},
+		})
+	})
+
+	t.Run("have batch in storage without batch number - store fails", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			isErrorExpected:      true,
+			listOffchainDataArgs: []interface{}{mock.Anything, []common.Hash{txHash}},
 			listOffchainDataReturns: []interface{}{
 				[]types.OffChainData{
 					{
@@ -490,8 +656,17 @@ func TestBatchSynchronizer_HandleEvent(t *testing.T) {
 					},
 				}, nil,
 			},
-			getTxArgs:    []interface{}{mock.Anything, event.Raw.TxHash},
-			getTxReturns: []interface{}{tx, true, nil},
+			storeOffChainDataArgs: []interface{}{mock.Anything,
+				[]types.OffChainData{{
+					Key:      txHash,
+					Value:    batchL2Data,
+					BatchNum: 10,
+					Index:    &index,
+				}},
+			},
+			storeOffChainDataReturns: []interface{}{errors.New("error")},
+			getTxArgs:                []interface{}{mock.Anything, event.Raw.TxHash},
+			getTxReturns:             []interface{}{tx, true, nil},
 		})
 	})
 }
@@ -518,6 +693,33 @@ func TestBatchSynchronizer_ProcessMissingBatches(t *testing.T) {
 	dbMock.AssertExpectations(t)
 }
 
+func TestBatchSynchronizer_Stop(t *testing.T) {
+	t.Parallel()
+
+	batchSynronizer := &BatchSynchronizer{
+		stop: make(chan struct{}),
+	}
+
+	var finished atomic.Bool
+	batchSynronizer.run(context.Background(), func(context.Context) {
+		<-batchSynronizer.stop
+		// An iteration still writing to the database when the synchronizer is stopped
+		time.Sleep(100 * time.Millisecond)
+		finished.Store(true)
+	})
+
+	batchSynronizer.Stop()
+	require.True(t, finished.Load())
+
+	// Stopping again does not close the stop channel twice
+	batchSynronizer.Stop()
+}
+
+// noneStored is the AllExist mock result of keys whose data is not stored
+func noneStored(_ context.Context, keys []common.Hash) (bool, []common.Hash, error) {
+	return len(keys) == 0, keys, nil
+}
+
 func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 	t.Parallel()
 
@@ -529,6 +731,7 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 		storeOffChainDataReturns      []interface{}
 		deleteMissingBatchKeysArgs    []interface{}
 		deleteMissingBatchKeysReturns []interface{}
+		allExistReturns               []interface{}
 		// sequencer mocks
 		getSequenceBatchArgs    []interface{}
 		getSequenceBatchReturns []interface{}
@@ -551,12 +754,33 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 				config.getMissingBatchKeysReturns...).Once()
 		}
 
+		if config.allExistReturns != nil {
+			dbMock.On("AllExist", mock.Anything, mock.Anything).Return(config.allExistReturns...).Once()
+		} else {
+			dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Maybe()
+		}
+
+		// The resolved data is stored and its keys deleted within a single transaction,
+		// while the keys whose data is already stored are deleted on their own
 		if config.storeOffChainDataArgs != nil && config.storeOffChainDataReturns != nil {
-			dbMock.On("StoreOffChainData", config.storeOffChainDataArgs...).Return(
+			txMock := mocks.NewTx(t)
+			dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+			dbMock.On("StoreOffChainDataTx", append(config.storeOffChainDataArgs, txMock)...).Return(
 				config.storeOffChainDataReturns...).Once()
-		}
 
-		if config.deleteMissingBatchKeysArgs != nil && config.deleteMissingBatchKeysReturns != nil {
+			committed := config.storeOffChainDataReturns[0] == nil
+			if committed && config.deleteMissingBatchKeysArgs != nil {
+				dbMock.On("DeleteMissingBatchKeysTx", append(config.deleteMissingBatchKeysArgs, txMock)...).Return(
+					config.deleteMissingBatchKeysReturns...).Once()
+				committed = config.deleteMissingBatchKeysReturns[0] == nil
+			}
+
+			if committed {
+				txMock.On("Commit").Return(nil).Once()
+			} else {
+				txMock.On("Rollback").Return(nil).Once()
+			}
+		} else if config.deleteMissingBatchKeysArgs != nil && config.deleteMissingBatchKeysReturns != nil {
 			dbMock.On("DeleteMissingBatchKeys", config.deleteMissingBatchKeysArgs...).Return(
 				config.deleteMissingBatchKeysReturns...).Once()
 		}
@@ -618,8 +842,70 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 			},
 			storeOffChainDataArgs: []interface{}{mock.Anything,
 				[]types.OffChainData{{
-					Key:   txHash,
-					Value: batchL2Data,
+					Key:      txHash,
+					Value:    batchL2Data,
+					BatchNum: 10,
+				}},
+			},
+			storeOffChainDataReturns: []interface{}{nil},
+			deleteMissingBatchKeysArgs: []interface{}{mock.Anything,
+				[]types.BatchKey{{
+					Number: 10,
+					Hash:   txHash,
+				}},
+			},
+			deleteMissingBatchKeysReturns: []interface{}{nil},
+			getSequenceBatchArgs:          []interface{}{context.Background(), uint64(10)},
+			getSequenceBatchReturns: []interface{}{&sequencer.SeqBatch{
+				Number:      types.ArgUint64(10),
+				BatchL2Data: types.ArgBytes(batchL2Data),
+			}, nil},
+			isErrorExpected: false,
+		})
+	})
+
+	t.Run("Missing batch key already stored", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			getMissingBatchKeysArgs: []interface{}{mock.Anything, uint(100)},
+			getMissingBatchKeysReturns: []interface{}{
+				[]types.BatchKey{{
+					Number: 10,
+					Hash:   txHash,
+				}},
+				nil,
+			},
+			allExistReturns: []interface{}{true, []common.Hash{}, nil},
+			deleteMissingBatchKeysArgs: []interface{}{mock.Anything,
+				[]types.BatchKey{{
+					Number: 10,
+					Hash:   txHash,
+				}},
+			},
+			deleteMissingBatchKeysReturns: []interface{}{nil},
+			isErrorExpected:               false,
+		})
+	})
+
+	t.Run("Stored check fails, missing batch is fetched", func(t *testing.T) {
+		t.Parallel()
+
+		testFn(t, testConfig{
+			getMissingBatchKeysArgs: []interface{}{mock.Anything, uint(100)},
+			getMissingBatchKeysReturns: []interface{}{
+				[]types.BatchKey{{
+					Number: 10,
+					Hash:   txHash,
+				}},
+				nil,
+			},
+			allExistReturns: []interface{}{false, nil, errors.New("error")},
+			storeOffChainDataArgs: []interface{}{mock.Anything,
+				[]types.OffChainData{{
+					Key:      txHash,
+					Value:    batchL2Data,
+					BatchNum: 10,
 				}},
 			},
 			storeOffChainDataReturns: []interface{}{nil},
@@ -628,7 +914,6 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 					Number: 10,
 					Hash:   txHash,
 				}},
-				mock.Anything,
 			},
 			deleteMissingBatchKeysReturns: []interface{}{nil},
 			getSequenceBatchArgs:          []interface{}{context.Background(), uint64(10)},
@@ -654,8 +939,9 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 			},
 			storeOffChainDataArgs: []interface{}{mock.Anything,
 				[]types.OffChainData{{
-					Key:   txHash,
-					Value: batchL2Data,
+					Key:      txHash,
+					Value:    batchL2Data,
+					BatchNum: 10,
 				}},
 			},
 			storeOffChainDataReturns: []interface{}{errors.New("error")},
@@ -682,8 +968,9 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 			},
 			storeOffChainDataArgs: []interface{}{mock.Anything,
 				[]types.OffChainData{{
-					Key:   txHash,
-					Value: batchL2Data,
+					Key:      txHash,
+					Value:    batchL2Data,
+					BatchNum: 10,
 				}},
 			},
 			storeOffChainDataReturns: []interface{}{nil},
@@ -692,7 +979,6 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 					Number: 10,
 					Hash:   txHash,
 				}},
-				mock.Anything,
 			},
 			deleteMissingBatchKeysReturns: []interface{}{errors.New("error")},
 			getSequenceBatchArgs:          []interface{}{context.Background(), uint64(10)},
@@ -742,7 +1028,7 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 				}},
 				mock.Anything,
 			},
-			storeMissingBatchKeysReturns: []interface{}{nil},
+			storeMissingBatchKeysReturns: []interface{}{uint64(1), nil},
 			commitReturns:                   []interface{}{nil},
 			isErrorExpected:                 false,
 		})
@@ -760,7 +1046,7 @@ func TestBatchSynchronizer_HandleMissingBatches(t *testing.T) {
 				}},
 				mock.Anything,
 			},
-			storeMissingBatchKeysReturns: []interface{}{errors.New("error")},
+			storeMissingBatchKeysReturns: []interface{}{uint64(0), errors.New("error")},
 			beginStateTransactionArgs:       []interface{}{mock.Anything},
 			rollbackArgs:                    []interface{}{mock.Anything},
 			getTxArgs:                       []interface{}{mock.Anything, event.Raw.TxHash},
@@ -855,3 +1141,368 @@ func TestBatchSynchronizer_HandleReorgs(t *testing.T) {
 		})
 	})
 }
+
+func TestBatchSynchronizer_FetchOffChainData(t *testing.T) {
+	t.Parallel()
+
+	batchL2Data := []byte{1, 2, 3, 4, 5, 6}
+	txHash := crypto.Keccak256Hash(batchL2Data)
+	batchKey := types.BatchKey{Number: 10, Hash: txHash}
+
+	tests := []struct {
+		name          string
+		missingErr    error
+		seqBatch      *sequencer.SeqBatch
+		seqErr        error
+		storeErr      error
+		expectedData  *types.OffChainData
+		expectedError string
+	}{
+		{
+			name:         "fetched from the sequencer",
+			seqBatch:     &sequencer.SeqBatch{Number: 10, BatchL2Data: batchL2Data},
+			expectedData: &types.OffChainData{Key: txHash, Value: batchL2Data, BatchNum: 10},
+		},
+		{
+			name:          "key is not missing",
+			missingErr:    db.ErrStateNotSynchronized,
+			expectedError: db.ErrStateNotSynchronized.Error(),
+		},
+		{
+			name:          "sequencer error",
+			seqErr:        errors.New("test error"),
+			expectedError: "no data found for number 10, key " + txHash.Hex(),
+		},
+		{
+			name:          "store error",
+			seqBatch:      &sequencer.SeqBatch{Number: 10, BatchL2Data: batchL2Data},
+			storeErr:      errors.New("test error"),
+			expectedError: "failed to store fetched offchain data: test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			sequencerMock := mocks.NewSequencerTracker(t)
+
+			if tt.missingErr != nil {
+				dbMock.On("GetMissingBatchKey", mock.Anything, txHash).Return(nil, tt.missingErr).Once()
+			} else {
+				dbMock.On("GetMissingBatchKey", mock.Anything, txHash).Return(&batchKey, nil).Once()
+				sequencerMock.On("GetSequenceBatch", mock.Anything, uint64(10)).Return(tt.seqBatch, tt.seqErr).Once()
+			}
+
+			if tt.seqBatch != nil {
+				dbMock.On("StoreOffChainData", mock.Anything,
+					[]types.OffChainData{{Key: txHash, Value: batchL2Data, BatchNum: 10}}).Return(tt.storeErr).Once()
+			}
+
+			if tt.seqBatch != nil && tt.storeErr == nil {
+				dbMock.On("DeleteMissingBatchKeys", mock.Anything, []types.BatchKey{batchKey}).Return(nil).Once()
+			}
+
+			batchSynronizer := &BatchSynchronizer{
+				db:           dbMock,
+				sequencer:    sequencerMock,
+				fetchTimeout: time.Second,
+			}
+
+			data, err := batchSynronizer.FetchOffChainData(context.Background(), txHash)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedData, data)
+			}
+		})
+	}
+}
+
+func TestBatchSynchronizer_TrySequencer(t *testing.T) {
+	t.Parallel()
+
+	batchL2Data := []byte{1, 2, 3, 4, 5, 6}
+	txHash := crypto.Keccak256Hash(batchL2Data)
+	batchKey := types.BatchKey{Number: 10, Hash: txHash}
+
+	tests := []struct {
+		name         string
+		seqBatch     *sequencer.SeqBatch
+		seqErr       error
+		hang         bool
+		expectedData *types.OffChainData
+	}{
+		{
+			name:         "batch returned by the sequencer",
+			seqBatch:     &sequencer.SeqBatch{Number: 10, BatchL2Data: batchL2Data},
+			expectedData: &types.OffChainData{Key: txHash, Value: batchL2Data, BatchNum: 10},
+		},
+		{
+			name:   "sequencer times out",
+			hang:   true,
+			seqErr: context.DeadlineExceeded,
+		},
+		{
+			name:     "partial batch data read",
+			seqBatch: &sequencer.SeqBatch{Number: 10, BatchL2Data: batchL2Data[:3]},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientMock := mocks.NewSequencerClient(t)
+			call := clientMock.On("GetData", mock.Anything, mock.Anything, uint64(10)).Return(tt.seqBatch, tt.seqErr).Once()
+			if tt.hang {
+				call.Run(func(args mock.Arguments) {
+					ctx, ok := args.Get(0).(context.Context)
+					require.True(t, ok)
+
+					<-ctx.Done()
+				})
+			}
+
+			batchSynronizer := &BatchSynchronizer{
+				sequencer: sequencer.NewTrackerWithClient(config.L1Config{}, mocks.NewEtherman(t), clientMock),
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			require.Equal(t, tt.expectedData, batchSynronizer.trySequencer(ctx, batchKey))
+		})
+	}
+
+	// The responses are served over http to the sequencer client, so they are parsed and checked as in production
+	accInputHash := common.Hash{}.Hex()
+	httpTests := []struct {
+		name         string
+		response     string
+		expectedData *types.OffChainData
+	}{
+		{
+			name: "batch served by the sequencer",
+			response: `{"result":{"number":"0xa","accInputHash":"` + accInputHash +
+				`","batchL2Data":"0x010203040506"}}`,
+			expectedData: &types.OffChainData{Key: txHash, Value: batchL2Data, BatchNum: 10},
+		},
+		{
+			name:     "malformed sequencer response",
+			response: `{"result":{"number":"0xa","accInputHash":"` + accInputHash + `","batchL2Data":`,
+		},
+		{
+			name:     "sequencer response without the batch data",
+			response: `{"result":{"number":"0xa","accInputHash":"` + accInputHash + `"}}`,
+		},
+		{
+			name: "sequencer returns another batch",
+			response: `{"result":{"number":"0xb","accInputHash":"` + accInputHash +
+				`","batchL2Data":"0x010203040506"}}`,
+		},
+	}
+
+	for _, tt := range httpTests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests.Add(1)
+
+				_, err := fmt.Fprint(w, tt.response)
+				require.NoError(t, err)
+			}))
+			defer svr.Close()
+
+			ethermanMock := mocks.NewEtherman(t)
+			ethermanMock.On("TrustedSequencer", mock.Anything).Return(common.Address{}, nil).Once()
+			ethermanMock.On("TrustedSequencerURL", mock.Anything).Return(svr.URL, nil).Once()
+
+			tracker := sequencer.NewTrackerWithClient(config.L1Config{
+				Timeout: cfgTypes.Duration{Duration: time.Second},
+			}, ethermanMock, sequencer.NewSequencerClient(svr.Client(), true, ""))
+			tracker.Start(context.Background())
+			defer tracker.Stop()
+
+			batchSynronizer := &BatchSynchronizer{sequencer: tracker}
+
+			require.Equal(t, tt.expectedData, batchSynronizer.trySequencer(context.Background(), batchKey))
+			require.Equal(t, int32(1), requests.Load())
+		})
+	}
+}
+
+func TestBatchSynchronizer_FinalizeBatches(t *testing.T) {
+	t.Parallel()
+
+	pending := []finalityCheckpoint{
+		{block: 10, batchNum: 5},
+		{block: 20, batchNum: 8},
+		{block: 30, batchNum: 12},
+	}
+
+	t.Run("nothing deep enough", func(t *testing.T) {
+		t.Parallel()
+
+		batchSynronizer := &BatchSynchronizer{
+			db:                mocks.NewDB(t),
+			finalizationDepth: 64,
+			pendingFinality:   append([]finalityCheckpoint{}, pending...),
+		}
+
+		batchSynronizer.finalizeBatches(context.Background(), 70)
+		require.Equal(t, pending, batchSynronizer.pendingFinality)
+	})
+
+	t.Run("marks the deep batches as finalized", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("MarkFinalized", mock.Anything, uint64(8)).Return(nil).Once()
+
+		batchSynronizer := &BatchSynchronizer{
+			db:                dbMock,
+			finalizationDepth: 64,
+			pendingFinality:   append([]finalityCheckpoint{}, pending...),
+		}
+
+		batchSynronizer.finalizeBatches(context.Background(), 90)
+		require.Equal(t, pending[2:], batchSynronizer.pendingFinality)
+	})
+
+	t.Run("keeps the batches pending on error", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("MarkFinalized", mock.Anything, uint64(12)).Return(errors.New("test error")).Once()
+
+		batchSynronizer := &BatchSynchronizer{
+			db:                dbMock,
+			finalizationDepth: 64,
+			pendingFinality:   append([]finalityCheckpoint{}, pending...),
+		}
+
+		batchSynronizer.finalizeBatches(context.Background(), 100)
+		require.Equal(t, pending, batchSynronizer.pendingFinality)
+	})
+
+	t.Run("prunes the finalized batches", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("MarkFinalized", mock.Anything, uint64(8)).Return(nil).Once()
+		dbMock.On("PruneFinalized", mock.Anything, uint64(8)).Return(uint64(2), nil).Once()
+
+		batchSynronizer := &BatchSynchronizer{
+			db:                dbMock,
+			finalizationDepth: 64,
+			pruneFinalized:    true,
+			pendingFinality:   append([]finalityCheckpoint{}, pending...),
+		}
+
+		batchSynronizer.finalizeBatches(context.Background(), 90)
+		require.Equal(t, pending[2:], batchSynronizer.pendingFinality)
+	})
+
+	t.Run("does not prune the batches within the challenge window", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("MarkFinalized", mock.Anything, uint64(12)).Return(nil).Once()
+		dbMock.On("GetFirstBatchSequencedAfter", mock.Anything, uint64(50)).Return(uint64(7), nil).Once()
+		dbMock.On("PruneFinalized", mock.Anything, uint64(6)).Return(uint64(3), nil).Once()
+
+		head := func(context.Context) (uint64, error) { return 100, nil }
+
+		batchSynronizer := &BatchSynchronizer{
+			db:                db.NewChallengeWindowDB(dbMock, 50, head),
+			finalizationDepth: 64,
+			pruneFinalized:    true,
+			pendingFinality:   append([]finalityCheckpoint{}, pending...),
+		}
+
+		batchSynronizer.finalizeBatches(context.Background(), 100)
+		require.Empty(t, batchSynronizer.pendingFinality)
+	})
+
+	t.Run("prune error keeps the batches finalized", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("MarkFinalized", mock.Anything, uint64(12)).Return(nil).Once()
+		dbMock.On("PruneFinalized", mock.Anything, uint64(12)).Return(uint64(0), errors.New("test error")).Once()
+
+		batchSynronizer := &BatchSynchronizer{
+			db:                dbMock,
+			finalizationDepth: 64,
+			pruneFinalized:    true,
+			pendingFinality:   append([]finalityCheckpoint{}, pending...),
+		}
+
+		batchSynronizer.finalizeBatches(context.Background(), 100)
+		require.Empty(t, batchSynronizer.pendingFinality)
+	})
+
+	t.Run("drops reorged batches", func(t *testing.T) {
+		t.Parallel()
+
+		batchSynronizer := &BatchSynchronizer{
+			pendingFinality: append([]finalityCheckpoint{}, pending...),
+		}
+
+		batchSynronizer.dropPendingFinality(20)
+		require.Equal(t, pending[:1], batchSynronizer.pendingFinality)
+	})
+
+	t.Run("tracks the verified batches", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("FilterVerifyBatches", mock.MatchedBy(func(opts *bind.FilterOpts) bool {
+			return opts.Start == 10 && *opts.End == 50
+		})).Return([]*etrogValidium.PolygonvalidiumetrogVerifyBatches{
+			{NumBatch: 4, Raw: ethTypes.Log{BlockNumber: 15}},
+			{NumBatch: 9, Raw: ethTypes.Log{BlockNumber: 40}},
+		}, nil).Once()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("MarkFinalized", mock.Anything, uint64(4)).Return(nil).Once()
+
+		batchSynronizer := &BatchSynchronizer{
+			client:                 ethermanMock,
+			db:                     dbMock,
+			finalizationDepth:      10,
+			finalizeOnVerification: true,
+		}
+
+		require.NoError(t, batchSynronizer.trackVerifications(context.Background(), 10, 50))
+
+		batchSynronizer.finalizeBatches(context.Background(), 30)
+		require.Equal(t, []finalityCheckpoint{{block: 40, batchNum: 9}}, batchSynronizer.pendingFinality)
+	})
+
+	t.Run("verification tracking error", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("FilterVerifyBatches", mock.Anything).Return(nil, errors.New("test error")).Once()
+
+		batchSynronizer := &BatchSynchronizer{
+			client:                 ethermanMock,
+			finalizeOnVerification: true,
+		}
+
+		require.ErrorContains(t, batchSynronizer.trackVerifications(context.Background(), 10, 50), "test error")
+		require.Empty(t, batchSynronizer.pendingFinality)
+	})
+}
@@ -0,0 +1,124 @@
+package synchronizer
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// ErrWritesPaused is returned instead of writing the offchain data while the writes are paused after too many
+// consecutive failures
+var ErrWritesPaused = errors.New("offchain data writes paused after consecutive failures")
+
+// writeCircuit pauses the writes of the offchain data after a number of consecutive failures, e.g. when the disk
+// is full, so the synchronizer does not retry them in a loop flooding the logs and the database. Once the backoff
+// elapses, a single write is let through to probe whether the database recovered. A nil circuit never pauses
+type writeCircuit struct {
+	threshold uint
+	backoff   time.Duration
+
+	mu        sync.Mutex
+	failures  uint
+	open      bool
+	openUntil time.Time
+}
+
+// newWriteCircuit returns the circuit pausing the writes for the given backoff after the given number of
+// consecutive failures, nil if the threshold is 0
+func newWriteCircuit(threshold uint, backoff time.Duration) *writeCircuit {
+	if threshold == 0 {
+		return nil
+	}
+
+	return &writeCircuit{threshold: threshold, backoff: backoff}
+}
+
+// allow returns whether a write can be attempted at the given time. Once the backoff elapsed, the write it allows
+// is the probe, and the next ones are not allowed until its result is recorded or another backoff elapses
+func (c *writeCircuit) allow(now time.Time) bool {
+	if c == nil {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.open {
+		return true
+	}
+
+	if now.Before(c.openUntil) {
+		return false
+	}
+
+	c.openUntil = now.Add(c.backoff)
+
+	return true
+}
+
+// paused returns whether the writes are paused at the given time, without letting a probe through
+func (c *writeCircuit) paused(now time.Time) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.open && now.Before(c.openUntil)
+}
+
+// isOpen returns whether the writes are paused or being probed
+func (c *writeCircuit) isOpen() bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.open
+}
+
+// record records the result of a write at the given time. The writes are paused once the failures reach the
+// threshold, which is alerted once, and resume as soon as a write succeeds
+func (c *writeCircuit) record(now time.Time, err error) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		if c.open {
+			log.Infof("offchain data writes resumed after %d consecutive failures", c.failures)
+			writesPaused.Set(0)
+		}
+
+		c.failures = 0
+		c.open = false
+
+		return
+	}
+
+	c.failures++
+
+	if c.open {
+		c.openUntil = now.Add(c.backoff)
+		log.Warnf("offchain data writes still failing, paused for another %s: %v", c.backoff, err)
+
+		return
+	}
+
+	if c.failures >= c.threshold {
+		c.open = true
+		c.openUntil = now.Add(c.backoff)
+		writesPaused.Set(1)
+		writeCircuitTrips.Inc()
+		log.Errorf("offchain data writes paused for %s after %d consecutive failures, "+
+			"check the database storage and permissions: %v", c.backoff, c.failures, err)
+	}
+}
@@ -0,0 +1,161 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCircuit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		c := newWriteCircuit(0, time.Minute)
+		require.Nil(t, c)
+
+		now := time.Now()
+		for i := 0; i < 10; i++ {
+			c.record(now, errors.New("test error"))
+		}
+
+		require.True(t, c.allow(now))
+		require.False(t, c.paused(now))
+		require.False(t, c.isOpen())
+	})
+
+	t.Run("pauses after consecutive failures", func(t *testing.T) {
+		t.Parallel()
+
+		c := newWriteCircuit(3, time.Minute)
+		now := time.Now()
+
+		c.record(now, errors.New("test error"))
+		c.record(now, errors.New("test error"))
+		// a success resets the failures
+		c.record(now, nil)
+		c.record(now, errors.New("test error"))
+		c.record(now, errors.New("test error"))
+		require.True(t, c.allow(now))
+		require.False(t, c.isOpen())
+
+		c.record(now, errors.New("test error"))
+		require.True(t, c.isOpen())
+		require.True(t, c.paused(now))
+		require.False(t, c.allow(now))
+		require.False(t, c.allow(now.Add(59*time.Second)))
+	})
+
+	t.Run("probes once the backoff elapsed", func(t *testing.T) {
+		t.Parallel()
+
+		c := newWriteCircuit(1, time.Minute)
+		now := time.Now()
+
+		c.record(now, errors.New("test error"))
+		require.True(t, c.paused(now))
+
+		now = now.Add(time.Minute)
+		require.False(t, c.paused(now))
+		require.True(t, c.allow(now))
+		// only a single probe is let through
+		require.False(t, c.allow(now))
+		require.True(t, c.isOpen())
+
+		// a failed probe pauses the writes for another backoff
+		c.record(now, errors.New("test error"))
+		require.True(t, c.paused(now.Add(59*time.Second)))
+
+		now = now.Add(time.Minute)
+		require.True(t, c.allow(now))
+
+		// a successful probe resumes the writes
+		c.record(now, nil)
+		require.False(t, c.isOpen())
+		require.True(t, c.allow(now))
+		require.True(t, c.allow(now))
+	})
+}
+
+func TestBatchSynchronizer_StoreOffchainDataCircuit(t *testing.T) {
+	t.Parallel()
+
+	dbMock := mocks.NewDB(t)
+	bs := &BatchSynchronizer{
+		db:     dbMock,
+		writes: newWriteCircuit(2, time.Hour),
+	}
+
+	data := []types.OffChainData{{Key: crypto.Keccak256Hash([]byte("value")), Value: []byte("value")}}
+
+	dbMock.On("StoreOffChainData", mock.Anything, data).Return(errors.New("disk full")).Twice()
+
+	require.EqualError(t, bs.storeOffchainData(context.Background(), data), "disk full")
+	require.False(t, bs.WritesPaused())
+	require.EqualError(t, bs.storeOffchainData(context.Background(), data), "disk full")
+	require.True(t, bs.WritesPaused())
+
+	// the database is no longer written to while the writes are paused
+	require.ErrorIs(t, bs.storeOffchainData(context.Background(), data), ErrWritesPaused)
+
+	// canceled writes are not failures of the database
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	bs.writes = newWriteCircuit(1, time.Hour)
+	dbMock.On("StoreOffChainData", mock.Anything, data).Return(context.Canceled).Once()
+	require.ErrorIs(t, bs.storeOffchainData(ctx, data), context.Canceled)
+	require.False(t, bs.WritesPaused())
+
+	// data rejected before reaching the database is not a failure of the database either
+	dbMock.On("StoreOffChainData", mock.Anything, data).
+		Return(fmt.Errorf("%w: key %s", db.ErrEmptyOffChainData, data[0].Key.Hex())).Once()
+	require.ErrorIs(t, bs.storeOffchainData(context.Background(), data), db.ErrEmptyOffChainData)
+	require.False(t, bs.WritesPaused())
+}
+
+func TestBatchSynchronizer_HandleMissingBatchesWritesPaused(t *testing.T) {
+	t.Parallel()
+
+	key := types.BatchKey{Number: 1, Hash: crypto.Keccak256Hash([]byte("batch1"))}
+
+	dbMock := mocks.NewDB(t)
+	sequencerMock := mocks.NewSequencerTracker(t)
+
+	bs := &BatchSynchronizer{
+		db:        dbMock,
+		sequencer: sequencerMock,
+		committee: NewCommitteeMapSafe(),
+		writes:    newWriteCircuit(1, time.Hour),
+		queue:     newResolveQueue(0),
+	}
+
+	// the failure pauses the writes, so the next rounds do not resolve the batch
+	dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+		Return([]types.BatchKey{key}, nil).Times(3)
+	dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Once()
+	sequencerMock.On("GetSequenceBatch", mock.Anything, key.Number).
+		Return(&sequencer.SeqBatch{Number: 1, BatchL2Data: []byte("batch1")}, nil).Once()
+	txMock := mocks.NewTx(t)
+	dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+	dbMock.On("StoreOffChainDataTx", mock.Anything, mock.Anything, txMock).Return(errors.New("disk full")).Once()
+	txMock.On("Rollback").Return(nil).Once()
+
+	require.EqualError(t, bs.handleMissingBatches(context.Background()),
+		"failed to store the resolved offchain data: disk full")
+	require.True(t, bs.WritesPaused())
+
+	require.NoError(t, bs.handleMissingBatches(context.Background()))
+	require.NoError(t, bs.handleMissingBatches(context.Background()))
+}
@@ -0,0 +1,285 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// commitmentSearchWindow is the number of batches looked for at once when searching the sequence of a batch
+	commitmentSearchWindow = 100
+
+	// consistencyPageSize is the number of stored keys of a batch listed at once when checking their consistency
+	consistencyPageSize = 100
+)
+
+// ErrSequenceNotFound indicates no sequence committed on L1 contains the requested batch
+var ErrSequenceNotFound = errors.New("sequence of the batch not found")
+
+// VerifyBatchCommitment checks the data stored for the key committed on L1 for the given batch against that key,
+// which is the transactions hash of the batch in the Banana sequence that contains it. The data is looked up by
+// its key rather than by the batch number, since it may be stored under another batch committing the same data
+func (v *AccInputHashVerifier) VerifyBatchCommitment(ctx context.Context, batchNum uint64) (*types.BatchCommitment, error) {
+	calldata, lastBatch, txHash, err := v.findSequence(ctx, batchNum)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.BatchCommitment{
+		BatchNum:  types.ArgUint64(batchNum),
+		L1TxHash:  txHash,
+		Committed: calldata.Batches[uint64(len(calldata.Batches))-1-(lastBatch-batchNum)].TransactionsHash,
+	}
+
+	stored, err := v.db.GetOffChainData(ctx, result.Committed)
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		return result, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get the stored data of batch %d: %w", batchNum, err)
+	}
+
+	result.Computed = crypto.Keccak256Hash(stored.Value)
+	result.Matches = result.Computed == result.Committed
+
+	return result, nil
+}
+
+// CheckKeyBatchConsistency checks that every key stored under the given batch number is the key committed on L1
+// for that batch. The keys that are not were attributed to the wrong batch, which the integrity of their data
+// alone does not reveal
+func (v *AccInputHashVerifier) CheckKeyBatchConsistency(
+	ctx context.Context,
+	batchNum uint64,
+) (*types.KeyBatchConsistency, error) {
+	result, _, err := v.keyBatchConsistency(ctx, batchNum)
+
+	return result, err
+}
+
+// RepairKeyBatchConsistency moves the keys stored under the given batch number but committed for another batch
+// of its sequence to that batch, and the committed key of the batch to it if it is stored under another one.
+// The keys and values are immutable, so only their attribution is corrected, all at once
+func (v *AccInputHashVerifier) RepairKeyBatchConsistency(
+	ctx context.Context,
+	batchNum uint64,
+) (*types.KeyBatchRepair, error) {
+	consistency, committed, err := v.keyBatchConsistency(ctx, batchNum)
+	if err != nil {
+		return nil, err
+	}
+
+	repair := &types.KeyBatchRepair{
+		BatchNum:       consistency.BatchNum,
+		L1TxHash:       consistency.L1TxHash,
+		Corrections:    []types.KeyBatchCorrection{},
+		UnresolvedKeys: []common.Hash{},
+	}
+
+	for _, key := range consistency.MisattributedKeys {
+		to, ok := committed[key]
+		if !ok {
+			repair.UnresolvedKeys = append(repair.UnresolvedKeys, key)
+			continue
+		}
+
+		repair.Corrections = append(repair.Corrections, types.KeyBatchCorrection{
+			Key:  key,
+			From: types.ArgUint64(batchNum),
+			To:   types.ArgUint64(to),
+		})
+	}
+
+	if !consistency.CommittedKeyStored {
+		correction, err := v.committedKeyCorrection(ctx, consistency.CommittedKey, batchNum)
+		if err != nil {
+			return nil, err
+		}
+
+		if correction != nil {
+			repair.Corrections = append(repair.Corrections, *correction)
+		}
+	}
+
+	if len(repair.Corrections) == 0 {
+		return repair, nil
+	}
+
+	if err = v.db.CorrectOffChainDataBatchNums(ctx, repair.Corrections); err != nil {
+		return nil, fmt.Errorf("failed to correct the batch numbers of the keys of batch %d: %w", batchNum, err)
+	}
+
+	for _, c := range repair.Corrections {
+		log.Infof("moved key %s from batch %d to batch %d", c.Key.Hex(), c.From, c.To)
+	}
+
+	return repair, nil
+}
+
+// committedKeyCorrection returns the correction moving the given committed key of the given batch, stored under
+// another batch, to it. A key stores one row only, so the same data committed for several batches is stored under
+// one of them: the key is not moved if it is committed for the batch it is stored under too, otherwise repairing
+// each of the batches would move it back and forth. It returns nil if the key is not to be moved
+func (v *AccInputHashVerifier) committedKeyCorrection(
+	ctx context.Context,
+	key common.Hash,
+	batchNum uint64,
+) (*types.KeyBatchCorrection, error) {
+	stored, err := v.db.GetOffChainData(ctx, key)
+	if errors.Is(err, db.ErrStateNotSynchronized) {
+		// not stored at all, so there is nothing to move
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get the stored data of key %s: %w", key.Hex(), err)
+	}
+
+	if stored.BatchNum > 0 {
+		owner, err := v.committedKey(ctx, stored.BatchNum)
+		switch {
+		case errors.Is(err, ErrSequenceNotFound):
+			// the batch it is stored under committed nothing, so the key does not belong to it
+		case err != nil:
+			return nil, fmt.Errorf("failed to get the committed key of batch %d: %w", stored.BatchNum, err)
+		case owner == key:
+			log.Debugf("key %s of batch %d is committed for batch %d too, where it is stored",
+				key.Hex(), batchNum, stored.BatchNum)
+
+			return nil, nil
+		}
+	}
+
+	return &types.KeyBatchCorrection{
+		Key:  key,
+		From: types.ArgUint64(stored.BatchNum),
+		To:   types.ArgUint64(batchNum),
+	}, nil
+}
+
+// committedKey returns the key of the data committed on L1 for the given batch
+func (v *AccInputHashVerifier) committedKey(ctx context.Context, batchNum uint64) (common.Hash, error) {
+	calldata, lastBatch, _, err := v.findSequence(ctx, batchNum)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return calldata.Batches[uint64(len(calldata.Batches))-1-(lastBatch-batchNum)].TransactionsHash, nil
+}
+
+// SequenceKeys returns the keys of the data committed on L1 for every batch of the sequence that contains
+// the given batch, by batch number
+func (v *AccInputHashVerifier) SequenceKeys(ctx context.Context, batchNum uint64) (map[uint64]common.Hash, error) {
+	calldata, lastBatch, _, err := v.findSequence(ctx, batchNum)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[uint64]common.Hash, len(calldata.Batches))
+	firstBatch := lastBatch + 1 - uint64(len(calldata.Batches))
+	for i, b := range calldata.Batches {
+		keys[firstBatch+uint64(i)] = b.TransactionsHash
+	}
+
+	return keys, nil
+}
+
+// keyBatchConsistency checks the keys stored under the given batch number, and also returns the batch number
+// each key of its sequence is committed for
+func (v *AccInputHashVerifier) keyBatchConsistency(
+	ctx context.Context,
+	batchNum uint64,
+) (*types.KeyBatchConsistency, map[common.Hash]uint64, error) {
+	calldata, lastBatch, txHash, err := v.findSequence(ctx, batchNum)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The same data may be committed for several batches, in which case the first one is kept
+	committed := make(map[common.Hash]uint64, len(calldata.Batches))
+	firstBatch := lastBatch + 1 - uint64(len(calldata.Batches))
+	for i, b := range calldata.Batches {
+		if _, ok := committed[b.TransactionsHash]; !ok {
+			committed[b.TransactionsHash] = firstBatch + uint64(i)
+		}
+	}
+
+	result := &types.KeyBatchConsistency{
+		BatchNum:          types.ArgUint64(batchNum),
+		L1TxHash:          txHash,
+		CommittedKey:      calldata.Batches[uint64(len(calldata.Batches))-1-(lastBatch-batchNum)].TransactionsHash,
+		MisattributedKeys: []common.Hash{},
+	}
+
+	for offset := uint(0); ; offset += consistencyPageSize {
+		stored, total, err := v.db.ListOffChainDataByBatch(ctx, batchNum, offset, consistencyPageSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list the stored data of batch %d: %w", batchNum, err)
+		}
+
+		for _, data := range stored {
+			if data.Key == result.CommittedKey {
+				result.CommittedKeyStored = true
+			} else {
+				result.MisattributedKeys = append(result.MisattributedKeys, data.Key)
+			}
+		}
+
+		if len(stored) == 0 || uint64(offset)+uint64(len(stored)) >= total {
+			break
+		}
+	}
+
+	result.Consistent = len(result.MisattributedKeys) == 0
+
+	return result, committed, nil
+}
+
+// findSequence returns the calldata, the last batch and the transaction hash of the Banana sequence
+// that contains the given batch
+func (v *AccInputHashVerifier) findSequence(
+	ctx context.Context,
+	batchNum uint64,
+) (*types.SequenceBananaCalldata, uint64, common.Hash, error) {
+	// A sequence is found by its last batch, and the contract sequences at most maxReplayBatches batches at once
+	for from := batchNum; from < batchNum+maxReplayBatches; from += commitmentSearchWindow {
+		numBatches := make([]uint64, commitmentSearchWindow)
+		for i := range numBatches {
+			numBatches[i] = from + uint64(i)
+		}
+
+		events, err := v.em.FilterSequenceBatchesBanana(ctx, v.startBlock, numBatches)
+		if err != nil {
+			return nil, 0, common.Hash{}, fmt.Errorf("failed to filter sequence batches events: %w", err)
+		}
+
+		if len(events) == 0 {
+			continue
+		}
+
+		// The sequence containing the batch is the first one ending at or after it
+		event := events[0]
+		for _, e := range events[1:] {
+			if e.NumBatch < event.NumBatch {
+				event = e
+			}
+		}
+
+		calldata, err := v.sequenceCalldata(ctx, event.Raw.TxHash)
+		if err != nil {
+			return nil, 0, common.Hash{}, err
+		}
+
+		if event.NumBatch-batchNum >= uint64(len(calldata.Batches)) {
+			break
+		}
+
+		return calldata, event.NumBatch, event.Raw.TxHash, nil
+	}
+
+	return nil, 0, common.Hash{}, fmt.Errorf("%w: %d", ErrSequenceNotFound, batchNum)
+}
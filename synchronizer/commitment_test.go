@@ -0,0 +1,465 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	bananaValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/banana/polygonvalidiumetrog"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccInputHashVerifier_VerifyBatchCommitment(t *testing.T) {
+	t.Parallel()
+
+	const startBlock = uint64(100)
+
+	values := [][]byte{[]byte("batch4"), []byte("batch5"), []byte("batch6")}
+
+	seq := types.SequenceBanana{MaxSequenceTimestamp: 1000}
+	for _, value := range values {
+		seq.Batches = append(seq.Batches, types.Batch{L2Data: value})
+	}
+
+	calldata, err := seq.EncodeCalldata()
+	require.NoError(t, err)
+
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{GasPrice: big.NewInt(10_000), Gas: 21_000, Data: calldata})
+
+	// The sequence holds batches 4 to 6, so it is found by its last batch
+	window := func(from uint64) []uint64 {
+		nums := make([]uint64, commitmentSearchWindow)
+		for i := range nums {
+			nums[i] = from + uint64(i)
+		}
+
+		return nums
+	}
+
+	events := []*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+		{NumBatch: 9, Raw: ethTypes.Log{TxHash: common.HexToHash("0x09")}},
+		{NumBatch: 6, Raw: ethTypes.Log{TxHash: tx.Hash()}},
+	}
+
+	tests := []struct {
+		name     string
+		stored   *types.OffChainData
+		expected *types.BatchCommitment
+	}{
+		{
+			name:   "stored data matches",
+			stored: &types.OffChainData{Key: crypto.Keccak256Hash(values[1]), Value: values[1], BatchNum: 5},
+			expected: &types.BatchCommitment{
+				BatchNum:  5,
+				L1TxHash:  tx.Hash(),
+				Committed: crypto.Keccak256Hash(values[1]),
+				Computed:  crypto.Keccak256Hash(values[1]),
+				Matches:   true,
+			},
+		},
+		{
+			name:   "stored data does not match",
+			stored: &types.OffChainData{Key: crypto.Keccak256Hash(values[1]), Value: []byte("corrupt"), BatchNum: 5},
+			expected: &types.BatchCommitment{
+				BatchNum:  5,
+				L1TxHash:  tx.Hash(),
+				Committed: crypto.Keccak256Hash(values[1]),
+				Computed:  crypto.Keccak256Hash([]byte("corrupt")),
+			},
+		},
+		{
+			name:   "stored under another batch committing the same data",
+			stored: &types.OffChainData{Key: crypto.Keccak256Hash(values[1]), Value: values[1], BatchNum: 2},
+			expected: &types.BatchCommitment{
+				BatchNum:  5,
+				L1TxHash:  tx.Hash(),
+				Committed: crypto.Keccak256Hash(values[1]),
+				Computed:  crypto.Keccak256Hash(values[1]),
+				Matches:   true,
+			},
+		},
+		{
+			name: "nothing stored",
+			expected: &types.BatchCommitment{
+				BatchNum:  5,
+				L1TxHash:  tx.Hash(),
+				Committed: crypto.Keccak256Hash(values[1]),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ethermanMock := mocks.NewEtherman(t)
+			ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, window(5)).
+				Return(events, nil).Once()
+			ethermanMock.On("GetTx", mock.Anything, tx.Hash()).Return(tx, false, nil).Once()
+
+			dbMock := mocks.NewDB(t)
+			if tt.stored != nil {
+				dbMock.On("GetOffChainData", mock.Anything, crypto.Keccak256Hash(values[1])).Return(tt.stored, nil).Once()
+			} else {
+				dbMock.On("GetOffChainData", mock.Anything, crypto.Keccak256Hash(values[1])).
+					Return(nil, db.ErrStateNotSynchronized).Once()
+			}
+
+			got, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+				VerifyBatchCommitment(context.Background(), 5)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+
+	t.Run("batch not sequenced", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, mock.Anything).
+			Return(nil, nil).Times(maxReplayBatches / commitmentSearchWindow)
+
+		_, err := NewAccInputHashVerifier(mocks.NewDB(t), ethermanMock, startBlock).
+			VerifyBatchCommitment(context.Background(), 5)
+		require.ErrorIs(t, err, ErrSequenceNotFound)
+	})
+
+	t.Run("first sequence after the batch does not contain it", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, window(2)).
+			Return(events, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, tx.Hash()).Return(tx, false, nil).Once()
+
+		_, err := NewAccInputHashVerifier(mocks.NewDB(t), ethermanMock, startBlock).
+			VerifyBatchCommitment(context.Background(), 2)
+		require.ErrorIs(t, err, ErrSequenceNotFound)
+	})
+
+	t.Run("filter events fails", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, window(5)).
+			Return(nil, errors.New("error")).Once()
+
+		_, err := NewAccInputHashVerifier(mocks.NewDB(t), ethermanMock, startBlock).
+			VerifyBatchCommitment(context.Background(), 5)
+		require.ErrorContains(t, err, "failed to filter sequence batches events")
+	})
+}
+
+func TestAccInputHashVerifier_SequenceKeys(t *testing.T) {
+	t.Parallel()
+
+	const startBlock = uint64(100)
+
+	values := [][]byte{[]byte("batch4"), []byte("batch5"), []byte("batch6")}
+
+	seq := types.SequenceBanana{MaxSequenceTimestamp: 1000}
+	for _, value := range values {
+		seq.Batches = append(seq.Batches, types.Batch{L2Data: value})
+	}
+
+	calldata, err := seq.EncodeCalldata()
+	require.NoError(t, err)
+
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{GasPrice: big.NewInt(10_000), Gas: 21_000, Data: calldata})
+
+	ethermanMock := mocks.NewEtherman(t)
+	ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, mock.Anything).
+		Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+			{NumBatch: 6, Raw: ethTypes.Log{TxHash: tx.Hash()}},
+		}, nil).Once()
+	ethermanMock.On("GetTx", mock.Anything, tx.Hash()).Return(tx, false, nil).Once()
+
+	keys, err := NewAccInputHashVerifier(mocks.NewDB(t), ethermanMock, startBlock).
+		SequenceKeys(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]common.Hash{
+		4: crypto.Keccak256Hash(values[0]),
+		5: crypto.Keccak256Hash(values[1]),
+		6: crypto.Keccak256Hash(values[2]),
+	}, keys)
+}
+
+func TestAccInputHashVerifier_CheckKeyBatchConsistency(t *testing.T) {
+	t.Parallel()
+
+	const startBlock = uint64(100)
+
+	values := [][]byte{[]byte("batch4"), []byte("batch5"), []byte("batch6")}
+
+	seq := types.SequenceBanana{MaxSequenceTimestamp: 1000}
+	for _, value := range values {
+		seq.Batches = append(seq.Batches, types.Batch{L2Data: value})
+	}
+
+	calldata, err := seq.EncodeCalldata()
+	require.NoError(t, err)
+
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{GasPrice: big.NewInt(10_000), Gas: 21_000, Data: calldata})
+
+	events := []*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+		{NumBatch: 6, Raw: ethTypes.Log{TxHash: tx.Hash()}},
+	}
+
+	committedKey := crypto.Keccak256Hash(values[1])
+	otherKey := crypto.Keccak256Hash(values[2])
+
+	// a full page of the committed key, as if it was stored many times
+	fullPage := make([]types.OffChainData, consistencyPageSize)
+	for i := range fullPage {
+		fullPage[i] = types.OffChainData{Key: committedKey, BatchNum: 5}
+	}
+
+	tests := []struct {
+		name     string
+		pages    [][]types.OffChainData
+		total    uint64
+		listErr  error
+		expected *types.KeyBatchConsistency
+		err      string
+	}{
+		{
+			name:  "only the committed key is stored",
+			pages: [][]types.OffChainData{{{Key: committedKey, BatchNum: 5}}},
+			total: 1,
+			expected: &types.KeyBatchConsistency{
+				BatchNum:           5,
+				L1TxHash:           tx.Hash(),
+				CommittedKey:       committedKey,
+				CommittedKeyStored: true,
+				MisattributedKeys:  []common.Hash{},
+				Consistent:         true,
+			},
+		},
+		{
+			name:  "nothing stored",
+			pages: [][]types.OffChainData{{}},
+			expected: &types.KeyBatchConsistency{
+				BatchNum:          5,
+				L1TxHash:          tx.Hash(),
+				CommittedKey:      committedKey,
+				MisattributedKeys: []common.Hash{},
+				Consistent:        true,
+			},
+		},
+		{
+			name: "key of another batch stored across pages",
+			pages: [][]types.OffChainData{
+				fullPage,
+				{{Key: otherKey, BatchNum: 5}},
+			},
+			total: consistencyPageSize + 1,
+			expected: &types.KeyBatchConsistency{
+				BatchNum:           5,
+				L1TxHash:           tx.Hash(),
+				CommittedKey:       committedKey,
+				CommittedKeyStored: true,
+				MisattributedKeys:  []common.Hash{otherKey},
+			},
+		},
+		{
+			name:    "list fails",
+			pages:   [][]types.OffChainData{nil},
+			listErr: errors.New("test error"),
+			err:     "failed to list the stored data of batch 5: test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ethermanMock := mocks.NewEtherman(t)
+			ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, mock.Anything).
+				Return(events, nil).Once()
+			ethermanMock.On("GetTx", mock.Anything, tx.Hash()).Return(tx, false, nil).Once()
+
+			dbMock := mocks.NewDB(t)
+			for i, page := range tt.pages {
+				dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(5),
+					uint(i*consistencyPageSize), uint(consistencyPageSize)).
+					Return(page, tt.total, tt.listErr).Once()
+			}
+
+			got, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+				CheckKeyBatchConsistency(context.Background(), 5)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestAccInputHashVerifier_RepairKeyBatchConsistency(t *testing.T) {
+	t.Parallel()
+
+	const startBlock = uint64(100)
+
+	values := [][]byte{[]byte("batch4"), []byte("batch5"), []byte("batch6")}
+
+	seq := types.SequenceBanana{MaxSequenceTimestamp: 1000}
+	for _, value := range values {
+		seq.Batches = append(seq.Batches, types.Batch{L2Data: value})
+	}
+
+	calldata, err := seq.EncodeCalldata()
+	require.NoError(t, err)
+
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{GasPrice: big.NewInt(10_000), Gas: 21_000, Data: calldata})
+
+	events := []*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+		{NumBatch: 6, Raw: ethTypes.Log{TxHash: tx.Hash()}},
+	}
+
+	committedKey := crypto.Keccak256Hash(values[1])
+	nextKey := crypto.Keccak256Hash(values[2])
+	unknownKey := crypto.Keccak256Hash([]byte("unknown"))
+
+	tests := []struct {
+		name       string
+		stored     []types.OffChainData
+		committed  *types.OffChainData
+		getErr     error
+		correctErr error
+		expected   *types.KeyBatchRepair
+		err        string
+	}{
+		{
+			name:   "consistent batch",
+			stored: []types.OffChainData{{Key: committedKey, BatchNum: 5}},
+			expected: &types.KeyBatchRepair{
+				BatchNum:       5,
+				L1TxHash:       tx.Hash(),
+				Corrections:    []types.KeyBatchCorrection{},
+				UnresolvedKeys: []common.Hash{},
+			},
+		},
+		{
+			name:      "keys of the batch and of the next one swapped",
+			stored:    []types.OffChainData{{Key: nextKey, BatchNum: 5}, {Key: unknownKey, BatchNum: 5}},
+			committed: &types.OffChainData{Key: committedKey, BatchNum: 6},
+			expected: &types.KeyBatchRepair{
+				BatchNum: 5,
+				L1TxHash: tx.Hash(),
+				Corrections: []types.KeyBatchCorrection{
+					{Key: nextKey, From: 5, To: 6},
+					{Key: committedKey, From: 6, To: 5},
+				},
+				UnresolvedKeys: []common.Hash{unknownKey},
+			},
+		},
+		{
+			name:   "committed key not stored",
+			stored: []types.OffChainData{},
+			getErr: db.ErrStateNotSynchronized,
+			expected: &types.KeyBatchRepair{
+				BatchNum:       5,
+				L1TxHash:       tx.Hash(),
+				Corrections:    []types.KeyBatchCorrection{},
+				UnresolvedKeys: []common.Hash{},
+			},
+		},
+		{
+			name:       "correction fails",
+			stored:     []types.OffChainData{{Key: committedKey, BatchNum: 5}, {Key: nextKey, BatchNum: 5}},
+			correctErr: errors.New("test error"),
+			err:        "failed to correct the batch numbers of the keys of batch 5: test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the sequence is looked up again for the batch the committed key is stored under
+			ethermanMock := mocks.NewEtherman(t)
+			ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, mock.Anything).
+				Return(events, nil)
+			ethermanMock.On("GetTx", mock.Anything, tx.Hash()).Return(tx, false, nil)
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(5), uint(0), uint(consistencyPageSize)).
+				Return(tt.stored, uint64(len(tt.stored)), nil).Once()
+
+			if tt.committed != nil || tt.getErr != nil {
+				dbMock.On("GetOffChainData", mock.Anything, committedKey).Return(tt.committed, tt.getErr).Once()
+			}
+
+			if tt.correctErr != nil || (tt.expected != nil && len(tt.expected.Corrections) > 0) {
+				dbMock.On("CorrectOffChainDataBatchNums", mock.Anything, mock.Anything).Return(tt.correctErr).Once()
+			}
+
+			got, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+				RepairKeyBatchConsistency(context.Background(), 5)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+
+	t.Run("data committed for two batches", func(t *testing.T) {
+		t.Parallel()
+
+		// batches 5 and 6 commit the same data, whose single row is stored under batch 6
+		shared := []byte("shared")
+		sharedSeq := types.SequenceBanana{MaxSequenceTimestamp: 1000, Batches: []types.Batch{
+			{L2Data: values[0]}, {L2Data: shared}, {L2Data: shared},
+		}}
+
+		sharedCalldata, err := sharedSeq.EncodeCalldata()
+		require.NoError(t, err)
+
+		sharedTx := ethTypes.NewTx(&ethTypes.LegacyTx{GasPrice: big.NewInt(10_000), Gas: 21_000, Data: sharedCalldata})
+		sharedKey := crypto.Keccak256Hash(shared)
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, mock.Anything).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+				{NumBatch: 6, Raw: ethTypes.Log{TxHash: sharedTx.Hash()}},
+			}, nil).Twice()
+		ethermanMock.On("GetTx", mock.Anything, sharedTx.Hash()).Return(sharedTx, false, nil).Twice()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(5), uint(0), uint(consistencyPageSize)).
+			Return([]types.OffChainData{}, uint64(0), nil).Once()
+		dbMock.On("GetOffChainData", mock.Anything, sharedKey).
+			Return(&types.OffChainData{Key: sharedKey, Value: shared, BatchNum: 6}, nil).Once()
+
+		// the key is not taken away from batch 6, which owns it as much as batch 5
+		got, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+			RepairKeyBatchConsistency(context.Background(), 5)
+		require.NoError(t, err)
+		require.Equal(t, &types.KeyBatchRepair{
+			BatchNum:       5,
+			L1TxHash:       sharedTx.Hash(),
+			Corrections:    []types.KeyBatchCorrection{},
+			UnresolvedKeys: []common.Hash{},
+		}, got)
+	})
+}
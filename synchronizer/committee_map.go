@@ -45,6 +45,25 @@ func (t *CommitteeMapSafe) Load(addr common.Address) (etherman.DataCommitteeMemb
 	return rawValue.(etherman.DataCommitteeMember), exists //nolint:forcetypeassert
 }
 
+// Replace sets the given members, deleting the stored ones that are not among them.
+func (t *CommitteeMapSafe) Replace(members []etherman.DataCommitteeMember) {
+	keep := make(map[common.Address]struct{}, len(members))
+	for _, m := range members {
+		keep[m.Addr] = struct{}{}
+	}
+
+	t.members.Range(func(rawAddr, _ any) bool {
+		addr := rawAddr.(common.Address) //nolint:forcetypeassert
+		if _, ok := keep[addr]; !ok {
+			t.Delete(addr)
+		}
+
+		return true
+	})
+
+	t.StoreBatch(members)
+}
+
 // Delete deletes the value for a key.
 func (t *CommitteeMapSafe) Delete(key common.Address) {
 	_, exists := t.members.LoadAndDelete(key)
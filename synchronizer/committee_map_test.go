@@ -105,6 +105,32 @@ func TestStoreBatch(t *testing.T) {
 	}
 }
 
+func TestReplace(t *testing.T) {
+	committee := NewCommitteeMapSafe()
+	committee.StoreBatch([]etherman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x1"), URL: "http://localhost:1001"},
+		{Addr: common.HexToAddress("0x2"), URL: "http://localhost:1002"},
+	})
+
+	members := []etherman.DataCommitteeMember{
+		{Addr: common.HexToAddress("0x2"), URL: "http://localhost:2002"},
+		{Addr: common.HexToAddress("0x3"), URL: "http://localhost:1003"},
+	}
+
+	committee.Replace(members)
+
+	require.Equal(t, len(members), committee.Length())
+
+	_, ok := committee.Load(common.HexToAddress("0x1"))
+	require.False(t, ok)
+
+	for _, member := range members {
+		loadedMember, ok := committee.Load(member.Addr)
+		require.True(t, ok)
+		require.Equal(t, member, loadedMember)
+	}
+}
+
 func TestAsSlice(t *testing.T) {
 	committee := NewCommitteeMapSafe()
 	committee.StoreBatch(
@@ -0,0 +1,71 @@
+package synchronizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// removeResolvedBatchKeys removes the given resolved keys from the missing batches. They are moved to the resolved
+// batches history along with the attempts it took to resolve them, as returned by the given function, when the
+// history is kept, and deleted otherwise
+func (bs *BatchSynchronizer) removeResolvedBatchKeys(
+	ctx context.Context, keys []types.BatchKey, attempts func(key types.BatchKey) uint,
+) error {
+	if bs.resolvedHistoryRetention == 0 {
+		return deleteMissingBatchKeys(ctx, bs.db, keys)
+	}
+
+	return archiveMissingBatchKeys(ctx, bs.db, toResolvedBatches(keys, attempts))
+}
+
+// removeResolvedBatchKeysTx removes the given resolved keys from the missing batches as removeResolvedBatchKeys
+// does, within the given transaction
+func (bs *BatchSynchronizer) removeResolvedBatchKeysTx(
+	ctx context.Context, keys []types.BatchKey, attempts func(key types.BatchKey) uint, tx db.Tx,
+) error {
+	if bs.resolvedHistoryRetention == 0 {
+		return bs.db.DeleteMissingBatchKeysTx(ctx, keys, tx)
+	}
+
+	return bs.db.ArchiveMissingBatchKeysTx(ctx, toResolvedBatches(keys, attempts), tx)
+}
+
+// toResolvedBatches returns the resolved batches history entries of the given keys, along with the attempts it
+// took to resolve them
+func toResolvedBatches(keys []types.BatchKey, attempts func(key types.BatchKey) uint) []types.ResolvedBatch {
+	resolved := make([]types.ResolvedBatch, len(keys))
+	for i, key := range keys {
+		resolved[i] = types.ResolvedBatch{
+			Number:   types.ArgUint64(key.Number),
+			Hash:     key.Hash,
+			Attempts: attempts(key),
+		}
+	}
+
+	return resolved
+}
+
+// pruneResolvedHistory deletes the resolved batches history older than its retention, at most once every
+// resolvedHistoryPruneInterval
+func (bs *BatchSynchronizer) pruneResolvedHistory(ctx context.Context) {
+	now := time.Now()
+	if bs.resolvedHistoryRetention == 0 || now.Sub(bs.resolvedHistoryPrunedAt) < resolvedHistoryPruneInterval {
+		return
+	}
+
+	pruned, err := pruneResolvedBatches(ctx, bs.db, now.Add(-bs.resolvedHistoryRetention))
+	if err != nil {
+		log.Errorf("failed to prune the resolved batches history: %v", err)
+		return
+	}
+
+	bs.resolvedHistoryPrunedAt = now
+
+	if pruned > 0 {
+		log.Infof("pruned %d resolved batches older than %s from the history", pruned, bs.resolvedHistoryRetention)
+	}
+}
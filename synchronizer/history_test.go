@@ -0,0 +1,120 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSynchronizer_removeResolvedBatchKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := []types.BatchKey{
+		{Number: 10, Hash: crypto.Keccak256Hash([]byte("batch10"))},
+		{Number: 11, Hash: crypto.Keccak256Hash([]byte("batch11"))},
+	}
+
+	attempts := func(key types.BatchKey) uint {
+		return uint(key.Number) - 9 //nolint:gosec
+	}
+
+	t.Run("deleted without history", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("DeleteMissingBatchKeys", mock.Anything, keys).Return(nil).Once()
+
+		bs := &BatchSynchronizer{db: dbMock}
+		require.NoError(t, bs.removeResolvedBatchKeys(context.Background(), keys, attempts))
+	})
+
+	t.Run("archived with their attempts with history", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("ArchiveMissingBatchKeys", mock.Anything, []types.ResolvedBatch{
+			{Number: 10, Hash: keys[0].Hash, Attempts: 1},
+			{Number: 11, Hash: keys[1].Hash, Attempts: 2},
+		}).Return(nil).Once()
+
+		bs := &BatchSynchronizer{db: dbMock, resolvedHistoryRetention: time.Hour}
+		require.NoError(t, bs.removeResolvedBatchKeys(context.Background(), keys, attempts))
+	})
+}
+
+func TestBatchSynchronizer_removeResolvedBatchKeysTx(t *testing.T) {
+	t.Parallel()
+
+	keys := []types.BatchKey{{Number: 10, Hash: crypto.Keccak256Hash([]byte("batch10"))}}
+
+	attempts := func(types.BatchKey) uint {
+		return 2
+	}
+
+	t.Run("deleted within the transaction without history", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		txMock := mocks.NewTx(t)
+		dbMock.On("DeleteMissingBatchKeysTx", mock.Anything, keys, txMock).Return(nil).Once()
+
+		bs := &BatchSynchronizer{db: dbMock}
+		require.NoError(t, bs.removeResolvedBatchKeysTx(context.Background(), keys, attempts, txMock))
+	})
+
+	t.Run("archived within the transaction with history", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		txMock := mocks.NewTx(t)
+		dbMock.On("ArchiveMissingBatchKeysTx", mock.Anything, []types.ResolvedBatch{
+			{Number: 10, Hash: keys[0].Hash, Attempts: 2},
+		}, txMock).Return(nil).Once()
+
+		bs := &BatchSynchronizer{db: dbMock, resolvedHistoryRetention: time.Hour}
+		require.NoError(t, bs.removeResolvedBatchKeysTx(context.Background(), keys, attempts, txMock))
+	})
+}
+
+func TestBatchSynchronizer_pruneResolvedHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not pruned without history", func(t *testing.T) {
+		t.Parallel()
+
+		bs := &BatchSynchronizer{db: mocks.NewDB(t)}
+		bs.pruneResolvedHistory(context.Background())
+	})
+
+	t.Run("pruned once per interval", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("PruneResolvedBatches", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= 24*time.Hour
+		})).Return(uint64(2), nil).Once()
+
+		bs := &BatchSynchronizer{db: dbMock, resolvedHistoryRetention: 24 * time.Hour}
+		bs.pruneResolvedHistory(context.Background())
+		bs.pruneResolvedHistory(context.Background())
+	})
+
+	t.Run("retried after a failure", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("PruneResolvedBatches", mock.Anything, mock.Anything).
+			Return(uint64(0), errors.New("test error")).Twice()
+
+		bs := &BatchSynchronizer{db: dbMock, resolvedHistoryRetention: 24 * time.Hour}
+		bs.pruneResolvedHistory(context.Background())
+		bs.pruneResolvedHistory(context.Background())
+	})
+}
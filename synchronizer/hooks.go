@@ -0,0 +1,170 @@
+package synchronizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// hookQueueSize is the number of resolved batches waiting for the hook before new ones are dropped
+	hookQueueSize = 1024
+
+	// defaultHookConcurrency is the number of hook invocations run at once when not configured
+	defaultHookConcurrency = 1
+)
+
+// BatchResolvedHook is notified of the batches whose missing data was resolved and stored,
+// to trigger actions outside of the node
+type BatchResolvedHook interface {
+	OnBatchResolved(batchNum uint64, keys []common.Hash)
+}
+
+// NoopBatchResolvedHook is a BatchResolvedHook that ignores the notifications
+type NoopBatchResolvedHook struct{}
+
+// OnBatchResolved does nothing
+func (NoopBatchResolvedHook) OnBatchResolved(uint64, []common.Hash) {}
+
+// resolvedBatch is the notification of a resolved batch waiting for the hook
+type resolvedBatch struct {
+	number uint64
+	keys   []common.Hash
+}
+
+// hookDispatcher invokes the hook asynchronously on a fixed number of workers, so a slow hook does not stall
+// the resolver. The notifications that do not fit in the queue are dropped
+type hookDispatcher struct {
+	hook    BatchResolvedHook
+	workers uint
+	pending chan resolvedBatch
+}
+
+// newHookDispatcher returns the dispatcher of the given hook, running up to the given number of invocations at once
+func newHookDispatcher(hook BatchResolvedHook, workers uint) *hookDispatcher {
+	if workers == 0 {
+		workers = defaultHookConcurrency
+	}
+
+	return &hookDispatcher{
+		hook:    hook,
+		workers: workers,
+		pending: make(chan resolvedBatch, hookQueueSize),
+	}
+}
+
+// start starts the workers invoking the hook until stop is closed
+func (d *hookDispatcher) start(stop <-chan struct{}) {
+	if d == nil {
+		return
+	}
+
+	for i := uint(0); i < d.workers; i++ {
+		go func() {
+			for {
+				select {
+				case batch := <-d.pending:
+					d.hook.OnBatchResolved(batch.number, batch.keys)
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+}
+
+// notify queues a notification per batch of the given resolved keys, in batch order
+func (d *hookDispatcher) notify(resolved []types.BatchKey) {
+	if d == nil || len(resolved) == 0 {
+		return
+	}
+
+	if _, ok := d.hook.(NoopBatchResolvedHook); ok {
+		return
+	}
+
+	byBatch := make(map[uint64][]common.Hash)
+	for _, key := range resolved {
+		byBatch[key.Number] = append(byBatch[key.Number], key.Hash)
+	}
+
+	batchNums := make([]uint64, 0, len(byBatch))
+	for batchNum := range byBatch {
+		batchNums = append(batchNums, batchNum)
+	}
+
+	sort.Slice(batchNums, func(i, j int) bool { return batchNums[i] < batchNums[j] })
+
+	for _, batchNum := range batchNums {
+		select {
+		case d.pending <- resolvedBatch{number: batchNum, keys: byBatch[batchNum]}:
+		default:
+			log.Warnf("batch resolved hook queue full, dropping the notification of batch %d", batchNum)
+			droppedHookNotifications.Inc()
+		}
+	}
+}
+
+// WebhookHook is a BatchResolvedHook that posts the resolved batches to a URL
+type WebhookHook struct {
+	url    string
+	client *http.Client
+}
+
+// webhookPayload is the JSON body posted by the WebhookHook
+type webhookPayload struct {
+	BatchNum uint64        `json:"batchNum"`
+	Keys     []common.Hash `json:"keys"`
+}
+
+// NewWebhookHook returns the hook posting the resolved batches to the given URL, giving up on a post
+// after the given timeout
+func NewWebhookHook(url string, timeout time.Duration) *WebhookHook {
+	return &WebhookHook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// OnBatchResolved posts the resolved batch to the webhook. Failures are logged, the post is not retried
+func (h *WebhookHook) OnBatchResolved(batchNum uint64, keys []common.Hash) {
+	if err := h.post(batchNum, keys); err != nil {
+		log.Errorf("failed to notify the webhook of resolved batch %d: %v", batchNum, err)
+	}
+}
+
+// post posts the given resolved batch to the webhook
+func (h *WebhookHook) post(batchNum uint64, keys []common.Hash) error {
+	body, err := json.Marshal(webhookPayload{BatchNum: batchNum, Keys: keys})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
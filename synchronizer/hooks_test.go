@@ -0,0 +1,124 @@
+package synchronizer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHook records the notifications it receives
+type recordingHook struct {
+	lock     sync.Mutex
+	resolved map[uint64][]common.Hash
+}
+
+func (h *recordingHook) OnBatchResolved(batchNum uint64, keys []common.Hash) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.resolved[batchNum] = keys
+}
+
+func (h *recordingHook) count() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return len(h.resolved)
+}
+
+func Test_hookDispatcher(t *testing.T) {
+	t.Parallel()
+
+	keys := []types.BatchKey{
+		{Number: 2, Hash: common.HexToHash("0x3")},
+		{Number: 1, Hash: common.HexToHash("0x1")},
+		{Number: 1, Hash: common.HexToHash("0x2")},
+	}
+
+	t.Run("notified per batch", func(t *testing.T) {
+		t.Parallel()
+
+		hook := &recordingHook{resolved: make(map[uint64][]common.Hash)}
+		stop := make(chan struct{})
+		defer close(stop)
+
+		d := newHookDispatcher(hook, 2)
+		d.start(stop)
+		d.notify(keys)
+
+		require.Eventually(t, func() bool { return hook.count() == 2 }, time.Second, 10*time.Millisecond)
+		require.Equal(t, map[uint64][]common.Hash{
+			1: {common.HexToHash("0x1"), common.HexToHash("0x2")},
+			2: {common.HexToHash("0x3")},
+		}, hook.resolved)
+	})
+
+	t.Run("dropped when the queue is full", func(t *testing.T) {
+		t.Parallel()
+
+		d := newHookDispatcher(&recordingHook{resolved: make(map[uint64][]common.Hash)}, 1)
+		for i := 0; i < hookQueueSize; i++ {
+			d.notify(keys[:1])
+		}
+
+		// the workers are not started, so nothing is consumed and the resolver is not blocked
+		d.notify(keys)
+		require.Len(t, d.pending, hookQueueSize)
+	})
+
+	t.Run("no-op hook skipped", func(t *testing.T) {
+		t.Parallel()
+
+		d := newHookDispatcher(NoopBatchResolvedHook{}, 1)
+		d.notify(keys)
+		require.Empty(t, d.pending)
+	})
+
+	t.Run("nil dispatcher", func(t *testing.T) {
+		t.Parallel()
+
+		var d *hookDispatcher
+		d.start(nil)
+		d.notify(keys)
+	})
+}
+
+func TestWebhookHook_OnBatchResolved(t *testing.T) {
+	t.Parallel()
+
+	keys := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+	received := make(chan webhookPayload, 1)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var payload webhookPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received <- payload
+	}))
+	defer svr.Close()
+
+	NewWebhookHook(svr.URL, time.Second).OnBatchResolved(10, keys)
+
+	require.Equal(t, webhookPayload{BatchNum: 10, Keys: keys}, <-received)
+}
+
+func TestWebhookHook_post(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	err := NewWebhookHook(svr.URL, time.Second).post(10, nil)
+	require.EqualError(t, err, "unexpected status 500")
+}
@@ -0,0 +1,34 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+)
+
+// SyncLag returns how many L1 blocks the synchronizer is behind the L1 head, and exposes it
+func (bs *BatchSynchronizer) SyncLag(ctx context.Context) (uint64, error) {
+	processed, err := bs.db.GetLastProcessedBlock(ctx, string(L1SyncTask))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the last processed block: %w", err)
+	}
+
+	header, err := bs.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the latest block: %w", err)
+	}
+
+	lag := blockLag(processed, header.Number.Uint64())
+	syncLag.Set(float64(lag))
+
+	return lag, nil
+}
+
+// blockLag returns how many blocks the processed block is behind the head. The processed block
+// should never be ahead of the head, but if it is, e.g. right after an L1 reorg, there is no lag
+func blockLag(processed, head uint64) uint64 {
+	if processed >= head {
+		return 0
+	}
+
+	return head - processed
+}
@@ -0,0 +1,92 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSynchronizer_SyncLag(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("test error")
+
+	tests := []struct {
+		name      string
+		processed uint64
+		dbErr     error
+		head      uint64
+		headerErr error
+		lag       uint64
+		err       string
+	}{
+		{
+			name:      "behind the head",
+			processed: 90,
+			head:      100,
+			lag:       10,
+		},
+		{
+			name:      "at the head",
+			processed: 100,
+			head:      100,
+		},
+		{
+			name:      "ahead of the head",
+			processed: 105,
+			head:      100,
+		},
+		{
+			name:  "last processed block not found",
+			dbErr: testErr,
+			err:   "failed to get the last processed block: test error",
+		},
+		{
+			name:      "latest block not found",
+			processed: 90,
+			headerErr: testErr,
+			err:       "failed to get the latest block: test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			dbMock.On("GetLastProcessedBlock", mock.Anything, string(L1SyncTask)).
+				Return(tt.processed, tt.dbErr).Once()
+
+			ethermanMock := mocks.NewEtherman(t)
+			if tt.dbErr == nil {
+				var header *ethTypes.Header
+				if tt.headerErr == nil {
+					header = &ethTypes.Header{Number: new(big.Int).SetUint64(tt.head)}
+				}
+
+				ethermanMock.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, tt.headerErr).Once()
+			}
+
+			bs := &BatchSynchronizer{
+				db:     dbMock,
+				client: ethermanMock,
+			}
+
+			lag, err := bs.SyncLag(context.Background())
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.lag, lag)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package synchronizer
+
+import (
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+)
+
+// observeResolution observes the resolution time of the given keys whose data was stored at the given time.
+// Keys with an unknown enqueue time are skipped
+func observeResolution(keys []types.BatchKey, now time.Time) {
+	for _, key := range keys {
+		if seconds, ok := resolutionSeconds(key, now); ok {
+			resolutionTime.Observe(seconds)
+		}
+	}
+}
+
+// resolutionSeconds returns the seconds since the given key was enqueued, if its enqueue time is known
+func resolutionSeconds(key types.BatchKey, now time.Time) (float64, bool) {
+	if key.EnqueuedAt.IsZero() {
+		return 0, false
+	}
+
+	// The enqueue time comes from the database clock, which may be slightly ahead
+	if now.Before(key.EnqueuedAt) {
+		return 0, true
+	}
+
+	return now.Sub(key.EnqueuedAt).Seconds(), true
+}
@@ -0,0 +1,49 @@
+package synchronizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_resolutionSeconds(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		name       string
+		enqueuedAt time.Time
+		seconds    float64
+		known      bool
+	}{
+		{
+			name: "unknown enqueue time",
+		},
+		{
+			name:       "enqueued in the past",
+			enqueuedAt: now.Add(-90 * time.Second),
+			seconds:    90,
+			known:      true,
+		},
+		{
+			name:       "enqueue time ahead of the local clock",
+			enqueuedAt: now.Add(time.Second),
+			known:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			seconds, known := resolutionSeconds(types.BatchKey{Number: 1, EnqueuedAt: tt.enqueuedAt}, now)
+			require.Equal(t, tt.known, known)
+			require.Equal(t, tt.seconds, seconds)
+		})
+	}
+}
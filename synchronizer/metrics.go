@@ -0,0 +1,117 @@
+package synchronizer
+
+import (
+	"github.com/0xPolygon/cdk-data-availability/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsSubsystem = "synchronizer"
+
+	resolvedFetched = "fetched"
+	resolvedSkipped = "skipped"
+
+	outcomeFirstTry = "first_try"
+	outcomeRetried  = "retried"
+)
+
+var (
+	reconciliationGaps = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "reconciliation_gaps",
+		Help:      "Number of batches found neither stored nor queued by the last startup reconciliation",
+	})
+
+	resolveQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "resolve_queue_depth",
+		Help:      "Number of discovered batches waiting to be resolved",
+	})
+
+	syncLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sync_lag_blocks",
+		Help:      "Number of L1 blocks the synchronizer is behind the L1 head",
+	})
+
+	queuedBatches = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "queued_batches_total",
+		Help:      "Number of discovered batches newly queued to be resolved, not counting the ones already queued",
+	})
+
+	resolutionTime = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "resolution_seconds",
+		Help:      "Time from a batch being queued to be resolved until its data is stored",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	})
+
+	deadLetteredBatches = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "dead_lettered_batches_total",
+		Help:      "Number of batch keys moved to the failed batches after too many failed resolution attempts",
+	})
+
+	prefetchMissingBatches = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "prefetch_missing_batches",
+		Help:      "Number of the most recent batches with no data stored yet, as of the last prefetch",
+	})
+
+	droppedHookNotifications = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "dropped_hook_notifications_total",
+		Help:      "Number of resolved batch notifications dropped because the hook could not keep up",
+	})
+
+	resolvedBatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "resolved_batches_total",
+		Help:      "Number of missing batches resolved, either fetched or skipped because their data was already stored",
+	}, []string{"source"})
+
+	resolveAttemptsCount = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "resolve_attempts",
+		Help:      "Number of attempts it took to resolve a missing batch, including the ones before a restart",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 8),
+	})
+
+	resolutionOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "resolution_outcomes_total",
+		Help:      "Number of missing batches resolved, either on the first attempt or after being retried",
+	}, []string{"outcome"})
+
+	writesPaused = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "writes_paused",
+		Help:      "Whether the offchain data writes are paused after consecutive failures (1) or not (0)",
+	})
+
+	writeCircuitTrips = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: metricsSubsystem,
+		Name:      "write_circuit_trips_total",
+		Help:      "Number of times the offchain data writes were paused after consecutive failures",
+	})
+)
+
+func init() {
+	metrics.Register(reconciliationGaps, resolveQueueDepth, syncLag, queuedBatches, resolutionTime, deadLetteredBatches,
+		prefetchMissingBatches, droppedHookNotifications, resolvedBatches, resolveAttemptsCount,
+		resolutionOutcomes, writesPaused, writeCircuitTrips)
+}
@@ -0,0 +1,92 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/log"
+)
+
+// prefetchPageSize is the number of values of a batch read from the database at once while prefetching
+const prefetchPageSize = 100
+
+// notifyPrefetch hands the last discovered batch number over to the prefetcher. Only the latest one matters,
+// so a batch number the prefetcher has not picked up yet is replaced instead of blocking the discovery
+func (bs *BatchSynchronizer) notifyPrefetch(batchNum uint64) {
+	if bs.prefetch == nil {
+		return
+	}
+
+	// The discovery is the only sender, so the channel always has room once drained
+	select {
+	case <-bs.prefetch:
+	default:
+	}
+
+	bs.prefetch <- batchNum
+}
+
+// prefetchRecent reads the offchain data of the most recent batches every time new batches are discovered,
+// so it is in the database cache when clients request it shortly after it is sequenced
+func (bs *BatchSynchronizer) prefetchRecent(ctx context.Context) {
+	log.Infof("starting prefetcher of the last %d batches", bs.prefetchWindow)
+	for {
+		select {
+		case batchNum := <-bs.prefetch:
+			if _, err := bs.prefetchBatches(ctx, batchNum); err != nil {
+				log.Errorf("failed to prefetch the batches up to %d: %v", batchNum, err)
+			}
+		case <-bs.stop:
+			return
+		}
+	}
+}
+
+// prefetchBatches reads the offchain data of the prefetch window of batches ending at the given batch number.
+// It returns the number of those batches with no data stored yet, which is also exposed
+func (bs *BatchSynchronizer) prefetchBatches(parentCtx context.Context, lastBatch uint64) (uint64, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, bs.rpcTimeout)
+	defer cancel()
+
+	firstBatch := uint64(1)
+	if lastBatch > bs.prefetchWindow {
+		firstBatch = lastBatch - bs.prefetchWindow + 1
+	}
+
+	var missing uint64
+	for batchNum := firstBatch; batchNum <= lastBatch; batchNum++ {
+		stored, err := bs.prefetchBatch(ctx, batchNum)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read the offchain data of batch %d: %w", batchNum, err)
+		}
+
+		if !stored {
+			missing++
+		}
+	}
+
+	prefetchMissingBatches.Set(float64(missing))
+
+	if missing > 0 {
+		log.Debugf("%d of the batches from %d to %d are not stored yet", missing, firstBatch, lastBatch)
+	}
+
+	return missing, nil
+}
+
+// prefetchBatch reads all the offchain data stored for the given batch page by page,
+// and returns whether any was stored
+func (bs *BatchSynchronizer) prefetchBatch(ctx context.Context, batchNum uint64) (bool, error) {
+	var read uint64
+	for offset := uint(0); ; offset += prefetchPageSize {
+		page, total, err := bs.db.ListOffChainDataByBatch(ctx, batchNum, offset, prefetchPageSize)
+		if err != nil {
+			return false, err
+		}
+
+		read += uint64(len(page))
+		if len(page) < prefetchPageSize || read >= total {
+			return read > 0, nil
+		}
+	}
+}
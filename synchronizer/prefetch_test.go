@@ -0,0 +1,111 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSynchronizer_NotifyPrefetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps the latest batch", func(t *testing.T) {
+		t.Parallel()
+
+		bs := &BatchSynchronizer{prefetch: make(chan uint64, 1)}
+		bs.notifyPrefetch(5)
+		bs.notifyPrefetch(7)
+
+		require.Equal(t, uint64(7), <-bs.prefetch)
+	})
+
+	t.Run("prefetch disabled", func(t *testing.T) {
+		t.Parallel()
+
+		bs := &BatchSynchronizer{}
+		bs.notifyPrefetch(5)
+	})
+}
+
+func TestBatchSynchronizer_PrefetchBatches(t *testing.T) {
+	t.Parallel()
+
+	page := make([]types.OffChainData, prefetchPageSize)
+	for i := range page {
+		page[i] = types.OffChainData{Key: common.BigToHash(common.Big1), Value: []byte("value")}
+	}
+
+	tests := []struct {
+		name      string
+		window    uint64
+		lastBatch uint64
+		mock      func(*mocks.DB)
+		missing   uint64
+		err       string
+	}{
+		{
+			name:      "recent batches read",
+			window:    3,
+			lastBatch: 5,
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(3), uint(0), uint(prefetchPageSize)).
+					Return(page, uint64(prefetchPageSize+1), nil).Once()
+				dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(3), uint(prefetchPageSize), uint(prefetchPageSize)).
+					Return(page[:1], uint64(prefetchPageSize+1), nil).Once()
+				// batch 4 is not stored yet
+				dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(4), uint(0), uint(prefetchPageSize)).
+					Return([]types.OffChainData{}, uint64(0), nil).Once()
+				dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(5), uint(0), uint(prefetchPageSize)).
+					Return(page[:2], uint64(2), nil).Once()
+			},
+			missing: 1,
+		},
+		{
+			name:      "window larger than the batches",
+			window:    10,
+			lastBatch: 1,
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(1), uint(0), uint(prefetchPageSize)).
+					Return(page[:1], uint64(1), nil).Once()
+			},
+		},
+		{
+			name:      "read fails",
+			window:    1,
+			lastBatch: 5,
+			mock: func(dbMock *mocks.DB) {
+				dbMock.On("ListOffChainDataByBatch", mock.Anything, uint64(5), uint(0), uint(prefetchPageSize)).
+					Return(nil, uint64(0), errors.New("test error")).Once()
+			},
+			err: "failed to read the offchain data of batch 5: test error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dbMock := mocks.NewDB(t)
+			tt.mock(dbMock)
+
+			bs := &BatchSynchronizer{db: dbMock, prefetchWindow: tt.window}
+
+			missing, err := bs.prefetchBatches(context.Background(), tt.lastBatch)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.missing, missing)
+		})
+	}
+}
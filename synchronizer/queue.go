@@ -0,0 +1,82 @@
+package synchronizer
+
+import (
+	"sync"
+)
+
+// resolveQueue keeps track of the discovered batches that are waiting to be resolved. It is bounded,
+// so the discovery of new batches pauses while the resolver is saturated instead of growing the
+// missing batches without limit. A nil queue is unbounded
+type resolveQueue struct {
+	mu       sync.Mutex
+	depth    uint64
+	capacity uint64
+}
+
+// newResolveQueue creates a queue of the given capacity, 0 means unbounded
+func newResolveQueue(capacity uint64) *resolveQueue {
+	return &resolveQueue{capacity: capacity}
+}
+
+// saturated returns whether the queue is full, so no new batches should be discovered
+func (q *resolveQueue) saturated() bool {
+	if q == nil || q.capacity == 0 {
+		return false
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.depth >= q.capacity
+}
+
+// enqueue accounts for the given number of discovered batches
+func (q *resolveQueue) enqueue(n int) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.setDepth(q.depth + uint64(n))
+}
+
+// done accounts for the given number of batches that are no longer waiting to be resolved
+func (q *resolveQueue) done(n int) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if uint64(n) > q.depth {
+		q.setDepth(0)
+	} else {
+		q.setDepth(q.depth - uint64(n))
+	}
+}
+
+// sync corrects the depth with the number of missing batches read from the database.
+// If the read was complete, the depth is exactly that number, otherwise it is at least that number
+func (q *resolveQueue) sync(read int, complete bool) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if complete {
+		q.setDepth(uint64(read))
+	} else {
+		q.setDepth(max(q.depth, uint64(read)))
+	}
+}
+
+// setDepth sets the depth of the queue and exposes it. The lock must be held
+func (q *resolveQueue) setDepth(depth uint64) {
+	q.depth = depth
+	resolveQueueDepth.Set(float64(depth))
+}
@@ -0,0 +1,82 @@
+package synchronizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil queue is unbounded", func(t *testing.T) {
+		t.Parallel()
+
+		var q *resolveQueue
+		q.enqueue(10)
+		q.done(5)
+		q.sync(100, true)
+		require.False(t, q.saturated())
+	})
+
+	t.Run("zero capacity is unbounded", func(t *testing.T) {
+		t.Parallel()
+
+		q := newResolveQueue(0)
+		q.enqueue(1000)
+		require.False(t, q.saturated())
+	})
+
+	t.Run("saturates at capacity", func(t *testing.T) {
+		t.Parallel()
+
+		q := newResolveQueue(10)
+		q.enqueue(9)
+		require.False(t, q.saturated())
+
+		q.enqueue(1)
+		require.True(t, q.saturated())
+
+		q.done(1)
+		require.False(t, q.saturated())
+
+		q.done(100)
+		require.Equal(t, uint64(0), q.depth)
+	})
+
+	t.Run("synced with the database", func(t *testing.T) {
+		t.Parallel()
+
+		q := newResolveQueue(10)
+		q.enqueue(10)
+
+		// a complete read is the exact depth
+		q.sync(3, true)
+		require.Equal(t, uint64(3), q.depth)
+
+		// an incomplete read is a lower bound
+		q.sync(2, false)
+		require.Equal(t, uint64(3), q.depth)
+
+		q.sync(5, false)
+		require.Equal(t, uint64(5), q.depth)
+	})
+}
+
+func TestBatchSynchronizer_FilterEvents_Saturated(t *testing.T) {
+	t.Parallel()
+
+	queue := newResolveQueue(1)
+	queue.enqueue(1)
+
+	// neither L1 nor the database are queried while the resolver is saturated
+	batchSynchronizer := &BatchSynchronizer{
+		db:     mocks.NewDB(t),
+		client: mocks.NewEtherman(t),
+		queue:  queue,
+	}
+
+	require.NoError(t, batchSynchronizer.filterEvents(context.Background()))
+}
@@ -0,0 +1,170 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const reconcileTimeout = time.Minute
+
+// SequenceKeysFinder returns the keys of the data committed on L1 for every batch of the sequence that contains
+// a given batch, by batch number
+type SequenceKeysFinder interface {
+	SequenceKeys(ctx context.Context, batchNum uint64) (map[uint64]common.Hash, error)
+}
+
+// Reconcile samples the range of stored batches for gaps, which are batches in scope whose data committed on L1
+// is neither stored nor queued as missing although the sync state claims to have processed them. A key stores
+// one row only, so the data of a batch may be stored under another batch committing the same data, which is
+// why the committed keys are checked rather than the batch numbers. Gaps are reported with a warning and a
+// metric. If resync is enabled, the L1 sync task is reset so the synchronizer queues them again.
+// It returns the number of gaps found
+func Reconcile(
+	parentCtx context.Context,
+	db db.DB,
+	keys SequenceKeysFinder,
+	cfg config.ReconciliationConfig,
+	scopeCfg config.BatchScopeConfig,
+) (uint64, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, reconcileTimeout)
+	defer cancel()
+
+	scope, err := newBatchScope(scopeCfg)
+	if err != nil {
+		return 0, err
+	}
+
+	first, last, err := db.GetBatchNumRange(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the range of stored batches: %w", err)
+	}
+
+	if last == 0 {
+		return 0, nil
+	}
+
+	var (
+		gaps      uint64
+		committed = make(map[uint64]common.Hash)
+	)
+
+	for _, window := range sampleWindows(first, last, uint64(cfg.Samples), uint64(cfg.SampleSize)) {
+		nums, err := db.GetDistinctBatchNums(ctx, window[0], window[1])
+		if err != nil {
+			return 0, fmt.Errorf("failed to get the batch numbers between %d and %d: %w", window[0], window[1], err)
+		}
+
+		found := make(map[uint64]struct{}, len(nums))
+		for _, num := range nums {
+			found[num] = struct{}{}
+		}
+
+		var missing uint64
+		for batchNum := window[0]; batchNum <= window[1]; batchNum++ {
+			if _, ok := found[batchNum]; ok || !scope.contains(batchNum) {
+				continue
+			}
+
+			gap, err := isGap(ctx, db, keys, committed, batchNum)
+			if err != nil {
+				return 0, err
+			}
+
+			if gap {
+				missing++
+			}
+		}
+
+		if missing > 0 {
+			log.Warnf("found %d batches between %d and %d that are neither stored nor queued", missing, window[0], window[1])
+			gaps += missing
+		}
+	}
+
+	reconciliationGaps.Set(float64(gaps))
+
+	if gaps == 0 {
+		return 0, nil
+	}
+
+	log.Errorf("RECONCILIATION FAILED: %d sampled batches are neither stored nor queued, stored data is incomplete", gaps)
+
+	if cfg.Resync {
+		log.Warn("resetting the L1 sync task to queue the missing batches again")
+
+		if err = setStartBlock(ctx, db, 0, L1SyncTask); err != nil {
+			return gaps, fmt.Errorf("failed to reset the L1 sync task: %w", err)
+		}
+	}
+
+	return gaps, nil
+}
+
+// isGap returns whether the data committed for the given batch, which has no data stored nor queued under its
+// number, is neither stored nor queued under any batch. The committed keys are cached by batch number, so the
+// sequence of the batches is only looked up once. A batch whose sequence is not found cannot be checked, and
+// is not counted as a gap
+func isGap(
+	ctx context.Context, store db.DB, keys SequenceKeysFinder, committed map[uint64]common.Hash, batchNum uint64,
+) (bool, error) {
+	key, ok := committed[batchNum]
+	if !ok {
+		sequenceKeys, err := keys.SequenceKeys(ctx, batchNum)
+		if errors.Is(err, ErrSequenceNotFound) {
+			log.Debugf("sequence of batch %d not found, its data cannot be reconciled", batchNum)
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("failed to get the committed key of batch %d: %w", batchNum, err)
+		}
+
+		for num, k := range sequenceKeys {
+			committed[num] = k
+		}
+
+		if key, ok = committed[batchNum]; !ok {
+			return false, nil
+		}
+	}
+
+	stored, err := store.OffChainDataExists(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if the data of batch %d is stored: %w", batchNum, err)
+	}
+
+	if stored {
+		return false, nil
+	}
+
+	if _, err = store.GetMissingBatchKey(ctx, key); err == nil {
+		return false, nil
+	} else if !errors.Is(err, db.ErrStateNotSynchronized) {
+		return false, fmt.Errorf("failed to check if the data of batch %d is queued: %w", batchNum, err)
+	}
+
+	return true, nil
+}
+
+// sampleWindows splits the inclusive range of batches into evenly spread windows of the given size.
+// The whole range is a single window if the samples would cover it anyway
+func sampleWindows(first, last, samples, size uint64) [][2]uint64 {
+	total := last - first + 1
+	if samples == 0 || size == 0 || samples*size >= total {
+		return [][2]uint64{{first, last}}
+	}
+
+	step := total / samples
+	windows := make([][2]uint64, 0, samples)
+	for i := uint64(0); i < samples; i++ {
+		start := first + i*step
+		windows = append(windows, [2]uint64{start, min(start+size-1, last)})
+	}
+
+	return windows
+}
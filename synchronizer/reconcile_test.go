@@ -0,0 +1,176 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceKeysFinderFunc func(ctx context.Context, batchNum uint64) (map[uint64]common.Hash, error)
+
+func (f sequenceKeysFinderFunc) SequenceKeys(ctx context.Context, batchNum uint64) (map[uint64]common.Hash, error) {
+	return f(ctx, batchNum)
+}
+
+func Test_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.ReconciliationConfig{Samples: 2, SampleSize: 3}
+
+	// batches 1 to 3 and 6 to 8 are committed in two sequences
+	sequences := map[uint64]map[uint64]common.Hash{
+		1: {1: common.HexToHash("0x1"), 2: common.HexToHash("0x2"), 3: common.HexToHash("0x3")},
+		6: {6: common.HexToHash("0x6"), 7: common.HexToHash("0x7"), 8: common.HexToHash("0x8")},
+	}
+
+	newFinder := func(t *testing.T, expectedCalls int) SequenceKeysFinder {
+		t.Helper()
+
+		var calls int
+		t.Cleanup(func() { require.Equal(t, expectedCalls, calls) })
+
+		return sequenceKeysFinderFunc(func(_ context.Context, batchNum uint64) (map[uint64]common.Hash, error) {
+			calls++
+			for _, keys := range sequences {
+				if _, ok := keys[batchNum]; ok {
+					return keys, nil
+				}
+			}
+
+			return nil, ErrSequenceNotFound
+		})
+	}
+
+	t.Run("nothing stored", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(0), uint64(0), nil).Once()
+
+		gaps, err := Reconcile(context.Background(), dbMock, newFinder(t, 0), cfg, config.BatchScopeConfig{})
+		require.NoError(t, err)
+		require.Zero(t, gaps)
+	})
+
+	t.Run("no gaps", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(1), uint64(10), nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(1), uint64(3)).Return([]uint64{1, 2, 3}, nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(6), uint64(8)).Return([]uint64{6, 7, 8}, nil).Once()
+
+		gaps, err := Reconcile(context.Background(), dbMock, newFinder(t, 0), cfg, config.BatchScopeConfig{})
+		require.NoError(t, err)
+		require.Zero(t, gaps)
+	})
+
+	t.Run("gaps found with resync", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(1), uint64(10), nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(1), uint64(3)).Return([]uint64{1, 3}, nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(6), uint64(8)).Return([]uint64{8}, nil).Once()
+		for _, key := range []common.Hash{sequences[1][2], sequences[6][6], sequences[6][7]} {
+			dbMock.On("OffChainDataExists", mock.Anything, key).Return(false, nil).Once()
+			dbMock.On("GetMissingBatchKey", mock.Anything, key).Return(nil, db.ErrStateNotSynchronized).Once()
+		}
+		dbMock.On("StoreLastProcessedBlock", mock.Anything, uint64(0), string(L1SyncTask)).Return(nil).Once()
+
+		resyncCfg := cfg
+		resyncCfg.Resync = true
+
+		gaps, err := Reconcile(context.Background(), dbMock, newFinder(t, 2), resyncCfg, config.BatchScopeConfig{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), gaps)
+	})
+
+	t.Run("committed data stored or queued under another batch", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(1), uint64(10), nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(1), uint64(3)).Return([]uint64{1, 3}, nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(6), uint64(8)).Return([]uint64{8}, nil).Once()
+		dbMock.On("OffChainDataExists", mock.Anything, sequences[1][2]).Return(true, nil).Once()
+		dbMock.On("OffChainDataExists", mock.Anything, sequences[6][6]).Return(false, nil).Once()
+		dbMock.On("GetMissingBatchKey", mock.Anything, sequences[6][6]).
+			Return(&types.BatchKey{Number: 4, Hash: sequences[6][6]}, nil).Once()
+		dbMock.On("OffChainDataExists", mock.Anything, sequences[6][7]).Return(false, nil).Once()
+		dbMock.On("GetMissingBatchKey", mock.Anything, sequences[6][7]).
+			Return(&types.BatchKey{Number: 7, Hash: sequences[6][7]}, nil).Once()
+
+		resyncCfg := cfg
+		resyncCfg.Resync = true
+
+		gaps, err := Reconcile(context.Background(), dbMock, newFinder(t, 2), resyncCfg, config.BatchScopeConfig{})
+		require.NoError(t, err)
+		require.Zero(t, gaps)
+	})
+
+	t.Run("batches out of scope or not found on L1", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(1), uint64(10), nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(1), uint64(3)).Return([]uint64{1, 2, 3}, nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(6), uint64(8)).Return([]uint64{}, nil).Once()
+
+		sequencesMissing := sequenceKeysFinderFunc(func(context.Context, uint64) (map[uint64]common.Hash, error) {
+			return nil, ErrSequenceNotFound
+		})
+
+		resyncCfg := cfg
+		resyncCfg.Resync = true
+
+		gaps, err := Reconcile(
+			context.Background(), dbMock, sequencesMissing, resyncCfg, config.BatchScopeConfig{MaxBatch: 6},
+		)
+		require.NoError(t, err)
+		require.Zero(t, gaps)
+	})
+
+	t.Run("error getting the committed keys", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(1), uint64(3), nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(1), uint64(3)).Return([]uint64{1, 3}, nil).Once()
+
+		failing := sequenceKeysFinderFunc(func(context.Context, uint64) (map[uint64]common.Hash, error) {
+			return nil, errors.New("test error")
+		})
+
+		_, err := Reconcile(context.Background(), dbMock, failing, cfg, config.BatchScopeConfig{})
+		require.EqualError(t, err, "failed to get the committed key of batch 2: test error")
+	})
+
+	t.Run("error getting batch numbers", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDB(t)
+		dbMock.On("GetBatchNumRange", mock.Anything).Return(uint64(1), uint64(4), nil).Once()
+		dbMock.On("GetDistinctBatchNums", mock.Anything, uint64(1), uint64(4)).
+			Return(nil, errors.New("test error")).Once()
+
+		_, err := Reconcile(context.Background(), dbMock, newFinder(t, 0), cfg, config.BatchScopeConfig{})
+		require.EqualError(t, err, "failed to get the batch numbers between 1 and 4: test error")
+	})
+}
+
+func Test_sampleWindows(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, [][2]uint64{{1, 5}}, sampleWindows(1, 5, 2, 3))
+	require.Equal(t, [][2]uint64{{1, 10}}, sampleWindows(1, 10, 0, 3))
+	require.Equal(t, [][2]uint64{{1, 2}, {34, 35}, {67, 68}}, sampleWindows(1, 100, 3, 2))
+}
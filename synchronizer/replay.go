@@ -0,0 +1,252 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	bananaValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/banana/polygonvalidiumetrog"
+	"github.com/0xPolygon/cdk-data-availability/db"
+	"github.com/0xPolygon/cdk-data-availability/etherman"
+	"github.com/0xPolygon/cdk-data-availability/log"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxReplayBatches is the maximum number of batches that can be replayed at once
+const maxReplayBatches = 1000
+
+// ErrAccInputHashChainGap indicates consecutive sequences of a range do not sequence consecutive batches
+var ErrAccInputHashChainGap = errors.New("accInputHash chain gap")
+
+// AccInputHashReplay is the result of replaying the stored data of a sequence
+type AccInputHashReplay struct {
+	TxHash     common.Hash
+	FirstBatch uint64
+	LastBatch  uint64
+
+	// Committed is the accInputHash committed on L1 for the sequence
+	Committed common.Hash
+
+	// Computed is the accInputHash computed from the stored data
+	Computed common.Hash
+
+	// Err is set when the sequence could not be replayed, e.g. because the stored data is incomplete
+	Err error
+}
+
+// Matches returns true if the stored data produces the accInputHash committed on L1
+func (r AccInputHashReplay) Matches() bool {
+	return r.Err == nil && r.Committed == r.Computed
+}
+
+// AccInputHashVerifier replays the stored data of sequenced batches to verify
+// that it produces the accInputHash committed on L1
+type AccInputHashVerifier struct {
+	db         db.BlobStore
+	em         etherman.Etherman
+	startBlock uint64
+}
+
+// NewAccInputHashVerifier creates a new AccInputHashVerifier that looks for sequences from the given L1 block
+func NewAccInputHashVerifier(db db.BlobStore, em etherman.Etherman, startBlock uint64) *AccInputHashVerifier {
+	return &AccInputHashVerifier{
+		db:         db,
+		em:         em,
+		startBlock: startBlock,
+	}
+}
+
+// Verify replays the Banana sequences whose last batch is in the given inclusive range of batches
+func (v *AccInputHashVerifier) Verify(ctx context.Context, from, to uint64) ([]AccInputHashReplay, error) {
+	events, err := v.sequencesEndingIn(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// committed keeps the accInputHash committed for the last batch of every replayed sequence,
+	// which is the old accInputHash of the next sequence
+	committed := make(map[uint64]common.Hash, len(events))
+
+	replays := make([]AccInputHashReplay, 0, len(events))
+	for _, event := range events {
+		replay := v.replay(ctx, event, committed)
+		if replay.Committed != (common.Hash{}) {
+			committed[replay.LastBatch] = replay.Committed
+		}
+
+		if !replay.Matches() {
+			log.Warnf("accInputHash replay of batches %d to %d diverged. Committed: %s, computed: %s, error: %v",
+				replay.FirstBatch, replay.LastBatch, replay.Committed.Hex(), replay.Computed.Hex(), replay.Err)
+		}
+
+		replays = append(replays, replay)
+	}
+
+	return replays, nil
+}
+
+// VerifyChain replays the Banana sequences whose last batch is in the given inclusive range of batches as a
+// single chain: the old accInputHash of every sequence is the one computed from the stored data of the previous
+// sequence, which must end right before it. Only the old accInputHash of the first sequence is read from L1.
+// It returns the replay of the first sequence breaking the chain, or nil if the whole chain matches L1
+func (v *AccInputHashVerifier) VerifyChain(ctx context.Context, from, to uint64) (*AccInputHashReplay, error) {
+	events, err := v.sequencesEndingIn(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// computed keeps the accInputHash computed for the last batch of every chained sequence
+	computed := make(map[uint64]common.Hash, len(events))
+
+	for i, event := range events {
+		replay := v.replay(ctx, event, computed)
+
+		// A sequence whose calldata could not be read has no first batch to check the gap with
+		if i > 0 && replay.FirstBatch > 0 && replay.FirstBatch != events[i-1].NumBatch+1 {
+			replay.Err = fmt.Errorf("%w: sequence starting at batch %d follows the one ending at batch %d",
+				ErrAccInputHashChainGap, replay.FirstBatch, events[i-1].NumBatch)
+		}
+
+		if !replay.Matches() {
+			log.Warnf("accInputHash chain from batch %d to %d breaks at batches %d to %d. Committed: %s, computed: %s, "+
+				"error: %v", from, to, replay.FirstBatch, replay.LastBatch, replay.Committed.Hex(), replay.Computed.Hex(),
+				replay.Err)
+
+			return &replay, nil
+		}
+
+		computed[replay.LastBatch] = replay.Computed
+	}
+
+	return nil, nil
+}
+
+// sequencesEndingIn returns the events of the Banana sequences whose last batch is in the given inclusive range
+// of batches, ordered by their last batch
+func (v *AccInputHashVerifier) sequencesEndingIn(
+	ctx context.Context,
+	from, to uint64,
+) ([]*bananaValidium.PolygonvalidiumetrogSequenceBatches, error) {
+	if to < from || to-from >= maxReplayBatches {
+		return nil, fmt.Errorf("invalid batch range, at most %d batches can be replayed", maxReplayBatches)
+	}
+
+	numBatches := make([]uint64, 0, to-from+1)
+	for num := from; num <= to; num++ {
+		numBatches = append(numBatches, num)
+	}
+
+	events, err := v.em.FilterSequenceBatchesBanana(ctx, v.startBlock, numBatches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter sequence batches events: %w", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].NumBatch < events[j].NumBatch
+	})
+
+	return events, nil
+}
+
+// replay rebuilds the sequence of the given event from the stored data and computes its accInputHash.
+// The old accInputHash is taken from the given hashes of the previous batches if it is there, from L1 otherwise
+func (v *AccInputHashVerifier) replay(
+	ctx context.Context,
+	event *bananaValidium.PolygonvalidiumetrogSequenceBatches,
+	oldAccInputHashes map[uint64]common.Hash,
+) AccInputHashReplay {
+	replay := AccInputHashReplay{
+		TxHash:    event.Raw.TxHash,
+		LastBatch: event.NumBatch,
+	}
+
+	calldata, err := v.sequenceCalldata(ctx, event.Raw.TxHash)
+	if err != nil {
+		replay.Err = err
+		return replay
+	}
+
+	replay.FirstBatch = event.NumBatch - uint64(len(calldata.Batches)) + 1
+	replay.Committed = calldata.ExpectedFinalAccInputHash
+
+	oldAccInputHash, ok := oldAccInputHashes[replay.FirstBatch-1]
+	if !ok {
+		if oldAccInputHash, err = v.committedAccInputHash(ctx, replay.FirstBatch-1); err != nil {
+			replay.Err = fmt.Errorf("failed to get the old accInputHash: %w", err)
+			return replay
+		}
+	}
+
+	keys := make([]common.Hash, len(calldata.Batches))
+	for i, batch := range calldata.Batches {
+		keys[i] = batch.TransactionsHash
+	}
+
+	stored, err := v.db.ListOffChainData(ctx, keys)
+	if err != nil {
+		replay.Err = fmt.Errorf("failed to list the stored data: %w", err)
+		return replay
+	}
+
+	values := make(map[common.Hash][]byte, len(stored))
+	for _, data := range stored {
+		values[data.Key] = data.Value
+	}
+
+	sequence := types.SequenceBanana{
+		Batches:              make([]types.Batch, len(calldata.Batches)),
+		OldAccInputHash:      oldAccInputHash,
+		L1InfoRoot:           event.L1InfoRoot,
+		MaxSequenceTimestamp: types.ArgUint64(calldata.MaxSequenceTimestamp),
+	}
+
+	for i, batch := range calldata.Batches {
+		value, ok := values[batch.TransactionsHash]
+		if !ok {
+			replay.Err = fmt.Errorf("missing stored data of batch %d", replay.FirstBatch+uint64(i))
+			return replay
+		}
+
+		sequence.Batches[i] = types.Batch{
+			L2Data:            value,
+			ForcedGER:         batch.ForcedGlobalExitRoot,
+			ForcedTimestamp:   types.ArgUint64(batch.ForcedTimestamp),
+			Coinbase:          calldata.L2Coinbase,
+			ForcedBlockHashL1: batch.ForcedBlockHashL1,
+		}
+	}
+
+	replay.Computed = sequence.AccInputHash()
+	return replay
+}
+
+// committedAccInputHash returns the accInputHash committed on L1 for the sequence ending at the given batch
+func (v *AccInputHashVerifier) committedAccInputHash(ctx context.Context, lastBatch uint64) (common.Hash, error) {
+	events, err := v.em.FilterSequenceBatchesBanana(ctx, v.startBlock, []uint64{lastBatch})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if len(events) == 0 {
+		return common.Hash{}, fmt.Errorf("no banana sequence ends at batch %d", lastBatch)
+	}
+
+	calldata, err := v.sequenceCalldata(ctx, events[0].Raw.TxHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return calldata.ExpectedFinalAccInputHash, nil
+}
+
+// sequenceCalldata returns the unpacked calldata of the given sequence transaction
+func (v *AccInputHashVerifier) sequenceCalldata(ctx context.Context, txHash common.Hash) (*types.SequenceBananaCalldata, error) {
+	tx, _, err := v.em.GetTx(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sequence tx %s: %w", txHash.Hex(), err)
+	}
+
+	return types.DecodeSequenceBananaCalldata(tx.Data())
+}
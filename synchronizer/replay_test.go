@@ -0,0 +1,327 @@
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	bananaValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/banana/polygonvalidiumetrog"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccInputHashVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	const (
+		startBlock           = uint64(100)
+		maxSequenceTimestamp = uint64(1000)
+	)
+
+	var (
+		l1InfoRoot = common.HexToHash("0x01")
+		coinbase   = common.HexToAddress("0xABCD")
+		values     = [][]byte{[]byte("batch1"), []byte("batch2"), []byte("batch3")}
+	)
+
+	// sequence builds the sequence of the given batch values and its sequenceBatchesValidium tx
+	sequence := func(t *testing.T, old common.Hash, batchValues [][]byte) (common.Hash, *ethTypes.Transaction) {
+		t.Helper()
+
+		seq := types.SequenceBanana{
+			OldAccInputHash:      old,
+			L1InfoRoot:           l1InfoRoot,
+			MaxSequenceTimestamp: types.ArgUint64(maxSequenceTimestamp),
+		}
+
+		for _, value := range batchValues {
+			seq.Batches = append(seq.Batches, types.Batch{L2Data: value, Coinbase: coinbase})
+		}
+
+		calldata, err := seq.EncodeCalldata()
+		require.NoError(t, err)
+
+		return common.BytesToHash(seq.HashToSign()), ethTypes.NewTx(&ethTypes.LegacyTx{
+			GasPrice: big.NewInt(10_000),
+			Gas:      21_000,
+			Data:     calldata,
+		})
+	}
+
+	event := func(numBatch uint64, tx *ethTypes.Transaction) *bananaValidium.PolygonvalidiumetrogSequenceBatches {
+		return &bananaValidium.PolygonvalidiumetrogSequenceBatches{
+			NumBatch:   numBatch,
+			L1InfoRoot: l1InfoRoot,
+			Raw:        ethTypes.Log{TxHash: tx.Hash()},
+		}
+	}
+
+	stored := func(batchValues ...[]byte) []types.OffChainData {
+		data := make([]types.OffChainData, len(batchValues))
+		for i, value := range batchValues {
+			data[i] = types.OffChainData{Key: crypto.Keccak256Hash(value), Value: value}
+		}
+
+		return data
+	}
+
+	keys := func(batchValues ...[]byte) []common.Hash {
+		hashes := make([]common.Hash, len(batchValues))
+		for i, value := range batchValues {
+			hashes[i] = crypto.Keccak256Hash(value)
+		}
+
+		return hashes
+	}
+
+	// the genesis sequence only provides the old accInputHash of batch 1
+	_, genesisTx := sequence(t, common.Hash{}, [][]byte{[]byte("genesis")})
+	genesisData, err := types.DecodeSequenceBananaCalldata(genesisTx.Data())
+	require.NoError(t, err)
+
+	firstHash, firstTx := sequence(t, genesisData.ExpectedFinalAccInputHash, values[:1])
+	secondHash, secondTx := sequence(t, firstHash, values[1:])
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		verifier := NewAccInputHashVerifier(mocks.NewDB(t), mocks.NewEtherman(t), startBlock)
+
+		_, err := verifier.Verify(context.Background(), 2, 1)
+		require.Error(t, err)
+
+		_, err = verifier.Verify(context.Background(), 1, maxReplayBatches+1)
+		require.Error(t, err)
+	})
+
+	t.Run("filter events fails", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1}).
+			Return(nil, errors.New("error")).Once()
+
+		_, err := NewAccInputHashVerifier(mocks.NewDB(t), ethermanMock, startBlock).
+			Verify(context.Background(), 1, 1)
+		require.ErrorContains(t, err, "failed to filter sequence batches events")
+	})
+
+	t.Run("stored data matches", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		dbMock := mocks.NewDB(t)
+
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1, 2, 3}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+				event(3, secondTx), event(1, firstTx),
+			}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{0}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(0, genesisTx)}, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, genesisTx.Hash()).Return(genesisTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, firstTx.Hash()).Return(firstTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, secondTx.Hash()).Return(secondTx, false, nil).Once()
+
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[0])).
+			Return(stored(values[0]), nil).Once()
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[1:]...)).
+			Return(stored(values[1:]...), nil).Once()
+
+		replays, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+			Verify(context.Background(), 1, 3)
+		require.NoError(t, err)
+		require.Len(t, replays, 2)
+
+		require.True(t, replays[0].Matches())
+		require.Equal(t, uint64(1), replays[0].FirstBatch)
+		require.Equal(t, uint64(1), replays[0].LastBatch)
+		require.Equal(t, firstHash, replays[0].Computed)
+
+		require.True(t, replays[1].Matches())
+		require.Equal(t, uint64(2), replays[1].FirstBatch)
+		require.Equal(t, uint64(3), replays[1].LastBatch)
+		require.Equal(t, secondHash, replays[1].Computed)
+	})
+
+	t.Run("stored data diverges", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		dbMock := mocks.NewDB(t)
+
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{3}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(3, secondTx)}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(1, firstTx)}, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, firstTx.Hash()).Return(firstTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, secondTx.Hash()).Return(secondTx, false, nil).Once()
+
+		// the stored value of batch 3 is not the one committed on L1
+		data := stored(values[1:]...)
+		data[1].Value = []byte("tampered")
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[1:]...)).Return(data, nil).Once()
+
+		replays, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+			Verify(context.Background(), 3, 3)
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		require.NoError(t, replays[0].Err)
+		require.False(t, replays[0].Matches())
+		require.Equal(t, secondHash, replays[0].Committed)
+		require.NotEqual(t, secondHash, replays[0].Computed)
+	})
+
+	t.Run("missing stored data", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		dbMock := mocks.NewDB(t)
+
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{3}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(3, secondTx)}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(1, firstTx)}, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, firstTx.Hash()).Return(firstTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, secondTx.Hash()).Return(secondTx, false, nil).Once()
+
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[1:]...)).
+			Return(stored(values[1]), nil).Once()
+
+		replays, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+			Verify(context.Background(), 3, 3)
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		require.False(t, replays[0].Matches())
+		require.ErrorContains(t, replays[0].Err, "missing stored data of batch 3")
+	})
+
+	t.Run("previous sequence not found", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{3}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(3, secondTx)}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1}).
+			Return(nil, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, secondTx.Hash()).Return(secondTx, false, nil).Once()
+
+		replays, err := NewAccInputHashVerifier(mocks.NewDB(t), ethermanMock, startBlock).
+			Verify(context.Background(), 3, 3)
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		require.ErrorContains(t, replays[0].Err, "failed to get the old accInputHash")
+	})
+
+	t.Run("not a banana sequence", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+
+		tx := ethTypes.NewTx(&ethTypes.LegacyTx{Data: []byte{0x01, 0x02, 0x03, 0x04}})
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{3}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(3, tx)}, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, tx.Hash()).Return(tx, false, nil).Once()
+
+		replays, err := NewAccInputHashVerifier(mocks.NewDB(t), ethermanMock, startBlock).
+			Verify(context.Background(), 3, 3)
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+		require.ErrorContains(t, replays[0].Err, "not a banana sequenceBatchesValidium call")
+	})
+	t.Run("chain matches", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		dbMock := mocks.NewDB(t)
+
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1, 2, 3}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+				event(3, secondTx), event(1, firstTx),
+			}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{0}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(0, genesisTx)}, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, genesisTx.Hash()).Return(genesisTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, firstTx.Hash()).Return(firstTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, secondTx.Hash()).Return(secondTx, false, nil).Once()
+
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[0])).
+			Return(stored(values[0]), nil).Once()
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[1:]...)).
+			Return(stored(values[1:]...), nil).Once()
+
+		chainBreak, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+			VerifyChain(context.Background(), 1, 3)
+		require.NoError(t, err)
+		require.Nil(t, chainBreak)
+	})
+
+	t.Run("chain breaks", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		dbMock := mocks.NewDB(t)
+
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1, 2, 3}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+				event(1, firstTx), event(3, secondTx),
+			}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{0}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(0, genesisTx)}, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, genesisTx.Hash()).Return(genesisTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, firstTx.Hash()).Return(firstTx, false, nil).Once()
+
+		// the stored value of batch 1 is not the one committed on L1, which breaks the chain at its sequence
+		// before the next one is read
+		data := stored(values[0])
+		data[0].Value = []byte("tampered")
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[0])).Return(data, nil).Once()
+
+		chainBreak, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+			VerifyChain(context.Background(), 1, 3)
+		require.NoError(t, err)
+		require.NotNil(t, chainBreak)
+		require.NoError(t, chainBreak.Err)
+		require.Equal(t, uint64(1), chainBreak.FirstBatch)
+		require.Equal(t, uint64(1), chainBreak.LastBatch)
+		require.Equal(t, firstHash, chainBreak.Committed)
+		require.NotEqual(t, firstHash, chainBreak.Computed)
+	})
+
+	t.Run("chain gap", func(t *testing.T) {
+		t.Parallel()
+
+		ethermanMock := mocks.NewEtherman(t)
+		dbMock := mocks.NewDB(t)
+
+		// the second sequence is seen ending at batch 4, so batch 2 is never sequenced in the range
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{1, 2, 3, 4}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{
+				event(1, firstTx), event(4, secondTx),
+			}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{0}).
+			Return([]*bananaValidium.PolygonvalidiumetrogSequenceBatches{event(0, genesisTx)}, nil).Once()
+		ethermanMock.On("FilterSequenceBatchesBanana", mock.Anything, startBlock, []uint64{2}).
+			Return(nil, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, genesisTx.Hash()).Return(genesisTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, firstTx.Hash()).Return(firstTx, false, nil).Once()
+		ethermanMock.On("GetTx", mock.Anything, secondTx.Hash()).Return(secondTx, false, nil).Once()
+
+		dbMock.On("ListOffChainData", mock.Anything, keys(values[0])).
+			Return(stored(values[0]), nil).Once()
+
+		chainBreak, err := NewAccInputHashVerifier(dbMock, ethermanMock, startBlock).
+			VerifyChain(context.Background(), 1, 4)
+		require.NoError(t, err)
+		require.NotNil(t, chainBreak)
+		require.ErrorIs(t, chainBreak.Err, ErrAccInputHashChainGap)
+		require.Equal(t, uint64(3), chainBreak.FirstBatch)
+		require.Equal(t, uint64(4), chainBreak.LastBatch)
+	})
+}
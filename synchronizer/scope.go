@@ -0,0 +1,51 @@
+package synchronizer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+)
+
+// batchScope tells which batches the synchronizer resolves and stores. A nil scope holds every batch
+type batchScope struct {
+	min, max uint64
+	ranged   bool
+	batches  map[uint64]struct{}
+}
+
+// newBatchScope returns the scope of the given config, nil if it does not restrict the batches
+func newBatchScope(cfg config.BatchScopeConfig) (*batchScope, error) {
+	if cfg.MinBatch == 0 && cfg.MaxBatch == 0 && len(cfg.Batches) == 0 {
+		return nil, nil
+	}
+
+	if cfg.MaxBatch > 0 && cfg.MaxBatch < cfg.MinBatch {
+		return nil, fmt.Errorf("invalid batch scope: max batch %d is lower than min batch %d", cfg.MaxBatch, cfg.MinBatch)
+	}
+
+	scope := &batchScope{
+		min:     cfg.MinBatch,
+		max:     cfg.MaxBatch,
+		ranged:  cfg.MinBatch > 0 || cfg.MaxBatch > 0,
+		batches: make(map[uint64]struct{}, len(cfg.Batches)),
+	}
+
+	for _, batchNum := range cfg.Batches {
+		scope.batches[batchNum] = struct{}{}
+	}
+
+	return scope, nil
+}
+
+// contains returns whether the given batch is in scope
+func (s *batchScope) contains(batchNum uint64) bool {
+	if s == nil {
+		return true
+	}
+
+	if _, ok := s.batches[batchNum]; ok {
+		return true
+	}
+
+	return s.ranged && batchNum >= s.min && (s.max == 0 || batchNum <= s.max)
+}
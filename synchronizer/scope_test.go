@@ -0,0 +1,119 @@
+package synchronizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/cdk-data-availability/config"
+	"github.com/0xPolygon/cdk-data-availability/mocks"
+	"github.com/0xPolygon/cdk-data-availability/sequencer"
+	"github.com/0xPolygon/cdk-data-availability/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchScope_contains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cfg      config.BatchScopeConfig
+		in       []uint64
+		out      []uint64
+		expected string
+	}{
+		{
+			name: "no scope",
+			in:   []uint64{0, 1, 1000},
+		},
+		{
+			name: "min batch",
+			cfg:  config.BatchScopeConfig{MinBatch: 10},
+			in:   []uint64{10, 1000},
+			out:  []uint64{0, 9},
+		},
+		{
+			name: "min and max batch",
+			cfg:  config.BatchScopeConfig{MinBatch: 10, MaxBatch: 20},
+			in:   []uint64{10, 15, 20},
+			out:  []uint64{9, 21},
+		},
+		{
+			name: "explicit batches",
+			cfg:  config.BatchScopeConfig{Batches: []uint64{3, 7}},
+			in:   []uint64{3, 7},
+			out:  []uint64{0, 4, 8},
+		},
+		{
+			name: "range and explicit batches",
+			cfg:  config.BatchScopeConfig{MinBatch: 10, MaxBatch: 20, Batches: []uint64{3}},
+			in:   []uint64{3, 10, 20},
+			out:  []uint64{4, 21},
+		},
+		{
+			name:     "max batch lower than min batch",
+			cfg:      config.BatchScopeConfig{MinBatch: 20, MaxBatch: 10},
+			expected: "invalid batch scope: max batch 10 is lower than min batch 20",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scope, err := newBatchScope(tt.cfg)
+			if tt.expected != "" {
+				require.EqualError(t, err, tt.expected)
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, batchNum := range tt.in {
+				require.True(t, scope.contains(batchNum), "batch %d should be in scope", batchNum)
+			}
+
+			for _, batchNum := range tt.out {
+				require.False(t, scope.contains(batchNum), "batch %d should be out of scope", batchNum)
+			}
+		})
+	}
+}
+
+func TestBatchSynchronizer_HandleMissingBatchesScope(t *testing.T) {
+	t.Parallel()
+
+	outOfScope := types.BatchKey{Number: 5, Hash: crypto.Keccak256Hash([]byte("batch5"))}
+	inScope := types.BatchKey{Number: 11, Hash: crypto.Keccak256Hash([]byte("batch11"))}
+
+	dbMock := mocks.NewDB(t)
+	sequencerMock := mocks.NewSequencerTracker(t)
+
+	scope, err := newBatchScope(config.BatchScopeConfig{MinBatch: 10})
+	require.NoError(t, err)
+
+	bs := &BatchSynchronizer{
+		db:        dbMock,
+		sequencer: sequencerMock,
+		committee: NewCommitteeMapSafe(),
+		scope:     scope,
+	}
+
+	dbMock.On("GetMissingBatchKeys", mock.Anything, uint(maxUnprocessedBatch)).
+		Return([]types.BatchKey{outOfScope, inScope}, nil).Once()
+	// the key queued before the scope was restricted is dropped without being resolved
+	dbMock.On("DeleteMissingBatchKeys", mock.Anything, []types.BatchKey{outOfScope}).Return(nil).Once()
+	dbMock.On("AllExist", mock.Anything, mock.Anything).Return(noneStored).Once()
+	sequencerMock.On("GetSequenceBatch", mock.Anything, inScope.Number).
+		Return(&sequencer.SeqBatch{Number: 11, BatchL2Data: []byte("batch11")}, nil).Once()
+	txMock := mocks.NewTx(t)
+	dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+	dbMock.On("StoreOffChainDataTx", mock.Anything, mock.Anything, txMock).Return(nil).Once()
+	dbMock.On("DeleteMissingBatchKeysTx", mock.Anything, []types.BatchKey{inScope}, txMock).Return(nil).Once()
+	txMock.On("Commit").Return(nil).Once()
+
+	require.NoError(t, bs.handleMissingBatches(context.Background()))
+}
@@ -2,6 +2,7 @@ package synchronizer
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	dbTypes "github.com/0xPolygon/cdk-data-availability/db"
@@ -43,11 +44,20 @@ func setStartBlock(parentCtx context.Context, db dbTypes.DB, block uint64, syncT
 	return db.StoreLastProcessedBlock(ctx, block, string(syncTask))
 }
 
-func storeMissingBatchKeys(parentCtx context.Context, db dbTypes.DB, keys []types.BatchKey) error {
+func storeMissingBatchKeys(parentCtx context.Context, db dbTypes.DB, keys []types.BatchKey) (uint64, error) {
 	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
 	defer cancel()
 
-	return db.StoreMissingBatchKeys(ctx, keys)
+	return db.InsertMissingBatchKeys(ctx, keys)
+}
+
+func storeL1TxHash(
+	parentCtx context.Context, db dbTypes.DB, from uint64, keys []common.Hash, txHash common.Hash, l1Block uint64,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.StoreL1TxHash(ctx, from, keys, txHash, l1Block)
 }
 
 func getMissingBatchKeys(parentCtx context.Context, db dbTypes.DB) ([]types.BatchKey, error) {
@@ -64,6 +74,34 @@ func deleteMissingBatchKeys(parentCtx context.Context, db dbTypes.DB, keys []typ
 	return db.DeleteMissingBatchKeys(ctx, keys)
 }
 
+func incrementBatchAttempts(parentCtx context.Context, db dbTypes.DB, key types.BatchKey) (uint, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.IncrementBatchAttempts(ctx, key)
+}
+
+func archiveMissingBatchKeys(parentCtx context.Context, db dbTypes.DB, resolved []types.ResolvedBatch) error {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.ArchiveMissingBatchKeys(ctx, resolved)
+}
+
+func pruneResolvedBatches(parentCtx context.Context, db dbTypes.DB, before time.Time) (uint64, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.PruneResolvedBatches(ctx, before)
+}
+
+func storeFailedBatch(parentCtx context.Context, db dbTypes.DB, key types.BatchKey, reason string) error {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.StoreFailedBatch(ctx, key, reason)
+}
+
 func listOffchainData(parentCtx context.Context, db dbTypes.DB, keys []common.Hash) ([]types.OffChainData, error) {
 	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
 	defer cancel()
@@ -71,9 +109,65 @@ func listOffchainData(parentCtx context.Context, db dbTypes.DB, keys []common.Ha
 	return db.ListOffChainData(ctx, keys)
 }
 
+func allExist(parentCtx context.Context, db dbTypes.DB, keys []common.Hash) (bool, []common.Hash, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.AllExist(ctx, keys)
+}
+
+// storeResolvedData stores the given offchain data and removes the keys it resolves from the missing batches with
+// the given function, within a single transaction
+func storeResolvedData(
+	parentCtx context.Context,
+	db dbTypes.DB,
+	data []types.OffChainData,
+	remove func(ctx context.Context, tx dbTypes.Tx) error,
+) error {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	tx, err := db.BeginStateTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin the resolved data transaction: %w", err)
+	}
+
+	if err = db.StoreOffChainDataTx(ctx, data, tx); err == nil {
+		err = remove(ctx, tx)
+	}
+
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("failed to rollback the resolved data transaction: %w", rollbackErr)
+		}
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the resolved data transaction: %w", err)
+	}
+
+	return nil
+}
+
 func storeOffchainData(parentCtx context.Context, db dbTypes.DB, data []types.OffChainData) error {
 	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
 	defer cancel()
 
 	return db.StoreOffChainData(ctx, data)
 }
+
+func markFinalized(parentCtx context.Context, db dbTypes.DB, upToBatch uint64) error {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.MarkFinalized(ctx, upToBatch)
+}
+
+func pruneFinalized(parentCtx context.Context, db dbTypes.DB, upToBatch uint64) (uint64, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, dbTimeout)
+	defer cancel()
+
+	return db.PruneFinalized(ctx, upToBatch)
+}
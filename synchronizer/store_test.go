@@ -143,12 +143,12 @@ func Test_storeMissingBatchKeys(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "StoreMissingBatchKeys returns error",
+			name: "InsertMissingBatchKeys returns error",
 			db: func(t *testing.T) db.DB {
 				t.Helper()
 				mockDB := mocks.NewDB(t)
 
-				mockDB.On("StoreMissingBatchKeys", mock.Anything, testData).Return(testError)
+				mockDB.On("InsertMissingBatchKeys", mock.Anything, testData).Return(uint64(0), testError)
 
 				return mockDB
 			},
@@ -161,7 +161,7 @@ func Test_storeMissingBatchKeys(t *testing.T) {
 				t.Helper()
 				mockDB := mocks.NewDB(t)
 
-				mockDB.On("StoreMissingBatchKeys", mock.Anything, testData).Return(nil)
+				mockDB.On("InsertMissingBatchKeys", mock.Anything, testData).Return(uint64(1), nil)
 
 				return mockDB
 			},
@@ -176,10 +176,11 @@ func Test_storeMissingBatchKeys(t *testing.T) {
 
 			testDB := tt.db(t)
 
-			if err := storeMissingBatchKeys(context.Background(), testDB, tt.keys); tt.wantErr {
+			if inserted, err := storeMissingBatchKeys(context.Background(), testDB, tt.keys); tt.wantErr {
 				require.ErrorIs(t, err, testError)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, uint64(len(tt.keys)), inserted)
 			}
 		})
 	}
@@ -320,7 +321,7 @@ func Test_storeOffchainData(t *testing.T) {
 		name    string
 		db      func(t *testing.T) db.DB
 		data    []types.OffChainData
-		wantErr bool
+		wantErr error
 	}{
 		{
 			name: "StoreOffChainData returns error",
@@ -333,7 +334,7 @@ func Test_storeOffchainData(t *testing.T) {
 				return mockDB
 			},
 			data:    testData,
-			wantErr: true,
+			wantErr: testError,
 		},
 		{
 			name: "all good",
@@ -356,11 +357,95 @@ func Test_storeOffchainData(t *testing.T) {
 
 			testDB := tt.db(t)
 
-			if err := storeOffchainData(context.Background(), testDB, tt.data); tt.wantErr {
-				require.ErrorIs(t, err, testError)
+			if err := storeOffchainData(context.Background(), testDB, tt.data); tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_storeResolvedData(t *testing.T) {
+	t.Parallel()
+
+	testError := errors.New("test error")
+	testData := []types.OffChainData{
+		{
+			Key:   common.HexToHash("0x01"),
+			Value: []byte("test data 1"),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		beginErr  error
+		storeErr  error
+		removeErr error
+		commitErr error
+		wantErr   error
+	}{
+		{
+			name:     "begin fails",
+			beginErr: testError,
+			wantErr:  testError,
+		},
+		{
+			name:     "store fails and rolls back",
+			storeErr: testError,
+			wantErr:  testError,
+		},
+		{
+			name:      "remove fails and rolls back",
+			removeErr: testError,
+			wantErr:   testError,
+		},
+		{
+			name:      "commit fails",
+			commitErr: testError,
+			wantErr:   testError,
+		},
+		{
+			name: "all good",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockDB := mocks.NewDB(t)
+			mockTx := mocks.NewTx(t)
+
+			if tt.beginErr != nil {
+				mockDB.On("BeginStateTransaction", mock.Anything).Return(nil, tt.beginErr).Once()
+			} else {
+				mockDB.On("BeginStateTransaction", mock.Anything).Return(mockTx, nil).Once()
+				mockDB.On("StoreOffChainDataTx", mock.Anything, testData, mockTx).Return(tt.storeErr).Once()
+
+				if tt.storeErr != nil || tt.removeErr != nil {
+					mockTx.On("Rollback").Return(nil).Once()
+				} else {
+					mockTx.On("Commit").Return(tt.commitErr).Once()
+				}
+			}
+
+			removed := false
+			err := storeResolvedData(context.Background(), mockDB, testData,
+				func(_ context.Context, tx db.Tx) error {
+					require.Equal(t, mockTx, tx)
+					removed = true
+
+					return tt.removeErr
+				})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 			} else {
 				require.NoError(t, err)
 			}
+
+			require.Equal(t, tt.beginErr == nil && tt.storeErr == nil, removed)
 		})
 	}
 }
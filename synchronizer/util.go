@@ -37,8 +37,29 @@ const (
 	methodIDLen = 4
 )
 
+// SequencedBatch is a batch of the calldata of a sequence
+type SequencedBatch struct {
+	Key common.Hash
+	// Forced is whether the batch is a forced batch, which is sequenced with the timestamp it was forced at
+	Forced bool
+}
+
 // UnpackTxData unpacks the keys in a SequenceBatches event
 func UnpackTxData(txData []byte) ([]common.Hash, error) {
+	batches, err := UnpackSequencedBatches(txData)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]common.Hash, len(batches))
+	for i, batch := range batches {
+		keys[i] = batch.Key
+	}
+	return keys, nil
+}
+
+// UnpackSequencedBatches unpacks the batches in a SequenceBatches event, in calldata order
+func UnpackSequencedBatches(txData []byte) ([]SequencedBatch, error) {
 	methodID := txData[:methodIDLen]
 
 	var (
@@ -88,9 +109,12 @@ func UnpackTxData(txData []byte) ([]common.Hash, error) {
 		return nil, err
 	}
 
-	keys := make([]common.Hash, len(batches))
+	sequenced := make([]SequencedBatch, len(batches))
 	for i, batch := range batches {
-		keys[i] = batch.TransactionsHash
+		sequenced[i] = SequencedBatch{
+			Key:    batch.TransactionsHash,
+			Forced: batch.ForcedTimestamp > 0,
+		}
 	}
-	return keys, nil
+	return sequenced, nil
 }
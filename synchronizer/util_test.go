@@ -2,8 +2,13 @@ package synchronizer
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 
+	etrogValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/etrog/polygonvalidiumetrog"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,3 +23,30 @@ func Test_SequenceBatchesValidiumMethodIDs_Equality(t *testing.T) {
 	require.Equal(t, expectedSequenceBatchesValidiumElderberry, hex.EncodeToString(methodIDSequenceBatchesValidiumElderberry))
 	require.Equal(t, expectedSequenceBatchesValidiumBanana, hex.EncodeToString(methodIDSequenceBatchesValidiumBanana))
 }
+
+func TestUnpackSequencedBatches(t *testing.T) {
+	t.Parallel()
+
+	regular := crypto.Keccak256Hash([]byte("regular"))
+	forced := crypto.Keccak256Hash([]byte("forced"))
+
+	a, err := abi.JSON(strings.NewReader(etrogValidium.PolygonvalidiumetrogABI))
+	require.NoError(t, err)
+
+	method, ok := a.Methods["sequenceBatchesValidium"]
+	require.True(t, ok)
+
+	data, err := method.Inputs.Pack([]etrogValidium.PolygonValidiumEtrogValidiumBatchData{
+		{TransactionsHash: regular},
+		{TransactionsHash: forced, ForcedTimestamp: 1700000000},
+	}, common.HexToAddress("0xABCD"), []byte{})
+	require.NoError(t, err)
+
+	batches, err := UnpackSequencedBatches(append(method.ID, data...))
+	require.NoError(t, err)
+	require.Equal(t, []SequencedBatch{{Key: regular}, {Key: forced, Forced: true}}, batches)
+
+	keys, err := UnpackTxData(append(method.ID, data...))
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{regular, forced}, keys)
+}
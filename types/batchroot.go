@@ -0,0 +1,56 @@
+package types
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BatchRoot is the root of the data stored for a batch. The committee members exchange it to detect
+// a member whose storage silently diverged, without exchanging the data itself
+type BatchRoot struct {
+	BatchNum ArgUint64   `json:"batchNum"`
+	Root     common.Hash `json:"root"`
+
+	// Values is the number of values the root is computed over
+	Values uint64 `json:"values"`
+}
+
+// ComputeBatchRoot returns the Merkle root of the given values of a batch, zero if there are none.
+// The leaves are the keccak of every key along with the hash of its value, ordered by key, so the root does
+// not depend on the order the values were stored in and a value that does not hash to its key changes it.
+// A node without a sibling is carried to the next level as is
+func ComputeBatchRoot(ods []OffChainData) common.Hash {
+	if len(ods) == 0 {
+		return common.Hash{}
+	}
+
+	sorted := make([]OffChainData, len(ods))
+	copy(sorted, ods)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Key.Bytes(), sorted[j].Key.Bytes()) < 0
+	})
+
+	level := make([]common.Hash, len(sorted))
+	for i, od := range sorted {
+		level[i] = crypto.Keccak256Hash(od.Key.Bytes(), crypto.Keccak256(od.Value))
+	}
+
+	for len(level) > 1 {
+		next := make([]common.Hash, 0, (len(level)+1)/2) //nolint:mnd
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+
+			next = append(next, crypto.Keccak256Hash(level[i].Bytes(), level[i+1].Bytes()))
+		}
+
+		level = next
+	}
+
+	return level[0]
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeBatchRoot(t *testing.T) {
+	t.Parallel()
+
+	value := func(v string) OffChainData {
+		return OffChainData{Key: crypto.Keccak256Hash([]byte(v)), Value: []byte(v)}
+	}
+
+	leaf := func(od OffChainData) common.Hash {
+		return crypto.Keccak256Hash(od.Key.Bytes(), crypto.Keccak256(od.Value))
+	}
+
+	a, b, c := value("a"), value("b"), value("c")
+
+	// the leaves are ordered by key
+	sorted := []OffChainData{a, b, c}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key.Cmp(sorted[j].Key) < 0 })
+
+	t.Run("no values", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, common.Hash{}, ComputeBatchRoot(nil))
+	})
+
+	t.Run("single value", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, leaf(a), ComputeBatchRoot([]OffChainData{a}))
+	})
+
+	t.Run("odd node carried to the next level", func(t *testing.T) {
+		t.Parallel()
+
+		pair := crypto.Keccak256Hash(leaf(sorted[0]).Bytes(), leaf(sorted[1]).Bytes())
+		expected := crypto.Keccak256Hash(pair.Bytes(), leaf(sorted[2]).Bytes())
+
+		require.Equal(t, expected, ComputeBatchRoot([]OffChainData{a, b, c}))
+	})
+
+	t.Run("independent of the order", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, ComputeBatchRoot([]OffChainData{a, b, c}), ComputeBatchRoot([]OffChainData{c, a, b}))
+	})
+
+	t.Run("corrupt value changes the root", func(t *testing.T) {
+		t.Parallel()
+
+		corrupt := OffChainData{Key: b.Key, Value: []byte("corrupt")}
+		require.NotEqual(t, ComputeBatchRoot([]OffChainData{a, b, c}), ComputeBatchRoot([]OffChainData{a, corrupt, c}))
+	})
+}
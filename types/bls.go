@@ -0,0 +1,213 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const (
+	// BLSPrivateKeyLen is the length of a serialized BLS private key
+	BLSPrivateKeyLen = fr.Bytes
+
+	// BLSPublicKeyLen is the length of a compressed BLS public key, which is a G1 point
+	BLSPublicKeyLen = bls12381.SizeOfG1AffineCompressed
+
+	// BLSSignatureLen is the length of a compressed BLS signature, which is a G2 point
+	BLSSignatureLen = bls12381.SizeOfG2AffineCompressed
+)
+
+var (
+	// ErrInvalidBLSSignature is returned when a BLS signature does not verify against the given public keys
+	ErrInvalidBLSSignature = errors.New("invalid BLS signature")
+
+	// blsDST is the domain separation tag of the hash to curve, from the proof of possession ciphersuite.
+	// Aggregating signatures over the same message is only safe if every public key proved possession
+	// of its private key when it was registered in the committee, which prevents rogue key attacks
+	blsDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+// BLSPrivateKey is a BLS12-381 private key. Its signatures over the same message can be aggregated into one
+type BLSPrivateKey struct {
+	secret big.Int
+}
+
+// GenerateBLSKey generates a random BLS private key
+func GenerateBLSKey() (*BLSPrivateKey, error) {
+	var secret fr.Element
+	if _, err := secret.SetRandom(); err != nil {
+		return nil, err
+	}
+
+	key := &BLSPrivateKey{}
+	secret.BigInt(&key.secret)
+
+	return key, nil
+}
+
+// BLSPrivateKeyFromBytes decodes a big endian BLS private key
+func BLSPrivateKeyFromBytes(b []byte) (*BLSPrivateKey, error) {
+	if len(b) != BLSPrivateKeyLen {
+		return nil, fmt.Errorf("invalid BLS private key length %d, expected %d", len(b), BLSPrivateKeyLen)
+	}
+
+	var secret fr.Element
+	if err := secret.SetBytesCanonical(b); err != nil {
+		return nil, fmt.Errorf("invalid BLS private key: %w", err)
+	}
+
+	if secret.IsZero() {
+		return nil, errors.New("invalid BLS private key: zero")
+	}
+
+	key := &BLSPrivateKey{}
+	secret.BigInt(&key.secret)
+
+	return key, nil
+}
+
+// Bytes returns the big endian encoding of the private key
+func (k *BLSPrivateKey) Bytes() []byte {
+	return k.secret.FillBytes(make([]byte, BLSPrivateKeyLen))
+}
+
+// PublicKey returns the compressed public key of the private key
+func (k *BLSPrivateKey) PublicKey() []byte {
+	var pub bls12381.G1Affine
+	pub.ScalarMultiplicationBase(&k.secret)
+
+	b := pub.Bytes()
+
+	return b[:]
+}
+
+// SignBLS signs the hashToSign with the given BLS private key
+func SignBLS(key *BLSPrivateKey, hashToSign []byte) ([]byte, error) {
+	point, err := bls12381.HashToG2(hashToSign, blsDST)
+	if err != nil {
+		return nil, err
+	}
+
+	var sig bls12381.G2Affine
+	sig.ScalarMultiplication(&point, &key.secret)
+
+	b := sig.Bytes()
+
+	return b[:], nil
+}
+
+// VerifyBLS checks the signature of the hashToSign against the given public key
+func VerifyBLS(pubKey []byte, hashToSign []byte, sig []byte) error {
+	return VerifyAggregateBLS([][]byte{pubKey}, hashToSign, sig)
+}
+
+// AggregateBLSSignatures aggregates signatures over the same message into a single one
+func AggregateBLSSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+
+	var agg bls12381.G2Jac
+	for i, sig := range sigs {
+		point, err := decodeBLSSignature(sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+
+		var p bls12381.G2Jac
+		agg.AddAssign(p.FromAffine(point))
+	}
+
+	var aggAffine bls12381.G2Affine
+	aggAffine.FromJacobian(&agg)
+
+	b := aggAffine.Bytes()
+
+	return b[:], nil
+}
+
+// VerifyAggregateBLS checks an aggregated signature of the hashToSign against the public keys of all the signers,
+// e.g. the committee members that signed a sequence
+func VerifyAggregateBLS(pubKeys [][]byte, hashToSign []byte, aggSig []byte) error {
+	if len(pubKeys) == 0 {
+		return errors.New("no public keys to verify against")
+	}
+
+	var aggPub bls12381.G1Jac
+	for i, pubKey := range pubKeys {
+		point, err := decodeBLSPublicKey(pubKey)
+		if err != nil {
+			return fmt.Errorf("public key %d: %w", i, err)
+		}
+
+		var p bls12381.G1Jac
+		aggPub.AddAssign(p.FromAffine(point))
+	}
+
+	sig, err := decodeBLSSignature(aggSig)
+	if err != nil {
+		return err
+	}
+
+	msg, err := bls12381.HashToG2(hashToSign, blsDST)
+	if err != nil {
+		return err
+	}
+
+	// e(aggPub, H(m)) == e(g1, sig) <=> e(aggPub, H(m)) * e(-g1, sig) == 1
+	var pub, negG1 bls12381.G1Affine
+	pub.FromJacobian(&aggPub)
+
+	_, _, g1, _ := bls12381.Generators()
+	negG1.Neg(&g1)
+
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{pub, negG1}, []bls12381.G2Affine{msg, *sig})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrInvalidBLSSignature
+	}
+
+	return nil
+}
+
+// decodeBLSPublicKey decodes a compressed public key, checking it is a valid point of the G1 subgroup
+func decodeBLSPublicKey(b []byte) (*bls12381.G1Affine, error) {
+	if len(b) != BLSPublicKeyLen {
+		return nil, fmt.Errorf("invalid BLS public key length %d, expected %d", len(b), BLSPublicKeyLen)
+	}
+
+	var point bls12381.G1Affine
+	if _, err := point.SetBytes(b); err != nil {
+		return nil, fmt.Errorf("invalid BLS public key: %w", err)
+	}
+
+	if point.IsInfinity() || !point.IsInSubGroup() {
+		return nil, errors.New("invalid BLS public key: not in the G1 subgroup")
+	}
+
+	return &point, nil
+}
+
+// decodeBLSSignature decodes a compressed signature, checking it is a valid point of the G2 subgroup
+func decodeBLSSignature(b []byte) (*bls12381.G2Affine, error) {
+	if len(b) != BLSSignatureLen {
+		return nil, fmt.Errorf("invalid BLS signature length %d, expected %d", len(b), BLSSignatureLen)
+	}
+
+	var point bls12381.G2Affine
+	if _, err := point.SetBytes(b); err != nil {
+		return nil, fmt.Errorf("invalid BLS signature: %w", err)
+	}
+
+	if !point.IsInSubGroup() {
+		return nil, errors.New("invalid BLS signature: not in the G2 subgroup")
+	}
+
+	return &point, nil
+}
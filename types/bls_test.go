@@ -0,0 +1,92 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLS(t *testing.T) {
+	t.Parallel()
+
+	hashToSign := crypto.Keccak256([]byte("sequence"))
+
+	keys := make([]*BLSPrivateKey, 3)
+	pubKeys := make([][]byte, len(keys))
+	sigs := make([][]byte, len(keys))
+	for i := range keys {
+		var err error
+		keys[i], err = GenerateBLSKey()
+		require.NoError(t, err)
+
+		pubKeys[i] = keys[i].PublicKey()
+		require.Len(t, pubKeys[i], BLSPublicKeyLen)
+
+		sigs[i], err = SignBLS(keys[i], hashToSign)
+		require.NoError(t, err)
+		require.Len(t, sigs[i], BLSSignatureLen)
+	}
+
+	t.Run("signature verifies", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, VerifyBLS(pubKeys[0], hashToSign, sigs[0]))
+	})
+
+	t.Run("signature of another message or key does not verify", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, VerifyBLS(pubKeys[0], crypto.Keccak256([]byte("other")), sigs[0]), ErrInvalidBLSSignature)
+		require.ErrorIs(t, VerifyBLS(pubKeys[1], hashToSign, sigs[0]), ErrInvalidBLSSignature)
+	})
+
+	t.Run("aggregated signature verifies against all signers", func(t *testing.T) {
+		t.Parallel()
+
+		agg, err := AggregateBLSSignatures(sigs)
+		require.NoError(t, err)
+		require.Len(t, agg, BLSSignatureLen)
+
+		require.NoError(t, VerifyAggregateBLS(pubKeys, hashToSign, agg))
+		require.ErrorIs(t, VerifyAggregateBLS(pubKeys[:2], hashToSign, agg), ErrInvalidBLSSignature)
+	})
+
+	t.Run("malformed inputs are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorContains(t, VerifyBLS(pubKeys[0][:10], hashToSign, sigs[0]), "invalid BLS public key length")
+		require.ErrorContains(t, VerifyBLS(pubKeys[0], hashToSign, sigs[0][:10]), "invalid BLS signature length")
+		require.Error(t, VerifyAggregateBLS(nil, hashToSign, sigs[0]))
+
+		_, err := AggregateBLSSignatures(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("private key round trips", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := BLSPrivateKeyFromBytes(keys[0].Bytes())
+		require.NoError(t, err)
+		require.Equal(t, pubKeys[0], key.PublicKey())
+
+		_, err = BLSPrivateKeyFromBytes(make([]byte, BLSPrivateKeyLen))
+		require.Error(t, err)
+
+		_, err = BLSPrivateKeyFromBytes([]byte{1})
+		require.Error(t, err)
+	})
+}
+
+func TestSequenceBanana_SignBLS(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBLSKey()
+	require.NoError(t, err)
+
+	sequence := SignedSequenceBanana{Sequence: SequenceBanana{ChainID: 1}}
+
+	sig, err := sequence.SignBLS(key)
+	require.NoError(t, err)
+	require.NoError(t, VerifyBLS(key.PublicKey(), sequence.Sequence.HashToSign(), sig))
+}
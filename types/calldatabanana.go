@@ -0,0 +1,232 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	bananaValidium "github.com/0xPolygon/cdk-contracts-tooling/contracts/banana/polygonvalidiumetrog"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// sequenceBatchesValidiumMethod is the name of the method of the PolygonValidium contract that sequences batches
+	sequenceBatchesValidiumMethod = "sequenceBatchesValidium"
+
+	// MaxSequenceBananaCalldataSize is the maximum size of the calldata of a sequence, well above what fits in an L1 block
+	MaxSequenceBananaCalldataSize = 2 << 20
+
+	// MaxSequenceBananaBatches is the maximum number of batches of a sequence, as enforced by the contract
+	MaxSequenceBananaBatches = 1000
+
+	// MaxL2DataSize is the maximum size of the L2 data of a batch accepted when rebuilding a sequence
+	MaxL2DataSize = 16 << 20
+
+	// abiWordSize is the size of a word of the ABI encoding
+	abiWordSize = 32
+)
+
+// bananaSequenceMethod returns the sequenceBatchesValidium method of the Banana fork ABI
+var bananaSequenceMethod = sync.OnceValues(func() (abi.Method, error) {
+	a, err := abi.JSON(strings.NewReader(bananaValidium.PolygonvalidiumetrogMetaData.ABI))
+	if err != nil {
+		return abi.Method{}, err
+	}
+
+	method, ok := a.Methods[sequenceBatchesValidiumMethod]
+	if !ok {
+		return abi.Method{}, fmt.Errorf("method %s not found in the banana ABI", sequenceBatchesValidiumMethod)
+	}
+
+	return method, nil
+})
+
+// SequenceBananaCalldata is the calldata of a sequenceBatchesValidium call of the Banana fork
+type SequenceBananaCalldata struct {
+	Batches                   []bananaValidium.PolygonValidiumEtrogValidiumBatchData
+	IndexL1InfoRoot           uint32
+	MaxSequenceTimestamp      uint64
+	ExpectedFinalAccInputHash common.Hash
+	L2Coinbase                common.Address
+	DataAvailabilityMessage   []byte
+}
+
+// Encode ABI-encodes the calldata, including the method id
+func (c *SequenceBananaCalldata) Encode() ([]byte, error) {
+	method, err := bananaSequenceMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := method.Inputs.Pack(
+		c.Batches,
+		c.IndexL1InfoRoot,
+		c.MaxSequenceTimestamp,
+		c.ExpectedFinalAccInputHash,
+		c.L2Coinbase,
+		c.DataAvailabilityMessage,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(method.ID, data...), nil
+}
+
+// DecodeSequenceBananaCalldata decodes the calldata of a sequenceBatchesValidium call of the Banana fork.
+// The calldata may come from anyone through L1, so it is bounded before being decoded and any malformed
+// input results in an error
+func DecodeSequenceBananaCalldata(calldata []byte) (decoded *SequenceBananaCalldata, err error) {
+	// The ABI decoder is not meant for adversarial input, so a panic is turned into an error as a last resort
+	defer func() {
+		if r := recover(); r != nil {
+			decoded, err = nil, fmt.Errorf("malformed sequence calldata: %v", r)
+		}
+	}()
+
+	method, err := bananaSequenceMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(calldata) < len(method.ID) || !bytes.Equal(calldata[:len(method.ID)], method.ID) {
+		return nil, errors.New("not a banana sequenceBatchesValidium call")
+	}
+
+	if len(calldata) > MaxSequenceBananaCalldataSize {
+		return nil, fmt.Errorf("sequence calldata of %d bytes exceeds the maximum of %d",
+			len(calldata), MaxSequenceBananaCalldataSize)
+	}
+
+	args := calldata[len(method.ID):]
+
+	numBatches, err := abiArrayLen(args, 0)
+	if err != nil {
+		return nil, fmt.Errorf("malformed sequence batches: %w", err)
+	}
+
+	if numBatches > MaxSequenceBananaBatches {
+		return nil, fmt.Errorf("sequence of %d batches exceeds the maximum of %d", numBatches, MaxSequenceBananaBatches)
+	}
+
+	values, err := method.Inputs.Unpack(args)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded = &SequenceBananaCalldata{}
+	if err = method.Inputs.Copy(decoded, values); err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// abiArrayLen returns the length of the dynamic array that is the argument at the given index of the ABI encoded args
+func abiArrayLen(args []byte, index int) (uint64, error) {
+	offset, err := abiWord(args, uint64(index)*abiWordSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return abiWord(args, offset)
+}
+
+// abiWord returns the word at the given position of the ABI encoded args as an uint64
+func abiWord(args []byte, pos uint64) (uint64, error) {
+	if pos > uint64(len(args)) || uint64(len(args))-pos < abiWordSize {
+		return 0, fmt.Errorf("word at %d out of bounds of %d bytes", pos, len(args))
+	}
+
+	word := new(big.Int).SetBytes(args[pos : pos+abiWordSize])
+	if !word.IsUint64() {
+		return 0, fmt.Errorf("word at %d overflows", pos)
+	}
+
+	return word.Uint64(), nil
+}
+
+// EncodeCalldata returns the calldata of the sequenceBatchesValidium call that sequences this sequence on L1.
+// The calldata commits to the hashes of the L2 data and to the resulting accInputHash, and it carries
+// an empty data availability message, as the signatures of the committee are not part of the sequence.
+// All the batches must have the same coinbase
+func (s *SequenceBanana) EncodeCalldata() ([]byte, error) {
+	calldata := SequenceBananaCalldata{
+		Batches:                   make([]bananaValidium.PolygonValidiumEtrogValidiumBatchData, len(s.Batches)),
+		IndexL1InfoRoot:           uint32(s.IndexL1InfoRoot),
+		MaxSequenceTimestamp:      uint64(s.MaxSequenceTimestamp),
+		ExpectedFinalAccInputHash: s.AccInputHash(),
+		DataAvailabilityMessage:   []byte{},
+	}
+
+	for i, b := range s.Batches {
+		if i == 0 {
+			calldata.L2Coinbase = b.Coinbase
+		} else if b.Coinbase != calldata.L2Coinbase {
+			return nil, fmt.Errorf("batch %d has coinbase %s, expected %s", i, b.Coinbase.Hex(), calldata.L2Coinbase.Hex())
+		}
+
+		calldata.Batches[i] = bananaValidium.PolygonValidiumEtrogValidiumBatchData{
+			TransactionsHash:     crypto.Keccak256Hash(b.L2Data),
+			ForcedGlobalExitRoot: b.ForcedGER,
+			ForcedTimestamp:      uint64(b.ForcedTimestamp),
+			ForcedBlockHashL1:    b.ForcedBlockHashL1,
+		}
+	}
+
+	return calldata.Encode()
+}
+
+// ParseSequenceBanana rebuilds the sequence sequenced by the given sequenceBatchesValidium calldata.
+// The calldata only commits to the hashes of the L2 data, so the L2 data of every batch has to be provided
+// keyed by its hash, along with the old accInputHash and the L1 info root the sequence was built on.
+// The rebuilt sequence is verified against the accInputHash committed in the calldata
+func ParseSequenceBanana(
+	calldata []byte,
+	oldAccInputHash, l1InfoRoot common.Hash,
+	l2Data map[common.Hash][]byte,
+) (*SequenceBanana, error) {
+	decoded, err := DecodeSequenceBananaCalldata(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SequenceBanana{
+		Batches:              make([]Batch, len(decoded.Batches)),
+		OldAccInputHash:      oldAccInputHash,
+		L1InfoRoot:           l1InfoRoot,
+		MaxSequenceTimestamp: ArgUint64(decoded.MaxSequenceTimestamp),
+		IndexL1InfoRoot:      ArgUint64(decoded.IndexL1InfoRoot),
+	}
+
+	for i, b := range decoded.Batches {
+		data, ok := l2Data[b.TransactionsHash]
+		if !ok {
+			return nil, fmt.Errorf("missing L2 data of batch %d with hash %s", i, common.Hash(b.TransactionsHash).Hex())
+		}
+
+		if len(data) > MaxL2DataSize {
+			return nil, fmt.Errorf("L2 data of batch %d of %d bytes exceeds the maximum of %d", i, len(data), MaxL2DataSize)
+		}
+
+		s.Batches[i] = Batch{
+			L2Data:            data,
+			ForcedGER:         b.ForcedGlobalExitRoot,
+			ForcedTimestamp:   ArgUint64(b.ForcedTimestamp),
+			Coinbase:          decoded.L2Coinbase,
+			ForcedBlockHashL1: b.ForcedBlockHashL1,
+		}
+	}
+
+	if accInputHash := s.AccInputHash(); accInputHash != decoded.ExpectedFinalAccInputHash {
+		return nil, fmt.Errorf("accInputHash mismatch: calldata commits to %s, sequence hashes to %s",
+			decoded.ExpectedFinalAccInputHash.Hex(), accInputHash.Hex())
+	}
+
+	return s, nil
+}
@@ -0,0 +1,189 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceBanana_EncodeCalldata(t *testing.T) {
+	t.Parallel()
+
+	coinbase := common.HexToAddress("0xABCD")
+
+	newSequence := func() SequenceBanana {
+		return SequenceBanana{
+			Batches: []Batch{
+				{
+					L2Data:   ArgBytes("batch1"),
+					Coinbase: coinbase,
+				},
+				{
+					L2Data:            ArgBytes("batch2"),
+					ForcedGER:         common.HexToHash("0x01"),
+					ForcedTimestamp:   10,
+					Coinbase:          coinbase,
+					ForcedBlockHashL1: common.HexToHash("0x02"),
+				},
+			},
+			OldAccInputHash:      common.HexToHash("0x03"),
+			L1InfoRoot:           common.HexToHash("0x04"),
+			MaxSequenceTimestamp: 20,
+			IndexL1InfoRoot:      5,
+		}
+	}
+
+	l2Data := func(s SequenceBanana) map[common.Hash][]byte {
+		data := make(map[common.Hash][]byte, len(s.Batches))
+		for _, b := range s.Batches {
+			data[crypto.Keccak256Hash(b.L2Data)] = b.L2Data
+		}
+
+		return data
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequence()
+
+		calldata, err := s.EncodeCalldata()
+		require.NoError(t, err)
+
+		decoded, err := DecodeSequenceBananaCalldata(calldata)
+		require.NoError(t, err)
+		require.Equal(t, common.BytesToHash(s.HashToSign()), decoded.ExpectedFinalAccInputHash)
+		require.Equal(t, uint32(5), decoded.IndexL1InfoRoot)
+		require.Equal(t, coinbase, decoded.L2Coinbase)
+
+		parsed, err := ParseSequenceBanana(calldata, s.OldAccInputHash, s.L1InfoRoot, l2Data(s))
+		require.NoError(t, err)
+		require.Equal(t, s, *parsed)
+	})
+
+	t.Run("batches with different coinbases", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequence()
+		s.Batches[1].Coinbase = common.HexToAddress("0xDCBA")
+
+		_, err := s.EncodeCalldata()
+		require.ErrorContains(t, err, "batch 1 has coinbase")
+	})
+
+	t.Run("missing L2 data", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequence()
+
+		calldata, err := s.EncodeCalldata()
+		require.NoError(t, err)
+
+		data := l2Data(s)
+		delete(data, crypto.Keccak256Hash(s.Batches[1].L2Data))
+
+		_, err = ParseSequenceBanana(calldata, s.OldAccInputHash, s.L1InfoRoot, data)
+		require.ErrorContains(t, err, "missing L2 data of batch 1")
+	})
+
+	t.Run("wrong old accInputHash", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSequence()
+
+		calldata, err := s.EncodeCalldata()
+		require.NoError(t, err)
+
+		_, err = ParseSequenceBanana(calldata, common.HexToHash("0x05"), s.L1InfoRoot, l2Data(s))
+		require.ErrorContains(t, err, "accInputHash mismatch")
+	})
+
+	t.Run("not a banana sequence call", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DecodeSequenceBananaCalldata([]byte{0x01, 0x02, 0x03, 0x04})
+		require.ErrorContains(t, err, "not a banana sequenceBatchesValidium call")
+	})
+}
+
+func TestDecodeSequenceBananaCalldata_Bounds(t *testing.T) {
+	t.Parallel()
+
+	method, err := bananaSequenceMethod()
+	require.NoError(t, err)
+
+	t.Run("too many batches", func(t *testing.T) {
+		t.Parallel()
+
+		calldata := append([]byte{}, method.ID...)
+		calldata = append(calldata, common.LeftPadBytes([]byte{abiWordSize}, abiWordSize)...)
+		calldata = append(calldata, common.LeftPadBytes(big.NewInt(MaxSequenceBananaBatches+1).Bytes(), abiWordSize)...)
+
+		_, err := DecodeSequenceBananaCalldata(calldata)
+		require.ErrorContains(t, err, "exceeds the maximum")
+	})
+
+	t.Run("huge batches offset", func(t *testing.T) {
+		t.Parallel()
+
+		calldata := append([]byte{}, method.ID...)
+		calldata = append(calldata, bytes.Repeat([]byte{0xff}, abiWordSize)...)
+
+		_, err := DecodeSequenceBananaCalldata(calldata)
+		require.ErrorContains(t, err, "malformed sequence batches")
+	})
+
+	t.Run("oversized calldata", func(t *testing.T) {
+		t.Parallel()
+
+		calldata := append(append([]byte{}, method.ID...), make([]byte, MaxSequenceBananaCalldataSize)...)
+
+		_, err := DecodeSequenceBananaCalldata(calldata)
+		require.ErrorContains(t, err, "exceeds the maximum")
+	})
+}
+
+func FuzzParseSequenceBanana(f *testing.F) {
+	s := SequenceBanana{
+		Batches: []Batch{
+			{L2Data: ArgBytes("batch1")},
+			{L2Data: ArgBytes("batch2"), ForcedTimestamp: 10, ForcedGER: common.HexToHash("0x01")},
+		},
+		MaxSequenceTimestamp: 20,
+		IndexL1InfoRoot:      5,
+	}
+
+	calldata, err := s.EncodeCalldata()
+	require.NoError(f, err)
+
+	empty, err := (&SequenceBanana{}).EncodeCalldata()
+	require.NoError(f, err)
+
+	// Known-good calldata and truncations of it
+	f.Add(calldata)
+	f.Add(empty)
+	f.Add(calldata[:len(calldata)/2])
+	f.Add(calldata[:4])
+	f.Add([]byte{})
+
+	l2Data := map[common.Hash][]byte{}
+	for _, b := range s.Batches {
+		l2Data[crypto.Keccak256Hash(b.L2Data)] = b.L2Data
+	}
+
+	f.Fuzz(func(t *testing.T, calldata []byte) {
+		decoded, err := DecodeSequenceBananaCalldata(calldata)
+		if err != nil {
+			return
+		}
+
+		require.LessOrEqual(t, len(decoded.Batches), MaxSequenceBananaBatches)
+
+		// Whatever decodes must also be parsed without panicking
+		_, _ = ParseSequenceBanana(calldata, common.Hash{}, common.Hash{}, l2Data)
+	})
+}
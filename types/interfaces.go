@@ -10,7 +10,9 @@ import (
 type SignedSequenceInterface interface {
 	Signer() (common.Address, error)
 	OffChainData() []OffChainData
+	HashToSign() []byte
 	Sign(privateKey *ecdsa.PrivateKey) (ArgBytes, error)
+	SignBLS(key *BLSPrivateKey) (ArgBytes, error)
 	SetSignature([]byte)
 	GetSignature() []byte
 }
@@ -3,6 +3,7 @@ package types
 import (
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -13,6 +14,9 @@ const (
 	signatureLen = 65
 )
 
+// ErrTooManyBatches is returned when a sequence holds more batches than allowed
+var ErrTooManyBatches = errors.New("too many batches in sequence")
+
 // Sequence represents the data that the sequencer will send to L1
 // and other metadata needed to build the accumulated input hash aka accInputHash
 type Sequence []ArgBytes
@@ -35,6 +39,11 @@ func (s *Sequence) HashToSign() []byte {
 	return currentHash
 }
 
+// Validate checks that the sequence holds at most maxBatches batches. 0 means no limit
+func (s *Sequence) Validate(maxBatches uint64) error {
+	return validateBatchCount(len(*s), maxBatches)
+}
+
 // Sign returns a signed sequence by the private key.
 // Note that what's being signed is the accumulated input hash
 func (s *Sequence) Sign(privateKey *ecdsa.PrivateKey) ([]byte, error) {
@@ -42,13 +51,21 @@ func (s *Sequence) Sign(privateKey *ecdsa.PrivateKey) ([]byte, error) {
 	return Sign(privateKey, hashToSign)
 }
 
+// SignBLS returns an aggregatable signature of the sequence by the BLS private key.
+// Note that what's being signed is the same hash as with Sign
+func (s *Sequence) SignBLS(key *BLSPrivateKey) ([]byte, error) {
+	return SignBLS(key, s.HashToSign())
+}
+
 // OffChainData returns the data that needs to be stored off chain from a given sequence
 func (s *Sequence) OffChainData() []OffChainData {
 	od := []OffChainData{}
-	for _, batchData := range ([]ArgBytes)(*s) {
+	for i, batchData := range ([]ArgBytes)(*s) {
+		index := uint(i)
 		od = append(od, OffChainData{
 			Key:   crypto.Keccak256Hash(batchData),
 			Value: batchData,
+			Index: &index,
 		})
 	}
 	return od
@@ -80,11 +97,21 @@ func (s *SignedSequence) OffChainData() []OffChainData {
 	return s.Sequence.OffChainData()
 }
 
+// HashToSign returns the hash the sequence is signed over
+func (s *SignedSequence) HashToSign() []byte {
+	return s.Sequence.HashToSign()
+}
+
 // Sign signs the sequence using the privateKey
 func (s *SignedSequence) Sign(privateKey *ecdsa.PrivateKey) (ArgBytes, error) {
 	return s.Sequence.Sign(privateKey)
 }
 
+// SignBLS signs the sequence using the BLS private key
+func (s *SignedSequence) SignBLS(key *BLSPrivateKey) (ArgBytes, error) {
+	return s.Sequence.SignBLS(key)
+}
+
 // SetSignature set signature
 func (s *SignedSequence) SetSignature(sign []byte) {
 	s.Signature = sign
@@ -94,3 +121,12 @@ func (s *SignedSequence) SetSignature(sign []byte) {
 func (s *SignedSequence) GetSignature() []byte {
 	return s.Signature
 }
+
+// validateBatchCount checks a sequence of count batches against maxBatches, 0 meaning no limit
+func validateBatchCount(count int, maxBatches uint64) error {
+	if maxBatches > 0 && uint64(count) > maxBatches {
+		return fmt.Errorf("%w: got %d, the maximum is %d", ErrTooManyBatches, count, maxBatches)
+	}
+
+	return nil
+}
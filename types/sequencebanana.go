@@ -3,6 +3,8 @@ package types
 import (
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
+	"math/big"
 
 	cdkCommon "github.com/0xPolygon/cdk/common"
 	cdkLog "github.com/0xPolygon/cdk/log"
@@ -10,6 +12,23 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+var (
+	// ErrInconsistentForcedBatch is returned when only some of the forced fields of a batch are set
+	ErrInconsistentForcedBatch = errors.New("inconsistent forced batch fields")
+
+	// ErrChainIDMismatch is returned when a sequence is bound to another chain ID than the expected one
+	ErrChainIDMismatch = errors.New("chain ID mismatch")
+
+	// ErrSignerRecovery is returned when the signer of a sequence cannot be recovered from its signature
+	ErrSignerRecovery = errors.New("failed to recover the sequence signer")
+
+	// ErrUnauthorizedSigner is returned when a sequence is not signed by the trusted sequencer
+	ErrUnauthorizedSigner = errors.New("sequence signer is not the trusted sequencer")
+
+	// ErrBatchTooLarge is returned when the data of a batch exceeds the maximum blob size
+	ErrBatchTooLarge = errors.New("batch data exceeds the maximum blob size")
+)
+
 // Batch represents the batch data that the sequencer will send to L1
 type Batch struct {
 	L2Data            ArgBytes       `json:"L2Data"`
@@ -26,24 +45,123 @@ type SequenceBanana struct {
 	OldAccInputHash      common.Hash `json:"oldAccInputhash"`
 	L1InfoRoot           common.Hash `json:"l1InfoRoot"`
 	MaxSequenceTimestamp ArgUint64   `json:"maxSequenceTimestamp"`
+
+	// IndexL1InfoRoot is the index of the L1 info root in the L1 info tree. It is only needed
+	// to build the L1 calldata of the sequence, and it is not part of the accInputHash
+	IndexL1InfoRoot ArgUint64 `json:"indexL1InfoRoot,omitempty"`
+
+	// ChainID is the chain the signature of the sequence is bound to, so it cannot be replayed
+	// on another chain served by the same committee. 0 means the signature is not bound to any chain,
+	// which is what the L1 contracts verify
+	ChainID ArgUint64 `json:"chainId,omitempty"`
+}
+
+// Validate checks that the sequence holds at most maxBatches batches, so the work done to hash
+// and store it is bounded. 0 means no limit. It also checks that the forced fields of every batch
+// are either all set or all zero, since otherwise the signed hash would not match the contract's
+func (s *SequenceBanana) Validate(maxBatches uint64) error {
+	if err := validateBatchCount(len(s.Batches), maxBatches); err != nil {
+		return err
+	}
+
+	for i, b := range s.Batches {
+		if err := b.validateForced(); err != nil {
+			return fmt.Errorf("batch %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateForced checks that the forced timestamp and the forced L1 block hash are either both set or both zero
+func (b *Batch) validateForced() error {
+	hasTimestamp := b.ForcedTimestamp > 0
+	hasBlockHash := b.ForcedBlockHashL1 != (common.Hash{})
+
+	switch {
+	case hasTimestamp && !hasBlockHash:
+		return fmt.Errorf("%w: forced timestamp %d without forced L1 block hash", ErrInconsistentForcedBatch,
+			b.ForcedTimestamp)
+	case hasBlockHash && !hasTimestamp:
+		return fmt.Errorf("%w: forced L1 block hash %s without forced timestamp", ErrInconsistentForcedBatch,
+			b.ForcedBlockHashL1.Hex())
+	}
+
+	return nil
 }
 
-// HashToSign returns the accumulated input hash of the sequence.
-// Note that this is equivalent to what happens on the smart contract
+// HashToSign returns the accumulated input hash of the sequence, bound to the chain ID if it is set.
+// Note that without a chain ID this is equivalent to what happens on the smart contract
 func (s *SequenceBanana) HashToSign() []byte {
-	accInputHash := s.OldAccInputHash
+	return s.hasher().Hash()
+}
+
+// AccInputHash returns the accumulated input hash of the sequence
+func (s *SequenceBanana) AccInputHash() common.Hash {
+	return s.hasher().AccInputHash()
+}
+
+// hasher returns the hasher of the sequence with all its batches added
+func (s *SequenceBanana) hasher() *AccInputHasher {
+	h := NewAccInputHasher(s.OldAccInputHash, s.L1InfoRoot, s.MaxSequenceTimestamp, s.ChainID)
 	for _, b := range s.Batches {
-		accInputHash = cdkCommon.CalculateAccInputHash(
-			cdkLog.GetDefaultLogger(),
-			accInputHash,
-			b.L2Data,
-			s.L1InfoRoot,
-			uint64(s.MaxSequenceTimestamp),
-			b.Coinbase, b.ForcedBlockHashL1,
-		)
+		h.AddBatch(b)
+	}
+
+	return h
+}
+
+// AccInputHasher computes the accumulated input hash of a sequence one batch at a time, so a sequence whose
+// batches are added as they are produced is not hashed again from its first batch on every addition.
+// The metadata of the sequence hashed along with every batch must be known before the first batch is added
+type AccInputHasher struct {
+	currentHash          common.Hash
+	l1InfoRoot           common.Hash
+	maxSequenceTimestamp ArgUint64
+	chainID              ArgUint64
+}
+
+// NewAccInputHasher returns the hasher of a sequence built on the given old accInputHash, L1 info root and
+// max sequence timestamp, whose hash to sign is bound to the given chain ID if it is set
+func NewAccInputHasher(
+	oldAccInputHash, l1InfoRoot common.Hash, maxSequenceTimestamp, chainID ArgUint64,
+) *AccInputHasher {
+	return &AccInputHasher{
+		currentHash:          oldAccInputHash,
+		l1InfoRoot:           l1InfoRoot,
+		maxSequenceTimestamp: maxSequenceTimestamp,
+		chainID:              chainID,
+	}
+}
+
+// AddBatch folds the given batch into the accumulated input hash
+func (h *AccInputHasher) AddBatch(b Batch) {
+	h.currentHash = cdkCommon.CalculateAccInputHash(
+		cdkLog.GetDefaultLogger(),
+		h.currentHash,
+		b.L2Data,
+		h.l1InfoRoot,
+		uint64(h.maxSequenceTimestamp),
+		b.Coinbase, b.ForcedBlockHashL1,
+	)
+}
+
+// AccInputHash returns the accumulated input hash of the batches added so far
+func (h *AccInputHasher) AccInputHash() common.Hash {
+	return h.currentHash
+}
+
+// Hash returns the hash to sign of the batches added so far, the same as HashToSign of the sequence
+// holding them
+func (h *AccInputHasher) Hash() []byte {
+	if h.chainID == 0 {
+		return h.currentHash.Bytes()
 	}
 
-	return accInputHash.Bytes()
+	return crypto.Keccak256(
+		h.currentHash.Bytes(),
+		common.LeftPadBytes(new(big.Int).SetUint64(uint64(h.chainID)).Bytes(), common.HashLength),
+	)
 }
 
 // Sign returns a signed sequence by the private key.
@@ -53,18 +171,38 @@ func (s *SequenceBanana) Sign(privateKey *ecdsa.PrivateKey) ([]byte, error) {
 	return Sign(privateKey, hashToSign)
 }
 
+// SignBLS returns an aggregatable signature of the sequence by the BLS private key.
+// Note that what's being signed is the same hash as with Sign
+func (s *SequenceBanana) SignBLS(key *BLSPrivateKey) ([]byte, error) {
+	return SignBLS(key, s.HashToSign())
+}
+
 // OffChainData returns the data that needs to be stored off chain from a given sequence
 func (s *SequenceBanana) OffChainData() []OffChainData {
 	od := []OffChainData{}
-	for _, b := range s.Batches {
+	for i, b := range s.Batches {
+		index := uint(i)
 		od = append(od, OffChainData{
-			Key:   crypto.Keccak256Hash(b.L2Data),
-			Value: b.L2Data,
+			Key:    crypto.Keccak256Hash(b.L2Data),
+			Value:  b.L2Data,
+			Index:  &index,
+			Forced: b.ForcedTimestamp > 0,
 		})
 	}
 	return od
 }
 
+// OffChainDataKeys returns the keys of the data the sequence stores off chain, in batch order,
+// without copying the values. It allows checking which batches are already stored before storing them
+func (s *SequenceBanana) OffChainDataKeys() []common.Hash {
+	keys := make([]common.Hash, len(s.Batches))
+	for i, b := range s.Batches {
+		keys[i] = crypto.Keccak256Hash(b.L2Data)
+	}
+
+	return keys
+}
+
 // SignedSequenceBanana is a sequence but signed
 type SignedSequenceBanana struct {
 	Sequence  SequenceBanana `json:"sequence"`
@@ -86,16 +224,60 @@ func (s *SignedSequenceBanana) Signer() (common.Address, error) {
 	return crypto.PubkeyToAddress(*pubKey), nil
 }
 
+// Verify runs all the checks of a signed sequence on ingest, in order: the structure of the sequence with at
+// most maxBatches batches, that it is bound to the given chain ID, the recovery of its signer, that the signer
+// is the given trusted sequencer, and that the data of every batch is at most maxBlobSize bytes. A zero chainID
+// skips the chain check, and a zero maxBatches or maxBlobSize means no limit. The first failure is returned
+func (s *SignedSequenceBanana) Verify(chainID, maxBatches uint64, sequencer common.Address, maxBlobSize uint64) error {
+	if err := s.Sequence.Validate(maxBatches); err != nil {
+		return fmt.Errorf("invalid sequence: %w", err)
+	}
+
+	if bound := uint64(s.Sequence.ChainID); chainID != 0 && bound != chainID {
+		return fmt.Errorf("%w: sequence bound to chain ID %d, expected %d", ErrChainIDMismatch, bound, chainID)
+	}
+
+	signer, err := s.Signer()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSignerRecovery, err)
+	}
+
+	if signer != sequencer {
+		return fmt.Errorf("%w: %s", ErrUnauthorizedSigner, signer.Hex())
+	}
+
+	if maxBlobSize > 0 {
+		for i, b := range s.Sequence.Batches {
+			if uint64(len(b.L2Data)) > maxBlobSize {
+				return fmt.Errorf("%w: batch %d holds %d bytes, the maximum is %d",
+					ErrBatchTooLarge, i, len(b.L2Data), maxBlobSize)
+			}
+		}
+	}
+
+	return nil
+}
+
 // OffChainData returns the data to be stored of the sequence
 func (s *SignedSequenceBanana) OffChainData() []OffChainData {
 	return s.Sequence.OffChainData()
 }
 
+// HashToSign returns the hash the sequence is signed over
+func (s *SignedSequenceBanana) HashToSign() []byte {
+	return s.Sequence.HashToSign()
+}
+
 // Sign signs the sequence using the privateKey
 func (s *SignedSequenceBanana) Sign(privateKey *ecdsa.PrivateKey) (ArgBytes, error) {
 	return s.Sequence.Sign(privateKey)
 }
 
+// SignBLS signs the sequence using the BLS private key
+func (s *SignedSequenceBanana) SignBLS(key *BLSPrivateKey) (ArgBytes, error) {
+	return s.Sequence.SignBLS(key)
+}
+
 // SetSignature set signature
 func (s *SignedSequenceBanana) SetSignature(sign []byte) {
 	s.Signature = sign
@@ -3,7 +3,12 @@ package types
 import (
 	"testing"
 
+	cdkCommon "github.com/0xPolygon/cdk/common"
+	cdkLog "github.com/0xPolygon/cdk/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetSetSignatureBanana(t *testing.T) {
@@ -12,3 +17,276 @@ func TestGetSetSignatureBanana(t *testing.T) {
 	sut.SetSignature(signature)
 	assert.Equal(t, signature, sut.GetSignature())
 }
+
+func TestSequenceBanana_HashToSign(t *testing.T) {
+	sequence := SequenceBanana{
+		Batches:              []Batch{{L2Data: ArgBytes{1, 2, 3}}},
+		OldAccInputHash:      common.HexToHash("0x01"),
+		L1InfoRoot:           common.HexToHash("0x02"),
+		MaxSequenceTimestamp: 10,
+	}
+
+	// Without a chain ID, the accInputHash is signed as the L1 contracts expect
+	require.Equal(t, sequence.AccInputHash().Bytes(), sequence.HashToSign())
+
+	bound := sequence
+	bound.ChainID = 1
+
+	other := sequence
+	other.ChainID = 2
+
+	require.NotEqual(t, sequence.HashToSign(), bound.HashToSign())
+	require.NotEqual(t, bound.HashToSign(), other.HashToSign())
+	require.Equal(t, sequence.AccInputHash(), bound.AccInputHash())
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signature, err := bound.Sign(key)
+	require.NoError(t, err)
+
+	signer, err := (&SignedSequenceBanana{Sequence: bound, Signature: signature}).Signer()
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	// The signature is not valid when replayed for another chain
+	signer, err = (&SignedSequenceBanana{Sequence: other, Signature: signature}).Signer()
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+}
+
+func TestAccInputHasher(t *testing.T) {
+	t.Parallel()
+
+	batches := []Batch{
+		{L2Data: ArgBytes{1, 2, 3}, Coinbase: common.HexToAddress("0xabcd")},
+		{L2Data: ArgBytes{4, 5}, ForcedTimestamp: 5, ForcedBlockHashL1: common.HexToHash("0x03")},
+		{},
+	}
+
+	for _, chainID := range []ArgUint64{0, 1} {
+		sequence := SequenceBanana{
+			OldAccInputHash:      common.HexToHash("0x01"),
+			L1InfoRoot:           common.HexToHash("0x02"),
+			MaxSequenceTimestamp: 10,
+			ChainID:              chainID,
+		}
+
+		hasher := NewAccInputHasher(sequence.OldAccInputHash, sequence.L1InfoRoot, sequence.MaxSequenceTimestamp,
+			sequence.ChainID)
+		require.Equal(t, sequence.HashToSign(), hasher.Hash())
+
+		// Computed from scratch as the contract does, for every batch added
+		expected := sequence.OldAccInputHash
+		for _, b := range batches {
+			sequence.Batches = append(sequence.Batches, b)
+			hasher.AddBatch(b)
+
+			expected = cdkCommon.CalculateAccInputHash(cdkLog.GetDefaultLogger(), expected, b.L2Data,
+				sequence.L1InfoRoot, uint64(sequence.MaxSequenceTimestamp), b.Coinbase, b.ForcedBlockHashL1)
+
+			require.Equal(t, expected, hasher.AccInputHash())
+			require.Equal(t, sequence.AccInputHash(), hasher.AccInputHash())
+			require.Equal(t, sequence.HashToSign(), hasher.Hash())
+		}
+	}
+}
+
+func TestSequenceBanana_OffChainDataKeys(t *testing.T) {
+	t.Parallel()
+
+	sequence := SequenceBanana{
+		Batches: []Batch{{L2Data: ArgBytes{1, 2, 3}}, {L2Data: ArgBytes{4, 5}, ForcedTimestamp: 1700000000}, {}},
+	}
+
+	keys := sequence.OffChainDataKeys()
+	require.Len(t, keys, 3)
+
+	for i, od := range sequence.OffChainData() {
+		require.Equal(t, od.Key, keys[i])
+		require.Equal(t, i == 1, od.Forced)
+	}
+
+	require.Empty(t, (&SequenceBanana{}).OffChainDataKeys())
+}
+
+func TestSequenceBanana_Validate(t *testing.T) {
+	t.Parallel()
+
+	sequence := SequenceBanana{Batches: make([]Batch, 3)}
+
+	require.NoError(t, sequence.Validate(0))
+	require.NoError(t, sequence.Validate(3))
+
+	err := sequence.Validate(2)
+	require.ErrorIs(t, err, ErrTooManyBatches)
+	require.EqualError(t, err, "too many batches in sequence: got 3, the maximum is 2")
+}
+
+func TestSequenceBanana_ValidateForced(t *testing.T) {
+	t.Parallel()
+
+	blockHash := common.HexToHash("0x1")
+
+	tests := []struct {
+		name    string
+		batch   Batch
+		wantErr string
+	}{
+		{
+			name:  "not forced",
+			batch: Batch{},
+		},
+		{
+			name:  "forced",
+			batch: Batch{ForcedTimestamp: 10, ForcedBlockHashL1: blockHash},
+		},
+		{
+			name:    "forced timestamp only",
+			batch:   Batch{ForcedTimestamp: 10},
+			wantErr: "batch 1: inconsistent forced batch fields: forced timestamp 10 without forced L1 block hash",
+		},
+		{
+			name:  "forced block hash only",
+			batch: Batch{ForcedBlockHashL1: blockHash},
+			wantErr: "batch 1: inconsistent forced batch fields: forced L1 block hash " + blockHash.Hex() +
+				" without forced timestamp",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sequence := SequenceBanana{Batches: []Batch{{}, tt.batch}}
+
+			err := sequence.Validate(0)
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, ErrInconsistentForcedBatch)
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSignedSequenceBanana_Verify(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sequencer := crypto.PubkeyToAddress(key.PublicKey)
+
+	signed := func(chainID uint64, batches ...Batch) SignedSequenceBanana {
+		t.Helper()
+
+		sequence := SignedSequenceBanana{Sequence: SequenceBanana{ChainID: ArgUint64(chainID), Batches: batches}}
+
+		signature, err := sequence.Sign(key)
+		require.NoError(t, err)
+
+		sequence.Signature = signature
+
+		return sequence
+	}
+
+	tests := []struct {
+		name        string
+		sequence    SignedSequenceBanana
+		chainID     uint64
+		maxBatches  uint64
+		sequencer   common.Address
+		maxBlobSize uint64
+		expectedErr error
+		errString   string
+	}{
+		{
+			name:        "valid sequence",
+			sequence:    signed(1, Batch{L2Data: ArgBytes{1, 2, 3}}),
+			chainID:     1,
+			maxBatches:  1,
+			sequencer:   sequencer,
+			maxBlobSize: 3,
+		},
+		{
+			name:      "no limits",
+			sequence:  signed(0, Batch{L2Data: ArgBytes{1, 2, 3}}, Batch{}),
+			sequencer: sequencer,
+		},
+		{
+			name:      "no chain ID expected",
+			sequence:  signed(2, Batch{}),
+			sequencer: sequencer,
+		},
+		{
+			name:        "invalid structure",
+			sequence:    signed(0, Batch{ForcedTimestamp: 10}),
+			sequencer:   sequencer,
+			expectedErr: ErrInconsistentForcedBatch,
+			errString: "invalid sequence: batch 0: inconsistent forced batch fields: " +
+				"forced timestamp 10 without forced L1 block hash",
+		},
+		{
+			name:        "too many batches",
+			sequence:    signed(0, Batch{}, Batch{}),
+			maxBatches:  1,
+			sequencer:   sequencer,
+			expectedErr: ErrTooManyBatches,
+			errString:   "invalid sequence: too many batches in sequence: got 2, the maximum is 1",
+		},
+		{
+			name:        "bound to another chain",
+			sequence:    signed(2, Batch{}),
+			chainID:     1,
+			sequencer:   sequencer,
+			expectedErr: ErrChainIDMismatch,
+			errString:   "chain ID mismatch: sequence bound to chain ID 2, expected 1",
+		},
+		{
+			name:        "invalid signature",
+			sequence:    SignedSequenceBanana{Signature: ArgBytes{1, 2, 3}},
+			sequencer:   sequencer,
+			expectedErr: ErrSignerRecovery,
+			errString:   "failed to recover the sequence signer: invalid signature",
+		},
+		{
+			name:        "signer is not the trusted sequencer",
+			sequence:    signed(0, Batch{}),
+			sequencer:   common.HexToAddress("0x1"),
+			expectedErr: ErrUnauthorizedSigner,
+			errString:   "sequence signer is not the trusted sequencer: " + sequencer.Hex(),
+		},
+		{
+			name:        "batch too large",
+			sequence:    signed(0, Batch{L2Data: ArgBytes{1}}, Batch{L2Data: ArgBytes{1, 2, 3}}),
+			sequencer:   sequencer,
+			maxBlobSize: 2,
+			expectedErr: ErrBatchTooLarge,
+			errString:   "batch data exceeds the maximum blob size: batch 1 holds 3 bytes, the maximum is 2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.sequence.Verify(tt.chainID, tt.maxBatches, tt.sequencer, tt.maxBlobSize)
+			if tt.errString == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			}
+
+			require.EqualError(t, err, tt.errString)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -21,18 +22,139 @@ type DACStatus struct {
 	Version               string `json:"version"`
 	KeyCount              uint64 `json:"key_count"`
 	LastSynchronizedBlock uint64 `json:"last_synchronized_block"`
+	// WritesPaused is whether the synchronizer paused storing the offchain data after consecutive failures
+	WritesPaused bool `json:"writes_paused"`
 }
 
 // BatchKey is the pairing of batch number and data hash of a batch
 type BatchKey struct {
 	Number uint64
 	Hash   common.Hash
+	// EnqueuedAt is when the key was queued to be resolved, zero if unknown
+	EnqueuedAt time.Time
+	// Index is the position of the batch in the L1 calldata of its sequence, nil if unknown
+	Index *uint
+	// Forced is whether the batch is a forced batch
+	Forced bool
+	// Attempts is the number of failed attempts to resolve the key so far
+	Attempts uint
+}
+
+// FailedBatch is a batch key the synchronizer gave up resolving
+type FailedBatch struct {
+	Number ArgUint64   `json:"number"`
+	Hash   common.Hash `json:"hash"`
+
+	// Reason is the error of the last attempt to resolve the key
+	Reason   string    `json:"reason"`
+	FailedAt time.Time `json:"failedAt"`
+}
+
+// ResolvedBatch is a missing batch key kept in the resolved batches history once its data is stored
+type ResolvedBatch struct {
+	Number ArgUint64   `json:"number"`
+	Hash   common.Hash `json:"hash"`
+
+	// Attempts is the number of attempts it took to resolve the key
+	Attempts   uint      `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+}
+
+// FailedRecord is an offchain data record that could not be stored, and why
+type FailedRecord struct {
+	Key      common.Hash `json:"key"`
+	BatchNum uint64      `json:"batchNum"`
+	Reason   string      `json:"reason"`
+}
+
+// DuplicateGroup is a set of keys storing the same value. Keys are the hash of their value,
+// so such a group means the keys of some values were not derived from them
+type DuplicateGroup struct {
+	Keys []common.Hash `json:"keys"`
 }
 
 // OffChainData represents some data that is not stored on chain and should be preserved
 type OffChainData struct {
-	Key   common.Hash
-	Value []byte
+	Key      common.Hash
+	Value    []byte
+	BatchNum uint64
+
+	// Index is the position of the batch of the data in the sequence that sequenced it, as of its L1 calldata.
+	// The values of a batch are ordered by it, nil if unknown
+	Index *uint
+
+	// Forced is whether the batch of the data is a forced batch, which originates from L1 instead of the sequencer
+	Forced bool
+
+	// L1TxHash is the hash of the L1 transaction that sequenced the batch of the data, if it is known.
+	// It is only populated when reading the data of a single key
+	L1TxHash common.Hash
+}
+
+// OffChainDataItem is the RPC representation of a single off chain data entry
+type OffChainDataItem struct {
+	Key   common.Hash `json:"key"`
+	Value ArgBytes    `json:"value"`
+}
+
+// OffChainDataPage is a page of the off chain data of a batch, ordered by key
+type OffChainDataPage struct {
+	Items []OffChainDataItem `json:"items"`
+	Total uint64             `json:"total"`
+}
+
+// BatchCommitment is the result of checking the stored data of a batch against the hash committed on L1
+type BatchCommitment struct {
+	BatchNum ArgUint64   `json:"batchNum"`
+	L1TxHash common.Hash `json:"l1TxHash"`
+
+	// Committed is the hash of the batch data committed on L1
+	Committed common.Hash `json:"committed"`
+
+	// Computed is the hash of the stored data of the batch, zero if nothing is stored
+	Computed common.Hash `json:"computed"`
+
+	Matches bool `json:"matches"`
+}
+
+// KeyBatchConsistency is the result of checking the keys stored under a batch number against the key
+// committed on L1 for that batch
+type KeyBatchConsistency struct {
+	BatchNum ArgUint64   `json:"batchNum"`
+	L1TxHash common.Hash `json:"l1TxHash"`
+
+	// CommittedKey is the key of the batch data committed on L1
+	CommittedKey common.Hash `json:"committedKey"`
+
+	// CommittedKeyStored is whether the committed key is stored under the batch number
+	CommittedKeyStored bool `json:"committedKeyStored"`
+
+	// MisattributedKeys are the keys stored under the batch number that are not committed for the batch
+	MisattributedKeys []common.Hash `json:"misattributedKeys"`
+
+	Consistent bool `json:"consistent"`
+}
+
+// KeyBatchCorrection moves a stored key from the batch number it was attributed to,
+// to the batch it is committed for on L1
+type KeyBatchCorrection struct {
+	Key  common.Hash `json:"key"`
+	From ArgUint64   `json:"from"`
+	To   ArgUint64   `json:"to"`
+}
+
+// KeyBatchRepair is the result of correcting the batch numbers of the keys attributed to the wrong batch
+type KeyBatchRepair struct {
+	BatchNum ArgUint64   `json:"batchNum"`
+	L1TxHash common.Hash `json:"l1TxHash"`
+
+	// Corrections are the keys moved to the batch they are committed for
+	Corrections []KeyBatchCorrection `json:"corrections"`
+
+	// UnresolvedKeys are the misattributed keys that are not committed for any batch of the sequence,
+	// so their batch cannot be told and they are left as they are
+	UnresolvedKeys []common.Hash `json:"unresolvedKeys"`
 }
 
 // RemoveDuplicateOffChainData removes duplicate off chain data